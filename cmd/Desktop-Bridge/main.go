@@ -53,7 +53,9 @@ import (
 func main() {
 	appErr := app.New().Run(xslices.Filter(os.Args, func(arg string) bool { return !strings.Contains(arg, "-psn_") }))
 	if appErr != nil {
-		_ = app.WithLocations(func(l *locations.Locations) error {
+		profile, _ := getFlagValue(os.Args, app.FlagProfile)
+
+		_ = app.WithProfileLocations(profile, func(l *locations.Locations) error {
 			logsPath, err := l.ProvideLogsPath()
 			if err != nil {
 				return err
@@ -67,6 +69,8 @@ func main() {
 				sessionID = logging.NewSessionID()
 			}
 
+			logFormat, _ := getFlagValue(os.Args, app.FlagLogFormat)
+
 			closer, err := logging.Init(
 				logsPath,
 				sessionID,
@@ -74,6 +78,7 @@ func main() {
 				logging.DefaultMaxLogFileSize,
 				logging.DefaultPruningSize,
 				"",
+				logFormat,
 			)
 			if err != nil {
 				return err
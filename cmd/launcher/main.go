@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -58,6 +59,8 @@ const (
 	FlagLauncher            = "launcher"
 	FlagWait                = "wait"
 	FlagSessionID           = "session-id"
+	FlagLogFormat           = "log-format"
+	FlagProfile             = "profile"
 	HyphenatedFlagLauncher  = "--" + FlagLauncher
 	HyphenatedFlagWait      = "--" + FlagWait
 	HyphenatedFlagSessionID = "--" + FlagSessionID
@@ -72,13 +75,11 @@ func main() { //nolint:funlen
 	crashHandler := crash.NewHandler(reporter.ReportException)
 	defer async.HandlePanic(crashHandler)
 
-	locationsProvider, err := locations.NewDefaultProvider(filepath.Join(constants.VendorName, constants.ConfigName))
+	locations, err := newLocations(getFlagValue(os.Args[1:], FlagProfile))
 	if err != nil {
 		l.WithError(err).Fatal("Failed to get locations provider")
 	}
 
-	locations := locations.New(locationsProvider, constants.ConfigName)
-
 	logsPath, err := locations.ProvideLogsPath()
 	if err != nil {
 		l.WithError(err).Fatal("Failed to get logs path")
@@ -95,6 +96,7 @@ func main() { //nolint:funlen
 		logging.DefaultMaxLogFileSize,
 		logging.NoPruning,
 		os.Getenv("VERBOSITY"),
+		getFlagValue(os.Args[1:], FlagLogFormat),
 	); err != nil {
 		l.WithError(err).Fatal("Failed to setup logging")
 	}
@@ -196,11 +198,58 @@ func hasFlag(args []string, flag string) bool {
 	return flagIndex(args, flag) >= 0
 }
 
+// newLocations returns the locations of the given profile, or of the default profile if it is empty.
+// Profiles have their own logs but share the updates with the default profile.
+func newLocations(profile string) (*locations.Locations, error) {
+	provider, err := locations.NewDefaultProvider(filepath.Join(constants.VendorName, constants.ConfigName))
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		return locations.New(provider, constants.ConfigName), nil
+	}
+
+	profiles, err := locations.NewDefaultProfiles(filepath.Join(constants.VendorName, constants.ConfigName))
+	if err != nil {
+		return nil, err
+	}
+
+	profileProvider, err := profiles.Provider(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return locations.NewForProfile(profileProvider, provider, constants.ConfigName, profile), nil
+}
+
 // flagIndex returns the position of the first occurrence of a flag int args, or -1 if the flag is not present.
 func flagIndex(args []string, flag string) int {
 	return slices.IndexFunc(args, func(arg string) bool { return (arg == "-"+flag) || (arg == "--"+flag) })
 }
 
+// getFlagValue returns the value of the first occurrence of a flag in args, given either as the next argument or
+// after an equal sign as in --flag=value, or an empty string if there is none.
+func getFlagValue(args []string, flag string) string {
+	for index, arg := range args {
+		if arg == "-"+flag || arg == "--"+flag {
+			if index+1 >= len(args) {
+				return ""
+			}
+
+			return args[index+1]
+		}
+
+		for _, prefix := range []string{"-" + flag + "=", "--" + flag + "="} {
+			if value, ok := strings.CutPrefix(arg, prefix); ok {
+				return value
+			}
+		}
+	}
+
+	return ""
+}
+
 // findAndStrip check if a value is present in s list and remove all occurrences of the value from this list.
 func findAndStrip[T comparable](slice []T, v T) (strippedList []T, found bool) {
 	strippedList = xslices.Filter(slice, func(value T) bool {
@@ -267,6 +316,11 @@ func getPathToUpdatedExecutable(
 		logrus.WithField("version", constants.Version).WithError(err).Error("Failed to parse current version")
 	}
 
+	health, err := ver.GetHealth()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to read the health of installed updates")
+	}
+
 	for _, version := range versions {
 		vlog := logrus.WithFields(logrus.Fields{
 			"version":       constants.Version,
@@ -289,12 +343,36 @@ func getPathToUpdatedExecutable(
 			continue
 		}
 
+		// Skip versions that were rolled back.
+		if health.IsRolledBack(version.SemVer()) {
+			vlog.Info("Skipping version that was rolled back")
+			continue
+		}
+
 		exe, err := version.GetExecutable(name)
 		if err != nil {
 			vlog.WithError(err).Error("Failed to get executable")
 			continue
 		}
 
+		// Until a version has been verified to start, each start is counted; too many and it is rolled back.
+		if !health.IsVerified(version.SemVer()) {
+			failedStarts, err := ver.RecordStart(version.SemVer())
+			if err != nil {
+				vlog.WithError(err).Error("Failed to record start")
+			}
+
+			if failedStarts >= versioner.DefaultMaxFailedStarts {
+				vlog.WithField("failed_starts", failedStarts).Warn("Version failed to start too many times and is rolled back")
+
+				if err := ver.RollBack(version.SemVer()); err != nil {
+					vlog.WithError(err).Error("Failed to roll back version")
+				}
+
+				continue
+			}
+		}
+
 		return exe, nil
 	}
 
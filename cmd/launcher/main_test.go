@@ -79,3 +79,12 @@ func TestAppendOrModifySessionID(t *testing.T) {
 	assert.Equal(t, appendOrModifySessionID([]string{"--cli", "--session-id"}, sessionID), []string{"--cli", "--session-id", sessionID})
 	assert.Equal(t, appendOrModifySessionID([]string{"--session-id", "<oldID>", "--cli"}, sessionID), []string{"--session-id", sessionID, "--cli"})
 }
+
+func TestGetFlagValue(t *testing.T) {
+	assert.Equal(t, "", getFlagValue(nil, FlagLogFormat))
+	assert.Equal(t, "", getFlagValue([]string{"--cli", "--log-format"}, FlagLogFormat))
+	assert.Equal(t, "json", getFlagValue([]string{"--cli", "--log-format", "json"}, FlagLogFormat))
+	assert.Equal(t, "json", getFlagValue([]string{"-log-format", "json", "--cli"}, FlagLogFormat))
+	assert.Equal(t, "work", getFlagValue([]string{"--cli", "--profile=work"}, FlagProfile))
+	assert.Equal(t, "work", getFlagValue([]string{"-profile=work", "--profile", "home"}, FlagProfile))
+}
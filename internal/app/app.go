@@ -67,6 +67,8 @@ const (
 	flagLogLevel      = "log-level"
 	flagLogLevelShort = "l"
 
+	FlagLogFormat = "log-format"
+
 	flagGRPC      = "grpc"
 	flagGRPCShort = "g"
 
@@ -88,6 +90,8 @@ const (
 
 	flagVersion      = "version"
 	flagVersionShort = "v"
+
+	FlagProfile = "profile"
 )
 
 // Hidden flags.
@@ -144,7 +148,12 @@ func New() *cli.App {
 		&cli.StringFlag{
 			Name:    flagLogLevel,
 			Aliases: []string{flagLogLevelShort},
-			Usage:   "Set the log level (one of panic, fatal, error, warn, info, debug)",
+			Usage:   "Set the log level (one of panic, fatal, error, warn, info, debug), optionally followed by per-component levels (e.g. info,smtp=debug,sync=warn)",
+		},
+		&cli.StringFlag{
+			Name:  FlagLogFormat,
+			Usage: "Set the format of the log files (one of text, json)",
+			Value: string(logging.FormatText),
 		},
 		&cli.BoolFlag{
 			Name:    flagGRPC,
@@ -193,6 +202,10 @@ func New() *cli.App {
 			Usage:              "Show the current version of the Proton Mail Bridge",
 			DisableDefaultText: true,
 		},
+		&cli.StringFlag{
+			Name:  FlagProfile,
+			Usage: "Run an isolated instance using the given profile, with its own vault, cache, logs, ports and keychain entry",
+		},
 
 		// Hidden flags
 		&cli.BoolFlag{
@@ -231,6 +244,8 @@ func New() *cli.App {
 		app.Flags = append(app.Flags, cliFlagEnableKeychainTest, cliFlagDisableKeychainTest)
 	}
 
+	app.Commands = []*cli.Command{newProfileCommand(), newDiagnoseCommand(), newUpdateCommand()}
+
 	app.Action = run
 
 	return app
@@ -281,7 +296,7 @@ func run(c *cli.Context) error {
 			// Run with profiling if requested.
 			return withProfiler(c, func() error {
 				// Load the locations where we store our files.
-				return WithLocations(func(locations *locations.Locations) error {
+				return WithProfileLocations(c.String(FlagProfile), func(locations *locations.Locations) error {
 					// Migrate the keychain helper.
 					if err := migrateKeychainHelper(locations); err != nil {
 						logrus.WithError(err).Error("Failed to migrate keychain helper")
@@ -426,6 +441,7 @@ func withLogging(c *cli.Context, crashHandler *crash.Handler, locations *locatio
 		logging.DefaultMaxLogFileSize,
 		logging.DefaultPruningSize,
 		c.String(flagLogLevel),
+		c.String(FlagLogFormat),
 	); err != nil {
 		return fmt.Errorf("could not initialize logging: %w", err)
 	}
@@ -467,7 +483,13 @@ func withLogging(c *cli.Context, crashHandler *crash.Handler, locations *locatio
 
 // WithLocations provides access to locations where we store our files.
 func WithLocations(fn func(*locations.Locations) error) error {
-	logrus.Debug("Creating locations")
+	return WithProfileLocations("", fn)
+}
+
+// WithProfileLocations provides access to locations where we store the files of the given profile.
+// The default profile is used if the profile name is empty.
+func WithProfileLocations(profile string, fn func(*locations.Locations) error) error {
+	logrus.WithField("profile", profile).Debug("Creating locations")
 	defer logrus.Debug("Locations stopped")
 
 	// Create a locations provider to determine where to store our files.
@@ -476,8 +498,23 @@ func WithLocations(fn func(*locations.Locations) error) error {
 		return fmt.Errorf("could not create locations provider: %w", err)
 	}
 
-	// Create a new locations object that will be used to provide paths to store files.
-	return fn(locations.New(provider, constants.ConfigName))
+	if profile == "" {
+		// Create a new locations object that will be used to provide paths to store files.
+		return fn(locations.New(provider, constants.ConfigName))
+	}
+
+	profiles, err := newProfiles()
+	if err != nil {
+		return err
+	}
+
+	profileProvider, err := profiles.Provider(profile)
+	if err != nil {
+		return fmt.Errorf("could not create locations provider for profile: %w", err)
+	}
+
+	// Profiles share the update files with the default profile, as those are managed by the launcher.
+	return fn(locations.NewForProfile(profileProvider, provider, constants.ConfigName, profile))
 }
 
 // Start profiling if requested.
@@ -29,9 +29,11 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
 	"github.com/ProtonMail/proton-bridge/v3/internal/crash"
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
 	"github.com/ProtonMail/proton-bridge/v3/internal/dialer"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/locations"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/sentry"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
@@ -85,10 +87,20 @@ func withBridge(
 	proxyDialer := dialer.NewProxyTLSDialer(pinningDialer, constants.APIHost, crashHandler)
 
 	// Create the autostarter.
-	autostarter := newAutostarter(exe)
+	autostarter := newAutostarter(exe, locations.GetProfile())
+
+	// Managed deployments configure bridge for all the users of the machine with a policy file.
+	pol, err := policy.Load(policy.DefaultPath())
+	if err != nil {
+		return fmt.Errorf("could not load policy: %w", err)
+	}
+
+	if locked := pol.Settings.GetLocked(); len(locked) > 0 {
+		logrus.WithField("locked", locked).Info("Some settings are locked by the policy")
+	}
 
 	// Create the update installer.
-	updater, err := newUpdater(locations)
+	updater, err := newUpdater(locations, pol.Updates)
 	if err != nil {
 		return fmt.Errorf("could not create updater: %w", err)
 	}
@@ -99,10 +111,12 @@ func withBridge(
 		locations,
 		vault,
 		autostarter,
+		desktopnotify.NewNotifier(constants.FullAppName),
 		updater,
 		version,
 		keychains,
 		obsService,
+		pol.Settings,
 
 		// The API stuff.
 		constants.APIHost,
@@ -133,9 +147,19 @@ func withBridge(
 	return fn(bridge, eventCh)
 }
 
-func newAutostarter(exe string) *autostart.App {
+func newAutostarter(exe, profile string) *autostart.App {
 	logrus.Debug("Creating autostarter")
 
+	if profile != "" {
+		name := fmt.Sprintf("%v (%v)", constants.FullAppName, profile)
+
+		return &autostart.App{
+			Name:        name,
+			DisplayName: name,
+			Exec:        []string{exe, "--" + flagNoWindow, "--" + FlagProfile, profile},
+		}
+	}
+
 	return &autostart.App{
 		Name:        constants.FullAppName,
 		DisplayName: constants.FullAppName,
@@ -143,7 +167,7 @@ func newAutostarter(exe string) *autostart.App {
 	}
 }
 
-func newUpdater(locations *locations.Locations) (*updater.Updater, error) {
+func newUpdater(locations *locations.Locations, updatesPolicy policy.Updates) (*updater.Updater, error) {
 	updatesDir, err := locations.ProvideUpdatesPath()
 	if err != nil {
 		return nil, fmt.Errorf("could not provide updates path: %w", err)
@@ -161,10 +185,34 @@ func newUpdater(locations *locations.Locations) (*updater.Updater, error) {
 		return nil, fmt.Errorf("could not create key ring: %w", err)
 	}
 
-	return updater.NewUpdater(
+	upd := updater.NewUpdater(
 		versioner.New(updatesDir),
 		verifier,
 		constants.UpdateName,
 		runtime.GOOS,
-	), nil
+	)
+
+	// Managed deployments can set where updates come from and which versions are allowed.
+	if updatesPolicy.Source != "" {
+		source, err := updater.NewSource(updatesPolicy.Source)
+		if err != nil {
+			return nil, fmt.Errorf("could not create update source: %w", err)
+		}
+
+		upd.SetSource(source)
+	}
+
+	allowedVersions, err := updatesPolicy.GetVersionConstraint()
+	if err != nil {
+		return nil, err
+	}
+
+	upd.SetAllowedVersions(allowedVersions)
+
+	logrus.WithFields(logrus.Fields{
+		"source":          updatesPolicy.Source,
+		"allowedVersions": updatesPolicy.AllowedVersions,
+	}).Debug("Update policy loaded")
+
+	return upd, nil
 }
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/locations"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/allan-simon/go-singleinstance"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagOutput      = "output"
+	flagOutputShort = "o"
+)
+
+func newDiagnoseCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "diagnose",
+		Usage: "Write a diagnostic bundle with logs, settings and account status, without starting bridge or sending anything",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagOutput,
+				Aliases:  []string{flagOutputShort},
+				Usage:    "Path of the zip file to write",
+				Required: true,
+			},
+		},
+		Action: diagnose,
+	}
+}
+
+// diagnose writes a diagnostic bundle from the vault, sync state and logs on disk. Bridge isn't started, so that the
+// bundle can be written when bridge fails to start, and nothing changes on disk or on the server while doing so.
+func diagnose(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	version, err := semver.NewVersion(constants.Version)
+	if err != nil {
+		return fmt.Errorf("could not create version: %w", err)
+	}
+
+	if err := WithProfileLocations(c.String(FlagProfile), func(locations *locations.Locations) error {
+		// A running bridge owns the vault and logs; its own command line interface can write the bundle instead.
+		lock, err := singleinstance.CreateLockFile(locations.GetLockFile())
+		if err != nil {
+			return errors.New("bridge is running: quit it first, or use the diagnose command of its command line interface")
+		}
+
+		defer func() {
+			if err := lock.Close(); err != nil {
+				logrus.WithError(err).Error("Failed to close lock file")
+			}
+		}()
+
+		keychains := keychain.NewList()
+
+		v, encrypted, err := openVault(locations, keychains)
+		if err != nil {
+			return fmt.Errorf("could not open vault: %w", err)
+		}
+
+		return writeDiagnosticBundle(output, func(file *os.File) error {
+			return bridge.WriteOfflineDiagnosticBundle(file, v, locations, keychains, version, encrypted)
+		})
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println("Diagnostic bundle written to", output)
+
+	return nil
+}
+
+// openVault opens the vault for reading, with the key from the keychain. If the key can't be retrieved, bridge uses an
+// insecure vault instead, so that one is opened. The returned bool tells whether the opened vault is encrypted.
+func openVault(locations *locations.Locations, keychains *keychain.List) (*vault.Vault, bool, error) {
+	vaultDir, err := locations.ProvideSettingsPath()
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get vault dir: %w", err)
+	}
+
+	key, err := getVaultKey(vaultDir, keychain.ProfileKeychainName(constants.KeyChainName, locations.GetProfile()), keychains)
+	if err != nil {
+		logrus.WithError(err).Warn("Could not get vault key, opening the insecure vault")
+
+		v, err := vault.Open(filepath.Join(vaultDir, "insecure"), nil)
+
+		return v, false, err
+	}
+
+	v, err := vault.Open(vaultDir, key)
+
+	return v, true, err
+}
+
+// getVaultKey gets the vault key from the keychain, without creating one if there is none.
+func getVaultKey(vaultDir, keychainName string, keychains *keychain.List) ([]byte, error) {
+	helper, err := vault.GetHelper(vaultDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not get keychain helper: %w", err)
+	}
+
+	if helper == "" {
+		helper = keychains.GetDefaultHelper()
+	}
+
+	kc, err := keychains.NewKeychain(helper, keychainName)
+	if err != nil {
+		return nil, fmt.Errorf("could not open keychain: %w", err)
+	}
+
+	return vault.GetVaultKey(kc)
+}
+
+func writeDiagnosticBundle(output string, write func(*os.File) error) error {
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("could not create diagnostic bundle: %w", err)
+	}
+
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("could not write diagnostic bundle: %w", err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/locations"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+	"github.com/allan-simon/go-singleinstance"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+func newProfileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "profile",
+		Usage: "Manage the isolated profiles that can be run with --" + FlagProfile,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the existing profiles",
+				Action: listProfiles,
+			},
+			{
+				Name:      "create",
+				Usage:     "Create a new profile",
+				ArgsUsage: "<name>",
+				Action:    createProfile,
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete a profile with all its accounts, settings and cached data",
+				ArgsUsage: "<name>",
+				Action:    deleteProfile,
+			},
+		},
+	}
+}
+
+func newProfiles() (*locations.Profiles, error) {
+	profiles, err := locations.NewDefaultProfiles(filepath.Join(constants.VendorName, constants.ConfigName))
+	if err != nil {
+		return nil, fmt.Errorf("could not create profiles provider: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func listProfiles(_ *cli.Context) error {
+	profiles, err := newProfiles()
+	if err != nil {
+		return err
+	}
+
+	names, err := profiles.List()
+	if err != nil {
+		return fmt.Errorf("could not list profiles: %w", err)
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No profiles.")
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+
+	return nil
+}
+
+func createProfile(c *cli.Context) error {
+	profile, err := getProfileArg(c)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := newProfiles()
+	if err != nil {
+		return err
+	}
+
+	if err := profiles.Create(profile); err != nil {
+		return fmt.Errorf("could not create profile: %w", err)
+	}
+
+	fmt.Printf("Profile %q created. Start it with --%v %v\n", profile, FlagProfile, profile)
+
+	return nil
+}
+
+func deleteProfile(c *cli.Context) error {
+	profile, err := getProfileArg(c)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := newProfiles()
+	if err != nil {
+		return err
+	}
+
+	if !profiles.Exists(profile) {
+		return fmt.Errorf("could not delete profile: %w: %q", locations.ErrNoSuchProfile, profile)
+	}
+
+	if err := WithProfileLocations(profile, func(locations *locations.Locations) error {
+		// Refuse to delete a profile that is currently running.
+		lock, err := singleinstance.CreateLockFile(locations.GetLockFile())
+		if err != nil {
+			return fmt.Errorf("profile %q is running, quit it before deleting it", profile)
+		}
+
+		if err := lock.Close(); err != nil {
+			logrus.WithError(err).Error("Failed to close lock file")
+		}
+
+		clearProfileKeychain(locations)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := profiles.Delete(profile); err != nil {
+		return fmt.Errorf("could not delete profile: %w", err)
+	}
+
+	fmt.Printf("Profile %q deleted.\n", profile)
+
+	return nil
+}
+
+// clearProfileKeychain removes the vault key and any other item stored in the keychain by the given profile.
+func clearProfileKeychain(locations *locations.Locations) {
+	settings, err := locations.ProvideSettingsPath()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get settings path")
+		return
+	}
+
+	helper, err := vault.GetHelper(settings)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get keychain helper")
+		return
+	}
+
+	keychains := keychain.NewList()
+
+	if helper == "" {
+		helper = keychains.GetDefaultHelper()
+	}
+
+	kc, err := keychains.NewKeychain(helper, keychain.ProfileKeychainName(constants.KeyChainName, locations.GetProfile()))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to open keychain")
+		return
+	}
+
+	if err := kc.Clear(); err != nil {
+		logrus.WithError(err).Error("Failed to clear keychain")
+	}
+}
+
+// setProfilePorts moves the ports of a new vault by the offset of its profile, so that the profile doesn't take the
+// ports of the default profile, or of other profiles, while these are not running.
+func setProfilePorts(vault *vault.Vault, profile string) {
+	if profile == "" {
+		return
+	}
+
+	profiles, err := newProfiles()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get the profiles")
+		return
+	}
+
+	offset, err := profiles.PortOffset(profile)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get the port offset of the profile")
+		return
+	}
+
+	imapPort := ports.FindFreePortFrom(vault.GetIMAPPort() + offset)
+	smtpPort := ports.FindFreePortFrom(vault.GetSMTPPort()+offset, imapPort)
+	imageProxyPort := ports.FindFreePortFrom(vault.GetImageProxyPort()+offset, imapPort, smtpPort)
+
+	logrus.WithFields(logrus.Fields{
+		"profile":        profile,
+		"imapPort":       imapPort,
+		"smtpPort":       smtpPort,
+		"imageProxyPort": imageProxyPort,
+	}).Info("Setting the ports of the profile")
+
+	if err := vault.SetIMAPPort(imapPort); err != nil {
+		logrus.WithError(err).Error("Failed to set the IMAP port of the profile")
+	}
+
+	if err := vault.SetSMTPPort(smtpPort); err != nil {
+		logrus.WithError(err).Error("Failed to set the SMTP port of the profile")
+	}
+
+	if err := vault.SetImageProxyPort(imageProxyPort); err != nil {
+		logrus.WithError(err).Error("Failed to set the image proxy port of the profile")
+	}
+}
+
+func getProfileArg(c *cli.Context) (string, error) {
+	if c.NArg() != 1 {
+		return "", errors.New("expected exactly one profile name")
+	}
+
+	profile := c.Args().First()
+
+	if err := locations.ValidateProfileName(profile); err != nil {
+		return "", err
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/locations"
+	"github.com/ProtonMail/proton-bridge/v3/internal/versioner"
+	"github.com/urfave/cli/v2"
+)
+
+func newUpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "update",
+		Usage: "Manage the installed updates",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "rollback",
+				Usage:  "Stop using the running version and go back to the previous one on next start",
+				Action: rollbackUpdate,
+			},
+		},
+	}
+}
+
+func rollbackUpdate(_ *cli.Context) error {
+	version, err := semver.NewVersion(constants.Version)
+	if err != nil {
+		return fmt.Errorf("could not parse version: %w", err)
+	}
+
+	return WithLocations(func(locations *locations.Locations) error {
+		updatesDir, err := locations.ProvideUpdatesPath()
+		if err != nil {
+			return fmt.Errorf("could not provide updates path: %w", err)
+		}
+
+		if err := versioner.New(updatesDir).RollBack(version); err != nil {
+			return fmt.Errorf("could not roll back version %v: %w", version, err)
+		}
+
+		fmt.Printf("Version %v was rolled back. Restart Bridge to start the previous version.\n", version)
+
+		return nil
+	})
+}
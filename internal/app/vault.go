@@ -58,6 +58,10 @@ func WithVault(reporter *sentry.Reporter, locations *locations.Locations, keycha
 		logrus.WithError(corrupt).Warn("Failed to load existing vault, vault has been reset")
 	}
 
+	if encVault.GetFirstStart() {
+		setProfilePorts(encVault, locations.GetProfile())
+	}
+
 	cert, _ := encVault.GetBridgeTLSCert()
 	certs.NewInstaller().LogCertInstallStatus(cert)
 
@@ -74,13 +78,22 @@ func newVault(reporter *sentry.Reporter, locations *locations.Locations, keychai
 
 	logrus.WithField("vaultDir", vaultDir).Debug("Loading vault from directory")
 
+	keychainName := keychain.ProfileKeychainName(constants.KeyChainName, locations.GetProfile())
+
+	// Complete or revert a vault key rotation that may have been interrupted by a crash, before the key is loaded.
+	if err := vault.RecoverKeyRotation(vaultDir, func(helper string) (*keychain.Keychain, error) {
+		return keychains.NewKeychain(helper, keychainName)
+	}); err != nil {
+		return nil, false, nil, fmt.Errorf("could not recover vault key rotation: %w", err)
+	}
+
 	var (
 		vaultKey       []byte
 		insecure       bool
 		lastUsedHelper string
 	)
 
-	if key, helper, err := loadVaultKey(vaultDir, keychains, featureFlags); err != nil {
+	if key, helper, err := loadVaultKey(vaultDir, keychainName, keychains, featureFlags); err != nil {
 		if errors.Is(err, keychain.ErrPreferredKeychainNotAvailable) {
 			if err := vault.IncrementKeychainFailedAttemptCount(vaultDir); err != nil {
 				logrus.WithError(err).Error("Failed to increment failed keychain attempt count")
@@ -142,7 +155,7 @@ func newVault(reporter *sentry.Reporter, locations *locations.Locations, keychai
 }
 
 // loadVaultKey - loads the key used to encrypt the vault alongside the keychain helper used to access it.
-func loadVaultKey(vaultDir string, keychains *keychain.List, featureFlags unleash.FeatureFlagStartupStore) (key []byte, keychainHelper string, err error) {
+func loadVaultKey(vaultDir, keychainName string, keychains *keychain.List, featureFlags unleash.FeatureFlagStartupStore) (key []byte, keychainHelper string, err error) {
 	keychainHelper, err = vault.GetHelper(vaultDir)
 	if err != nil {
 		return nil, keychainHelper, fmt.Errorf("could not get keychain helper: %w", err)
@@ -154,7 +167,7 @@ func loadVaultKey(vaultDir string, keychains *keychain.List, featureFlags unleas
 	}
 
 	kc, keychainHelper, err := keychain.NewKeychain(
-		keychainHelper, constants.KeyChainName,
+		keychainHelper, keychainName,
 		keychains.GetHelpers(),
 		keychains.GetDefaultHelper(),
 		keychainFailedAttemptCount,
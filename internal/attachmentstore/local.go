@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package attachmentstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoBaseURL is returned when uploading to a directory which isn't served under any URL.
+var ErrNoBaseURL = errors.New("the attachment directory has no base URL")
+
+// LocalDir stores attachments in a local directory, e.g. one served by a web server or synced by a file sharing client.
+// Each attachment is written to its own randomly named subdirectory so its link can't be guessed.
+type LocalDir struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalDir returns a store writing attachments to the given directory.
+// Links are formed by appending the attachment's path to the base URL, under which the directory must be served.
+// File URLs aren't supported: they would only work on the sender's machine.
+func NewLocalDir(dir, baseURL string) *LocalDir {
+	return &LocalDir{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (store *LocalDir) Upload(ctx context.Context, name, _ string, data []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if store.baseURL == "" {
+		return "", ErrNoBaseURL
+	}
+
+	id, err := newRandomID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate attachment ID: %w", err)
+	}
+
+	name = sanitizeName(name)
+
+	if err := os.MkdirAll(filepath.Join(store.dir, id), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	path := filepath.Join(store.dir, id, name)
+
+	if err := os.WriteFile(path, data, 0o640); err != nil { //nolint:gosec
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	return store.baseURL + "/" + id + "/" + url.PathEscape(name), nil
+}
+
+// sanitizeName returns the base name of the given file name, so that attachments can't be written outside their directory.
+func sanitizeName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	if name == "." || name == ".." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
+
+func newRandomID() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package attachmentstore
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalDir_Upload(t *testing.T) {
+	dir := t.TempDir()
+
+	store := NewLocalDir(dir, "https://files.example.com/att/")
+
+	link, err := store.Upload(context.Background(), "my report.pdf", "application/pdf", []byte("data"))
+	require.NoError(t, err)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	require.Equal(t, "files.example.com", u.Host)
+	require.Equal(t, "my report.pdf", path.Base(u.Path))
+
+	id := path.Base(path.Dir(u.Path))
+	require.Len(t, id, 32)
+
+	data, err := os.ReadFile(filepath.Join(dir, id, "my report.pdf"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	// Each upload gets its own directory.
+	other, err := store.Upload(context.Background(), "my report.pdf", "application/pdf", []byte("other"))
+	require.NoError(t, err)
+	require.NotEqual(t, link, other)
+}
+
+func TestLocalDir_UploadNoBaseURL(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewLocalDir(dir, "").Upload(context.Background(), "image.png", "image/png", []byte("data"))
+	require.ErrorIs(t, err, ErrNoBaseURL)
+
+	// Nothing is written without a link to it.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestLocalDir_UploadSanitizesName(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"../../escape.txt", "..\\escape.txt", "..", ""} {
+		link, err := NewLocalDir(dir, "https://files.example.com").Upload(context.Background(), name, "text/plain", []byte("data"))
+		require.NoError(t, err)
+
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+
+		rel, err := url.PathUnescape(strings.TrimPrefix(u.EscapedPath(), "/"))
+		require.NoError(t, err)
+		require.NotContains(t, rel, "..")
+		require.Equal(t, ".", path.Dir(path.Dir(rel)))
+
+		_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(rel)))
+		require.NoError(t, err)
+	}
+}
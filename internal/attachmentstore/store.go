@@ -0,0 +1,28 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package attachmentstore holds the attachments too large to be sent with a message.
+// The message carries a link to the stored attachment instead.
+package attachmentstore
+
+import "context"
+
+// Store uploads attachments and returns the links under which they can be downloaded.
+type Store interface {
+	// Upload stores the attachment with the given file name and MIME type and returns its link.
+	Upload(ctx context.Context, name, mimeType string, data []byte) (string, error)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package audit keeps an encrypted, append-only record of what mail clients did through Bridge: which clients logged
+// in and from where, which messages they moved, removed or flagged, and which messages they sent.
+package audit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Action is the kind of an audited action.
+type Action string
+
+const (
+	ActionIMAPLogin Action = "imap-login"
+	ActionSMTPLogin Action = "smtp-login"
+	ActionMove      Action = "move"
+	ActionRemove    Action = "remove"
+	ActionFlag      Action = "flag"
+	ActionSend      Action = "send"
+)
+
+// Actions lists all the audited actions.
+var Actions = []Action{ActionIMAPLogin, ActionSMTPLogin, ActionMove, ActionRemove, ActionFlag, ActionSend} //nolint:gochecknoglobals
+
+// Entry is a record of the audit log.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	UserID string    `json:"userID"`
+	Action Action    `json:"action"`
+
+	// Client is the name and version of the mail client, as reported by the client itself.
+	Client string `json:"client,omitempty"`
+
+	// Remote is the network address of the client. For actions of IMAP sessions, which don't tell their session, it
+	// lists the addresses of the sessions of the user whose client reported the same name.
+	Remote string `json:"remote,omitempty"`
+
+	// Address is the email address the client logged in or sent from.
+	Address string `json:"address,omitempty"`
+
+	// Mailbox is the mailbox the messages were moved, removed or flagged in.
+	Mailbox string `json:"mailbox,omitempty"`
+
+	// Target is the mailbox the messages were moved to, or the flag that was set or cleared.
+	Target string `json:"target,omitempty"`
+
+	// MessageIDs are the IDs of the messages the action was about. Sent messages are identified by their Message-ID.
+	MessageIDs []string `json:"messageIDs,omitempty"`
+
+	// Recipients are the recipients of a sent message.
+	Recipients []string `json:"recipients,omitempty"`
+
+	// Error is why a login or a sent message failed. Failed logins are recorded without a user.
+	Error string `json:"error,omitempty"`
+}
+
+// String returns a one-line description of the entry.
+func (e Entry) String() string {
+	var b strings.Builder
+
+	b.WriteString(e.Time.Local().Format(time.DateTime))
+	b.WriteString(" " + string(e.Action))
+
+	for _, field := range []struct{ name, value string }{
+		{"user", e.UserID},
+		{"client", e.Client},
+		{"remote", e.Remote},
+		{"address", e.Address},
+		{"mailbox", e.Mailbox},
+		{"target", e.Target},
+		{"messages", strings.Join(e.MessageIDs, ",")},
+		{"recipients", strings.Join(e.Recipients, ",")},
+	} {
+		if field.value != "" {
+			b.WriteString(" " + field.name + "=" + field.value)
+		}
+	}
+
+	if e.Error != "" {
+		b.WriteString(" error=" + strconv.Quote(e.Error))
+	}
+
+	return b.String()
+}
+
+// Recorder records audited actions.
+type Recorder interface {
+	Record(entry Entry)
+}
+
+// Filter selects entries of the audit log. Zero fields don't filter anything.
+type Filter struct {
+	Since   time.Time
+	Until   time.Time
+	UserID  string
+	Actions []Action
+
+	// Limit is the maximum number of entries to return; the most recent ones are kept.
+	Limit int
+}
+
+func (f Filter) matches(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && !e.Time.Before(f.Until) {
+		return false
+	}
+
+	if f.UserID != "" && e.UserID != f.UserID {
+		return false
+	}
+
+	if len(f.Actions) > 0 && !slices.Contains(f.Actions, e.Action) {
+		return false
+	}
+
+	return true
+}
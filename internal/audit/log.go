@@ -0,0 +1,500 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	currentFileName   = "audit.log"
+	rotatedFilePrefix = "audit-"
+	fileSuffix        = ".log"
+
+	// rotatedFileTime names rotated files so that they sort in the order they were written.
+	rotatedFileTime = "20060102T150405.000000000"
+
+	// maxRecordSize is the size of the largest record that can be read back.
+	maxRecordSize = 16 << 20
+
+	// DefaultRotationSize is the size above which the current file of the log is rotated.
+	DefaultRotationSize = 4 << 20
+
+	// DefaultMaxFiles is the number of files of the log, including the current one, above which the oldest are removed.
+	DefaultMaxFiles = 25
+)
+
+var (
+	// ErrCorrupt is reported for records that can't be read back: they were changed, cut short by a crash, or
+	// written with another key.
+	ErrCorrupt = errors.New("the audit log record can't be read")
+
+	// ErrTampered is reported for records that aren't chained to the record before them, because records were
+	// removed or reordered.
+	ErrTampered = errors.New("records of the audit log were removed or reordered before this one")
+)
+
+// Corruption is a record of the log that is corrupt or not chained to the record before it.
+type Corruption struct {
+	// File is the name of the file of the record.
+	File string
+
+	// Line is the line of the record in the file, starting at 1.
+	Line int
+
+	Err error
+}
+
+func (c Corruption) String() string {
+	return fmt.Sprintf("%v:%v: %v", c.File, c.Line, c.Err)
+}
+
+// Log is the audit log, stored as files of records in a directory.
+//
+// Each record is a line holding the hash of the previous line and the entry encrypted with AES-GCM. The hash is
+// authenticated along with the entry, so that records can't be removed or reordered without it being noticed.
+// Once the current file grows over the rotation size, it is renamed and a new one is started; the oldest files are
+// removed when there are more than the maximum number of files.
+type Log struct {
+	dir          string
+	gcm          cipher.AEAD
+	rotationSize int64
+	maxFiles     int
+	isEnabled    func() bool
+
+	// prev is the hash of the last record written.
+	prev []byte
+
+	lock sync.Mutex
+}
+
+// NewLog opens the audit log in the given directory, encrypted with the given key.
+// Entries are only recorded while isEnabled returns true; the log can be queried either way.
+func NewLog(dir string, key []byte, rotationSize int64, maxFiles int, isEnabled func() bool) (*Log, error) {
+	hash := sha256.Sum256(key)
+
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &Log{
+		dir:          dir,
+		gcm:          gcm,
+		rotationSize: rotationSize,
+		maxFiles:     maxFiles,
+		isEnabled:    isEnabled,
+	}
+
+	// Continue the chain of records from the last one written.
+	if log.prev, err = log.lastRecordHash(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return log, nil
+}
+
+// Record appends the entry to the log, if it is enabled. Failures are logged, as they must not stop the action.
+func (l *Log) Record(entry Entry) {
+	if l.isEnabled != nil && !l.isEnabled() {
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if err := l.record(entry); err != nil {
+		logrus.WithField("pkg", "audit").WithError(err).WithField("action", entry.Action).Error("Failed to record audit entry")
+	}
+}
+
+func (l *Log) record(entry Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := l.rotate(); err != nil {
+		return err
+	}
+
+	line, err := l.encrypt(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Join(l.dir, currentFileName), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	// A record cut short by a crash would otherwise swallow this one; it is ended first.
+	if partial, err := endsWithPartialRecord(file); err != nil {
+		_ = file.Close()
+		return err
+	} else if partial {
+		line = append([]byte{'\n'}, line...)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	l.prev = hashRecord(bytes.TrimPrefix(line, []byte{'\n'}))
+
+	return nil
+}
+
+// endsWithPartialRecord returns whether the file doesn't end with a complete line.
+func endsWithPartialRecord(file *os.File) (bool, error) {
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return false, err
+	}
+
+	last := make([]byte, 1)
+
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return false, err
+	}
+
+	return last[0] != '\n', nil
+}
+
+// Query returns the entries matching the filter, oldest first, and the corrupt records met while reading them.
+// Corrupt records are left out; those that are only not chained to the record before them are still returned.
+// The log is read as it was when the query started, so that recording isn't blocked while it is read.
+func (l *Log) Query(filter Filter) ([]Entry, []Corruption, error) {
+	files, err := l.snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		entries     []Entry
+		corruptions []Corruption
+		prev        []byte
+	)
+
+	for _, file := range files {
+		r, err := l.openFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			// The file was removed by a rotation since; the records after it can't be checked against it.
+			prev = nil
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		name := filepath.Base(file.path)
+
+		lineNum, err := forEachRecord(io.LimitReader(r, file.info.Size()), func(lineNum int, line []byte) {
+			entry, linked, err := l.decrypt(line)
+
+			// The first record read has no previous record to check, as the files before it may have been removed by rotation.
+			if err == nil && prev != nil && !bytes.Equal(linked, prev) {
+				corruptions = append(corruptions, Corruption{File: name, Line: lineNum, Err: ErrTampered})
+			}
+
+			prev = hashRecord(line)
+
+			if err != nil {
+				corruptions = append(corruptions, Corruption{File: name, Line: lineNum, Err: err})
+			} else if filter.matches(entry) {
+				entries = append(entries, entry)
+			}
+		})
+
+		_ = r.Close()
+
+		if err != nil {
+			// The rest of the file can't be read, e.g. because of a line longer than any record.
+			corruptions = append(corruptions, Corruption{File: name, Line: lineNum + 1, Err: fmt.Errorf("%w: %v", ErrCorrupt, err)})
+			prev = nil
+		}
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+
+	return entries, corruptions, nil
+}
+
+// logFile is a file of the log, as it was when it was listed.
+type logFile struct {
+	path string
+	info os.FileInfo
+}
+
+// snapshot lists the files of the log, oldest first, with their size at that point.
+func (l *Log) snapshot() ([]logFile, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	paths, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]logFile, 0, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, logFile{path: path, info: info})
+	}
+
+	return files, nil
+}
+
+// openFile opens a file listed by snapshot. The current file may have been rotated since, under another name.
+// If the file was removed since, an error wrapping os.ErrNotExist is returned.
+func (l *Log) openFile(file logFile) (*os.File, error) {
+	if r, ok, err := openSameFile(file.path, file.info); ok || err != nil {
+		return r, err
+	}
+
+	l.lock.Lock()
+	paths, err := l.files()
+	l.lock.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		if r, ok, err := openSameFile(path, file.info); ok || err != nil {
+			return r, err
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", filepath.Base(file.path), os.ErrNotExist)
+}
+
+// openSameFile opens the file at the given path if it is the given file.
+func openSameFile(path string, info os.FileInfo) (*os.File, bool, error) {
+	r, err := os.Open(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	if rInfo, err := r.Stat(); err != nil || !os.SameFile(rInfo, info) {
+		_ = r.Close()
+		return nil, false, err
+	}
+
+	return r, true, nil
+}
+
+// encrypt returns the record of the entry, chained to the previous record.
+func (l *Log) encrypt(entry Entry) ([]byte, error) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, l.gcm.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	prev := base64.StdEncoding.EncodeToString(l.prev)
+	data := base64.StdEncoding.EncodeToString(l.gcm.Seal(nonce, nonce, b, l.prev))
+
+	return []byte(prev + " " + data), nil
+}
+
+// decrypt returns the entry of the record, and the hash of the previous record it is chained to.
+func (l *Log) decrypt(line []byte) (Entry, []byte, error) {
+	prevEnc, dataEnc, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return Entry{}, nil, ErrCorrupt
+	}
+
+	linked, err := base64.StdEncoding.DecodeString(string(prevEnc))
+	if err != nil {
+		return Entry{}, nil, ErrCorrupt
+	}
+
+	data, err := base64.StdEncoding.DecodeString(string(dataEnc))
+	if err != nil || len(data) < l.gcm.NonceSize() {
+		return Entry{}, nil, ErrCorrupt
+	}
+
+	b, err := l.gcm.Open(nil, data[:l.gcm.NonceSize()], data[l.gcm.NonceSize():], linked)
+	if err != nil {
+		return Entry{}, nil, ErrCorrupt
+	}
+
+	var entry Entry
+
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return Entry{}, nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+
+	return entry, linked, nil
+}
+
+// rotate renames the current file if it is over the rotation size, and removes the oldest files if there are too many.
+func (l *Log) rotate() error {
+	current := filepath.Join(l.dir, currentFileName)
+
+	info, err := os.Stat(current)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() < l.rotationSize) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	rotated := filepath.Join(l.dir, rotatedFilePrefix+time.Now().UTC().Format(rotatedFileTime)+fileSuffix)
+
+	if err := os.Rename(current, rotated); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+
+	paths, err := l.files()
+	if err != nil {
+		return err
+	}
+
+	// Make room for the new current file.
+	for len(paths) >= l.maxFiles {
+		if err := os.Remove(paths[0]); err != nil {
+			return fmt.Errorf("failed to remove old audit log: %w", err)
+		}
+
+		paths = paths[1:]
+	}
+
+	return nil
+}
+
+// files returns the paths of the files of the log, oldest first.
+func (l *Log) files() ([]string, error) {
+	dirEntries, err := os.ReadDir(l.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var rotated []string
+
+	for _, dirEntry := range dirEntries {
+		if name := dirEntry.Name(); strings.HasPrefix(name, rotatedFilePrefix) && strings.HasSuffix(name, fileSuffix) {
+			rotated = append(rotated, filepath.Join(l.dir, name))
+		}
+	}
+
+	sort.Strings(rotated)
+
+	if _, err := os.Stat(filepath.Join(l.dir, currentFileName)); err == nil {
+		rotated = append(rotated, filepath.Join(l.dir, currentFileName))
+	}
+
+	return rotated, nil
+}
+
+// lastRecordHash returns the hash of the last record of the log, or nil if it is empty.
+func (l *Log) lastRecordHash() ([]byte, error) {
+	paths, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var last []byte
+
+	for i := len(paths) - 1; i >= 0 && last == nil; i-- {
+		file, err := os.Open(paths[i]) //nolint:gosec
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = forEachRecord(file, func(_ int, line []byte) {
+			last = hashRecord(line)
+		})
+
+		_ = file.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return last, nil
+}
+
+// forEachRecord calls fn with each record read from r and its line number, and returns the number of the last line read.
+func forEachRecord(r io.Reader, fn func(lineNum int, line []byte)) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var lineNum int
+
+	for scanner.Scan() {
+		lineNum++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		fn(lineNum, scanner.Bytes())
+	}
+
+	return lineNum, scanner.Err()
+}
+
+func hashRecord(line []byte) []byte {
+	hash := sha256.Sum256(line)
+
+	return hash[:]
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_RecordQuery(t *testing.T) {
+	log, err := NewLog(t.TempDir(), []byte("key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	log.Record(Entry{Time: now.Add(-2 * time.Hour), UserID: "user1", Action: ActionIMAPLogin, Client: "Thunderbird/115", Remote: "127.0.0.1:5000"})
+	log.Record(Entry{Time: now.Add(-time.Hour), UserID: "user1", Action: ActionMove, Mailbox: "Inbox", Target: "Archive", MessageIDs: []string{"m1", "m2"}})
+	log.Record(Entry{Time: now, UserID: "user2", Action: ActionSend, Address: "user2@pm.me", Recipients: []string{"a@b.c"}})
+
+	entries, corruptions, err := log.Query(Filter{})
+	require.NoError(t, err)
+	require.Empty(t, corruptions)
+	require.Len(t, entries, 3)
+	require.Equal(t, []string{"m1", "m2"}, entries[1].MessageIDs)
+
+	entries, _, err = log.Query(Filter{UserID: "user1"})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, _, err = log.Query(Filter{Actions: []Action{ActionSend, ActionIMAPLogin}})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, _, err = log.Query(Filter{Since: now.Add(-90 * time.Minute), Until: now})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, ActionMove, entries[0].Action)
+
+	entries, _, err = log.Query(Filter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, ActionSend, entries[0].Action)
+}
+
+func TestLog_Disabled(t *testing.T) {
+	enabled := false
+
+	log, err := NewLog(t.TempDir(), []byte("key"), DefaultRotationSize, DefaultMaxFiles, func() bool { return enabled })
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionFlag})
+
+	enabled = true
+
+	log.Record(Entry{UserID: "user", Action: ActionRemove})
+
+	entries, corruptions, err := log.Query(Filter{})
+	require.NoError(t, err)
+	require.Empty(t, corruptions)
+	require.Len(t, entries, 1)
+	require.Equal(t, ActionRemove, entries[0].Action)
+	require.False(t, entries[0].Time.IsZero())
+}
+
+func TestLog_Encrypted(t *testing.T) {
+	dir := t.TempDir()
+
+	log, err := NewLog(dir, []byte("key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionSend, Recipients: []string{"secret@example.com"}})
+
+	b, err := os.ReadFile(filepath.Join(dir, currentFileName))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "secret@example.com")
+
+	other, err := NewLog(dir, []byte("other key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	entries, corruptions, err := other.Query(Filter{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.Len(t, corruptions, 1)
+	require.ErrorIs(t, corruptions[0].Err, ErrCorrupt)
+}
+
+func TestLog_Rotation(t *testing.T) {
+	dir := t.TempDir()
+
+	log, err := NewLog(dir, []byte("key"), 1, 3, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		log.Record(Entry{UserID: "user", Action: ActionFlag, Target: "seen"})
+	}
+
+	// Every record is in its own file, and only the last three files are kept.
+	paths, err := log.files()
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+
+	entries, corruptions, err := log.Query(Filter{})
+	require.NoError(t, err)
+	require.Empty(t, corruptions)
+	require.Len(t, entries, 3)
+
+	// The chain continues after reopening the log.
+	log, err = NewLog(dir, []byte("key"), 1, 3, nil)
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionFlag, Target: "unseen"})
+
+	entries, _, err = log.Query(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, "unseen", entries[2].Target)
+}
+
+func TestLog_Tampered(t *testing.T) {
+	dir := t.TempDir()
+
+	log, err := NewLog(dir, []byte("key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	for _, action := range []Action{ActionIMAPLogin, ActionRemove, ActionSend} {
+		log.Record(Entry{UserID: "user", Action: action})
+	}
+
+	path := filepath.Join(dir, currentFileName)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// The first record starts with a space, as it isn't chained to any record.
+	lines := bytes.Split(bytes.TrimSuffix(b, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	// Removing a record breaks the chain. The remaining entries are still returned.
+	require.NoError(t, os.WriteFile(path, bytes.Join([][]byte{lines[0], lines[2]}, []byte("\n")), 0o600))
+
+	entries, corruptions, err := log.Query(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, []Corruption{{File: currentFileName, Line: 2, Err: ErrTampered}}, corruptions)
+
+	// So does reordering records.
+	require.NoError(t, os.WriteFile(path, bytes.Join([][]byte{lines[0], lines[2], lines[1]}, []byte("\n")), 0o600))
+
+	entries, corruptions, err = log.Query(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, []Corruption{
+		{File: currentFileName, Line: 2, Err: ErrTampered},
+		{File: currentFileName, Line: 3, Err: ErrTampered},
+	}, corruptions)
+
+	// A changed record is left out, without hiding the others.
+	changed := bytes.Clone(lines[1])
+	changed[len(changed)-2] ^= 1
+
+	require.NoError(t, os.WriteFile(path, bytes.Join([][]byte{lines[0], changed, lines[2]}, []byte("\n")), 0o600))
+
+	entries, corruptions, err = log.Query(Filter{})
+	require.NoError(t, err)
+	require.Equal(t, []Action{ActionIMAPLogin, ActionSend}, []Action{entries[0].Action, entries[1].Action})
+	require.Len(t, corruptions, 2)
+	require.ErrorIs(t, corruptions[0].Err, ErrCorrupt)
+	require.Equal(t, 2, corruptions[0].Line)
+	require.Equal(t, Corruption{File: currentFileName, Line: 3, Err: ErrTampered}, corruptions[1])
+}
+
+func TestLog_PartialRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	log, err := NewLog(dir, []byte("key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionIMAPLogin})
+	log.Record(Entry{UserID: "user", Action: ActionRemove})
+
+	// A crash cuts the last record short.
+	path := filepath.Join(dir, currentFileName)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b[:len(b)-10], 0o600))
+
+	// Records written after it are read back.
+	log, err = NewLog(dir, []byte("key"), DefaultRotationSize, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionSend})
+
+	entries, corruptions, err := log.Query(Filter{})
+	require.NoError(t, err)
+	require.Equal(t, []Action{ActionIMAPLogin, ActionSend}, []Action{entries[0].Action, entries[1].Action})
+	require.Len(t, corruptions, 1)
+	require.ErrorIs(t, corruptions[0].Err, ErrCorrupt)
+	require.Equal(t, 2, corruptions[0].Line)
+}
+
+func TestLog_QueryRotatedSince(t *testing.T) {
+	dir := t.TempDir()
+
+	log, err := NewLog(dir, []byte("key"), 1, DefaultMaxFiles, nil)
+	require.NoError(t, err)
+
+	log.Record(Entry{UserID: "user", Action: ActionIMAPLogin})
+
+	files, err := log.snapshot()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// The current file is rotated after the snapshot; it is still read under its new name.
+	log.Record(Entry{UserID: "user", Action: ActionSend})
+
+	r, err := log.openFile(files[0])
+	require.NoError(t, err)
+	defer r.Close() //nolint:errcheck
+
+	require.NotEqual(t, files[0].path, r.Name())
+}
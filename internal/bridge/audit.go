@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"fmt"
+
+	imapEvents "github.com/ProtonMail/gluon/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// newAuditLog opens the audit log. Vaults created before the audit log existed get a key.
+func newAuditLog(bridge *Bridge) (*audit.Log, error) {
+	if len(bridge.vault.GetAuditLogKey()) == 0 {
+		if err := bridge.vault.ResetAuditLogKey(); err != nil {
+			return nil, fmt.Errorf("failed to save audit log key: %w", err)
+		}
+	}
+
+	dir, err := bridge.locator.ProvideAuditLogPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to provide audit log path: %w", err)
+	}
+
+	return audit.NewLog(dir, bridge.vault.GetAuditLogKey(), audit.DefaultRotationSize, audit.DefaultMaxFiles, bridge.vault.GetAuditLogEnabled)
+}
+
+// GetAuditLogEnabled returns whether the actions of mail clients are recorded in the audit log.
+func (bridge *Bridge) GetAuditLogEnabled() bool {
+	return bridge.vault.GetAuditLogEnabled()
+}
+
+// SetAuditLogEnabled sets whether the actions of mail clients are recorded in the audit log.
+// Disabling it keeps the entries already recorded.
+func (bridge *Bridge) SetAuditLogEnabled(enabled bool) error {
+	logPkg.WithField("enabled", enabled).Info("Setting audit log")
+
+	return bridge.vault.SetAuditLogEnabled(enabled)
+}
+
+// QueryAuditLog returns the entries of the audit log that match the filter, oldest first, and the corrupt records
+// met while reading them.
+func (bridge *Bridge) QueryAuditLog(filter audit.Filter) ([]audit.Entry, []audit.Corruption, error) {
+	return bridge.auditLog.Query(filter)
+}
+
+// auditIMAPEvent records IMAP logins in the audit log. The client and its address are taken from the session registry.
+// It is only called from the goroutine handling IMAP events, after the registry is updated.
+func (bridge *Bridge) auditIMAPEvent(event imapEvents.Event) {
+	switch event := event.(type) {
+	case imapEvents.Login:
+		session, _ := bridge.getIMAPSession(event.SessionID)
+
+		bridge.auditLog.Record(audit.Entry{
+			UserID: bridge.getUserIDFromGluonID(event.UserID),
+			Action: audit.ActionIMAPLogin,
+			Client: session.Client,
+			Remote: session.Remote,
+		})
+
+	case imapEvents.LoginFailed:
+		session, _ := bridge.getIMAPSession(event.SessionID)
+
+		bridge.auditLog.Record(audit.Entry{
+			Action:  audit.ActionIMAPLogin,
+			Client:  session.Client,
+			Remote:  session.Remote,
+			Address: event.Username,
+			Error:   "invalid username or password",
+		})
+	}
+}
+
+// bridgeAuditRecorder records the actions of IMAP clients in the audit log, with the address of their sessions.
+type bridgeAuditRecorder struct {
+	b *Bridge
+}
+
+func (r *bridgeAuditRecorder) Record(entry audit.Entry) {
+	r.b.auditLog.Record(entry)
+}
+
+func (r *bridgeAuditRecorder) GetIMAPSessionRemote(userID, client string) string {
+	return r.b.getIMAPSessionRemote(userID, client)
+}
+
+// getUserIDFromGluonID returns the ID of the user with the given gluon ID, or the gluon ID if there is none.
+func (bridge *Bridge) getUserIDFromGluonID(gluonID string) string {
+	return safe.RLockRet(func() string {
+		for userID, user := range bridge.users {
+			if slices.Contains(maps.Values(user.GetGluonIDs()), gluonID) {
+				return userID
+			}
+		}
+
+		return gluonID
+	}, bridge.usersLock)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/bradenaw/juniper/xslices"
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_AuditLog(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			require.False(t, b.GetAuditLogEnabled())
+			require.NoError(t, b.SetAuditLogEnabled(true))
+
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			// Send a message over SMTP.
+			smtpClient, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+			require.NoError(t, err)
+			defer smtpClient.Close() //nolint:errcheck
+
+			require.NoError(t, smtpClient.Hello("audit-client"))
+			require.NoError(t, smtpClient.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+			require.Error(t, smtpClient.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], "wrong password")))
+			require.NoError(t, smtpClient.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+			// Sending from an address of someone else fails.
+			require.NoError(t, smtpClient.Mail("someone@example.com", nil))
+			require.NoError(t, smtpClient.Rcpt("recipient@"+s.GetDomain()))
+
+			w, err := smtpClient.Data()
+			require.NoError(t, err)
+
+			_, err = w.Write([]byte("Message-Id: <forged@example.com>\r\nSubject: Audit\r\n\r\nHello world!"))
+			require.NoError(t, err)
+			require.Error(t, w.Close())
+
+			require.NoError(t, smtpClient.SendMail(
+				info.Addresses[0],
+				[]string{"recipient@" + s.GetDomain()},
+				strings.NewReader("Message-Id: <audit@example.com>\r\nSubject: Audit\r\n\r\nHello world!"),
+			))
+
+			// Flag it and move it to the archive over IMAP.
+			imapClient, err := eventuallyDial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetIMAPPort())))
+			require.NoError(t, err)
+			require.Error(t, imapClient.Login(info.Addresses[0], "wrong password"))
+			require.NoError(t, imapClient.Login(info.Addresses[0], string(info.BridgePass)))
+			defer imapClient.Logout() //nolint:errcheck
+
+			require.Eventually(t, func() bool {
+				status, err := imapClient.Status("Sent", []imap.StatusItem{imap.StatusMessages})
+				require.NoError(t, err)
+
+				return status.Messages == 1
+			}, 10*time.Second, 100*time.Millisecond)
+
+			_, err = imapClient.Select("Sent", false)
+			require.NoError(t, err)
+
+			seq := new(imap.SeqSet)
+			seq.AddNum(1)
+
+			require.NoError(t, imapClient.Store(seq, imap.FormatFlagsOp(imap.AddFlags, true), []interface{}{imap.FlaggedFlag}, nil))
+			require.NoError(t, imapClient.Move(seq, "Archive"))
+
+			// IMAP logins are recorded asynchronously.
+			require.Eventually(t, func() bool {
+				entries, _, err := b.QueryAuditLog(audit.Filter{UserID: userID, Actions: []audit.Action{audit.ActionIMAPLogin}})
+				require.NoError(t, err)
+
+				return len(entries) == 1
+			}, 10*time.Second, 100*time.Millisecond)
+
+			entries, corruptions, err := b.QueryAuditLog(audit.Filter{UserID: userID})
+			require.NoError(t, err)
+			require.Empty(t, corruptions)
+
+			actions := make(map[audit.Action][]audit.Entry)
+			for _, entry := range entries {
+				actions[entry.Action] = append(actions[entry.Action], entry)
+			}
+
+			require.Len(t, actions[audit.ActionSMTPLogin], 1)
+			require.Equal(t, info.Addresses[0], actions[audit.ActionSMTPLogin][0].Address)
+			require.Equal(t, "audit-client", actions[audit.ActionSMTPLogin][0].Client)
+			require.NotEmpty(t, actions[audit.ActionSMTPLogin][0].Remote)
+			require.NotEmpty(t, actions[audit.ActionIMAPLogin][0].Remote)
+
+			require.Len(t, actions[audit.ActionSend], 2)
+			require.Equal(t, []string{"<forged@example.com>"}, actions[audit.ActionSend][0].MessageIDs)
+			require.NotEmpty(t, actions[audit.ActionSend][0].Error)
+			require.Equal(t, []string{"<audit@example.com>"}, actions[audit.ActionSend][1].MessageIDs)
+			require.Equal(t, []string{"recipient@" + s.GetDomain()}, actions[audit.ActionSend][1].Recipients)
+			require.Empty(t, actions[audit.ActionSend][1].Error)
+
+			require.Equal(t, "flagged", actions[audit.ActionFlag][0].Target)
+			require.Equal(t, actions[audit.ActionIMAPLogin][0].Remote, actions[audit.ActionFlag][0].Remote)
+			require.Equal(t, "Sent", actions[audit.ActionMove][0].Mailbox)
+			require.Equal(t, "Archive", actions[audit.ActionMove][0].Target)
+			require.Len(t, actions[audit.ActionMove][0].MessageIDs, 1)
+
+			// Failed logins are recorded without a user.
+			failed, _, err := b.QueryAuditLog(audit.Filter{Actions: []audit.Action{audit.ActionIMAPLogin, audit.ActionSMTPLogin}})
+			require.NoError(t, err)
+
+			failed = xslices.Filter(failed, func(entry audit.Entry) bool { return entry.Error != "" })
+			require.Len(t, failed, 2)
+
+			for _, entry := range failed {
+				require.Empty(t, entry.UserID)
+				require.Equal(t, info.Addresses[0], entry.Address)
+				require.NotEmpty(t, entry.Remote)
+			}
+
+			// Actions are no longer recorded once the audit log is disabled, but the existing entries are kept.
+			require.NoError(t, b.SetAuditLogEnabled(false))
+
+			_, err = imapClient.Select("Archive", false)
+			require.NoError(t, err)
+			require.NoError(t, imapClient.Store(seq, imap.FormatFlagsOp(imap.RemoveFlags, true), []interface{}{imap.FlaggedFlag}, nil))
+
+			flagEntries, _, err := b.QueryAuditLog(audit.Filter{Actions: []audit.Action{audit.ActionFlag}})
+			require.NoError(t, err)
+			require.Len(t, flagEntries, 1)
+
+			// The filters are applied to the entries.
+			entries, _, err = b.QueryAuditLog(audit.Filter{UserID: "unknown"})
+			require.NoError(t, err)
+			require.Empty(t, entries)
+
+			entries, _, err = b.QueryAuditLog(audit.Filter{Since: time.Now().Add(time.Hour)})
+			require.NoError(t, err)
+			require.Empty(t, entries)
+		})
+	})
+}
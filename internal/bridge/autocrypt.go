@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"errors"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/user"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetAutocryptMode returns the Autocrypt mode used when sending the given user's mail.
+func (bridge *Bridge) GetAutocryptMode(userID string) (vault.AutocryptMode, error) {
+	return safe.RLockRetErr(func() (vault.AutocryptMode, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return 0, ErrNoSuchUser
+		}
+
+		return user.GetAutocryptMode(), nil
+	}, bridge.usersLock)
+}
+
+// SetAutocryptMode sets the Autocrypt mode used when sending the given user's mail.
+func (bridge *Bridge) SetAutocryptMode(userID string, mode vault.AutocryptMode) error {
+	return bridge.withAutocrypt(userID, func(user *user.User) error {
+		return user.SetAutocryptMode(mode)
+	})
+}
+
+// GetAutocryptCollect returns whether peer keys are collected from the given user's received mail.
+func (bridge *Bridge) GetAutocryptCollect(userID string) (bool, error) {
+	return safe.RLockRetErr(func() (bool, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return false, ErrNoSuchUser
+		}
+
+		return user.GetAutocryptCollect(), nil
+	}, bridge.usersLock)
+}
+
+// SetAutocryptCollect sets whether peer keys are collected from the given user's received mail.
+// Disabling collection forgets all collected keys.
+func (bridge *Bridge) SetAutocryptCollect(userID string, collect bool) error {
+	return bridge.withAutocrypt(userID, func(user *user.User) error {
+		return user.SetAutocryptCollect(collect)
+	})
+}
+
+// GetAutocryptPeers returns the peer keys collected from the given user's received mail.
+func (bridge *Bridge) GetAutocryptPeers(userID string) ([]vault.AutocryptPeer, error) {
+	return safe.RLockRetErr(func() ([]vault.AutocryptPeer, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return user.GetAutocryptPeers(), nil
+	}, bridge.usersLock)
+}
+
+// RemoveAutocryptPeer forgets the collected key of the given peer of the given user.
+func (bridge *Bridge) RemoveAutocryptPeer(userID, email string) error {
+	return bridge.withAutocrypt(userID, func(user *user.User) error {
+		return user.RemoveAutocryptPeer(email)
+	})
+}
+
+func (bridge *Bridge) withAutocrypt(userID string, fn func(*user.User) error) error {
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if err := fn(user); errors.Is(err, vault.ErrNoSuchAutocryptPeer) {
+			return ErrNoSuchAutocryptPeer
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	}, bridge.usersLock)
+}
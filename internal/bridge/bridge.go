@@ -35,17 +35,23 @@ import (
 	"github.com/ProtonMail/gluon/async"
 	imapEvents "github.com/ProtonMail/gluon/events"
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
 	"github.com/ProtonMail/gluon/reporter"
 	"github.com/ProtonMail/gluon/watcher"
 	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/focus"
 	"github.com/ProtonMail/proton-bridge/v3/internal/identifier"
 	"github.com/ProtonMail/proton-bridge/v3/internal/platform"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
 	"github.com/ProtonMail/proton-bridge/v3/internal/sentry"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imageproxy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/metrics"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/notifications"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/syncservice"
@@ -88,6 +94,9 @@ type Bridge struct {
 	installChLegacy chan installJobLegacy
 	installCh       chan installJob
 
+	// updateHealth verifies that the running version managed to start, so that it is kept for rollback.
+	updateHealth *updateHealthCheck
+
 	// heartbeat is the telemetry heartbeat for metrics.
 	heartbeat *heartBeatState
 
@@ -106,6 +115,12 @@ type Bridge struct {
 	// autostarter is the bridge's autostarter.
 	autostarter Autostarter
 
+	// notifier shows the desktop notifications about new mail and failed sends.
+	notifier desktopnotify.Notifier
+
+	// settingsPolicy holds the settings managed by the system-wide policy.
+	settingsPolicy policy.Settings
+
 	// locator is the bridge's locator.
 	locator Locator
 
@@ -154,6 +169,21 @@ type Bridge struct {
 	// notificationStore is used for notification deduplication
 	notificationStore *notifications.Store
 
+	// imageProxy serves the remote images of users who enabled it.
+	imageProxy *imageproxy.Service
+
+	// auditLog records the actions of mail clients, when enabled.
+	auditLog *audit.Log
+
+	// imapSessions is the registry of open IMAP sessions; blockedIMAPClients holds until when each client type is blocked.
+	imapSessions       map[int]*IMAPSessionInfo
+	blockedIMAPClients map[connectionlimiter.Client]time.Time
+	imapSessionsLock   safe.RWMutex
+
+	// metrics records bridge's health and sync metrics; metricsService serves them on localhost if enabled.
+	metrics        *metrics.Registry
+	metricsService *metrics.Service
+
 	// getHostVersion primarily used for testing the update logic - it should return an OS version
 	getHostVersion func(host types.Host) string
 }
@@ -165,10 +195,12 @@ func New(
 	locator Locator, // the locator to provide paths to store data
 	vault *vault.Vault, // the bridge's encrypted data store
 	autostarter Autostarter, // the autostarter to manage autostart settings
+	notifier desktopnotify.Notifier, // the notifier to show desktop notifications
 	updater Updater, // the updater to fetch and install updates
 	curVersion *semver.Version, // the current version of the bridge
 	keychains *keychain.List, // usable keychains
 	obsService *observability.Service,
+	settingsPolicy policy.Settings, // the settings managed by the system-wide policy
 
 	apiURL string, // the URL of the API to use
 	cookieJar http.CookieJar, // the cookie jar to use
@@ -184,8 +216,11 @@ func New(
 	logIMAPClient, logIMAPServer bool, // whether to log IMAP client/server activity
 	logSMTP bool, // whether to log SMTP activity
 ) (*Bridge, <-chan events.Event, error) {
+	// metricsRegistry records the metrics served by the local metrics endpoint, including those of API requests.
+	metricsRegistry := metrics.NewRegistry()
+
 	// api is the user's API manager.
-	api := proton.New(newAPIOptions(apiURL, curVersion, cookieJar, roundTripper, panicHandler)...)
+	api := proton.New(newAPIOptions(apiURL, curVersion, cookieJar, metrics.NewTransport(roundTripper, metricsRegistry), panicHandler)...)
 
 	// tasks holds all the bridge's background tasks.
 	tasks := async.NewGroup(context.Background(), panicHandler)
@@ -202,12 +237,15 @@ func New(
 		locator,
 		vault,
 		autostarter,
+		notifier,
 		updater,
 		curVersion,
 		keychains,
 		panicHandler,
 		reporter,
 		obsService,
+		metricsRegistry,
+		settingsPolicy,
 
 		api,
 		identifier,
@@ -239,12 +277,15 @@ func newBridge(
 	locator Locator,
 	vault *vault.Vault,
 	autostarter Autostarter,
+	notifier desktopnotify.Notifier,
 	updater Updater,
 	curVersion *semver.Version,
 	keychains *keychain.List,
 	panicHandler async.PanicHandler,
 	reporter reporter.Reporter,
 	obsService *observability.Service,
+	metricsRegistry *metrics.Registry,
+	settingsPolicy policy.Settings,
 
 	api *proton.Manager,
 	identifier identifier.Identifier,
@@ -254,6 +295,10 @@ func newBridge(
 
 	logIMAPClient, logIMAPServer, logSMTP bool,
 ) (*Bridge, error) {
+	if err := applySettingsPolicy(vault, autostarter, settingsPolicy); err != nil {
+		return nil, fmt.Errorf("failed to apply settings policy: %w", err)
+	}
+
 	tlsConfig, err := loadTLSConfig(vault)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
@@ -297,6 +342,8 @@ func newBridge(
 		installChLegacy: make(chan installJobLegacy),
 		installCh:       make(chan installJob),
 
+		updateHealth: newUpdateHealthCheck(),
+
 		curVersion:     curVersion,
 		newVersion:     curVersion,
 		newVersionLock: safe.NewRWMutex(),
@@ -308,9 +355,11 @@ func newBridge(
 
 		heartbeat: newHeartBeatState(ctx, panicHandler),
 
-		focusService: focusService,
-		autostarter:  autostarter,
-		locator:      locator,
+		focusService:   focusService,
+		autostarter:    autostarter,
+		notifier:       notifier,
+		settingsPolicy: settingsPolicy,
+		locator:        locator,
 
 		logIMAPClient: logIMAPClient,
 		logIMAPServer: logIMAPServer,
@@ -326,9 +375,24 @@ func newBridge(
 
 		observabilityService: obsService,
 
+		metrics:        metricsRegistry,
+		metricsService: metrics.NewService(metricsRegistry, panicHandler),
+
 		notificationStore: notifications.NewStore(locator.ProvideNotificationsCachePath),
 
 		getHostVersion: func(host types.Host) string { return host.Info().OS.Version },
+
+		imapSessions:       make(map[int]*IMAPSessionInfo),
+		blockedIMAPClients: make(map[connectionlimiter.Client]time.Time),
+		imapSessionsLock:   safe.NewRWMutex(),
+	}
+
+	if bridge.imageProxy, err = newImageProxy(bridge); err != nil {
+		return nil, fmt.Errorf("failed to create image proxy: %w", err)
+	}
+
+	if bridge.auditLog, err = newAuditLog(bridge); err != nil {
+		return nil, fmt.Errorf("failed to create audit log: %w", err)
 	}
 
 	bridge.serverManager = imapsmtpserver.NewService(context.Background(),
@@ -350,6 +414,8 @@ func newBridge(
 		return nil, err
 	}
 
+	bridge.initMetrics()
+
 	if heartbeatManager == nil {
 		bridge.heartbeat.init(bridge, bridge)
 	} else {
@@ -521,6 +587,9 @@ func (bridge *Bridge) Close(ctx context.Context) {
 	// Stop observability service
 	bridge.observabilityService.Stop()
 
+	// A normal exit before the servers are ready isn't a failed start.
+	bridge.recordCleanExit()
+
 	// Stop heart beat before closing users.
 	bridge.heartbeat.stop()
 
@@ -536,6 +605,14 @@ func (bridge *Bridge) Close(ctx context.Context) {
 		logPkg.WithError(err).Error("Failed to close servers")
 	}
 
+	if err := bridge.imageProxy.Stop(ctx); err != nil {
+		logPkg.WithError(err).Error("Failed to close image proxy")
+	}
+
+	if err := bridge.metricsService.Stop(ctx); err != nil {
+		logPkg.WithError(err).Error("Failed to close metrics endpoint")
+	}
+
 	bridge.syncService.Close()
 
 	// Stop all ongoing tasks.
@@ -564,6 +641,9 @@ func (bridge *Bridge) publish(event events.Event) {
 
 	logPkg.WithField("event", event).Debug("Publishing event")
 
+	bridge.recordEventMetrics(event)
+	bridge.checkUpdateHealth(event)
+
 	for _, watcher := range bridge.watchers {
 		if watcher.IsWatching(event) {
 			if ok := watcher.Send(event); !ok {
@@ -786,6 +866,11 @@ func (bridge *Bridge) SetHostVersionGetterTest(fn func(host types.Host) string)
 	bridge.getHostVersion = fn
 }
 
+// SetKeychainsTest - sets the usable keychains; should only be used for tests.
+func (bridge *Bridge) SetKeychainsTest(keychains *keychain.List) {
+	bridge.keychains = keychains
+}
+
 // SetRolloutPercentageTest - sets the rollout percentage; should only be used for testing.
 func (bridge *Bridge) SetRolloutPercentageTest(rollout float64) error {
 	return bridge.vault.SetUpdateRollout(rollout)
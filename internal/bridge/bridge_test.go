@@ -44,6 +44,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/focus"
 	"github.com/ProtonMail/proton-bridge/v3/internal/locations"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
@@ -917,6 +918,7 @@ func withBridgeNoMocks(
 	netCtl *proton.NetCtl,
 	locator bridge.Locator,
 	vaultKey []byte,
+	settingsPolicy policy.Settings,
 	tests func(*bridge.Bridge),
 	waitOnServers bool,
 ) {
@@ -942,10 +944,12 @@ func withBridgeNoMocks(
 		locator,
 		vault,
 		mocks.Autostarter,
+		mocks.Notifier,
 		mocks.Updater,
 		v2_3_0,
 		keychain.NewTestKeychainsList(),
 		observability.NewTestService(),
+		settingsPolicy,
 
 		// The API stuff.
 		apiURL,
@@ -999,7 +1003,7 @@ func withBridge(
 	tests func(*bridge.Bridge, *bridge.Mocks),
 ) {
 	withMocks(t, func(mocks *bridge.Mocks) {
-		withBridgeNoMocks(ctx, t, mocks, apiURL, netCtl, locator, vaultKey, func(bridge *bridge.Bridge) {
+		withBridgeNoMocks(ctx, t, mocks, apiURL, netCtl, locator, vaultKey, policy.Settings{}, func(bridge *bridge.Bridge) {
 			tests(bridge, mocks)
 		}, false)
 	})
@@ -1016,12 +1020,30 @@ func withBridgeWaitForServers(
 	tests func(*bridge.Bridge, *bridge.Mocks),
 ) {
 	withMocks(t, func(mocks *bridge.Mocks) {
-		withBridgeNoMocks(ctx, t, mocks, apiURL, netCtl, locator, vaultKey, func(bridge *bridge.Bridge) {
+		withBridgeNoMocks(ctx, t, mocks, apiURL, netCtl, locator, vaultKey, policy.Settings{}, func(bridge *bridge.Bridge) {
 			tests(bridge, mocks)
 		}, true)
 	})
 }
 
+// withBridgePolicy is the same as withBridge, but the bridge is managed by the given settings policy.
+func withBridgePolicy(
+	ctx context.Context,
+	t *testing.T,
+	apiURL string,
+	netCtl *proton.NetCtl,
+	locator bridge.Locator,
+	vaultKey []byte,
+	settingsPolicy policy.Settings,
+	tests func(*bridge.Bridge, *bridge.Mocks),
+) {
+	withMocks(t, func(mocks *bridge.Mocks) {
+		withBridgeNoMocks(ctx, t, mocks, apiURL, netCtl, locator, vaultKey, settingsPolicy, func(bridge *bridge.Bridge) {
+			tests(bridge, mocks)
+		}, false)
+	})
+}
+
 func waitForEvent[T any](t *testing.T, eventCh <-chan events.Event, _ T) {
 	t.Helper()
 
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/certs"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/sirupsen/logrus"
+)
+
+// GetClientCertAuth returns whether IMAP and SMTP clients must authenticate with a client certificate.
+func (bridge *Bridge) GetClientCertAuth() vault.ClientCertAuth {
+	return bridge.vault.GetClientCertAuth()
+}
+
+// SetClientCertAuth sets whether IMAP and SMTP clients must authenticate with a client certificate
+// and restarts the servers to apply the new TLS configuration.
+func (bridge *Bridge) SetClientCertAuth(ctx context.Context, auth vault.ClientCertAuth) error {
+	if auth == bridge.vault.GetClientCertAuth() {
+		return nil
+	}
+
+	// Client certificates are only requested by IMAP with implicit TLS; with STARTTLS, clients could log in without
+	// their certificate being bound to the login.
+	if auth != vault.ClientCertAuthDisabled && !bridge.vault.GetIMAPSSL() {
+		return ErrClientCertRequiresSSL
+	}
+
+	if err := bridge.vault.SetClientCertAuth(auth); err != nil {
+		return err
+	}
+
+	if err := bridge.restartIMAP(ctx); err != nil {
+		return err
+	}
+
+	return bridge.restartSMTP(ctx)
+}
+
+// IssueClientCert issues a new client certificate for the given device of the given user.
+// It returns the PEM-encoded certificate and private key; the key is not kept by bridge.
+func (bridge *Bridge) IssueClientCert(userID, device string, scope vault.ClientCertScope) ([]byte, []byte, error) {
+	if !bridge.vault.HasUser(userID) {
+		return nil, nil, ErrNoSuchUser
+	}
+
+	caCertPEM, caKeyPEM, err := bridge.vault.GetClientCA()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := certs.NewClientTLSTemplate(device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, keyPEM, err := certs.GenerateSignedCert(template, caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := bridge.vault.AddClientCert(vault.ClientCert{
+		SerialNumber: template.SerialNumber.Text(16),
+		Device:       device,
+		UserID:       userID,
+		Scope:        scope,
+		NotAfter:     template.NotAfter,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	logPkg.WithFields(logrus.Fields{
+		"userID": userID,
+		"device": device,
+		"scope":  scope,
+	}).Info("Issued client certificate")
+
+	return certPEM, keyPEM, nil
+}
+
+// GetClientCerts returns the issued client certificates, including the revoked ones.
+func (bridge *Bridge) GetClientCerts() []vault.ClientCert {
+	return bridge.vault.GetClientCerts()
+}
+
+// GetClientCert returns the issued client certificate with the given serial number.
+func (bridge *Bridge) GetClientCert(serialNumber string) (vault.ClientCert, bool) {
+	return bridge.vault.GetClientCert(serialNumber)
+}
+
+// RevokeClientCert revokes the client certificate with the given serial number.
+// The certificate is rejected from the next TLS handshake on.
+func (bridge *Bridge) RevokeClientCert(serialNumber string) error {
+	if err := bridge.vault.RevokeClientCert(serialNumber); err != nil {
+		if errors.Is(err, vault.ErrNoSuchClientCert) {
+			return ErrNoSuchClientCert
+		}
+
+		return err
+	}
+
+	logPkg.WithField("serial", serialNumber).Info("Revoked client certificate")
+
+	return nil
+}
+
+// clientCertTLSConfig returns the TLS config of the server of the given protocol.
+// When client certificate authentication is enabled, the certificates are verified against the client CA
+// and then checked against the registry of issued certificates at each handshake.
+func (bridge *Bridge) clientCertTLSConfig(scope vault.ClientCertScope) *tls.Config {
+	auth := bridge.vault.GetClientCertAuth()
+	if auth == vault.ClientCertAuthDisabled {
+		return bridge.tlsConfig
+	}
+
+	// IMAP logins are only bound to the user of the certificate with implicit TLS.
+	if scope == vault.ClientCertScopeIMAP && !bridge.vault.GetIMAPSSL() {
+		logPkg.Warn("IMAP doesn't use SSL, client certificates are disabled for IMAP")
+		return bridge.tlsConfig
+	}
+
+	caCertPEM, _, err := bridge.vault.GetClientCA()
+	if err != nil {
+		logPkg.WithError(err).Error("Failed to load client CA, client certificates are disabled")
+		return bridge.tlsConfig
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caCertPEM)
+
+	tlsConfig := bridge.tlsConfig.Clone()
+	tlsConfig.ClientCAs = pool
+
+	if auth == vault.ClientCertAuthRequired {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return nil
+		}
+
+		if _, err := bridge.verifyClientCert(state.PeerCertificates[0], scope); err != nil {
+			logPkg.WithError(err).WithField("device", state.PeerCertificates[0].Subject.CommonName).Warn("Rejected client certificate")
+			return err
+		}
+
+		return nil
+	}
+
+	return tlsConfig
+}
+
+// verifyClientCert checks that the given certificate, already verified against the client CA,
+// is known, not revoked and valid for the given protocol. It returns the ID of the user it was issued to.
+func (bridge *Bridge) verifyClientCert(cert *x509.Certificate, scope vault.ClientCertScope) (string, error) {
+	record, ok := bridge.vault.GetClientCert(cert.SerialNumber.Text(16))
+	if !ok || record.Device != cert.Subject.CommonName {
+		return "", ErrNoSuchClientCert
+	}
+
+	if record.Revoked {
+		return "", ErrClientCertRevoked
+	}
+
+	if !record.Scope.Has(scope) {
+		return "", ErrClientCertScope
+	}
+
+	if !bridge.vault.HasUser(record.UserID) {
+		return "", ErrNoSuchUser
+	}
+
+	return record.UserID, nil
+}
+
+type bridgeClientCertAuthenticator struct {
+	b     *Bridge
+	scope vault.ClientCertScope
+}
+
+func (a *bridgeClientCertAuthenticator) AuthenticateClientCert(cert *x509.Certificate) (string, error) {
+	return a.b.verifyClientCert(cert, a.scope)
+}
+
+func (a *bridgeClientCertAuthenticator) PasswordAuthDisabled() bool {
+	return a.b.vault.GetClientCertAuth() == vault.ClientCertAuthRequired
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_ClientCert_SMTP(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("other", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			// Client certificates are refused while IMAP does not use implicit TLS.
+			require.ErrorIs(t, b.SetClientCertAuth(ctx, vault.ClientCertAuthRequired), bridge.ErrClientCertRequiresSSL)
+			require.ErrorIs(t, b.SetClientCertAuth(ctx, vault.ClientCertAuthOptional), bridge.ErrClientCertRequiresSSL)
+
+			require.NoError(t, b.SetIMAPSSL(ctx, true))
+			require.NoError(t, b.SetClientCertAuth(ctx, vault.ClientCertAuthOptional))
+
+			// IMAP can't use STARTTLS while client certificates are enabled.
+			require.ErrorIs(t, b.SetIMAPSSL(ctx, false), bridge.ErrClientCertRequiresSSL)
+
+			certPEM, keyPEM, err := b.IssueClientCert(userID, "laptop", vault.ClientCertScopeSMTP)
+			require.NoError(t, err)
+
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			require.NoError(t, err)
+
+			send := func() error {
+				client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer client.Close() //nolint:errcheck
+
+				if err := client.StartTLS(&tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{cert}}); err != nil {
+					return err
+				}
+
+				// The session is authenticated by the certificate, no AUTH is needed.
+				return client.SendMail(info.Addresses[0], []string{info.Addresses[0]}, strings.NewReader("Subject: Test\r\n\r\nHello world!"))
+			}
+
+			require.NoError(t, send())
+
+			// A session authenticated by the certificate cannot authenticate as another user.
+			otherID, err := b.LoginFull(ctx, "other", password, nil, nil)
+			require.NoError(t, err)
+
+			otherInfo, err := b.GetUserInfo(otherID)
+			require.NoError(t, err)
+
+			client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+			require.NoError(t, err)
+			defer client.Close() //nolint:errcheck
+
+			require.NoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{cert}}))
+			require.Error(t, client.Auth(sasl.NewPlainClient("", otherInfo.Addresses[0], string(otherInfo.BridgePass))))
+
+			require.Len(t, b.GetClientCerts(), 1)
+			require.NoError(t, b.RevokeClientCert(b.GetClientCerts()[0].SerialNumber))
+			require.ErrorIs(t, b.RevokeClientCert("unknown"), bridge.ErrNoSuchClientCert)
+
+			require.Error(t, send())
+		})
+	})
+}
+
+func TestBridge_ClientCert_IMAP(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("other", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+
+			otherID, err := b.LoginFull(ctx, "other", password, nil, nil)
+			require.NoError(t, err)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			otherInfo, err := b.GetUserInfo(otherID)
+			require.NoError(t, err)
+
+			require.NoError(t, b.SetIMAPSSL(ctx, true))
+			require.NoError(t, b.SetClientCertAuth(ctx, vault.ClientCertAuthRequired))
+
+			certPEM, keyPEM, err := b.IssueClientCert(userID, "laptop", vault.ClientCertScopeIMAP)
+			require.NoError(t, err)
+
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			require.NoError(t, err)
+
+			dial := func() *client.Client {
+				client, err := client.DialTLS(
+					net.JoinHostPort(constants.Host, fmt.Sprint(b.GetIMAPPort())),
+					&tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{cert}},
+				)
+				require.NoError(t, err)
+
+				return client
+			}
+
+			// The certificate of a user doesn't allow to log in as another user, even with their password.
+			otherClient := dial()
+			defer otherClient.Close() //nolint:errcheck
+
+			require.Error(t, otherClient.Login(otherInfo.Addresses[0], string(otherInfo.BridgePass)))
+
+			// The user of the certificate can log in.
+			imapClient := dial()
+			defer imapClient.Logout() //nolint:errcheck
+
+			require.NoError(t, imapClient.Login(info.Addresses[0], string(info.BridgePass)))
+
+			_, err = imapClient.Select("INBOX", false)
+			require.NoError(t, err)
+		})
+	})
+}
@@ -56,7 +56,7 @@ func (bridge *Bridge) ConfigureAppleMail(ctx context.Context, userID, address st
 		}
 
 		var username, displayName, addresses string
-		if user.GetAddressMode() == vault.CombinedMode {
+		if user.GetAddressMode() != vault.SplitMode {
 			username = address
 			displayName = displayNames[username]
 			addresses = strings.Join(emails, ",")
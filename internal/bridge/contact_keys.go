@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"errors"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/user"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetContactKeyPolicy returns the policy applied when the key of one of the given user's recipients changes.
+func (bridge *Bridge) GetContactKeyPolicy(userID string) (vault.ContactKeyPolicy, error) {
+	return safe.RLockRetErr(func() (vault.ContactKeyPolicy, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return 0, ErrNoSuchUser
+		}
+
+		return user.GetContactKeyPolicy(), nil
+	}, bridge.usersLock)
+}
+
+// SetContactKeyPolicy sets the policy applied when the key of one of the given user's recipients changes.
+func (bridge *Bridge) SetContactKeyPolicy(userID string, policy vault.ContactKeyPolicy) error {
+	return bridge.withContactKeys(userID, func(user *user.User) error {
+		return user.SetContactKeyPolicy(policy)
+	})
+}
+
+// GetContactKeys returns the key fingerprints recorded for the given user's recipients.
+func (bridge *Bridge) GetContactKeys(userID string) ([]vault.ContactKey, error) {
+	return safe.RLockRetErr(func() ([]vault.ContactKey, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return user.GetContactKeys(), nil
+	}, bridge.usersLock)
+}
+
+// ApproveContactKeyChange trusts the new key of the given recipient of the given user.
+func (bridge *Bridge) ApproveContactKeyChange(userID, email string) error {
+	return bridge.withContactKeys(userID, func(user *user.User) error {
+		return user.ApproveContactKeyChange(email)
+	})
+}
+
+// RemoveContactKey forgets the key recorded for the given recipient of the given user.
+// The next key used for the recipient is trusted.
+func (bridge *Bridge) RemoveContactKey(userID, email string) error {
+	return bridge.withContactKeys(userID, func(user *user.User) error {
+		return user.RemoveContactKey(email)
+	})
+}
+
+func (bridge *Bridge) withContactKeys(userID string, fn func(*user.User) error) error {
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		switch err := fn(user); {
+		case errors.Is(err, vault.ErrNoSuchContactKey):
+			return ErrNoSuchContactKey
+
+		case errors.Is(err, vault.ErrNoPendingContactKey):
+			return ErrNoPendingContactKey
+
+		default:
+			return err
+		}
+	}, bridge.usersLock)
+}
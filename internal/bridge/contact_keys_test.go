@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/go-proton-api/server/backend"
+	"github.com/ProtonMail/gopenpgp/v2/helper"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_ContactKeyChange(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		recipientID, recipientAddrID, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			keyCh, done := chToType[events.Event, events.ContactKeyChanged](b.GetEvents(events.ContactKeyChanged{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			send := func(subject string) error {
+				client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer client.Close() //nolint:errcheck
+
+				require.NoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+				require.NoError(t, client.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+				return client.SendMail(info.Addresses[0], []string{"recipient@" + s.GetDomain()}, strings.NewReader("Subject: "+subject+"\r\n\r\nHello world!"))
+			}
+
+			// The recipient's key is trusted on first use.
+			require.NoError(t, send("First"))
+
+			keys, err := b.GetContactKeys(userID)
+			require.NoError(t, err)
+			require.Len(t, keys, 1)
+			require.False(t, keys[0].HasPendingChange())
+
+			// Replace the recipient's key.
+			withClient(ctx, t, s, "recipient", password, func(ctx context.Context, c *proton.Client) {
+				addr, err := c.GetAddress(ctx, recipientAddrID)
+				require.NoError(t, err)
+
+				// The test server reuses a precomputed key; generate a real one so that the key actually changes.
+				backend.GenerateKey = helper.GenerateKey
+				defer func() { backend.GenerateKey = backend.FastGenerateKey }()
+
+				require.NoError(t, s.CreateAddressKey(recipientID, recipientAddrID, password))
+				require.NoError(t, s.RemoveAddressKey(recipientID, recipientAddrID, addr.Keys[0].ID))
+			})
+
+			// The block policy refuses to send with the new key.
+			require.NoError(t, b.SetContactKeyPolicy(userID, vault.ContactKeyPolicyBlock))
+			require.Error(t, send("Blocked"))
+
+			event := <-keyCh
+			require.True(t, event.Blocked)
+			require.Equal(t, keys[0].Fingerprint, event.OldFingerprint)
+
+			keys, err = b.GetContactKeys(userID)
+			require.NoError(t, err)
+			require.Equal(t, event.NewFingerprint, keys[0].PendingFingerprint)
+
+			// Once the new key is approved, the message can be sent.
+			require.NoError(t, b.ApproveContactKeyChange(userID, keys[0].Email))
+			require.ErrorIs(t, b.ApproveContactKeyChange(userID, keys[0].Email), bridge.ErrNoPendingContactKey)
+			require.NoError(t, send("Approved"))
+		})
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/user"
+	"github.com/sirupsen/logrus"
+)
+
+// GetDesktopNotifications returns the desktop notification preferences.
+func (bridge *Bridge) GetDesktopNotifications() desktopnotify.Settings {
+	return bridge.vault.GetDesktopNotifications()
+}
+
+// SetDesktopNotifications sets the desktop notification preferences. They apply to the next notification.
+func (bridge *Bridge) SetDesktopNotifications(settings desktopnotify.Settings) error {
+	if !isValidTimeOfDay(settings.QuietHours.Start) || !isValidTimeOfDay(settings.QuietHours.End) {
+		return ErrInvalidQuietHours
+	}
+
+	logPkg.WithFields(logrus.Fields{
+		"newMail":      settings.NewMail,
+		"sendFailures": settings.SendFailures,
+		"quietHours":   settings.QuietHours,
+		"rules":        len(settings.Rules),
+	}).Info("Setting desktop notifications")
+
+	return bridge.vault.SetDesktopNotifications(settings)
+}
+
+func isValidTimeOfDay(d time.Duration) bool {
+	return d >= 0 && d < 24*time.Hour
+}
+
+func (bridge *Bridge) handleUserReceivedMessage(user *user.User, event events.UserReceivedMessage) {
+	if !bridge.vault.GetDesktopNotifications().ShouldNotifyNewMail(event.UserID, event.Mailboxes, time.Now()) {
+		return
+	}
+
+	subject := event.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+
+	bridge.notify("New message from "+event.Sender, subject+"\n"+user.Name())
+}
+
+func (bridge *Bridge) handleUserSendFailed(user *user.User, event events.UserSendFailed) {
+	if !bridge.vault.GetDesktopNotifications().ShouldNotifySendFailure(time.Now()) {
+		return
+	}
+
+	bridge.notify("Message not sent", "To "+strings.Join(event.Recipients, ", ")+" from "+user.Name()+": "+event.Error)
+}
+
+func (bridge *Bridge) notify(title, body string) {
+	if err := bridge.notifier.Notify(title, body); err != nil {
+		logPkg.WithError(err).Warn("Failed to show desktop notification")
+	}
+}
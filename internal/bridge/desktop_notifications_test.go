@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/bradenaw/juniper/stream"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_DesktopNotifications(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, mocks *bridge.Mocks) {
+			// Invalid quiet hours are rejected.
+			require.ErrorIs(t, b.SetDesktopNotifications(desktopnotify.Settings{
+				QuietHours: desktopnotify.QuietHours{Start: 25 * time.Hour},
+			}), bridge.ErrInvalidQuietHours)
+
+			// The quiet hours cover the current time.
+			now := time.Now()
+			sinceMidnight := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+
+			quietHours := desktopnotify.QuietHours{
+				Start: (sinceMidnight + 23*time.Hour) % (24 * time.Hour),
+				End:   (sinceMidnight + time.Hour) % (24 * time.Hour),
+			}
+
+			require.NoError(t, b.SetDesktopNotifications(desktopnotify.Settings{
+				NewMail:      true,
+				SendFailures: true,
+				QuietHours:   quietHours,
+			}))
+			require.Equal(t, quietHours, b.GetDesktopNotifications().QuietHours)
+
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			receivedCh, receivedDone := chToType[events.Event, events.UserReceivedMessage](b.GetEvents(events.UserReceivedMessage{}))
+			defer receivedDone()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			withClient(ctx, t, s, username, password, func(ctx context.Context, c *proton.Client) {
+				addrs, err := c.GetAddresses(ctx)
+				require.NoError(t, err)
+
+				// No notification is shown during the quiet hours.
+				importUnreadMessage(ctx, t, c, addrs[0].ID, proton.InboxLabel, "Quiet")
+				require.Equal(t, "Quiet", (<-receivedCh).Subject)
+				require.Empty(t, mocks.Notifier.GetNotifications())
+
+				require.NoError(t, b.SetDesktopNotifications(desktopnotify.Settings{
+					NewMail:      true,
+					SendFailures: true,
+				}))
+
+				// New messages of the inbox are notified.
+				importUnreadMessage(ctx, t, c, addrs[0].ID, proton.InboxLabel, "Hello")
+
+				require.Eventually(t, func() bool {
+					return len(mocks.Notifier.GetNotifications()) == 1
+				}, 10*time.Second, 100*time.Millisecond)
+
+				require.Equal(t, "New message from sender@pm.me", mocks.Notifier.GetNotifications()[0].Title)
+				require.True(t, strings.HasPrefix(mocks.Notifier.GetNotifications()[0].Body, "Hello"))
+
+				// Rules select the mailboxes to notify about.
+				require.NoError(t, b.SetDesktopNotifications(desktopnotify.Settings{
+					NewMail:      true,
+					SendFailures: true,
+					Rules: []desktopnotify.Rule{
+						{Mailbox: "INBOX", Notify: false},
+						{Mailbox: "Spam", Notify: true},
+					},
+				}))
+
+				importUnreadMessage(ctx, t, c, addrs[0].ID, proton.InboxLabel, "Inbox")
+				importUnreadMessage(ctx, t, c, addrs[0].ID, proton.SpamLabel, "Spam")
+
+				require.Eventually(t, func() bool {
+					return len(mocks.Notifier.GetNotifications()) == 2
+				}, 10*time.Second, 100*time.Millisecond)
+
+				require.True(t, strings.HasPrefix(mocks.Notifier.GetNotifications()[1].Body, "Spam"))
+			})
+
+			// Messages that cannot be sent are notified.
+			s.AddStatusHook(func(req *http.Request) (int, bool) {
+				if req.Method == http.MethodPost && req.URL.Path == "/mail/v4/messages" {
+					return http.StatusUnprocessableEntity, true
+				}
+
+				return 0, false
+			})
+
+			smtpClient, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+			require.NoError(t, err)
+			defer smtpClient.Close() //nolint:errcheck
+
+			require.NoError(t, smtpClient.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+			require.NoError(t, smtpClient.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+			require.Error(t, smtpClient.SendMail(
+				info.Addresses[0],
+				[]string{"recipient@" + s.GetDomain()},
+				strings.NewReader("Subject: Failure\r\n\r\nHello world!"),
+			))
+
+			require.Eventually(t, func() bool {
+				return len(mocks.Notifier.GetNotifications()) == 3
+			}, 10*time.Second, 100*time.Millisecond)
+
+			require.Equal(t, "Message not sent", mocks.Notifier.GetNotifications()[2].Title)
+			require.Contains(t, mocks.Notifier.GetNotifications()[2].Body, "recipient@"+s.GetDomain())
+		})
+	})
+}
+
+func importUnreadMessage(ctx context.Context, t *testing.T, c *proton.Client, addrID, labelID, subject string) {
+	user, err := c.GetUser(ctx)
+	require.NoError(t, err)
+
+	addr, err := c.GetAddresses(ctx)
+	require.NoError(t, err)
+
+	salt, err := c.GetSalts(ctx)
+	require.NoError(t, err)
+
+	keyPass, err := salt.SaltForKey(password, user.Keys.Primary().ID)
+	require.NoError(t, err)
+
+	_, addrKRs, err := proton.Unlock(user, addr, keyPass, async.NoopPanicHandler{})
+	require.NoError(t, err)
+
+	str, err := c.ImportMessages(ctx, addrKRs[addrID], runtime.NumCPU(), runtime.NumCPU(), proton.ImportReq{
+		Metadata: proton.ImportMetadata{
+			AddressID: addrID,
+			LabelIDs:  []string{labelID},
+			Flags:     proton.MessageFlagReceived,
+			Unread:    true,
+		},
+		Message: []byte("To: recipient@pm.me\r\nFrom: sender@pm.me\r\nSubject: " + subject + "\r\n\r\nHello world!"),
+	})
+	require.NoError(t, err)
+
+	_, err = stream.Collect(ctx, str)
+	require.NoError(t, err)
+}
@@ -0,0 +1,527 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapservice"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// diagnosticMaxLogsSize is the size limit of the logs in a diagnostic bundle.
+	// It is larger than for bug reports since the bundle isn't uploaded.
+	diagnosticMaxLogsSize = 50 * 1024 * 1024
+
+	// diagnosticManifestName is the name of the file listing the content of a diagnostic bundle.
+	diagnosticManifestName = "manifest.json"
+
+	// diagnosticPortTimeout is how long port checks wait for a connection.
+	diagnosticPortTimeout = time.Second
+)
+
+type diagnosticManifest struct {
+	CreatedAt time.Time
+	Version   string
+	Files     []diagnosticFile
+}
+
+type diagnosticFile struct {
+	Name        string
+	Description string
+	Error       string `json:",omitempty"`
+}
+
+// WriteDiagnosticBundle writes a zip archive of information useful to investigate issues, for when a bug report
+// can't be sent. Nothing is sent anywhere. Secrets, addresses and message content are left out; logs are those of
+// bug reports, in which sensitive values are already redacted. If checkClientState is set, the messages seen by
+// IMAP clients are compared with the server's, which can take a while. The archive has a manifest of its files.
+func (bridge *Bridge) WriteDiagnosticBundle(ctx context.Context, w io.Writer, checkClientState bool) error {
+	bundle := newDiagnosticBundle(w, bridge.curVersion)
+
+	vaultEncrypted := !slices.ContainsFunc(bridge.errors, func(err error) bool {
+		return errors.Is(err, ErrVaultInsecure)
+	})
+
+	bundle.add("version.json", "Bridge version and platform.", func() (any, error) {
+		return getDiagnosticVersion(bridge.vault, bridge.curVersion, bridge.lastVersion), nil
+	})
+
+	bundle.add("settings.json", "Bridge settings, without keys, passwords, certificates or paths.", func() (any, error) {
+		return getDiagnosticSettings(bridge.vault, bridge.locator), nil
+	})
+
+	bundle.add("users.json", "State and sync status of each account, without addresses.", bridge.getDiagnosticUsers)
+
+	bundle.add("rate_limits.json", "API rate limits and throttled requests of each loaded account.", bridge.getDiagnosticRateLimits)
+
+	bundle.add("keychain.json", "Available keychains and the one holding the vault key.", func() (any, error) {
+		return getDiagnosticKeychain(bridge.keychains, bridge.locator, vaultEncrypted)
+	})
+
+	bundle.add("ports.json", "Whether something listens on the ports used by bridge.", func() (any, error) {
+		return getDiagnosticPorts(bridge.vault), nil
+	})
+
+	if checkClientState {
+		bundle.add("client_state.json", "Messages missing from what IMAP clients see, compared with the server.", func() (any, error) {
+			return bridge.getDiagnosticClientState(ctx)
+		})
+	}
+
+	return bundle.close(bridge.locator)
+}
+
+// WriteOfflineDiagnosticBundle writes a diagnostic bundle like WriteDiagnosticBundle, for when bridge isn't running.
+// It only reads the given vault, the sync state of its accounts and the logs, so it has nothing on rate limits or on
+// what IMAP clients see, and accounts are described as they were last saved.
+func WriteOfflineDiagnosticBundle(
+	w io.Writer,
+	v *vault.Vault,
+	locator Locator,
+	keychains *keychain.List,
+	curVersion *semver.Version,
+	vaultEncrypted bool,
+) error {
+	bundle := newDiagnosticBundle(w, curVersion)
+
+	bundle.add("version.json", "Bridge version and platform.", func() (any, error) {
+		return getDiagnosticVersion(v, curVersion, v.GetLastVersion()), nil
+	})
+
+	bundle.add("settings.json", "Bridge settings, without keys, passwords, certificates or paths.", func() (any, error) {
+		return getDiagnosticSettings(v, locator), nil
+	})
+
+	bundle.add("users.json", "State and sync status of each account, without addresses.", func() (any, error) {
+		return getDiagnosticUsers(v, locator)
+	})
+
+	bundle.add("keychain.json", "Available keychains and the one holding the vault key.", func() (any, error) {
+		return getDiagnosticKeychain(keychains, locator, vaultEncrypted)
+	})
+
+	bundle.add("ports.json", "Whether something listens on the ports used by bridge.", func() (any, error) {
+		return getDiagnosticPorts(v), nil
+	})
+
+	return bundle.close(locator)
+}
+
+// diagnosticBundle is a diagnostic bundle being written.
+// Errors collecting a file are reported in the manifest; errors writing the archive are returned by close.
+type diagnosticBundle struct {
+	zw       *zip.Writer
+	manifest diagnosticManifest
+	err      error
+}
+
+func newDiagnosticBundle(w io.Writer, version *semver.Version) *diagnosticBundle {
+	return &diagnosticBundle{
+		zw: zip.NewWriter(w),
+		manifest: diagnosticManifest{
+			CreatedAt: time.Now().UTC(),
+			Version:   version.String(),
+		},
+	}
+}
+
+func (bundle *diagnosticBundle) add(name, description string, collect func() (any, error)) {
+	if bundle.err != nil {
+		return
+	}
+
+	file := diagnosticFile{Name: name, Description: description}
+
+	data, err := collect()
+	if err != nil {
+		file.Error = err.Error()
+	}
+
+	if data != nil {
+		if err := writeDiagnosticJSON(bundle.zw, name, data); err != nil {
+			bundle.err = err
+			return
+		}
+	}
+
+	bundle.manifest.Files = append(bundle.manifest.Files, file)
+}
+
+// close adds the logs and the manifest to the bundle, and finishes the archive.
+func (bundle *diagnosticBundle) close(locator Locator) error {
+	if bundle.err != nil {
+		return bundle.err
+	}
+
+	logFiles, err := writeDiagnosticLogs(bundle.zw, locator)
+	if err != nil {
+		bundle.manifest.Files = append(bundle.manifest.Files, diagnosticFile{Name: "logs/", Description: "Logs of the last sessions.", Error: err.Error()})
+	}
+
+	bundle.manifest.Files = append(bundle.manifest.Files, logFiles...)
+
+	if err := writeDiagnosticJSON(bundle.zw, diagnosticManifestName, bundle.manifest); err != nil {
+		return err
+	}
+
+	return bundle.zw.Close()
+}
+
+func getDiagnosticVersion(v *vault.Vault, curVersion, lastVersion *semver.Version) any {
+	return struct {
+		Version       string
+		LastVersion   string
+		OS            string
+		Arch          string
+		GoVersion     string
+		UpdateChannel string
+		AutoUpdate    bool
+	}{
+		Version:       curVersion.String(),
+		LastVersion:   lastVersion.String(),
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		GoVersion:     runtime.Version(),
+		UpdateChannel: string(v.GetUpdateChannel()),
+		AutoUpdate:    v.GetAutoUpdate(),
+	}
+}
+
+func getDiagnosticSettings(v *vault.Vault, locator Locator) any {
+	return struct {
+		Profile             string
+		IMAPPort            int
+		IMAPSSL             bool
+		SMTPPort            int
+		SMTPSSL             bool
+		ClientCertAuth      string
+		ShowAllMail         bool
+		Autostart           bool
+		ProxyAllowed        bool
+		TelemetryDisabled   bool
+		ImageProxyPort      int
+		ImageProxyCacheSize int64
+		MetricsEnabled      bool
+		MetricsPort         int
+		MaxSyncMemory       uint64
+	}{
+		Profile:             locator.GetProfile(),
+		IMAPPort:            v.GetIMAPPort(),
+		IMAPSSL:             v.GetIMAPSSL(),
+		SMTPPort:            v.GetSMTPPort(),
+		SMTPSSL:             v.GetSMTPSSL(),
+		ClientCertAuth:      v.GetClientCertAuth().String(),
+		ShowAllMail:         v.GetShowAllMail(),
+		Autostart:           v.GetAutostart(),
+		ProxyAllowed:        v.GetProxyAllowed(),
+		TelemetryDisabled:   v.GetTelemetryDisabled(),
+		ImageProxyPort:      v.GetImageProxyPort(),
+		ImageProxyCacheSize: v.GetImageProxyCacheSize(),
+		MetricsEnabled:      v.GetMetricsEnabled(),
+		MetricsPort:         v.GetMetricsPort(),
+		MaxSyncMemory:       v.GetMaxSyncMemory(),
+	}
+}
+
+type diagnosticUser struct {
+	UserID             string
+	State              string
+	AddressMode        string
+	Addresses          int
+	Loaded             bool
+	SyncHasLabels      bool
+	SyncHasMessages    bool
+	SyncedMessages     int64
+	TotalMessages      int64
+	SyncFailedMessages int
+	LastEventPoll      *time.Time `json:",omitempty"`
+}
+
+// getDiagnosticUsers describes the accounts as saved in the vault, with their sync status.
+func getDiagnosticUsers(v *vault.Vault, locator Locator) ([]diagnosticUser, error) {
+	var users []diagnosticUser
+
+	syncConfigDir, err := locator.ProvideIMAPSyncConfigPath()
+	if err != nil {
+		return users, err
+	}
+
+	for _, userID := range v.GetUserIDs() {
+		var info UserInfo
+
+		if err := v.GetUser(userID, func(user *vault.User) {
+			state := Locked
+			if len(user.AuthUID()) == 0 {
+				state = SignedOut
+			}
+
+			info = getUserInfo(user.UserID(), user.Username(), user.PrimaryEmail(), state, user.AddressMode())
+		}); err != nil {
+			return users, err
+		}
+
+		user := diagnosticUser{
+			UserID:      userID,
+			State:       getUserStateName(info.State),
+			AddressMode: info.AddressMode.String(),
+			Addresses:   len(info.Addresses),
+		}
+
+		syncState, err := imapservice.NewSyncState(imapservice.GetSyncConfigPath(syncConfigDir, userID))
+		if err != nil {
+			return users, err
+		}
+
+		status, err := syncState.GetSyncStatus(context.Background())
+		if err != nil {
+			return users, err
+		}
+
+		user.SyncHasLabels = status.HasLabels
+		user.SyncHasMessages = status.HasMessages
+		user.SyncedMessages = status.NumSyncedMessages
+		user.TotalMessages = status.TotalMessageCount
+		user.SyncFailedMessages = len(status.FailedMessages)
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// getDiagnosticUsers describes the accounts, with the state of those that are loaded.
+func (bridge *Bridge) getDiagnosticUsers() (any, error) {
+	users, err := getDiagnosticUsers(bridge.vault, bridge.locator)
+
+	safe.RLock(func() {
+		for i := range users {
+			loaded, ok := bridge.users[users[i].UserID]
+			if !ok {
+				continue
+			}
+
+			info := getConnUserInfo(loaded)
+
+			users[i].State = getUserStateName(info.State)
+			users[i].Addresses = len(info.Addresses)
+			users[i].Loaded = true
+
+			if lastPoll := loaded.GetLastEventPollTime(); !lastPoll.IsZero() {
+				users[i].LastEventPoll = &lastPoll
+			}
+		}
+	}, bridge.usersLock)
+
+	return users, err
+}
+
+func (bridge *Bridge) getDiagnosticRateLimits() (any, error) {
+	return struct {
+		Limits map[ratelimit.Class]ratelimit.Limit
+		Users  map[string][]ratelimit.Status
+	}{
+		Limits: bridge.GetAPIRateLimits(),
+		Users:  bridge.GetAPIRateLimitStatus(),
+	}, nil
+}
+
+func getDiagnosticKeychain(keychains *keychain.List, locator Locator, vaultEncrypted bool) (any, error) {
+	helpers := maps.Keys(keychains.GetHelpers())
+	slices.Sort(helpers)
+
+	var configured string
+
+	vaultDir, err := locator.ProvideSettingsPath()
+	if err == nil {
+		configured, err = vault.GetHelper(vaultDir)
+	}
+
+	return struct {
+		Helpers        []string
+		Default        string
+		Configured     string
+		VaultEncrypted bool
+	}{
+		Helpers:        helpers,
+		Default:        keychains.GetDefaultHelper(),
+		Configured:     configured,
+		VaultEncrypted: vaultEncrypted,
+	}, err
+}
+
+type diagnosticPort struct {
+	Name      string
+	Port      int
+	Listening bool
+}
+
+func getDiagnosticPorts(v *vault.Vault) any {
+	ports := []diagnosticPort{
+		{Name: "imap", Port: v.GetIMAPPort()},
+		{Name: "smtp", Port: v.GetSMTPPort()},
+		{Name: "imageproxy", Port: v.GetImageProxyPort()},
+	}
+
+	if port := v.GetMetricsPort(); port != 0 {
+		ports = append(ports, diagnosticPort{Name: "metrics", Port: port})
+	}
+
+	for i := range ports {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(constants.Host, strconv.Itoa(ports[i].Port)), diagnosticPortTimeout)
+		if err != nil {
+			continue
+		}
+
+		_ = conn.Close()
+
+		ports[i].Listening = true
+	}
+
+	return ports
+}
+
+type diagnosticMissingMessage struct {
+	UserID    string
+	AddressID string
+	MessageID string
+	Flags     []string
+}
+
+func (bridge *Bridge) getDiagnosticClientState(ctx context.Context) (any, error) {
+	result, err := bridge.CheckClientState(ctx, true, nil)
+
+	missing := []diagnosticMissingMessage{}
+
+	for userID, messages := range result.MissingMessages {
+		for _, message := range messages {
+			missing = append(missing, diagnosticMissingMessage{
+				UserID:    userID,
+				AddressID: message.AddressID,
+				MessageID: message.ID,
+				Flags:     message.Flags.ToSlice(),
+			})
+		}
+	}
+
+	return struct {
+		MissingMessages []diagnosticMissingMessage
+	}{
+		MissingMessages: missing,
+	}, err
+}
+
+// writeDiagnosticLogs copies the logs that would be attached to a bug report to the bundle's logs directory.
+func writeDiagnosticLogs(zw *zip.Writer, locator Locator) ([]diagnosticFile, error) {
+	logsPath, err := locator.ProvideLogsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	buffer, err := logging.ZipLogsForBugReport(logsPath, DefaultMaxSessionCountForBugReport, diagnosticMaxLogsSize)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]diagnosticFile, 0, len(zr.File))
+
+	for _, file := range zr.File {
+		name := path.Join("logs", path.Base(file.Name))
+
+		if err := copyDiagnosticFile(zw, name, file); err != nil {
+			return files, err
+		}
+
+		files = append(files, diagnosticFile{Name: name, Description: "Log file; sensitive values are redacted when logged."})
+	}
+
+	return files, nil
+}
+
+func copyDiagnosticFile(zw *zip.Writer, name string, file *zip.File) error {
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close() //nolint:errcheck
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: file.Modified})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r) //nolint:gosec
+
+	return err
+}
+
+func writeDiagnosticJSON(zw *zip.Writer, name string, data any) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+func getUserStateName(state UserState) string {
+	switch state {
+	case SignedOut:
+		return "signed-out"
+
+	case Locked:
+		return "locked"
+
+	case Connected:
+		return "connected"
+
+	default:
+		return "unknown"
+	}
+}
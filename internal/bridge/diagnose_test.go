@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_WriteDiagnosticBundle(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, b.WriteDiagnosticBundle(ctx, &buf, true))
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+
+			files := make(map[string][]byte)
+
+			for _, file := range zr.File {
+				r, err := file.Open()
+				require.NoError(t, err)
+
+				files[file.Name], err = io.ReadAll(r)
+				require.NoError(t, err)
+				require.NoError(t, r.Close())
+			}
+
+			// The manifest lists every file of the bundle.
+			var manifest struct {
+				Version string
+				Files   []struct{ Name, Error string }
+			}
+			require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+			require.Equal(t, b.GetCurrentVersion().String(), manifest.Version)
+
+			for _, file := range manifest.Files {
+				if file.Error == "" {
+					require.Contains(t, files, file.Name)
+				}
+			}
+
+			for _, name := range []string{"version.json", "settings.json", "users.json", "rate_limits.json", "keychain.json", "ports.json", "client_state.json"} {
+				require.Contains(t, files, name)
+			}
+
+			// The account is described without its addresses.
+			var users []struct {
+				UserID          string
+				State           string
+				Addresses       int
+				SyncHasMessages bool
+			}
+			require.NoError(t, json.Unmarshal(files["users.json"], &users))
+			require.Len(t, users, 1)
+			require.Equal(t, userID, users[0].UserID)
+			require.Equal(t, "connected", users[0].State)
+			require.Equal(t, len(info.Addresses), users[0].Addresses)
+			require.True(t, users[0].SyncHasMessages)
+
+			// The rate limiter of the account is described.
+			var rateLimits struct {
+				Users map[string][]struct{ Class string }
+			}
+			require.NoError(t, json.Unmarshal(files["rate_limits.json"], &rateLimits))
+			require.Len(t, rateLimits.Users[userID], len(ratelimit.Classes))
+
+			// The IMAP and SMTP servers are listening.
+			var ports []struct {
+				Name      string
+				Listening bool
+			}
+			require.NoError(t, json.Unmarshal(files["ports.json"], &ports))
+			require.Contains(t, ports, struct {
+				Name      string
+				Listening bool
+			}{Name: "imap", Listening: true})
+
+			// The mailbox state matches the server's.
+			require.JSONEq(t, `{"MissingMessages": []}`, string(files["client_state.json"]))
+
+			// No secret or address ends up in the bundle.
+			for name, data := range files {
+				require.NotContains(t, string(data), string(info.BridgePass), name)
+
+				for _, addr := range info.Addresses {
+					require.NotContains(t, string(data), addr, name)
+				}
+			}
+		})
+	})
+}
+
+func TestBridge_WriteOfflineDiagnosticBundle(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		var userID string
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			var err error
+
+			userID, err = b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+		})
+
+		vaultDir, err := locator.ProvideSettingsPath()
+		require.NoError(t, err)
+
+		v, err := vault.Open(vaultDir, storeKey)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, bridge.WriteOfflineDiagnosticBundle(&buf, v, locator, keychain.NewTestKeychainsList(), v2_4_0, true))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+
+		files := make(map[string][]byte)
+
+		for _, file := range zr.File {
+			r, err := file.Open()
+			require.NoError(t, err)
+
+			files[file.Name], err = io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+		}
+
+		for _, name := range []string{"manifest.json", "version.json", "settings.json", "users.json", "keychain.json", "ports.json"} {
+			require.Contains(t, files, name)
+		}
+
+		// Nothing that needs a running bridge is collected.
+		require.NotContains(t, files, "rate_limits.json")
+		require.NotContains(t, files, "client_state.json")
+
+		// The account is described as saved in the vault.
+		var users []struct {
+			UserID          string
+			State           string
+			Loaded          bool
+			SyncHasMessages bool
+		}
+		require.NoError(t, json.Unmarshal(files["users.json"], &users))
+		require.Len(t, users, 1)
+		require.Equal(t, userID, users[0].UserID)
+		require.Equal(t, "locked", users[0].State)
+		require.False(t, users[0].Loaded)
+		require.True(t, users[0].SyncHasMessages)
+
+		// Paths are left out of the settings.
+		require.NotContains(t, string(files["settings.json"]), v.GetGluonCacheDir())
+		require.NotContains(t, string(files["settings.json"]), "GluonCacheDir")
+	})
+}
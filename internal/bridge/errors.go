@@ -30,4 +30,39 @@ var (
 	ErrNotImplemented      = errors.New("not implemented")
 
 	ErrSizeTooLarge = errors.New("file is too big")
+
+	ErrNoSuchClientCert      = errors.New("no such client certificate")
+	ErrClientCertRevoked     = errors.New("the client certificate has been revoked")
+	ErrClientCertScope       = errors.New("the client certificate is not valid for this protocol")
+	ErrClientCertRequiresSSL = errors.New("client certificates need IMAP to use SSL")
+
+	ErrNoSuchContactKey    = errors.New("no key recorded for this recipient")
+	ErrNoPendingContactKey = errors.New("the key of this recipient has not changed")
+
+	ErrNoSuchAutocryptPeer = errors.New("no Autocrypt key collected for this address")
+
+	ErrNoSuchSMIMEIdentity  = errors.New("no S/MIME certificate imported for this address")
+	ErrSMIMEAddressMismatch = errors.New("the S/MIME certificate is not issued for any address of this account")
+	ErrNoSuchSMIMERecipient = errors.New("this recipient doesn't get S/MIME signed mail")
+
+	ErrInvalidImageProxyCacheSize = errors.New("the image cache size must be positive")
+
+	ErrInvalidLargeAttachmentDir       = errors.New("the large attachment directory must be an absolute path")
+	ErrInvalidLargeAttachmentThreshold = errors.New("the large attachment threshold must be positive")
+	ErrInvalidLargeAttachmentURL       = errors.New("the large attachment base URL is required and must be an http or https URL")
+
+	ErrInvalidMetricsPort = errors.New("the metrics port must be between 1 and 65535")
+
+	ErrSettingLocked = errors.New("the setting is locked by the system policy")
+
+	ErrNoSuchIMAPSession    = errors.New("no such IMAP session")
+	ErrUnknownClientType    = errors.New("unknown client type")
+	ErrClientTypeNotBlocked = errors.New("this client type is not blocked")
+	ErrInvalidBlockDuration = errors.New("the block duration must be positive")
+	ErrInvalidClientLimit   = errors.New("the client limit must not be negative")
+
+	ErrUnknownRateLimitClass = errors.New("unknown operation class")
+	ErrInvalidRateLimit      = errors.New("the rate and burst must not be negative, and the burst must be positive if the rate is")
+
+	ErrInvalidQuietHours = errors.New("the quiet hours must be times of day between 00:00 and 23:59")
 )
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/dialer"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imageproxy"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+)
+
+// newImageProxy creates the image proxy. Vaults created before the image proxy existed get a port and a key.
+// Images are fetched with the same proxy dialer stack as the API, minus the pins, which only apply to Proton hosts.
+// It is wrapped by the dialer for non-Proton hosts, which verifies certificate chains and refuses to connect to
+// local and private addresses.
+func newImageProxy(bridge *Bridge) (*imageproxy.Service, error) {
+	if bridge.vault.GetImageProxyPort() == 0 {
+		port := ports.FindFreePortFrom(vault.DefaultImageProxyPort, bridge.vault.GetIMAPPort(), bridge.vault.GetSMTPPort())
+
+		if err := bridge.vault.SetImageProxyPort(port); err != nil {
+			return nil, fmt.Errorf("failed to save image proxy port: %w", err)
+		}
+	}
+
+	if len(bridge.vault.GetImageProxyKey()) == 0 {
+		if err := bridge.vault.ResetImageProxyKey(); err != nil {
+			return nil, fmt.Errorf("failed to save image proxy key: %w", err)
+		}
+	}
+
+	return imageproxy.NewService(
+		dialer.CreateTransportWithVerifyingDialer(dialer.NewVerifyingTLSDialer(
+			dialer.NewProxyTLSDialer(dialer.NewBasicTLSDialer(constants.APIHost), constants.APIHost, bridge.panicHandler),
+		)),
+		bridge.locator.ProvideImageProxyCachePath,
+		bridge.vault.GetImageProxyKey(),
+		bridge.vault.GetImageProxyPort(),
+		bridge.vault.GetImageProxyCacheSize(),
+		bridge.isImageProxyEnabled,
+		bridge.panicHandler,
+	)
+}
+
+// startImageProxy starts the image proxy if it isn't running yet.
+// It keeps running until bridge is closed, but only serves users who enabled it.
+func (bridge *Bridge) startImageProxy() {
+	if err := bridge.imageProxy.Start(); err != nil {
+		logPkg.WithError(err).Error("Failed to start image proxy")
+	}
+}
+
+func (bridge *Bridge) isImageProxyEnabled(userID string) bool {
+	return safe.RLockRet(func() bool {
+		user, ok := bridge.users[userID]
+
+		return ok && user.GetUseImageProxy()
+	}, bridge.usersLock)
+}
+
+// GetUseImageProxy returns whether the remote images of the given user's HTML messages are loaded through the image proxy.
+func (bridge *Bridge) GetUseImageProxy(userID string) (bool, error) {
+	return safe.RLockRetErr(func() (bool, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return false, ErrNoSuchUser
+		}
+
+		return user.GetUseImageProxy(), nil
+	}, bridge.usersLock)
+}
+
+// SetUseImageProxy sets whether the remote images of the given user's HTML messages are loaded through the image proxy.
+// Tracking pixels are removed either way.
+func (bridge *Bridge) SetUseImageProxy(ctx context.Context, userID string, useProxy bool) error {
+	logUser.WithField("userID", userID).WithField("useProxy", useProxy).Info("Setting image proxy use")
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if user.GetUseImageProxy() == useProxy {
+			return nil
+		}
+
+		if useProxy {
+			bridge.startImageProxy()
+		}
+
+		return user.SetUseImageProxy(ctx, useProxy)
+	}, bridge.usersLock)
+}
+
+// SetImageProxyTransportTest - sets the transport the image proxy fetches images with; should only be used for tests.
+func (bridge *Bridge) SetImageProxyTransportTest(transport http.RoundTripper) {
+	bridge.imageProxy.SetTransportTest(transport)
+}
+
+// GetImageProxyURL returns the proxy URL of the given remote image for the given user.
+func (bridge *Bridge) GetImageProxyURL(userID, remote string) string {
+	return bridge.imageProxy.URL(userID, remote)
+}
+
+// GetImageProxyCacheSize returns the size limit of the image proxy cache, in bytes.
+func (bridge *Bridge) GetImageProxyCacheSize() int64 {
+	return bridge.vault.GetImageProxyCacheSize()
+}
+
+// SetImageProxyCacheSize sets the size limit of the image proxy cache, in bytes.
+func (bridge *Bridge) SetImageProxyCacheSize(size int64) error {
+	if size <= 0 {
+		return ErrInvalidImageProxyCacheSize
+	}
+
+	if err := bridge.vault.SetImageProxyCacheSize(size); err != nil {
+		return err
+	}
+
+	bridge.imageProxy.SetMaxCacheSize(size)
+
+	return nil
+}
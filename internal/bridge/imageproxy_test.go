@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	go_imap "github.com/emersion/go-imap"
+	gomessage "github.com/emersion/go-message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_ImageProxy(t *testing.T) {
+	image := []byte("\x89PNG\r\n\x1a\n not really a png")
+
+	var requests atomic.Int32
+
+	// A stand-in for the remote server hosting the message's images.
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(image)
+	}))
+	defer remote.Close()
+
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withClient(ctx, t, s, username, password, func(ctx context.Context, c *proton.Client) {
+			addrs, err := c.GetAddresses(ctx)
+			require.NoError(t, err)
+
+			createMessages(ctx, t, c, addrs[0].ID, proton.InboxLabel, []byte(fmt.Sprintf(
+				"From: sender@example.com\r\nTo: user@proton.local\r\nSubject: Newsletter\r\nContent-Type: text/html\r\n\r\n"+
+					`<html><body><img src="%v/logo.png">Hello</body></html>`,
+				remote.URL,
+			)))
+		})
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			// The stand-in remote server listens on localhost, which the image proxy otherwise refuses to reach.
+			b.SetImageProxyTransportTest(http.DefaultTransport)
+
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			cli, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+			require.NoError(t, err)
+			require.NoError(t, cli.Login(info.Addresses[0], string(info.BridgePass)))
+			defer func() { _ = cli.Logout() }()
+
+			fetch := func() (string, string) {
+				messages, err := clientFetch(cli, "INBOX")
+				require.NoError(t, err)
+				require.Len(t, messages, 1)
+
+				literal, err := io.ReadAll(messages[0].GetBody(must(go_imap.ParseBodySectionName("BODY[]"))))
+				require.NoError(t, err)
+
+				entity, err := gomessage.Read(bytes.NewReader(literal))
+				require.NoError(t, err)
+
+				body, err := io.ReadAll(entity.Body)
+				require.NoError(t, err)
+
+				return entity.Header.Get(message.RemoteContentHeader), string(body)
+			}
+
+			// By default, the remote image is left as is.
+			header, body := fetch()
+			require.Empty(t, header)
+			require.Contains(t, body, remote.URL+"/logo.png")
+
+			// Once the proxy is enabled, the message is rebuilt to load the image through it.
+			require.NoError(t, b.SetUseImageProxy(ctx, userID, true))
+			require.Eventually(t, func() bool { header, _ = fetch(); return header != "" }, 30*time.Second, 100*time.Millisecond)
+
+			header, body = fetch()
+			require.Equal(t, "proxied; images=1; css=0; trackers=0", header)
+			require.NotContains(t, body, `src="`+remote.URL)
+
+			match := regexp.MustCompile(`src="(http://[^"]+)"`).FindStringSubmatch(body)
+			require.Len(t, match, 2)
+
+			proxyURL := match[1]
+
+			client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+			for i := 0; i < 2; i++ {
+				res, err := client.Get(proxyURL) //nolint:noctx
+				require.NoError(t, err)
+
+				data, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.Equal(t, image, data)
+			}
+
+			// The second request is served from the cache.
+			require.Equal(t, int32(1), requests.Load())
+
+			// The proxy no longer serves the user once they disable it.
+			require.NoError(t, b.SetUseImageProxy(ctx, userID, false))
+
+			res, err := client.Get(proxyURL) //nolint:noctx
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusNotFound, res.StatusCode)
+		})
+	})
+}
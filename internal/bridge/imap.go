@@ -24,10 +24,12 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	imapEvents "github.com/ProtonMail/gluon/events"
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
 	"github.com/sirupsen/logrus"
 )
 
@@ -38,6 +40,9 @@ func (bridge *Bridge) restartIMAP(ctx context.Context) error {
 func (bridge *Bridge) handleIMAPEvent(event imapEvents.Event) {
 	log := logrus.WithField("pkg", "bridge/event/imap")
 
+	bridge.trackIMAPSession(event)
+	bridge.auditIMAPEvent(event)
+
 	switch event := event.(type) {
 	case imapEvents.UserAdded:
 		for labelID, count := range event.Counts {
@@ -83,7 +88,7 @@ func (b *bridgeIMAPSettings) EventPublisher() imapsmtpserver.IMAPEventPublisher
 }
 
 func (b *bridgeIMAPSettings) TLSConfig() *tls.Config {
-	return b.b.tlsConfig
+	return b.b.clientCertTLSConfig(vault.ClientCertScopeIMAP)
 }
 
 func (b *bridgeIMAPSettings) LogClient() bool {
@@ -126,6 +131,14 @@ func (b *bridgeIMAPSettings) Version() *semver.Version {
 	return b.b.curVersion
 }
 
+func (b *bridgeIMAPSettings) ClientLimits() map[connectionlimiter.Client]int {
+	return b.b.getUserIMAPClientLimits()
+}
+
+func (b *bridgeIMAPSettings) ClientCertAuthenticator() imapsmtpserver.IMAPClientCertAuthenticator {
+	return &bridgeClientCertAuthenticator{b: b.b, scope: vault.ClientCertScopeIMAP}
+}
+
 func (b *bridgeIMAPSettings) PublishIMAPEvent(ctx context.Context, event imapEvents.Event) {
 	select {
 	case <-ctx.Done():
@@ -17,8 +17,88 @@
 
 package bridge
 
-import "golang.org/x/exp/maps"
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+)
 
 func (bridge *Bridge) GetHelpersNames() []string {
 	return maps.Keys(bridge.keychains.GetHelpers())
 }
+
+// RotateVaultKey re-encrypts the vault with a new random key and stores that key in the given keychain helper.
+// The old key is removed from the previously used helper. An empty helper keeps the current one.
+// If the vault is insecure or the previously used helper can't be opened, the vault is encrypted with a fresh key
+// stored in the given helper instead, so that users can switch away from a broken keychain.
+func (bridge *Bridge) RotateVaultKey(helper string) error {
+	insecure := slices.ContainsFunc(bridge.errors, func(err error) bool { return errors.Is(err, ErrVaultInsecure) })
+
+	if insecure && helper == "" {
+		return ErrVaultInsecure
+	}
+
+	vaultDir, err := bridge.locator.ProvideSettingsPath()
+	if err != nil {
+		return err
+	}
+
+	oldHelper, err := vault.GetHelper(vaultDir)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := bridge.keychains.GetHelpers()[oldHelper]; !ok {
+		oldHelper = bridge.keychains.GetDefaultHelper()
+	}
+
+	if helper == "" {
+		helper = oldHelper
+	}
+
+	keychainName := keychain.ProfileKeychainName(constants.KeyChainName, bridge.locator.GetProfile())
+
+	newKC, err := bridge.keychains.NewKeychain(helper, keychainName)
+	if err != nil {
+		return fmt.Errorf("could not open keychain %q: %w", helper, err)
+	}
+
+	if insecure {
+		return bridge.resetVaultKey(vaultDir, newKC, helper)
+	}
+
+	oldKC, err := bridge.keychains.NewKeychain(oldHelper, keychainName)
+	if err != nil {
+		if helper == oldHelper {
+			return fmt.Errorf("could not open keychain %q: %w", oldHelper, err)
+		}
+
+		logrus.WithError(err).WithField("helper", oldHelper).Warn("Could not open the current keychain, resetting the vault key")
+
+		return bridge.resetVaultKey(vaultDir, newKC, helper)
+	}
+
+	if err := bridge.vault.RotateKey(oldKC, newKC, oldHelper, helper); err != nil {
+		return fmt.Errorf("could not rotate vault key: %w", err)
+	}
+
+	bridge.heartbeat.SetKeyChainPref(helper)
+
+	return nil
+}
+
+func (bridge *Bridge) resetVaultKey(vaultDir string, kc *keychain.Keychain, helper string) error {
+	if err := bridge.vault.ResetKey(vaultDir, kc, helper); err != nil {
+		return fmt.Errorf("could not reset vault key: %w", err)
+	}
+
+	bridge.heartbeat.SetKeyChainPref(helper)
+
+	return nil
+}
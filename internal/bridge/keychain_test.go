@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_RotateVaultKey_BrokenKeychain(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			vaultDir, err := locator.ProvideSettingsPath()
+			require.NoError(t, err)
+
+			kcs := keychain.NewTestKeychainsListWithHelpers("old", "new")
+			b.SetKeychainsTest(kcs)
+
+			require.NoError(t, vault.SetHelper(vaultDir, "old"))
+			require.NoError(t, b.SetShowAllMail(false))
+
+			// The keychain holding the vault key can no longer be opened.
+			kcs.BreakTestHelper("old")
+
+			// Switching away from it still works: the vault is encrypted with a fresh key stored in the new keychain.
+			require.NoError(t, b.RotateVaultKey("new"))
+
+			helper, err := b.GetKeychainApp()
+			require.NoError(t, err)
+			require.Equal(t, "new", helper)
+
+			// The running vault keeps working.
+			require.NoError(t, b.SetShowAllMail(true))
+
+			kc, err := kcs.NewKeychain("new", keychain.ProfileKeychainName(constants.KeyChainName, locator.GetProfile()))
+			require.NoError(t, err)
+
+			key, err := vault.GetVaultKey(kc)
+			require.NoError(t, err)
+
+			v, corrupt, err := vault.New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+			require.NoError(t, err)
+			require.NoError(t, corrupt)
+			require.True(t, v.GetShowAllMail())
+		})
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetLargeAttachments returns the settings of the given user's attachments too large to be sent with a message.
+func (bridge *Bridge) GetLargeAttachments(userID string) (vault.LargeAttachmentSettings, error) {
+	return safe.RLockRetErr(func() (vault.LargeAttachmentSettings, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return vault.LargeAttachmentSettings{}, ErrNoSuchUser
+		}
+
+		return user.GetLargeAttachments(), nil
+	}, bridge.usersLock)
+}
+
+// SetLargeAttachments sets the settings of the given user's attachments too large to be sent with a message.
+// When enabled, attachments above the threshold are written to the directory and sent as links under the base URL instead.
+func (bridge *Bridge) SetLargeAttachments(userID string, settings vault.LargeAttachmentSettings) error {
+	logUser.WithField("userID", userID).WithField("enabled", settings.Enabled).Info("Setting large attachments")
+
+	if settings.Enabled {
+		if err := validateLargeAttachments(settings); err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(settings.Dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create large attachment directory: %w", err)
+		}
+	}
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		return user.SetLargeAttachments(settings)
+	}, bridge.usersLock)
+}
+
+func validateLargeAttachments(settings vault.LargeAttachmentSettings) error {
+	if !filepath.IsAbs(settings.Dir) {
+		return ErrInvalidLargeAttachmentDir
+	}
+
+	if settings.Threshold <= 0 {
+		return ErrInvalidLargeAttachmentThreshold
+	}
+
+	if u, err := url.Parse(settings.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidLargeAttachmentURL
+	}
+
+	return nil
+}
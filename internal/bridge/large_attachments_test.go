@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_SendLargeAttachments(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		dir := filepath.Join(t.TempDir(), "attachments")
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			send := func(to string, attachment []byte) error {
+				client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer client.Close() //nolint:errcheck
+
+				require.NoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+				require.NoError(t, client.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+				return client.SendMail(info.Addresses[0], []string{to}, strings.NewReader(newMessageWithAttachment(info.Addresses[0], to, attachment)))
+			}
+
+			// Attachments exceeding the API limit are rejected with a clear error.
+			require.ErrorContains(t, send("recipient@example.com", bytes.Repeat([]byte("a"), 26*1024*1024)), "exceed 25 MB")
+
+			// Invalid settings are rejected.
+			require.ErrorIs(t, b.SetLargeAttachments(userID, vault.LargeAttachmentSettings{Enabled: true, Dir: "relative", Threshold: 1024}), bridge.ErrInvalidLargeAttachmentDir)
+			require.ErrorIs(t, b.SetLargeAttachments(userID, vault.LargeAttachmentSettings{Enabled: true, Dir: dir}), bridge.ErrInvalidLargeAttachmentThreshold)
+			require.ErrorIs(t, b.SetLargeAttachments(userID, vault.LargeAttachmentSettings{Enabled: true, Dir: dir, Threshold: 1024, BaseURL: "ftp://files"}), bridge.ErrInvalidLargeAttachmentURL)
+			require.ErrorIs(t, b.SetLargeAttachments(userID, vault.LargeAttachmentSettings{Enabled: true, Dir: dir, Threshold: 1024}), bridge.ErrInvalidLargeAttachmentURL)
+
+			require.NoError(t, b.SetLargeAttachments(userID, vault.LargeAttachmentSettings{
+				Enabled:   true,
+				Threshold: 1024,
+				Dir:       dir,
+				BaseURL:   "https://files.example.com",
+			}))
+
+			attachment := bytes.Repeat([]byte("b"), 2048)
+
+			// Links are stored unencrypted, so they aren't sent in messages encrypted end-to-end, e.g. to Proton users.
+			require.ErrorContains(t, send("recipient@"+s.GetDomain(), attachment), "end-to-end encrypted")
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+
+			// The attachment above the threshold is stored and sent as a link.
+			require.NoError(t, send("recipient@example.com", attachment))
+
+			entries, err = os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+
+			data, err := os.ReadFile(filepath.Join(dir, entries[0].Name(), "data.bin"))
+			require.NoError(t, err)
+			require.Equal(t, attachment, data)
+		})
+
+		withClient(ctx, t, s, username, password, func(ctx context.Context, c *proton.Client) {
+			metadata, err := c.GetMessageMetadataPage(ctx, 0, 10, proton.MessageFilter{LabelID: proton.SentLabel})
+			require.NoError(t, err)
+			require.Len(t, metadata, 1)
+			require.Zero(t, metadata[0].NumAttachments)
+		})
+	})
+}
+
+func newMessageWithAttachment(from, to string, attachment []byte) string {
+	var encoded strings.Builder
+
+	for data := base64.StdEncoding.EncodeToString(attachment); len(data) > 0; {
+		n := min(len(data), 76)
+		encoded.WriteString(data[:n] + "\r\n")
+		data = data[n:]
+	}
+
+	return fmt.Sprintf(
+		"From: %v\r\nTo: %v\r\nSubject: Attachment\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n"+
+			"--b\r\nContent-Type: text/plain\r\n\r\nHello world!\r\n"+
+			"--b\r\nContent-Type: application/octet-stream\r\nContent-Disposition: attachment; filename=data.bin\r\n"+
+			"Content-Transfer-Encoding: base64\r\n\r\n%v"+
+			"--b--\r\n",
+		from, to, encoded.String(),
+	)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	go_imap "github.com/emersion/go-imap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_MessageProfile(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withClient(ctx, t, s, username, password, func(ctx context.Context, c *proton.Client) {
+			addrs, err := c.GetAddresses(ctx)
+			require.NoError(t, err)
+
+			createNumMessages(ctx, t, c, addrs[0].ID, proton.InboxLabel, 1)
+		})
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			cli, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+			require.NoError(t, err)
+			require.NoError(t, cli.Login(info.Addresses[0], string(info.BridgePass)))
+			defer func() { _ = cli.Logout() }()
+
+			fetchHeader := func() *rfc822.Header {
+				messages, err := clientFetch(cli, "INBOX")
+				require.NoError(t, err)
+				require.Len(t, messages, 1)
+
+				literal, err := io.ReadAll(messages[0].GetBody(must(go_imap.ParseBodySectionName("BODY[]"))))
+				require.NoError(t, err)
+
+				header, err := rfc822.Parse(literal).ParseHeader()
+				require.NoError(t, err)
+
+				return header
+			}
+
+			// By default, messages carry bridge's own headers.
+			profile, err := b.GetMessageProfile(userID)
+			require.NoError(t, err)
+			require.Equal(t, vault.MessageProfileCompat, profile)
+			require.True(t, fetchHeader().Has("X-Pm-Internal-Id"))
+
+			// The cached message is rebuilt in the background without them.
+			require.NoError(t, b.SetMessageProfile(ctx, userID, vault.MessageProfileMinimal))
+			require.Eventually(t, func() bool { return !fetchHeader().Has("X-Pm-Internal-Id") }, 30*time.Second, 100*time.Millisecond)
+
+			// The forensic profile adds the raw metadata.
+			require.NoError(t, b.SetMessageProfile(ctx, userID, vault.MessageProfileForensic))
+			require.Eventually(t, func() bool { return fetchHeader().Has("X-Pm-Label-Ids") }, 30*time.Second, 100*time.Millisecond)
+
+			header := fetchHeader()
+			require.True(t, header.Has("X-Pm-Internal-Id"))
+			require.Contains(t, header.Get("X-Pm-Label-Ids"), proton.InboxLabel)
+
+			// The size reported to clients is the one of the rebuilt message.
+			messages, err := clientFetch(cli, "INBOX", go_imap.FetchRFC822Size)
+			require.NoError(t, err)
+			require.Len(t, messages, 1)
+
+			literal, err := io.ReadAll(messages[0].GetBody(must(go_imap.ParseBodySectionName("BODY[]"))))
+			require.NoError(t, err)
+			require.Equal(t, uint32(len(literal)), messages[0].Size)
+
+			require.Error(t, b.SetMessageProfile(ctx, userID, vault.MessageProfileForensic))
+		})
+	})
+}
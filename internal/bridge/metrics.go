@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/metrics"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+)
+
+// cacheSizeMaxAge is how long the size of the caches is reused before walking their directories again.
+const cacheSizeMaxAge = time.Minute
+
+const (
+	metricSyncProgress    = "bridge_sync_progress_ratio"
+	metricSyncFailures    = "bridge_sync_failures_total"
+	metricEventLoopLag    = "bridge_event_loop_lag_seconds"
+	metricIMAPSessions    = "bridge_imap_open_sessions"
+	metricCacheSize       = "bridge_cache_size_bytes"
+	metricSMTPSendSuccess = "bridge_smtp_send_success_total"
+	metricSMTPErrors      = "bridge_smtp_errors_total"
+)
+
+// initMetrics sets up the metrics that aren't recorded as they happen, and starts the endpoint if it is enabled.
+// Observability metrics are recorded locally too, so the endpoint works even when telemetry is disabled.
+func (bridge *Bridge) initMetrics() {
+	bridge.observabilityService.SetLocalRecorder(bridge.metrics)
+
+	bridge.metrics.Describe(metricSyncProgress, metrics.TypeGauge, "Progress of the user's sync, from 0 to 1.")
+	bridge.metrics.Describe(metricSyncFailures, metrics.TypeCounter, "Syncs of the user that failed.")
+	bridge.metrics.Describe(metricEventLoopLag, metrics.TypeGauge, "Time since the user's event loop last caught up with the API.")
+	bridge.metrics.Describe(metricIMAPSessions, metrics.TypeGauge, "Open IMAP sessions.")
+	bridge.metrics.Describe(metricCacheSize, metrics.TypeGauge, "Size of bridge's caches on disk.")
+	bridge.metrics.Describe(metricSMTPSendSuccess, metrics.TypeCounter, "Messages sent over SMTP.")
+	bridge.metrics.Describe(metricSMTPErrors, metrics.TypeCounter, "Messages that failed to be sent over SMTP, by step.")
+
+	gluonCacheSize := metrics.NewDirSize(func() (string, error) { return bridge.GetGluonCacheDir(), nil }, cacheSizeMaxAge)
+	imageCacheSize := metrics.NewDirSize(bridge.locator.ProvideImageProxyCachePath, cacheSizeMaxAge)
+
+	bridge.metrics.AddCollector(func(emit func(string, metrics.Labels, float64)) {
+		emit(metricIMAPSessions, nil, float64(bridge.serverManager.GetOpenIMAPSessionCount()))
+		emit(metricCacheSize, metrics.Labels{"cache": "gluon"}, float64(gluonCacheSize.Get()))
+		emit(metricCacheSize, metrics.Labels{"cache": "imageproxy"}, float64(imageCacheSize.Get()))
+
+		safe.RLock(func() {
+			for userID, user := range bridge.users {
+				if lastPoll := user.GetLastEventPollTime(); !lastPoll.IsZero() {
+					emit(metricEventLoopLag, metrics.Labels{"user_id": userID}, time.Since(lastPoll).Seconds())
+				}
+			}
+		}, bridge.usersLock)
+	})
+
+	if bridge.vault.GetMetricsEnabled() {
+		if err := bridge.metricsService.Start(bridge.vault.GetMetricsPort()); err != nil {
+			logPkg.WithError(err).Error("Failed to start metrics endpoint")
+		}
+	}
+}
+
+// recordEventMetrics updates the metrics that follow bridge's events.
+func (bridge *Bridge) recordEventMetrics(event events.Event) {
+	switch event := event.(type) {
+	case events.SyncStarted:
+		bridge.metrics.Set(metricSyncProgress, metrics.Labels{"user_id": event.UserID}, 0)
+
+	case events.SyncProgress:
+		bridge.metrics.Set(metricSyncProgress, metrics.Labels{"user_id": event.UserID}, event.Progress)
+
+	case events.SyncFinished:
+		bridge.metrics.Set(metricSyncProgress, metrics.Labels{"user_id": event.UserID}, 1)
+
+	case events.SyncFailed:
+		bridge.metrics.Add(metricSyncFailures, metrics.Labels{"user_id": event.UserID}, 1)
+
+	case events.UserLoggedOut:
+		bridge.metrics.Delete("user_id", event.UserID)
+	}
+}
+
+// GetMetricsEnabled returns whether the metrics endpoint is enabled.
+func (bridge *Bridge) GetMetricsEnabled() bool {
+	return bridge.vault.GetMetricsEnabled()
+}
+
+// SetMetricsEnabled sets whether the metrics endpoint is enabled.
+// The first time it is enabled, it gets the first free port from the default one.
+func (bridge *Bridge) SetMetricsEnabled(ctx context.Context, enabled bool) error {
+	if enabled == bridge.vault.GetMetricsEnabled() {
+		return nil
+	}
+
+	if enabled {
+		if bridge.vault.GetMetricsPort() == 0 {
+			port := ports.FindFreePortFrom(
+				vault.DefaultMetricsPort,
+				bridge.vault.GetIMAPPort(),
+				bridge.vault.GetSMTPPort(),
+				bridge.vault.GetImageProxyPort(),
+			)
+
+			if err := bridge.vault.SetMetricsPort(port); err != nil {
+				return err
+			}
+		}
+
+		if err := bridge.metricsService.Start(bridge.vault.GetMetricsPort()); err != nil {
+			return err
+		}
+	} else if err := bridge.metricsService.Stop(ctx); err != nil {
+		return err
+	}
+
+	return bridge.vault.SetMetricsEnabled(enabled)
+}
+
+// GetMetricsPort returns the port of the metrics endpoint. It is zero if the endpoint was never enabled.
+func (bridge *Bridge) GetMetricsPort() int {
+	return bridge.vault.GetMetricsPort()
+}
+
+// SetMetricsPort sets the port of the metrics endpoint. If the endpoint is enabled, it moves to the new port.
+func (bridge *Bridge) SetMetricsPort(port int) error {
+	if port <= 0 || port > 65535 {
+		return ErrInvalidMetricsPort
+	}
+
+	if port == bridge.vault.GetMetricsPort() {
+		return nil
+	}
+
+	if bridge.vault.GetMetricsEnabled() {
+		if err := bridge.metricsService.Start(port); err != nil {
+			return err
+		}
+	}
+
+	return bridge.vault.SetMetricsPort(port)
+}
+
+// GetMetricsURL returns the URL of the metrics endpoint.
+func (bridge *Bridge) GetMetricsURL() string {
+	return fmt.Sprintf("http://%v/metrics", net.JoinHostPort(constants.Host, strconv.Itoa(bridge.vault.GetMetricsPort())))
+}
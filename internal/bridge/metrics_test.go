@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_Metrics(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			// The endpoint is opt-in, and works without telemetry.
+			require.False(t, b.GetMetricsEnabled())
+			require.NoError(t, b.SetTelemetryDisabled(true))
+
+			require.ErrorIs(t, b.SetMetricsPort(0), bridge.ErrInvalidMetricsPort)
+			require.NoError(t, b.SetMetricsEnabled(ctx, true))
+			require.NotZero(t, b.GetMetricsPort())
+
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			imapClient, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+			require.NoError(t, err)
+			require.NoError(t, imapClient.Login(info.Addresses[0], string(info.BridgePass)))
+			defer func() { _ = imapClient.Logout() }()
+
+			smtpClient, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+			require.NoError(t, err)
+			defer smtpClient.Close() //nolint:errcheck
+
+			require.NoError(t, smtpClient.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+			require.NoError(t, smtpClient.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+			require.NoError(t, smtpClient.SendMail(info.Addresses[0], []string{"recipient@" + s.GetDomain()}, strings.NewReader(
+				fmt.Sprintf("From: %v\r\nSubject: Hello\r\n\r\nHello world!\r\n", info.Addresses[0]),
+			)))
+
+			scrape := func() string {
+				res, err := http.Get(b.GetMetricsURL()) //nolint:noctx
+				require.NoError(t, err)
+
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+
+				return string(body)
+			}
+
+			out := scrape()
+			require.Contains(t, out, fmt.Sprintf(`bridge_sync_progress_ratio{user_id=%q} 1`, userID))
+			require.Contains(t, out, `bridge_smtp_send_success_total 1`)
+			require.Contains(t, out, `bridge_api_responses_total{code="200"}`)
+			require.Contains(t, out, `bridge_cache_size_bytes{cache="gluon"}`)
+			require.Regexp(t, regexp.MustCompile(`(?m)^bridge_imap_open_sessions [1-9]`), out)
+
+			// The event loop lag is reported once the user's event loop has polled.
+			require.Eventually(t, func() bool {
+				return strings.Contains(scrape(), fmt.Sprintf(`bridge_event_loop_lag_seconds{user_id=%q}`, userID))
+			}, 30*time.Second, 100*time.Millisecond)
+
+			// Once disabled, the port is free again.
+			port := b.GetMetricsPort()
+			require.NoError(t, b.SetMetricsEnabled(ctx, false))
+			require.True(t, ports.IsPortFree(port))
+		})
+	})
+}
@@ -22,6 +22,7 @@ type Mocks struct {
 
 	Updater     *TestUpdater
 	Autostarter *mocks.MockAutostarter
+	Notifier    *TestNotifier
 
 	CrashHandler *mocks.MockPanicHandler
 	Reporter     *mocks.MockReporter
@@ -38,6 +39,7 @@ func NewMocks(tb testing.TB, version, minAuto *semver.Version) *Mocks {
 
 		Updater:     NewTestUpdater(version, minAuto),
 		Autostarter: mocks.NewMockAutostarter(ctl),
+		Notifier:    &TestNotifier{},
 
 		CrashHandler: mocks.NewMockPanicHandler(ctl),
 		Reporter:     mocks.NewMockReporter(ctl),
@@ -121,9 +123,38 @@ func (provider *TestLocationsProvider) UserCache() string {
 	return provider.cache
 }
 
+// TestNotification is a desktop notification shown by the TestNotifier.
+type TestNotification struct {
+	Title string
+	Body  string
+}
+
+// TestNotifier records the desktop notifications instead of showing them.
+type TestNotifier struct {
+	notifications []TestNotification
+	lock          sync.Mutex
+}
+
+func (notifier *TestNotifier) Notify(title, body string) error {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	notifier.notifications = append(notifier.notifications, TestNotification{Title: title, Body: body})
+
+	return nil
+}
+
+func (notifier *TestNotifier) GetNotifications() []TestNotification {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	return append([]TestNotification(nil), notifier.notifications...)
+}
+
 type TestUpdater struct {
 	latest   updater.VersionInfoLegacy
 	releases updater.VersionInfo
+	verified *semver.Version
 	lock     sync.RWMutex
 }
 
@@ -165,6 +196,26 @@ func (testUpdater *TestUpdater) RemoveOldUpdates() error {
 	return nil
 }
 
+func (testUpdater *TestUpdater) MarkVersionVerified(version *semver.Version) error {
+	testUpdater.lock.Lock()
+	defer testUpdater.lock.Unlock()
+
+	testUpdater.verified = version
+
+	return nil
+}
+
+func (testUpdater *TestUpdater) RecordCleanExit(_ *semver.Version) error {
+	return nil
+}
+
+func (testUpdater *TestUpdater) GetVerifiedVersion() *semver.Version {
+	testUpdater.lock.RLock()
+	defer testUpdater.lock.RUnlock()
+
+	return testUpdater.verified
+}
+
 func (testUpdater *TestUpdater) SetLatestVersion(releases updater.VersionInfo) {
 	testUpdater.lock.Lock()
 	defer testUpdater.lock.Unlock()
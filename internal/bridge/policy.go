@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/files"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// IsSettingLocked returns whether the given setting (one of the policy.Setting* names) is locked by the system-wide
+// policy, in which case its setter returns ErrSettingLocked.
+func (bridge *Bridge) IsSettingLocked(name string) bool {
+	return bridge.settingsPolicy.IsLocked(name)
+}
+
+// GetLockedSettings returns the names of the settings locked by the system-wide policy.
+func (bridge *Bridge) GetLockedSettings() []string {
+	return bridge.settingsPolicy.GetLocked()
+}
+
+// applySettingsPolicy writes the values set by the settings policy to the vault, over those chosen by the user.
+// It is called before the servers are started, so they use the enforced values from the start.
+// Autostart is also enabled or disabled in the system, as SetAutostart does.
+func applySettingsPolicy(vault *vault.Vault, autostarter Autostarter, settings policy.Settings) error {
+	if settings.IMAPPort != nil {
+		if err := vault.SetIMAPPort(*settings.IMAPPort); err != nil {
+			return err
+		}
+	}
+
+	if settings.IMAPSSL != nil {
+		if err := vault.SetIMAPSSL(*settings.IMAPSSL); err != nil {
+			return err
+		}
+	}
+
+	if settings.SMTPPort != nil {
+		if err := vault.SetSMTPPort(*settings.SMTPPort); err != nil {
+			return err
+		}
+	}
+
+	if settings.SMTPSSL != nil {
+		if err := vault.SetSMTPSSL(*settings.SMTPSSL); err != nil {
+			return err
+		}
+	}
+
+	if settings.ProxyAllowed != nil {
+		if err := vault.SetProxyAllowed(*settings.ProxyAllowed); err != nil {
+			return err
+		}
+	}
+
+	if settings.TelemetryDisabled != nil {
+		if err := vault.SetTelemetryDisabled(*settings.TelemetryDisabled); err != nil {
+			return err
+		}
+	}
+
+	if settings.UpdateChannel != nil {
+		if err := vault.SetUpdateChannel(*settings.UpdateChannel); err != nil {
+			return err
+		}
+	}
+
+	if settings.AutoUpdate != nil {
+		if err := vault.SetAutoUpdate(*settings.AutoUpdate); err != nil {
+			return err
+		}
+	}
+
+	if settings.Autostart != nil {
+		if err := vault.SetAutostart(*settings.Autostart); err != nil {
+			return err
+		}
+
+		if err := applyAutostart(autostarter, *settings.Autostart); err != nil {
+			logPkg.WithError(err).Error("Failed to apply autostart policy")
+		}
+	}
+
+	if settings.CacheDir != nil {
+		if err := applyCacheDirPolicy(vault, *settings.CacheDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyCacheDirPolicy moves the message cache to the directory set by the policy, if it isn't already there.
+func applyCacheDirPolicy(vault *vault.Vault, dir string) error {
+	oldGluonDir, newGluonDir := vault.GetGluonCacheDir(), filepath.Join(dir, "gluon")
+	if oldGluonDir == newGluonDir {
+		return nil
+	}
+
+	oldCacheDir := imapsmtpserver.ApplyGluonCachePathSuffix(oldGluonDir)
+
+	if _, err := os.Stat(oldCacheDir); err == nil {
+		if err := files.CopyDir(oldCacheDir, imapsmtpserver.ApplyGluonCachePathSuffix(newGluonDir)); err != nil {
+			return fmt.Errorf("failed to copy gluon cache dir: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to stat gluon cache dir: %w", err)
+	}
+
+	if err := vault.SetGluonDir(newGluonDir); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(oldCacheDir); err != nil {
+		logPkg.WithError(err).Error("Failed to remove old gluon cache dir")
+	}
+
+	return nil
+}
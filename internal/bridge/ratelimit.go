@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// GetAPIRateLimits returns the rate limits of the API requests caused by each user's clients, per operation class.
+func (bridge *Bridge) GetAPIRateLimits() map[ratelimit.Class]ratelimit.Limit {
+	limits := maps.Clone(ratelimit.DefaultLimits)
+
+	maps.Copy(limits, bridge.getAPIRateLimits())
+
+	return limits
+}
+
+// SetAPIRateLimit sets the rate limit of the given operation class. It applies at once to all users.
+func (bridge *Bridge) SetAPIRateLimit(class ratelimit.Class, limit ratelimit.Limit) error {
+	if !slices.Contains(ratelimit.Classes, class) {
+		return ErrUnknownRateLimitClass
+	}
+
+	if limit.PerMinute < 0 || limit.Burst < 0 || (limit.PerMinute > 0 && limit.Burst == 0) {
+		return ErrInvalidRateLimit
+	}
+
+	logPkg.WithFields(logrus.Fields{
+		"class": class,
+		"limit": limit,
+	}).Info("Setting API rate limit")
+
+	if err := bridge.vault.SetAPIRateLimit(string(class), limit); err != nil {
+		return err
+	}
+
+	bridge.setUsersAPIRateLimit(class, limit)
+
+	return nil
+}
+
+// ResetAPIRateLimit restores the default rate limit of the given operation class. It applies at once to all users.
+func (bridge *Bridge) ResetAPIRateLimit(class ratelimit.Class) error {
+	if !slices.Contains(ratelimit.Classes, class) {
+		return ErrUnknownRateLimitClass
+	}
+
+	logPkg.WithField("class", class).Info("Resetting API rate limit")
+
+	if err := bridge.vault.ResetAPIRateLimit(string(class)); err != nil {
+		return err
+	}
+
+	bridge.setUsersAPIRateLimit(class, ratelimit.DefaultLimits[class])
+
+	return nil
+}
+
+// GetAPIRateLimitStatus returns the state of the rate limiters of the loaded users, by user ID.
+func (bridge *Bridge) GetAPIRateLimitStatus() map[string][]ratelimit.Status {
+	return safe.RLockRet(func() map[string][]ratelimit.Status {
+		status := make(map[string][]ratelimit.Status, len(bridge.users))
+
+		for userID, user := range bridge.users {
+			status[userID] = user.GetRateLimiter().GetStatus()
+		}
+
+		return status
+	}, bridge.usersLock)
+}
+
+func (bridge *Bridge) setUsersAPIRateLimit(class ratelimit.Class, limit ratelimit.Limit) {
+	safe.RLock(func() {
+		for _, user := range bridge.users {
+			user.GetRateLimiter().SetLimit(class, limit)
+		}
+	}, bridge.usersLock)
+}
+
+func (bridge *Bridge) getAPIRateLimits() map[ratelimit.Class]ratelimit.Limit {
+	limits := make(map[ratelimit.Class]ratelimit.Limit)
+
+	for class, limit := range bridge.vault.GetAPIRateLimits() {
+		limits[ratelimit.Class(class)] = limit
+	}
+
+	return limits
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_APIRateLimit(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			// Invalid limits are rejected.
+			require.ErrorIs(t, b.SetAPIRateLimit("unknown", ratelimit.Limit{}), bridge.ErrUnknownRateLimitClass)
+			require.ErrorIs(t, b.SetAPIRateLimit(ratelimit.ClassSend, ratelimit.Limit{PerMinute: 1}), bridge.ErrInvalidRateLimit)
+			require.ErrorIs(t, b.SetAPIRateLimit(ratelimit.ClassSend, ratelimit.Limit{PerMinute: -1, Burst: 1}), bridge.ErrInvalidRateLimit)
+
+			require.NoError(t, b.SetAPIRateLimit(ratelimit.ClassSend, ratelimit.Limit{PerMinute: 1, Burst: 1}))
+			require.NoError(t, b.SetAPIRateLimit(ratelimit.ClassLabel, ratelimit.Limit{PerMinute: 1, Burst: 1}))
+			require.Equal(t, ratelimit.Limit{PerMinute: 1, Burst: 1}, b.GetAPIRateLimits()[ratelimit.ClassSend])
+			require.Equal(t, ratelimit.DefaultLimits[ratelimit.ClassFetch], b.GetAPIRateLimits()[ratelimit.ClassFetch])
+
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			// The client quits after sending a message, so each message is sent over a new connection.
+			// Identical messages are only sent once, so each one has its own subject.
+			var sent int
+
+			send := func() error {
+				sent++
+
+				smtpClient, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer smtpClient.Close() //nolint:errcheck
+
+				require.NoError(t, smtpClient.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+				require.NoError(t, smtpClient.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+				return smtpClient.SendMail(
+					info.Addresses[0],
+					[]string{"recipient@" + s.GetDomain()},
+					strings.NewReader(fmt.Sprintf("Subject: Rate limit %v\r\n\r\nHello world!", sent)),
+				)
+			}
+
+			// The second message is refused with a temporary failure.
+			require.NoError(t, send())
+
+			var smtpErr *smtp.SMTPError
+			require.True(t, errors.As(send(), &smtpErr))
+			require.Equal(t, 451, smtpErr.Code)
+
+			// Changed limits apply at once.
+			require.NoError(t, b.ResetAPIRateLimit(ratelimit.ClassSend))
+			require.Equal(t, ratelimit.DefaultLimits[ratelimit.ClassSend], b.GetAPIRateLimits()[ratelimit.ClassSend])
+			require.NoError(t, send())
+
+			// The second flag change over IMAP is refused.
+			imapClient, err := eventuallyDial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetIMAPPort())))
+			require.NoError(t, err)
+			require.NoError(t, imapClient.Login(info.Addresses[0], string(info.BridgePass)))
+			defer imapClient.Logout() //nolint:errcheck
+
+			require.Eventually(t, func() bool {
+				status, err := imapClient.Status("Sent", []imap.StatusItem{imap.StatusMessages})
+				require.NoError(t, err)
+
+				return status.Messages == 2
+			}, 10*time.Second, 100*time.Millisecond)
+
+			_, err = imapClient.Select("Sent", false)
+			require.NoError(t, err)
+
+			store := func(num uint32) error {
+				seq := new(imap.SeqSet)
+				seq.AddNum(num)
+
+				return imapClient.Store(seq, imap.FormatFlagsOp(imap.AddFlags, true), []interface{}{imap.FlaggedFlag}, nil)
+			}
+
+			require.NoError(t, store(1))
+			require.ErrorContains(t, store(2), "too many label requests")
+
+			// The throttled requests are shown in diagnostics.
+			status := b.GetAPIRateLimitStatus()[userID]
+			require.Len(t, status, len(ratelimit.Classes))
+
+			for _, classStatus := range status {
+				switch classStatus.Class {
+				case ratelimit.ClassSend:
+					require.Equal(t, uint64(2), classStatus.Allowed)
+					require.Equal(t, uint64(1), classStatus.Throttled)
+
+				case ratelimit.ClassLabel:
+					require.Equal(t, uint64(1), classStatus.Allowed)
+					require.Equal(t, uint64(1), classStatus.Throttled)
+					require.False(t, classStatus.LastThrottled.IsZero())
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	imapEvents "github.com/ProtonMail/gluon/events"
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+)
+
+// IMAPSessionInfo describes an open IMAP session.
+type IMAPSessionInfo struct {
+	ID int
+
+	// UserID is the ID of the user the client logged in as. It is empty until the client logs in.
+	UserID string
+
+	// Client is the name and version of the mail client, as reported by the client itself with the IMAP ID command.
+	Client     string
+	ClientType connectionlimiter.Client
+
+	Remote      string
+	Mailbox     string
+	ConnectedAt time.Time
+
+	BytesReceived uint64
+	BytesSent     uint64
+}
+
+// GetIMAPSessions returns the open IMAP sessions, oldest first.
+func (bridge *Bridge) GetIMAPSessions() []IMAPSessionInfo {
+	sessions := safe.RLockRet(func() []IMAPSessionInfo {
+		sessions := make([]IMAPSessionInfo, 0, len(bridge.imapSessions))
+
+		for _, session := range bridge.imapSessions {
+			sessions = append(sessions, *session)
+		}
+
+		return sessions
+	}, bridge.imapSessionsLock)
+
+	for idx := range sessions {
+		sessions[idx].BytesReceived, sessions[idx].BytesSent = bridge.serverManager.GetIMAPSessionTraffic(sessions[idx].Remote)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+
+	return sessions
+}
+
+// CloseIMAPSession disconnects the client of the given IMAP session. The client may reconnect right away;
+// use BlockIMAPClient to prevent it.
+func (bridge *Bridge) CloseIMAPSession(sessionID int) error {
+	logPkg.WithField("sessionID", sessionID).Info("Closing IMAP session")
+
+	if _, ok := bridge.getIMAPSession(sessionID); !ok {
+		return ErrNoSuchIMAPSession
+	}
+
+	return bridge.serverManager.CloseIMAPSession(sessionID, "Session closed by the user")
+}
+
+// BlockIMAPClient disconnects the clients of the given type and refuses their IMAP sessions for the given duration.
+func (bridge *Bridge) BlockIMAPClient(clientType connectionlimiter.Client, duration time.Duration) error {
+	if !slices.Contains(imapsmtpserver.ClientTypes, clientType) {
+		return ErrUnknownClientType
+	}
+
+	if duration <= 0 {
+		return ErrInvalidBlockDuration
+	}
+
+	logPkg.WithFields(logrus.Fields{
+		"client":   clientType,
+		"duration": duration,
+	}).Info("Blocking IMAP client")
+
+	safe.Lock(func() {
+		bridge.blockedIMAPClients[clientType] = time.Now().Add(duration)
+	}, bridge.imapSessionsLock)
+
+	for _, session := range bridge.GetIMAPSessions() {
+		if session.ClientType == clientType {
+			bridge.closeBlockedIMAPSession(session.ID, clientType)
+		}
+	}
+
+	return nil
+}
+
+// UnblockIMAPClient accepts the IMAP sessions of the given client type again.
+func (bridge *Bridge) UnblockIMAPClient(clientType connectionlimiter.Client) error {
+	logPkg.WithField("client", clientType).Info("Unblocking IMAP client")
+
+	return safe.LockRet(func() error {
+		if _, ok := bridge.blockedIMAPClients[clientType]; !ok {
+			return ErrClientTypeNotBlocked
+		}
+
+		delete(bridge.blockedIMAPClients, clientType)
+
+		return nil
+	}, bridge.imapSessionsLock)
+}
+
+// GetBlockedIMAPClients returns the client types whose IMAP sessions are refused, and until when.
+func (bridge *Bridge) GetBlockedIMAPClients() map[connectionlimiter.Client]time.Time {
+	return safe.LockRet(func() map[connectionlimiter.Client]time.Time {
+		blocked := make(map[connectionlimiter.Client]time.Time)
+
+		for clientType, until := range bridge.blockedIMAPClients {
+			if time.Now().Before(until) {
+				blocked[clientType] = until
+			} else {
+				delete(bridge.blockedIMAPClients, clientType)
+			}
+		}
+
+		return blocked
+	}, bridge.imapSessionsLock)
+}
+
+// GetIMAPClientLimits returns the maximum number of IMAP sessions per client type. A limit of 0 means unlimited.
+func (bridge *Bridge) GetIMAPClientLimits() map[connectionlimiter.Client]int {
+	limits, _ := imapsmtpserver.GetClientLimits(bridge.getUserIMAPClientLimits())
+
+	clientLimits := make(map[connectionlimiter.Client]int)
+
+	for _, clientType := range imapsmtpserver.ClientTypes {
+		if limit, ok := limits.PerClient[clientType]; ok {
+			clientLimits[clientType] = limit
+		} else {
+			clientLimits[clientType] = limits.UnknownLimit
+		}
+	}
+
+	return clientLimits
+}
+
+// SetIMAPClientLimit sets the maximum number of IMAP sessions of the given client type. A limit of 0 means unlimited.
+// The limit applies the next time the IMAP server is created, when bridge restarts.
+func (bridge *Bridge) SetIMAPClientLimit(clientType connectionlimiter.Client, limit int) error {
+	if !slices.Contains(imapsmtpserver.ClientTypes, clientType) {
+		return ErrUnknownClientType
+	}
+
+	if limit < 0 {
+		return ErrInvalidClientLimit
+	}
+
+	logPkg.WithFields(logrus.Fields{
+		"client": clientType,
+		"limit":  limit,
+	}).Info("Setting IMAP client limit")
+
+	return bridge.vault.SetIMAPClientLimit(string(clientType), limit)
+}
+
+// ResetIMAPClientLimit restores the default maximum number of IMAP sessions of the given client type.
+// The limit applies the next time the IMAP server is created, when bridge restarts.
+func (bridge *Bridge) ResetIMAPClientLimit(clientType connectionlimiter.Client) error {
+	if !slices.Contains(imapsmtpserver.ClientTypes, clientType) {
+		return ErrUnknownClientType
+	}
+
+	logPkg.WithField("client", clientType).Info("Resetting IMAP client limit")
+
+	return bridge.vault.ResetIMAPClientLimit(string(clientType))
+}
+
+func (bridge *Bridge) getUserIMAPClientLimits() map[connectionlimiter.Client]int {
+	limits := make(map[connectionlimiter.Client]int)
+
+	for clientType, limit := range bridge.vault.GetIMAPClientLimits() {
+		limits[connectionlimiter.Client(clientType)] = limit
+	}
+
+	return limits
+}
+
+func (bridge *Bridge) getIMAPSession(sessionID int) (IMAPSessionInfo, bool) {
+	bridge.imapSessionsLock.RLock()
+	defer bridge.imapSessionsLock.RUnlock()
+
+	session, ok := bridge.imapSessions[sessionID]
+	if !ok {
+		return IMAPSessionInfo{}, false
+	}
+
+	return *session, true
+}
+
+// getIMAPSessionRemote returns the addresses of the open IMAP sessions of the user whose client reported the given name,
+// separated by commas. Clients that didn't report their name match the given empty name.
+func (bridge *Bridge) getIMAPSessionRemote(userID, client string) string {
+	bridge.imapSessionsLock.RLock()
+	defer bridge.imapSessionsLock.RUnlock()
+
+	var remotes []string
+
+	for _, session := range bridge.imapSessions {
+		if session.UserID == userID && session.Client == client && !slices.Contains(remotes, session.Remote) {
+			remotes = append(remotes, session.Remote)
+		}
+	}
+
+	slices.Sort(remotes)
+
+	return strings.Join(remotes, ",")
+}
+
+// trackIMAPSession keeps the registry of open IMAP sessions up to date and disconnects the clients that are blocked.
+// It is only called from the goroutine handling IMAP events.
+func (bridge *Bridge) trackIMAPSession(event imapEvents.Event) {
+	var (
+		sessionID  int
+		clientType connectionlimiter.Client
+		blocked    bool
+		userID     string
+	)
+
+	// The user is looked up first so that the users lock is never taken while holding the sessions lock.
+	if event, ok := event.(imapEvents.Login); ok {
+		userID = bridge.getUserIDFromGluonID(event.UserID)
+	}
+
+	bridge.imapSessionsLock.Lock()
+
+	switch event := event.(type) {
+	case imapEvents.SessionAdded:
+		bridge.imapSessions[event.SessionID] = &IMAPSessionInfo{
+			ID:          event.SessionID,
+			ClientType:  connectionlimiter.ClientUnknown,
+			Remote:      event.RemoteAddr.String(),
+			ConnectedAt: time.Now(),
+		}
+
+	case imapEvents.SessionRemoved:
+		delete(bridge.imapSessions, event.SessionID)
+
+	case imapEvents.IMAPID:
+		if session, ok := bridge.imapSessions[event.SessionID]; ok {
+			session.Client = strings.TrimSpace(event.IMAPID.Name + " " + event.IMAPID.Version)
+			session.ClientType = imapsmtpserver.GetClientType(event.IMAPID)
+
+			sessionID, clientType, blocked = session.ID, session.ClientType, bridge.isIMAPClientBlocked(session.ClientType)
+		}
+
+	case imapEvents.Login:
+		if session, ok := bridge.imapSessions[event.SessionID]; ok {
+			session.UserID = userID
+
+			// Clients that don't send their IMAP ID are only known once they log in.
+			sessionID, clientType, blocked = session.ID, session.ClientType, bridge.isIMAPClientBlocked(session.ClientType)
+		}
+
+	case imapEvents.Select:
+		if session, ok := bridge.imapSessions[event.SessionID]; ok {
+			session.Mailbox = event.Mailbox
+		}
+	}
+
+	bridge.imapSessionsLock.Unlock()
+
+	if blocked {
+		bridge.closeBlockedIMAPSession(sessionID, clientType)
+	}
+}
+
+// isIMAPClientBlocked returns whether the given client type is blocked. The caller must hold imapSessionsLock.
+func (bridge *Bridge) isIMAPClientBlocked(clientType connectionlimiter.Client) bool {
+	until, ok := bridge.blockedIMAPClients[clientType]
+
+	return ok && time.Now().Before(until)
+}
+
+func (bridge *Bridge) closeBlockedIMAPSession(sessionID int, clientType connectionlimiter.Client) {
+	logPkg.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"client":    clientType,
+	}).Warn("Rejecting IMAP session of blocked client")
+
+	if err := bridge.serverManager.CloseIMAPSession(sessionID, fmt.Sprintf("Sessions of %v clients are temporarily blocked", clientType)); err != nil {
+		logPkg.WithError(err).Warn("Failed to close IMAP session")
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	imapid "github.com/emersion/go-imap-id"
+	"github.com/emersion/go-imap/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_IMAPSessions(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			dial := func(name string) *client.Client {
+				imapClient, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+				require.NoError(t, err)
+
+				_, err = imapid.NewClient(imapClient).ID(imapid.ID{imapid.FieldName: name, imapid.FieldVersion: "1.0"})
+				require.NoError(t, err)
+
+				return imapClient
+			}
+
+			// Connect a client, log in and select the inbox.
+			imapClient := dial("Mozilla Thunderbird")
+			defer imapClient.Logout() //nolint:errcheck
+
+			require.NoError(t, imapClient.Login(info.Addresses[0], string(info.BridgePass)))
+
+			_, err = imapClient.Select("INBOX", false)
+			require.NoError(t, err)
+
+			// The session is listed with its client, user and mailbox.
+			var session bridge.IMAPSessionInfo
+
+			require.Eventually(t, func() bool {
+				sessions := b.GetIMAPSessions()
+				if len(sessions) != 1 {
+					return false
+				}
+
+				session = sessions[0]
+
+				return session.UserID == userID && session.Mailbox == "INBOX"
+			}, 10*time.Second, 100*time.Millisecond)
+
+			require.Equal(t, "Mozilla Thunderbird 1.0", session.Client)
+			require.Equal(t, connectionlimiter.ClientThunderbird, session.ClientType)
+			require.NotEmpty(t, session.Remote)
+			require.NotZero(t, session.BytesReceived)
+			require.NotZero(t, session.BytesSent)
+
+			// Closing the session disconnects the client.
+			require.NoError(t, b.CloseIMAPSession(session.ID))
+			require.ErrorIs(t, b.CloseIMAPSession(-1), bridge.ErrNoSuchIMAPSession)
+
+			require.Eventually(t, func() bool {
+				return len(b.GetIMAPSessions()) == 0
+			}, 10*time.Second, 100*time.Millisecond)
+
+			// Blocked client types are disconnected as soon as they identify themselves.
+			require.ErrorIs(t, b.BlockIMAPClient("mutt", time.Hour), bridge.ErrUnknownClientType)
+			require.NoError(t, b.BlockIMAPClient(connectionlimiter.ClientThunderbird, time.Hour))
+			require.Contains(t, b.GetBlockedIMAPClients(), connectionlimiter.ClientThunderbird)
+
+			blockedClient := dial("Mozilla Thunderbird")
+			defer blockedClient.Logout() //nolint:errcheck
+
+			require.Eventually(t, func() bool {
+				return len(b.GetIMAPSessions()) == 0
+			}, 10*time.Second, 100*time.Millisecond)
+
+			// Other client types are still accepted.
+			otherClient := dial("Microsoft Outlook")
+			defer otherClient.Logout() //nolint:errcheck
+
+			require.NoError(t, otherClient.Login(info.Addresses[0], string(info.BridgePass)))
+
+			// Unblocked client types are accepted again.
+			require.NoError(t, b.UnblockIMAPClient(connectionlimiter.ClientThunderbird))
+			require.ErrorIs(t, b.UnblockIMAPClient(connectionlimiter.ClientThunderbird), bridge.ErrClientTypeNotBlocked)
+			require.Empty(t, b.GetBlockedIMAPClients())
+
+			unblockedClient := dial("Mozilla Thunderbird")
+			defer unblockedClient.Logout() //nolint:errcheck
+
+			require.NoError(t, unblockedClient.Login(info.Addresses[0], string(info.BridgePass)))
+		})
+	})
+}
+
+func TestBridge_IMAPClientLimits(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			require.Equal(t, 60, b.GetIMAPClientLimits()[connectionlimiter.ClientAppleMail])
+			require.Equal(t, 0, b.GetIMAPClientLimits()[connectionlimiter.ClientUnknown])
+
+			require.NoError(t, b.SetIMAPClientLimit(connectionlimiter.ClientAppleMail, 10))
+			require.NoError(t, b.SetIMAPClientLimit(connectionlimiter.ClientUnknown, 5))
+			require.NoError(t, b.SetIMAPClientLimit(connectionlimiter.ClientOutlook, 1))
+			require.ErrorIs(t, b.SetIMAPClientLimit(connectionlimiter.ClientOutlook, -1), bridge.ErrInvalidClientLimit)
+			require.ErrorIs(t, b.SetIMAPClientLimit("mutt", 1), bridge.ErrUnknownClientType)
+		})
+
+		// The limits are kept across restarts.
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			require.Equal(t, 10, b.GetIMAPClientLimits()[connectionlimiter.ClientAppleMail])
+			require.Equal(t, 5, b.GetIMAPClientLimits()[connectionlimiter.ClientUnknown])
+
+			require.NoError(t, b.ResetIMAPClientLimit(connectionlimiter.ClientAppleMail))
+			require.Equal(t, 60, b.GetIMAPClientLimits()[connectionlimiter.ClientAppleMail])
+
+			// Only one Outlook session is accepted; the second one is closed by the limiter.
+			outlookID := imapid.ID{imapid.FieldName: "Microsoft Outlook", imapid.FieldVersion: "16.0"}
+
+			first, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+			require.NoError(t, err)
+			defer first.Logout() //nolint:errcheck
+
+			_, err = imapid.NewClient(first).ID(outlookID)
+			require.NoError(t, err)
+
+			second, err := eventuallyDial(fmt.Sprintf("%v:%v", constants.Host, b.GetIMAPPort()))
+			require.NoError(t, err)
+
+			_, err = imapid.NewClient(second).ID(outlookID)
+			require.Error(t, err)
+
+			require.Eventually(t, func() bool {
+				return len(b.GetIMAPSessions()) == 1
+			}, 10*time.Second, 100*time.Millisecond)
+		})
+	})
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ProtonMail/proton-bridge/v3/internal/kb"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/userevents"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
@@ -58,6 +59,10 @@ func (bridge *Bridge) SetIMAPPort(ctx context.Context, newPort int) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingIMAPPort) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetIMAPPort(newPort); err != nil {
 		return err
 	}
@@ -76,6 +81,15 @@ func (bridge *Bridge) SetIMAPSSL(ctx context.Context, newSSL bool) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingIMAPSSL) {
+		return ErrSettingLocked
+	}
+
+	// Client certificates are only requested by IMAP with implicit TLS.
+	if !newSSL && bridge.vault.GetClientCertAuth() != vault.ClientCertAuthDisabled {
+		return ErrClientCertRequiresSSL
+	}
+
 	if err := bridge.vault.SetIMAPSSL(newSSL); err != nil {
 		return err
 	}
@@ -94,6 +108,10 @@ func (bridge *Bridge) SetSMTPPort(ctx context.Context, newPort int) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingSMTPPort) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetSMTPPort(newPort); err != nil {
 		return err
 	}
@@ -112,6 +130,10 @@ func (bridge *Bridge) SetSMTPSSL(ctx context.Context, newSSL bool) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingSMTPSSL) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetSMTPSSL(newSSL); err != nil {
 		return err
 	}
@@ -130,6 +152,10 @@ func (bridge *Bridge) GetGluonDataDir() (string, error) {
 }
 
 func (bridge *Bridge) SetGluonDir(ctx context.Context, newGluonDir string) error {
+	if bridge.IsSettingLocked(policy.SettingCacheDir) {
+		return ErrSettingLocked
+	}
+
 	bridge.usersLock.RLock()
 
 	defer func() {
@@ -170,6 +196,10 @@ func (bridge *Bridge) GetProxyAllowed() bool {
 }
 
 func (bridge *Bridge) SetProxyAllowed(allowed bool) error {
+	if allowed != bridge.vault.GetProxyAllowed() && bridge.IsSettingLocked(policy.SettingProxyAllowed) {
+		return ErrSettingLocked
+	}
+
 	if allowed {
 		bridge.proxyCtl.AllowProxy()
 	} else {
@@ -203,6 +233,10 @@ func (bridge *Bridge) GetAutostart() bool {
 
 func (bridge *Bridge) SetAutostart(autostart bool) error {
 	if autostart != bridge.vault.GetAutostart() {
+		if bridge.IsSettingLocked(policy.SettingAutostart) {
+			return ErrSettingLocked
+		}
+
 		if err := bridge.vault.SetAutostart(autostart); err != nil {
 			return err
 		}
@@ -210,22 +244,24 @@ func (bridge *Bridge) SetAutostart(autostart bool) error {
 		bridge.heartbeat.SetAutoStart(autostart)
 	}
 
-	var err error
+	return applyAutostart(bridge.autostarter, autostart)
+}
+
+// applyAutostart enables or disables the autostart of bridge in the system, unless it already is.
+func applyAutostart(autostarter Autostarter, autostart bool) error {
 	if autostart {
 		// do nothing if already enabled
-		if bridge.autostarter.IsEnabled() {
+		if autostarter.IsEnabled() {
 			return nil
 		}
-		err = bridge.autostarter.Enable()
-	} else {
-		// do nothing if already disabled
-		if !bridge.autostarter.IsEnabled() {
-			return nil
-		}
-		err = bridge.autostarter.Disable()
+		return autostarter.Enable()
 	}
 
-	return err
+	// do nothing if already disabled
+	if !autostarter.IsEnabled() {
+		return nil
+	}
+	return autostarter.Disable()
 }
 
 func (bridge *Bridge) GetUpdateRollout() float64 {
@@ -241,6 +277,10 @@ func (bridge *Bridge) SetAutoUpdate(autoUpdate bool) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingAutoUpdate) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetAutoUpdate(autoUpdate); err != nil {
 		return err
 	}
@@ -257,6 +297,10 @@ func (bridge *Bridge) GetTelemetryDisabled() bool {
 }
 
 func (bridge *Bridge) SetTelemetryDisabled(isDisabled bool) error {
+	if isDisabled != bridge.vault.GetTelemetryDisabled() && bridge.IsSettingLocked(policy.SettingTelemetryDisabled) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetTelemetryDisabled(isDisabled); err != nil {
 		return err
 	}
@@ -279,6 +323,10 @@ func (bridge *Bridge) SetUpdateChannel(channel updater.Channel) error {
 		return nil
 	}
 
+	if bridge.IsSettingLocked(policy.SettingUpdateChannel) {
+		return ErrSettingLocked
+	}
+
 	if err := bridge.vault.SetUpdateChannel(channel); err != nil {
 		return err
 	}
@@ -20,12 +20,15 @@ package bridge_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/go-proton-api/server"
 	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
+	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/stretchr/testify/require"
 )
 
@@ -206,3 +209,64 @@ func TestBridge_Settings_FirstStart(t *testing.T) {
 		})
 	})
 }
+
+func TestBridge_Settings_Policy(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		// The user prefers the stable channel and telemetry.
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			require.NoError(t, b.SetUpdateChannel(updater.StableChannel))
+			require.NoError(t, b.SetTelemetryDisabled(false))
+			require.Empty(t, b.GetLockedSettings())
+		})
+
+		channel, disabled, cacheDir := updater.EarlyChannel, true, t.TempDir()
+
+		settingsPolicy := policy.Settings{
+			UpdateChannel:     &channel,
+			TelemetryDisabled: &disabled,
+			CacheDir:          &cacheDir,
+			Locked:            []string{policy.SettingTelemetryDisabled, policy.SettingAutoUpdate},
+		}
+
+		withBridgePolicy(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, settingsPolicy, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			// The values of the policy are applied over those of the user.
+			require.Equal(t, updater.EarlyChannel, b.GetUpdateChannel())
+			require.True(t, b.GetTelemetryDisabled())
+			require.Equal(t, filepath.Join(cacheDir, "gluon"), b.GetGluonCacheDir())
+
+			// Only the locked settings are immutable.
+			require.Equal(t, []string{policy.SettingAutoUpdate, policy.SettingTelemetryDisabled}, b.GetLockedSettings())
+			require.True(t, b.IsSettingLocked(policy.SettingAutoUpdate))
+			require.False(t, b.IsSettingLocked(policy.SettingUpdateChannel))
+
+			require.ErrorIs(t, b.SetTelemetryDisabled(false), bridge.ErrSettingLocked)
+			require.ErrorIs(t, b.SetAutoUpdate(!b.GetAutoUpdate()), bridge.ErrSettingLocked)
+			require.NoError(t, b.SetUpdateChannel(updater.StableChannel))
+
+			// Setting a locked setting to its current value is not a change.
+			require.NoError(t, b.SetTelemetryDisabled(true))
+		})
+	})
+}
+
+func TestBridge_Settings_PolicyAutostart(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		autostart := false
+
+		settingsPolicy := policy.Settings{
+			Autostart: &autostart,
+			Locked:    []string{policy.SettingAutostart},
+		}
+
+		withMocks(t, func(mocks *bridge.Mocks) {
+			// The policy disables autostart in the system, not only in the settings.
+			mocks.Autostarter.EXPECT().IsEnabled().Return(true)
+			mocks.Autostarter.EXPECT().Disable().Return(nil)
+
+			withBridgeNoMocks(ctx, t, mocks, s.GetHostURL(), netCtl, locator, storeKey, settingsPolicy, func(b *bridge.Bridge) {
+				require.False(t, b.GetAutostart())
+				require.ErrorIs(t, b.SetAutostart(true), bridge.ErrSettingLocked)
+			}, false)
+		})
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"errors"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
+	"github.com/ProtonMail/proton-bridge/v3/internal/user"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetSMIMEIdentities returns the S/MIME identities used to sign the given user's mail.
+func (bridge *Bridge) GetSMIMEIdentities(userID string) ([]vault.SMIMEIdentity, error) {
+	return safe.RLockRetErr(func() ([]vault.SMIMEIdentity, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return user.GetSMIMEIdentities(), nil
+	}, bridge.usersLock)
+}
+
+// ImportSMIMEIdentity imports the PEM-encoded S/MIME certificate chain and private key for the given user.
+// It returns the addresses of the user the certificate is issued for, whose mail is signed with it
+// when sent to the S/MIME recipients of the user.
+func (bridge *Bridge) ImportSMIMEIdentity(userID string, certPEM, keyPEM []byte) ([]string, error) {
+	return safe.RLockRetErr(func() ([]string, error) {
+		u, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		emails, err := u.ImportSMIMEIdentity(certPEM, keyPEM)
+		if errors.Is(err, user.ErrNoSuchAddress) {
+			return nil, ErrSMIMEAddressMismatch
+		}
+
+		return emails, err
+	}, bridge.usersLock)
+}
+
+// RemoveSMIMEIdentity removes the S/MIME identity of the given address of the given user.
+func (bridge *Bridge) RemoveSMIMEIdentity(userID, email string) error {
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if err := user.RemoveSMIMEIdentity(email); errors.Is(err, vault.ErrNoSuchSMIMEIdentity) {
+			return ErrNoSuchSMIMEIdentity
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	}, bridge.usersLock)
+}
+
+// GetSMIMERecipients returns the external recipients who get S/MIME signed mail from the given user.
+func (bridge *Bridge) GetSMIMERecipients(userID string) ([]string, error) {
+	return safe.RLockRetErr(func() ([]string, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return user.GetSMIMERecipients(), nil
+	}, bridge.usersLock)
+}
+
+// AddSMIMERecipient makes the mail the given user sends to the given external recipient S/MIME signed,
+// unless it is encrypted with PGP. The sender address needs an S/MIME certificate.
+func (bridge *Bridge) AddSMIMERecipient(userID, email string) error {
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		return user.AddSMIMERecipient(email)
+	}, bridge.usersLock)
+}
+
+// RemoveSMIMERecipient stops signing the mail the given user sends to the given recipient with S/MIME.
+func (bridge *Bridge) RemoveSMIMERecipient(userID, email string) error {
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if err := user.RemoveSMIMERecipient(email); errors.Is(err, vault.ErrNoSuchSMIMERecipient) {
+			return ErrNoSuchSMIMERecipient
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	}, bridge.usersLock)
+}
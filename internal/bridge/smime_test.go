@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_SendSMIME(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		const recipient = "smime@example.com"
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			// The recipient gets S/MIME signed mail.
+			require.NoError(t, b.AddSMIMERecipient(userID, recipient))
+
+			recipients, err := b.GetSMIMERecipients(userID)
+			require.NoError(t, err)
+			require.Equal(t, []string{recipient}, recipients)
+
+			send := func() error {
+				client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer client.Close() //nolint:errcheck
+
+				require.NoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+				require.NoError(t, client.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+				return client.SendMail(info.Addresses[0], []string{recipient}, strings.NewReader("Subject: Signed\r\n\r\nHello world!"))
+			}
+
+			// Without a certificate for the sender address, the message can't be sent.
+			require.Error(t, send())
+
+			// Certificates not issued for an address of the user are rejected.
+			certPEM, keyPEM := newTestSMIMECert(t, "someone@example.com")
+			_, err = b.ImportSMIMEIdentity(userID, certPEM, keyPEM)
+			require.ErrorIs(t, err, bridge.ErrSMIMEAddressMismatch)
+
+			certPEM, keyPEM = newTestSMIMECert(t, info.Addresses[0])
+			emails, err := b.ImportSMIMEIdentity(userID, certPEM, keyPEM)
+			require.NoError(t, err)
+			require.Equal(t, []string{info.Addresses[0]}, emails)
+
+			require.NoError(t, send())
+
+			require.NoError(t, b.RemoveSMIMEIdentity(userID, info.Addresses[0]))
+			require.ErrorIs(t, b.RemoveSMIMEIdentity(userID, info.Addresses[0]), bridge.ErrNoSuchSMIMEIdentity)
+
+			require.NoError(t, b.RemoveSMIMERecipient(userID, recipient))
+			require.ErrorIs(t, b.RemoveSMIMERecipient(userID, recipient), bridge.ErrNoSuchSMIMERecipient)
+		})
+	})
+}
+
+// newTestSMIMECert returns a self-signed S/MIME certificate and its private key, issued for the given address.
+func newTestSMIMECert(t *testing.T, email string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: email},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
@@ -21,7 +21,10 @@ import (
 	"context"
 	"crypto/tls"
 
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/identifier"
+	smtpservice "github.com/ProtonMail/proton-bridge/v3/internal/services/smtp"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
 )
 
 func (bridge *Bridge) restartSMTP(ctx context.Context) error {
@@ -33,7 +36,7 @@ type bridgeSMTPSettings struct {
 }
 
 func (b *bridgeSMTPSettings) TLSConfig() *tls.Config {
-	return b.b.tlsConfig
+	return b.b.clientCertTLSConfig(vault.ClientCertScopeSMTP)
 }
 
 func (b *bridgeSMTPSettings) Log() bool {
@@ -55,3 +58,11 @@ func (b *bridgeSMTPSettings) UseSSL() bool {
 func (b *bridgeSMTPSettings) Identifier() identifier.UserAgentUpdater {
 	return &bridgeUserAgentUpdater{Bridge: b.b}
 }
+
+func (b *bridgeSMTPSettings) ClientCertAuthenticator() smtpservice.ClientCertAuthenticator {
+	return &bridgeClientCertAuthenticator{b: b.b, scope: vault.ClientCertScopeSMTP}
+}
+
+func (b *bridgeSMTPSettings) AuditRecorder() audit.Recorder {
+	return b.b.auditLog
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/go-proton-api/server"
+	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/bradenaw/juniper/xslices"
+	go_imap "github.com/emersion/go-imap"
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_Templates(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridge.Locator, storeKey []byte) {
+		_, _, err := s.CreateUser("recipient", password)
+		require.NoError(t, err)
+
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, storeKey, func(b *bridge.Bridge, _ *bridge.Mocks) {
+			syncCh, done := chToType[events.Event, events.SyncFinished](b.GetEvents(events.SyncFinished{}))
+			defer done()
+
+			userID, err := b.LoginFull(ctx, username, password, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, userID, (<-syncCh).UserID)
+
+			info, err := b.GetUserInfo(userID)
+			require.NoError(t, err)
+
+			client, err := eventuallyDial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetIMAPPort())))
+			require.NoError(t, err)
+			require.NoError(t, client.Login(info.Addresses[0], string(info.BridgePass)))
+			defer func() { _ = client.Logout() }()
+
+			// Every user has a Templates mailbox, even before the first template is saved.
+			require.Contains(t, xslices.Map(clientList(client), func(mbox *go_imap.MailboxInfo) string { return mbox.Name }), "Templates")
+
+			// Save a template.
+			template := fmt.Sprintf("From: %v\r\nDate: 01 Jan 1980 00:00:00 +0000\r\nSubject: Weekly report\r\n\r\nHello {{recipient_name}}!", info.Addresses[0])
+			require.NoError(t, client.Append("Templates", nil, time.Now(), strings.NewReader(template)))
+
+			// The template is a draft; the label marking it is not listed under Labels.
+			require.Eventually(t, func() bool {
+				messages, err := clientFetch(client, "Drafts")
+				require.NoError(t, err)
+				return len(messages) == 1
+			}, 10*time.Second, 100*time.Millisecond)
+
+			messages, err := clientFetch(client, "Templates")
+			require.NoError(t, err)
+			require.Len(t, messages, 1)
+			require.NotContains(t, xslices.Map(clientList(client), func(mbox *go_imap.MailboxInfo) string { return mbox.Name }), "Labels/Bridge Templates")
+
+			// Other drafts only get the labels of drafts, even when flagged.
+			draft := fmt.Sprintf("From: %v\r\nDate: 01 Jan 1980 00:00:00 +0000\r\nSubject: Draft\r\n\r\nHello!", info.Addresses[0])
+			require.NoError(t, client.Append("Drafts", []string{go_imap.FlaggedFlag}, time.Now(), strings.NewReader(draft)))
+
+			withClient(ctx, t, s, username, password, func(ctx context.Context, c *proton.Client) {
+				starred, err := c.GetMessageMetadataPage(ctx, 0, 10, proton.MessageFilter{LabelID: proton.StarredLabel})
+				require.NoError(t, err)
+				require.Empty(t, starred)
+			})
+
+			send := func(templateName string) error {
+				client, err := smtp.Dial(net.JoinHostPort(constants.Host, fmt.Sprint(b.GetSMTPPort())))
+				require.NoError(t, err)
+				defer client.Close() //nolint:errcheck
+
+				require.NoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+				require.NoError(t, client.Auth(sasl.NewPlainClient(info.Addresses[0], info.Addresses[0], string(info.BridgePass))))
+
+				return client.SendMail(info.Addresses[0], []string{"recipient@" + s.GetDomain()}, strings.NewReader(fmt.Sprintf(
+					"From: %v\r\nTo: Robin <recipient@%v>\r\nX-Pm-Template: %v\r\n\r\n",
+					info.Addresses[0], s.GetDomain(), templateName,
+				)))
+			}
+
+			// Unknown templates are reported.
+			require.ErrorContains(t, send("Monthly report"), "template")
+
+			// The message is expanded from the template.
+			require.NoError(t, send("weekly report"))
+
+			require.Eventually(t, func() bool {
+				messages, err := clientFetch(client, "Sent")
+				require.NoError(t, err)
+				return len(messages) == 1
+			}, 10*time.Second, 100*time.Millisecond)
+
+			messages, err = clientFetch(client, "Sent")
+			require.NoError(t, err)
+			require.Equal(t, "Weekly report", messages[0].Envelope.Subject)
+
+			literal, err := io.ReadAll(messages[0].GetBody(must(go_imap.ParseBodySectionName("BODY[]"))))
+			require.NoError(t, err)
+			require.Contains(t, string(literal), "Hello Robin!")
+		})
+	})
+}
@@ -20,6 +20,7 @@ package bridge
 import (
 	"context"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 )
 
@@ -34,6 +35,9 @@ type Locator interface {
 	ProvideIMAPSyncConfigPath() (string, error)
 	ProvideUnleashCachePath() (string, error)
 	ProvideNotificationsCachePath() (string, error)
+	ProvideImageProxyCachePath() (string, error)
+	ProvideAuditLogPath() (string, error)
+	GetProfile() string
 }
 
 type ProxyController interface {
@@ -55,6 +59,8 @@ type Updater interface {
 	GetVersionInfoLegacy(context.Context, updater.Downloader, updater.Channel) (updater.VersionInfoLegacy, error)
 	InstallUpdateLegacy(context.Context, updater.Downloader, updater.VersionInfoLegacy) error
 	RemoveOldUpdates() error
+	MarkVersionVerified(*semver.Version) error
+	RecordCleanExit(*semver.Version) error
 	GetVersionInfo(context.Context, updater.Downloader) (updater.VersionInfo, error)
 	InstallUpdate(context.Context, updater.Downloader, updater.Release) error
 }
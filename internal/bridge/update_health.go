@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/sirupsen/logrus"
+)
+
+// updateHealthCheckTimeout is how long the IMAP and SMTP servers have to become ready after startup for the running
+// version to be verified. Unverified updates are rolled back by the launcher after too many failed starts.
+const updateHealthCheckTimeout = 2 * time.Minute
+
+type updateHealthCheck struct {
+	startedAt time.Time
+	imapReady bool
+	smtpReady bool
+	done      bool
+	lock      sync.Mutex
+}
+
+func newUpdateHealthCheck() *updateHealthCheck {
+	return &updateHealthCheck{startedAt: time.Now()}
+}
+
+// checkUpdateHealth marks the running version as verified once both the IMAP and SMTP servers are ready.
+func (bridge *Bridge) checkUpdateHealth(event events.Event) {
+	check := bridge.updateHealth
+
+	check.lock.Lock()
+	defer check.lock.Unlock()
+
+	if check.done {
+		return
+	}
+
+	switch event.(type) {
+	case events.IMAPServerReady:
+		check.imapReady = true
+
+	case events.SMTPServerReady:
+		check.smtpReady = true
+
+	default:
+		return
+	}
+
+	if !check.imapReady || !check.smtpReady {
+		return
+	}
+
+	check.done = true
+
+	log := logrus.WithField("pkg", "bridge/update").WithField("version", bridge.curVersion)
+
+	if elapsed := time.Since(check.startedAt); elapsed > updateHealthCheckTimeout {
+		log.WithField("elapsed", elapsed).Warn("IMAP and SMTP servers became ready too late, the version is not verified")
+		return
+	}
+
+	if err := bridge.updater.MarkVersionVerified(bridge.curVersion); err != nil {
+		log.WithError(err).Error("Failed to mark the version as verified")
+		return
+	}
+
+	log.Info("IMAP and SMTP servers are ready, the version is verified")
+}
+
+// recordCleanExit uncounts the start of the running version if it is closed normally before being verified,
+// for instance because the user quit or a port was already taken. Only crashes count as failed starts.
+func (bridge *Bridge) recordCleanExit() {
+	check := bridge.updateHealth
+
+	check.lock.Lock()
+	defer check.lock.Unlock()
+
+	if check.done {
+		return
+	}
+
+	check.done = true
+
+	if err := bridge.updater.RecordCleanExit(bridge.curVersion); err != nil {
+		logrus.WithField("pkg", "bridge/update").WithError(err).Error("Failed to record clean exit")
+	}
+}
@@ -699,3 +699,15 @@ func Test_Update_UpdateFromMinVer_NoCompatibleVersionForceManual_BetaMismatch(t
 		})
 	})
 }
+
+func Test_Update_VersionVerifiedWhenServersReady(t *testing.T) {
+	withEnv(t, func(ctx context.Context, s *server.Server, netCtl *proton.NetCtl, locator bridgePkg.Locator, vaultKey []byte) {
+		withBridge(ctx, t, s.GetHostURL(), netCtl, locator, vaultKey, func(bridge *bridgePkg.Bridge, mocks *bridgePkg.Mocks) {
+			require.Eventually(t, func() bool {
+				return mocks.Updater.GetVerifiedVersion() != nil
+			}, 10*time.Second, 100*time.Millisecond)
+
+			require.Equal(t, bridge.GetCurrentVersion(), mocks.Updater.GetVerifiedVersion())
+		})
+	})
+}
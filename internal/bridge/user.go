@@ -30,6 +30,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/hv"
 	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapservice"
 	"github.com/ProtonMail/proton-bridge/v3/internal/try"
@@ -38,6 +39,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
 var logUser = logrus.WithField("pkg", "bridge/user") //nolint:gochecknoglobals
@@ -292,6 +294,10 @@ func (bridge *Bridge) DeleteUser(ctx context.Context, userID string) error {
 			logUser.WithError(err).Error("Failed to delete vault user")
 		}
 
+		if err := bridge.vault.RevokeUserClientCerts(userID); err != nil {
+			logUser.WithError(err).Error("Failed to revoke user client certificates")
+		}
+
 		bridge.publish(events.UserDeleted{
 			UserID: userID,
 		})
@@ -336,6 +342,130 @@ func (bridge *Bridge) SetAddressMode(ctx context.Context, userID string, mode va
 	}, bridge.usersLock)
 }
 
+// GetMessageProfile returns the profile used to build the given user's messages.
+func (bridge *Bridge) GetMessageProfile(userID string) (vault.MessageProfile, error) {
+	return safe.RLockRetErr(func() (vault.MessageProfile, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return 0, ErrNoSuchUser
+		}
+
+		return user.GetMessageProfile(), nil
+	}, bridge.usersLock)
+}
+
+// SetMessageProfile sets the profile used to build the given user's messages.
+func (bridge *Bridge) SetMessageProfile(ctx context.Context, userID string, profile vault.MessageProfile) error {
+	logUser.WithField("userID", userID).WithField("profile", profile).Info("Setting message profile")
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if user.GetMessageProfile() == profile {
+			return fmt.Errorf("message profile is already %q", profile)
+		}
+
+		return user.SetMessageProfile(ctx, profile)
+	}, bridge.usersLock)
+}
+
+// GetUnwrapPGPMIME returns whether the given user's PGP/MIME messages are built as their decrypted MIME tree.
+func (bridge *Bridge) GetUnwrapPGPMIME(userID string) (bool, error) {
+	return safe.RLockRetErr(func() (bool, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return false, ErrNoSuchUser
+		}
+
+		return user.GetUnwrapPGPMIME(), nil
+	}, bridge.usersLock)
+}
+
+// SetUnwrapPGPMIME sets whether the given user's PGP/MIME messages are built as their decrypted MIME tree.
+// The original encrypted message remains available as the last part of the message.
+func (bridge *Bridge) SetUnwrapPGPMIME(ctx context.Context, userID string, unwrap bool) error {
+	logUser.WithField("userID", userID).WithField("unwrap", unwrap).Info("Setting PGP/MIME unwrapping")
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if user.GetUnwrapPGPMIME() == unwrap {
+			return nil
+		}
+
+		return user.SetUnwrapPGPMIME(ctx, unwrap)
+	}, bridge.usersLock)
+}
+
+// GetTrustedAuthServIDs returns the servers whose Authentication-Results headers are trusted in the given user's messages.
+// None are configured if the Proton MX servers are trusted.
+func (bridge *Bridge) GetTrustedAuthServIDs(userID string) ([]string, error) {
+	return safe.RLockRetErr(func() ([]string, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return user.GetTrustedAuthServIDs(), nil
+	}, bridge.usersLock)
+}
+
+// SetTrustedAuthServIDs sets the servers whose Authentication-Results headers are trusted in the given user's messages,
+// e.g. when mail reaches Proton through another gateway. The Proton MX servers are trusted again if none are given.
+func (bridge *Bridge) SetTrustedAuthServIDs(ctx context.Context, userID string, servIDs []string) error {
+	logUser.WithField("userID", userID).WithField("servIDs", servIDs).Info("Setting trusted authentication servers")
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if slices.Equal(user.GetTrustedAuthServIDs(), servIDs) {
+			return nil
+		}
+
+		return user.SetTrustedAuthServIDs(ctx, servIDs)
+	}, bridge.usersLock)
+}
+
+// GetBlockRemoteContent returns whether remote images and CSS are removed from the given user's HTML messages.
+func (bridge *Bridge) GetBlockRemoteContent(userID string) (bool, error) {
+	return safe.RLockRetErr(func() (bool, error) {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return false, ErrNoSuchUser
+		}
+
+		return user.GetBlockRemoteContent(), nil
+	}, bridge.usersLock)
+}
+
+// SetBlockRemoteContent sets whether remote images and CSS are removed from the given user's HTML messages.
+// Blocked messages carry a header recording what was removed.
+func (bridge *Bridge) SetBlockRemoteContent(ctx context.Context, userID string, block bool) error {
+	logUser.WithField("userID", userID).WithField("block", block).Info("Setting remote content blocking")
+
+	return safe.RLockRet(func() error {
+		user, ok := bridge.users[userID]
+		if !ok {
+			return ErrNoSuchUser
+		}
+
+		if user.GetBlockRemoteContent() == block {
+			return nil
+		}
+
+		return user.SetBlockRemoteContent(ctx, block)
+	}, bridge.usersLock)
+}
+
 // SendBadEventUserFeedback passes the feedback to the given user.
 func (bridge *Bridge) SendBadEventUserFeedback(_ context.Context, userID string, doResync bool) error {
 	logUser.WithField("userID", userID).WithField("doResync", doResync).Info("Passing bad event feedback to user")
@@ -551,12 +681,19 @@ func (bridge *Bridge) addUserWithVault(
 		syncSettingsPath,
 		isNew,
 		bridge.notificationStore,
+		bridge.imageProxy,
+		&bridgeAuditRecorder{b: bridge},
+		ratelimit.New(bridge.getAPIRateLimits()),
 		bridge.unleashService,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if user.GetUseImageProxy() {
+		bridge.startImageProxy()
+	}
+
 	// Handle events coming from the user before forwarding them to the bridge.
 	// For example, if the user's addresses change, we need to update them in gluon.
 	bridge.tasks.Once(func(ctx context.Context) {
@@ -622,6 +759,10 @@ func (bridge *Bridge) logoutUser(ctx context.Context, user *user.User, withAPI,
 		logUser.WithError(err).Error("Failed to logout user")
 	}
 
+	if err := bridge.imageProxy.RemoveUser(user.ID()); err != nil {
+		logUser.WithError(err).Error("Failed to remove image proxy cache")
+	}
+
 	bridge.heartbeat.SetNumberConnectedAccounts(len(bridge.users) - 1)
 
 	user.Close()
@@ -38,6 +38,12 @@ func (bridge *Bridge) handleUserEvent(ctx context.Context, user *user.User, even
 
 	case events.UserLoadedCheckResync:
 		user.VerifyResyncAndExecute()
+
+	case events.UserReceivedMessage:
+		bridge.handleUserReceivedMessage(user, event)
+
+	case events.UserSendFailed:
+		bridge.handleUserSendFailed(user, event)
 	}
 }
 
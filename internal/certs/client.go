@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package certs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NewClientCATemplate creates a new template for the CA issuing the client certificates of the devices
+// allowed to connect to the IMAP and SMTP servers.
+func NewClientCATemplate() (*x509.Certificate, error) {
+	template, err := NewTLSTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	template.Subject.CommonName = "Proton Mail Bridge Client CA"
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	template.IPAddresses = nil
+
+	return template, nil
+}
+
+// NewClientTLSTemplate creates a new template for the client certificate of the given device.
+func NewClientTLSTemplate(device string) (*x509.Certificate, error) {
+	template, err := NewTLSTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	template.Subject.CommonName = device
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	template.IsCA = false
+	template.IPAddresses = nil
+	template.NotAfter = time.Now().Add(2 * 365 * 24 * time.Hour)
+
+	return template, nil
+}
+
+// GenerateSignedCert generates a new certificate from the given template, signed by the given CA,
+// and returns it as PEM.
+func GenerateSignedCert(template *x509.Certificate, caCertPEM, caKeyPEM []byte) ([]byte, []byte, error) {
+	ca, err := tls.X509KeyPair(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to load CA keypair")
+	}
+
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate private key")
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &priv.PublicKey, ca.PrivateKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	certPEM := new(bytes.Buffer)
+
+	if err := pem.Encode(certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM := new(bytes.Buffer)
+
+	if err := pem.Encode(keyPEM, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
+		return nil, nil, err
+	}
+
+	return certPEM.Bytes(), keyPEM.Bytes(), nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package desktopnotify decides which desktop notifications to show about new mail and failed sends, following the
+// user's rules and quiet hours, and shows them with the system's notification mechanism.
+package desktopnotify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xAX/notificator"
+)
+
+// Settings are the user's desktop notification preferences.
+type Settings struct {
+	// NewMail enables notifications about new messages.
+	NewMail bool
+
+	// SendFailures enables notifications about messages that could not be sent.
+	SendFailures bool
+
+	// QuietHours is the time of day during which no notification is shown.
+	QuietHours QuietHours
+
+	// Rules select the new messages to notify about. Without a matching rule, only messages of the inbox are notified.
+	Rules []Rule
+}
+
+// Rule enables or disables notifications about the new messages of a user and mailbox.
+type Rule struct {
+	// UserID is the user the rule applies to. If empty, it applies to all users.
+	UserID string
+
+	// Mailbox is the IMAP name of the mailbox the rule applies to, such as INBOX or Folders/Work. If empty, it applies
+	// to all mailboxes.
+	Mailbox string
+
+	// Notify is whether matching messages are notified.
+	Notify bool
+}
+
+// QuietHours is a daily period of local time, from Start to End after midnight. It may span midnight, e.g. from 22h
+// to 7h. The period is empty if Start equals End.
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether the given time is within the quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if q.Start < q.End {
+		return sinceMidnight >= q.Start && sinceMidnight < q.End
+	}
+
+	return sinceMidnight >= q.Start || sinceMidnight < q.End
+}
+
+func (q QuietHours) String() string {
+	if q.Start == q.End {
+		return "none"
+	}
+
+	return formatTimeOfDay(q.Start) + "-" + formatTimeOfDay(q.End)
+}
+
+// ParseTimeOfDay parses a time of day such as 22:30 into the duration since midnight.
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// ShouldNotifyNewMail reports whether a new message of the given user, in the given mailboxes, is notified at the
+// given time. The most specific matching rule applies: one for the user and mailbox, then one for the mailbox, then
+// one for the user, then one for everything. The last one wins among equally specific rules.
+func (s Settings) ShouldNotifyNewMail(userID string, mailboxes []string, now time.Time) bool {
+	if !s.NewMail || s.QuietHours.Contains(now) {
+		return false
+	}
+
+	var (
+		notify      = containsMailbox(mailboxes, "INBOX")
+		specificity = -1
+	)
+
+	for _, rule := range s.Rules {
+		if rule.UserID != "" && rule.UserID != userID {
+			continue
+		}
+
+		if rule.Mailbox != "" && !containsMailbox(mailboxes, rule.Mailbox) {
+			continue
+		}
+
+		var ruleSpecificity int
+
+		if rule.UserID != "" {
+			ruleSpecificity++
+		}
+
+		if rule.Mailbox != "" {
+			ruleSpecificity += 2
+		}
+
+		if ruleSpecificity >= specificity {
+			notify, specificity = rule.Notify, ruleSpecificity
+		}
+	}
+
+	return notify
+}
+
+// ShouldNotifySendFailure reports whether a failed send is notified at the given time.
+func (s Settings) ShouldNotifySendFailure(now time.Time) bool {
+	return s.SendFailures && !s.QuietHours.Contains(now)
+}
+
+func containsMailbox(mailboxes []string, name string) bool {
+	for _, mailbox := range mailboxes {
+		if strings.EqualFold(mailbox, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Notifier shows desktop notifications.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+type notificatorNotifier struct {
+	notify *notificator.Notificator
+}
+
+// NewNotifier returns a notifier using the system's notification mechanism, as for crash notifications.
+// It doesn't need a GUI, so it also works when bridge runs with the CLI or non-interactively.
+func NewNotifier(appName string) Notifier {
+	return &notificatorNotifier{
+		notify: notificator.New(notificator.Options{AppName: appName}),
+	}
+}
+
+func (n *notificatorNotifier) Notify(title, body string) error {
+	return n.notify.Push(title, body, "", notificator.UR_NORMAL)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package desktopnotify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	// The same start and end mean no quiet hours.
+	require.False(t, QuietHours{}.Contains(at(0, 0)))
+
+	day := QuietHours{Start: 9 * time.Hour, End: 17 * time.Hour}
+	require.True(t, day.Contains(at(9, 0)))
+	require.True(t, day.Contains(at(16, 59)))
+	require.False(t, day.Contains(at(17, 0)))
+	require.False(t, day.Contains(at(8, 59)))
+
+	night := QuietHours{Start: 22*time.Hour + 30*time.Minute, End: 7 * time.Hour}
+	require.True(t, night.Contains(at(23, 0)))
+	require.True(t, night.Contains(at(3, 0)))
+	require.False(t, night.Contains(at(22, 0)))
+	require.False(t, night.Contains(at(7, 0)))
+	require.Equal(t, "22:30-07:00", night.String())
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	d, err := ParseTimeOfDay("22:30")
+	require.NoError(t, err)
+	require.Equal(t, 22*time.Hour+30*time.Minute, d)
+
+	_, err = ParseTimeOfDay("25:00")
+	require.Error(t, err)
+}
+
+func TestSettings_ShouldNotifyNewMail(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+
+	// Nothing is notified unless enabled.
+	require.False(t, Settings{}.ShouldNotifyNewMail("user", []string{"INBOX"}, now))
+
+	// Without rules, only messages of the inbox are notified.
+	settings := Settings{NewMail: true}
+	require.True(t, settings.ShouldNotifyNewMail("user", []string{"Inbox", "All Mail"}, now))
+	require.False(t, settings.ShouldNotifyNewMail("user", []string{"Folders/Work"}, now))
+
+	settings.Rules = []Rule{
+		{Mailbox: "Folders/Work", Notify: true},
+		{UserID: "muted", Notify: false},
+		{UserID: "muted", Mailbox: "Folders/Work", Notify: false},
+		{UserID: "other", Mailbox: "INBOX", Notify: false},
+	}
+
+	// A rule for a mailbox applies to all users.
+	require.True(t, settings.ShouldNotifyNewMail("user", []string{"Folders/Work"}, now))
+
+	// A rule for a user is overridden by a rule for a mailbox, which is overridden by a rule for both.
+	require.False(t, settings.ShouldNotifyNewMail("muted", []string{"INBOX"}, now))
+	require.False(t, settings.ShouldNotifyNewMail("muted", []string{"Folders/Work"}, now))
+	require.False(t, settings.ShouldNotifyNewMail("other", []string{"INBOX"}, now))
+	require.True(t, settings.ShouldNotifyNewMail("other", []string{"Folders/Work"}, now))
+
+	// Nothing is notified during quiet hours.
+	settings.QuietHours = QuietHours{Start: 11 * time.Hour, End: 13 * time.Hour}
+	require.False(t, settings.ShouldNotifyNewMail("user", []string{"Folders/Work"}, now))
+}
+
+func TestSettings_ShouldNotifySendFailure(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+
+	require.False(t, Settings{}.ShouldNotifySendFailure(now))
+	require.True(t, Settings{SendFailures: true}.ShouldNotifySendFailure(now))
+	require.False(t, Settings{SendFailures: true, QuietHours: QuietHours{Start: 12 * time.Hour, End: 14 * time.Hour}}.ShouldNotifySendFailure(now))
+}
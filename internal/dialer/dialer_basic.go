@@ -20,10 +20,15 @@ package dialer
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -118,3 +123,174 @@ func (d *BasicTLSDialer) DialTLSContext(ctx context.Context, network, address st
 		},
 	}).DialContext(ctx, network, address)
 }
+
+// ErrNonPublicAddress is returned when dialing an address that isn't reachable from the internet.
+var ErrNonPublicAddress = errors.New("refusing to connect to a non-public address")
+
+// VerifyingTLSDialer wraps a TLSDialer for hosts other than the API, such as remote image servers.
+// Such hosts aren't pinned, so it verifies the certificate chain of every connection against the system roots,
+// whether or not the wrapped dialer did. It only connects to public addresses, so that the URLs it is given
+// can't reach the local machine or network: the host is checked once resolved, and the connection once established,
+// as the host could resolve to another address by the time the wrapped dialer connects.
+type VerifyingTLSDialer struct {
+	dialer    TLSDialer
+	netDialer *net.Dialer
+	roots     *x509.CertPool
+}
+
+// NewVerifyingTLSDialer returns a new VerifyingTLSDialer wrapping the given dialer.
+func NewVerifyingTLSDialer(dialer TLSDialer) *VerifyingTLSDialer {
+	return &VerifyingTLSDialer{
+		dialer: dialer,
+		netDialer: &net.Dialer{
+			Timeout: 30 * time.Second,
+
+			// Plain connections are checked right before connecting, once the address is resolved.
+			Control: func(_, address string, _ syscall.RawConn) error {
+				return checkPublicAddress(address)
+			},
+		},
+	}
+}
+
+// DialContext returns a plain connection to the given address using the given network.
+func (d *VerifyingTLSDialer) DialContext(ctx context.Context, network, address string) (conn net.Conn, err error) {
+	return d.netDialer.DialContext(ctx, network, address)
+}
+
+// DialTLSContext returns a connection to the given address using the given network.
+func (d *VerifyingTLSDialer) DialTLSContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPublicHost(ctx, address); err != nil {
+		return nil, err
+	}
+
+	conn, err := d.dialer.DialTLSContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPublicAddress(conn.RemoteAddr().String()); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		_ = conn.Close()
+		return nil, errors.New("connection is not a TLS connection")
+	}
+
+	if err := d.verifyChain(tlsConn, host); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// verifyChain checks that the certificate chain of the given connection is valid for the given host.
+func (d *VerifyingTLSDialer) verifyChain(conn *tls.Conn, host string) error {
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no peer certificates available")
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         d.roots,
+		Intermediates: intermediates,
+	}); err != nil {
+		return fmt.Errorf("failed to verify certificate chain: %w", err)
+	}
+
+	return nil
+}
+
+// CreateTransportWithVerifyingDialer creates an http.Transport that makes both plain and TLS connections
+// with the given dialer. Unlike CreateTransportWithDialer, it doesn't use the system proxy:
+// the dialer would only see the proxy's address, which is usually local.
+func CreateTransportWithVerifyingDialer(dialer *VerifyingTLSDialer) *http.Transport {
+	t := &http.Transport{
+		DialContext:    dialer.DialContext,
+		DialTLSContext: dialer.DialTLSContext,
+	}
+
+	SetBasicTransportTimeouts(t)
+
+	return t
+}
+
+// nonPublicPrefixes are the special-purpose ranges (RFC 6890) which netip.Addr doesn't classify but which
+// aren't reachable from the internet, or could reach the local network.
+var nonPublicPrefixes = []netip.Prefix{ //nolint:gochecknoglobals
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT (RFC 6598)
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking (RFC 2544)
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, including the limited broadcast address
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which could embed a private IPv4 address (RFC 6052)
+}
+
+// checkPublicHost resolves the host of the given address and checks that all its addresses are public.
+func checkPublicHost(ctx context.Context, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if _, err := netip.ParseAddr(host); err == nil {
+		return checkPublicAddress(address)
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range ips {
+		if err := checkPublicAddress(net.JoinHostPort(ip.String(), port)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPublicAddress returns ErrNonPublicAddress if the given resolved address is loopback, private (RFC 1918 or
+// unique local), link-local, multicast, unspecified or in one of the other non-public ranges.
+func checkPublicAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return fmt.Errorf("%w: %v", ErrNonPublicAddress, ip)
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %v", ErrNonPublicAddress, ip)
+		}
+	}
+
+	return nil
+}
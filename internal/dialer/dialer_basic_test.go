@@ -18,6 +18,12 @@
 package dialer
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -132,3 +138,104 @@ func TestBasicTLSDialer_ShouldSkipCertificateChainVerification(t *testing.T) {
 		require.Equal(t, tt.expected, result)
 	}
 }
+
+func TestCheckPublicAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{address: "93.184.216.34:443", public: true},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443", public: true},
+		{address: "127.0.0.1:80", public: false},
+		{address: "[::1]:80", public: false},
+		{address: "10.0.0.1:80", public: false},
+		{address: "172.16.0.1:80", public: false},
+		{address: "192.168.1.1:80", public: false},
+		{address: "169.254.169.254:80", public: false},
+		{address: "100.64.0.1:80", public: false},
+		{address: "0.0.0.0:80", public: false},
+		{address: "0.1.2.3:80", public: false},
+		{address: "192.0.0.8:80", public: false},
+		{address: "198.18.0.1:80", public: false},
+		{address: "240.0.0.1:80", public: false},
+		{address: "255.255.255.255:80", public: false},
+		{address: "224.0.0.1:80", public: false},
+		{address: "[64:ff9b::7f00:1]:80", public: false},
+		{address: "[ff02::1]:80", public: false},
+		{address: "[fe80::1]:80", public: false},
+		{address: "[fd00::1]:80", public: false},
+		{address: "[::ffff:127.0.0.1]:80", public: false},
+	}
+
+	for _, tt := range tests {
+		err := checkPublicAddress(tt.address)
+
+		if tt.public {
+			require.NoError(t, err, tt.address)
+		} else {
+			require.ErrorIs(t, err, ErrNonPublicAddress, tt.address)
+		}
+	}
+}
+
+func TestVerifyingTLSDialer_RefusesLocalAddresses(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close() //nolint:errcheck
+
+	dialer := NewVerifyingTLSDialer(NewBasicTLSDialer("https://proton.me"))
+
+	_, err = dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+	require.ErrorIs(t, err, ErrNonPublicAddress)
+
+	_, err = dialer.DialTLSContext(context.Background(), "tcp", listener.Addr().String())
+	require.ErrorIs(t, err, ErrNonPublicAddress)
+
+	// Host names are checked once resolved.
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	_, err = dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("localhost", port))
+	require.ErrorIs(t, err, ErrNonPublicAddress)
+}
+
+func TestVerifyingTLSDialer_ChecksEstablishedConnections(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// The wrapped dialer connects to the local server whatever the address, like a host that resolves differently once checked.
+	dialer := NewVerifyingTLSDialer(tlsDialerFunc(func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}).DialContext(ctx, network, server.Listener.Addr().String()) //nolint:gosec
+	}))
+
+	_, err := dialer.DialTLSContext(context.Background(), "tcp", "93.184.216.34:443")
+	require.ErrorIs(t, err, ErrNonPublicAddress)
+}
+
+func TestVerifyingTLSDialer_VerifiesCertificateChain(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	dialer := NewVerifyingTLSDialer(NewBasicTLSDialer("https://proton.me"))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	conn, err := (&tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}).Dial("tcp", server.Listener.Addr().String()) //nolint:gosec
+	require.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	// The wrapped dialer skipped verification, the system roots don't know the test certificate.
+	require.Error(t, dialer.verifyChain(conn.(*tls.Conn), "example.com"))
+
+	dialer.roots = roots
+
+	require.NoError(t, dialer.verifyChain(conn.(*tls.Conn), "example.com"))
+	require.Error(t, dialer.verifyChain(conn.(*tls.Conn), "other.example"))
+}
+
+type tlsDialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+func (fn tlsDialerFunc) DialTLSContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return fn(ctx, network, address)
+}
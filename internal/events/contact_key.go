@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+)
+
+// ContactKeyChanged is emitted when the key used to encrypt mail to a recipient no longer matches the trusted one.
+// If Blocked is set, the message was not sent.
+type ContactKeyChanged struct {
+	eventBase
+
+	UserID         string
+	Email          string
+	OldFingerprint string
+	NewFingerprint string
+	Blocked        bool
+}
+
+func (event ContactKeyChanged) String() string {
+	return fmt.Sprintf(
+		"ContactKeyChanged: UserID: %s, Email: %s, OldFingerprint: %s, NewFingerprint: %s, Blocked: %t",
+		event.UserID,
+		logging.Sensitive(event.Email),
+		event.OldFingerprint,
+		event.NewFingerprint,
+		event.Blocked,
+	)
+}
@@ -225,3 +225,33 @@ type UserNotification struct {
 func (event UserNotification) String() string {
 	return fmt.Sprintf("UserNotification: UserID: %s, Title: %s, Subtitle: %s, Body: %s", event.UserID, event.Title, event.Subtitle, event.Body)
 }
+
+// UserReceivedMessage is published when the user receives a new message.
+type UserReceivedMessage struct {
+	eventBase
+
+	UserID    string
+	MessageID string
+	Sender    string
+	Subject   string
+
+	// Mailboxes are the IMAP names of the mailboxes the message is in.
+	Mailboxes []string
+}
+
+func (event UserReceivedMessage) String() string {
+	return fmt.Sprintf("UserReceivedMessage: UserID: %s, MessageID: %s", event.UserID, event.MessageID)
+}
+
+// UserSendFailed is published when a message of the user could not be sent.
+type UserSendFailed struct {
+	eventBase
+
+	UserID     string
+	Recipients []string
+	Error      string
+}
+
+func (event UserSendFailed) String() string {
+	return fmt.Sprintf("UserSendFailed: UserID: %s, Error: %s", event.UserID, event.Error)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ProtonMail/go-proton-api"
@@ -31,6 +32,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/hv"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
 	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
 	"github.com/abiosoft/ishell"
 	"github.com/sirupsen/logrus"
 )
@@ -78,7 +80,7 @@ func (f *frontendCLI) showAccountInfo(c *ishell.Context) {
 	default:
 	}
 
-	if user.AddressMode == vault.CombinedMode {
+	if user.AddressMode != vault.SplitMode {
 		f.showAccountAddressInfo(user, user.Addresses[0])
 	} else {
 		for _, address := range user.Addresses {
@@ -400,12 +402,30 @@ func (f *frontendCLI) changeMode(c *ishell.Context) {
 		return
 	}
 
-	var targetMode vault.AddressMode
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
 
-	if user.AddressMode == vault.CombinedMode {
-		targetMode = vault.SplitMode
-	} else {
-		targetMode = vault.CombinedMode
+	modes := []vault.AddressMode{vault.CombinedMode, vault.SplitMode, vault.HybridMode}
+
+	parseMode := func(val string) (vault.AddressMode, bool) {
+		idx := slices.IndexFunc(modes, func(mode vault.AddressMode) bool { return mode.String() == strings.ToLower(val) })
+		if idx < 0 || modes[idx] == user.AddressMode {
+			return 0, false
+		}
+
+		return modes[idx], true
+	}
+
+	f.Printf("Account %s is in %s mode.\n", bold(user.Username), bold(user.AddressMode.String()))
+
+	value := f.readStringInAttempts("New address mode (combined, split or hybrid)", c.ReadLine, func(val string) bool {
+		_, ok := parseMode(val)
+		return ok
+	})
+
+	targetMode, ok := parseMode(value)
+	if !ok {
+		return
 	}
 
 	if !f.yesNoQuestion("Are you sure you want to change the mode for account " + bold(user.Username) + " to " + bold(targetMode.String())) {
@@ -419,6 +439,146 @@ func (f *frontendCLI) changeMode(c *ishell.Context) {
 	f.Printf("Address mode for account %s changed to %s\n", user.Username, targetMode)
 }
 
+func (f *frontendCLI) changeMessageProfile(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	current, err := f.bridge.GetMessageProfile(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get message profile:", err)
+		return
+	}
+
+	profiles := []vault.MessageProfile{vault.MessageProfileCompat, vault.MessageProfileMinimal, vault.MessageProfileForensic}
+
+	parseProfile := func(val string) (vault.MessageProfile, bool) {
+		idx := slices.IndexFunc(profiles, func(profile vault.MessageProfile) bool { return profile.String() == strings.ToLower(val) })
+		if idx < 0 || profiles[idx] == current {
+			return 0, false
+		}
+
+		return profiles[idx], true
+	}
+
+	f.Printf("Messages of account %s are built with the %s profile.\n", bold(user.Username), bold(current.String()))
+
+	value := f.readStringInAttempts("New message profile (compat, minimal or forensic)", c.ReadLine, func(val string) bool {
+		_, ok := parseProfile(val)
+		return ok
+	})
+
+	profile, ok := parseProfile(value)
+	if !ok {
+		return
+	}
+
+	if err := f.bridge.SetMessageProfile(context.Background(), user.UserID, profile); err != nil {
+		f.printAndLogError("Cannot change message profile:", err)
+		return
+	}
+
+	f.Printf("Message profile for account %s changed to %s. Messages are rebuilt in the background.\n", user.Username, profile)
+}
+
+func (f *frontendCLI) changeTrustedAuthServers(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	current, err := f.bridge.GetTrustedAuthServIDs(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get trusted authentication servers:", err)
+		return
+	}
+
+	if len(current) == 0 {
+		current = message.DefaultTrustedAuthServIDs
+	}
+
+	f.Printf("Authentication results of account %s are trusted from %s.\n", bold(user.Username), bold(strings.Join(current, ", ")))
+	f.Print("New trusted servers, separated by commas (leave empty for the Proton servers): ")
+
+	var servIDs []string
+
+	for _, servID := range strings.Split(c.ReadLine(), ",") {
+		if servID = strings.TrimSpace(servID); servID != "" {
+			servIDs = append(servIDs, servID)
+		}
+	}
+
+	if err := f.bridge.SetTrustedAuthServIDs(context.Background(), user.UserID, servIDs); err != nil {
+		f.printAndLogError("Cannot change trusted authentication servers:", err)
+		return
+	}
+
+	f.Printf("Trusted authentication servers for account %s changed. Messages are rebuilt in the background.\n", user.Username)
+}
+
+func (f *frontendCLI) enableUnwrapPGPMIME(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetUnwrapPGPMIME(context.Background(), user.UserID, true); err != nil {
+		f.printAndLogError("Cannot enable PGP/MIME unwrapping:", err)
+		return
+	}
+
+	f.Printf("PGP/MIME messages of account %s are shown decrypted; the original is attached as encrypted.asc.\n", user.Username)
+}
+
+func (f *frontendCLI) disableUnwrapPGPMIME(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetUnwrapPGPMIME(context.Background(), user.UserID, false); err != nil {
+		f.printAndLogError("Cannot disable PGP/MIME unwrapping:", err)
+		return
+	}
+
+	f.Printf("PGP/MIME messages of account %s are shown as signed or encrypted.\n", user.Username)
+}
+
+func (f *frontendCLI) enableBlockRemoteContent(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetBlockRemoteContent(context.Background(), user.UserID, true); err != nil {
+		f.printAndLogError("Cannot block remote content:", err)
+		return
+	}
+
+	f.Printf("Remote images and tracking pixels are removed from HTML messages of account %s.\n", user.Username)
+}
+
+func (f *frontendCLI) disableBlockRemoteContent(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetBlockRemoteContent(context.Background(), user.UserID, false); err != nil {
+		f.printAndLogError("Cannot allow remote content:", err)
+		return
+	}
+
+	f.Printf("HTML messages of account %s are shown with their remote content.\n", user.Username)
+}
+
 func (f *frontendCLI) configureAppleMail(c *ishell.Context) {
 	user := f.askUserByIndexOrName(c)
 	if user.UserID == "" {
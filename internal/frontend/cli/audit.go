@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
+	"github.com/abiosoft/ishell"
+	"golang.org/x/exp/slices"
+)
+
+func (f *frontendCLI) enableAuditLog(_ *ishell.Context) {
+	if err := f.bridge.SetAuditLogEnabled(true); err != nil {
+		f.printAndLogError("Cannot enable the audit log:", err)
+		return
+	}
+
+	f.Println("The actions of mail clients are recorded in the audit log.")
+}
+
+func (f *frontendCLI) disableAuditLog(_ *ishell.Context) {
+	if err := f.bridge.SetAuditLogEnabled(false); err != nil {
+		f.printAndLogError("Cannot disable the audit log:", err)
+		return
+	}
+
+	f.Println("The audit log is disabled. The entries already recorded are kept.")
+}
+
+func (f *frontendCLI) showAuditLog(c *ishell.Context) {
+	filter, err := f.parseAuditFilter(c.Args)
+	if err != nil {
+		f.printAndLogError(err)
+		f.Println("Usage: audit show [since=<date|duration>] [until=<date|duration>] [user=<index|username>] [action=<action,...>] [limit=<n>]")
+		return
+	}
+
+	entries, corruptions, err := f.bridge.QueryAuditLog(filter)
+	if err != nil {
+		f.printAndLogError("Cannot read the audit log:", err)
+		return
+	}
+
+	if !f.bridge.GetAuditLogEnabled() {
+		f.Println("The audit log is disabled.")
+	}
+
+	for _, corruption := range corruptions {
+		f.Println("Warning:", corruption.String())
+	}
+
+	if len(entries) == 0 {
+		f.Println("No matching entries.")
+		return
+	}
+
+	for _, entry := range entries {
+		f.Println(entry.String())
+	}
+}
+
+// parseAuditFilter parses the key=value arguments of the audit show command.
+func (f *frontendCLI) parseAuditFilter(args []string) (audit.Filter, error) {
+	var filter audit.Filter
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return audit.Filter{}, fmt.Errorf("invalid argument %q", arg)
+		}
+
+		switch key {
+		case "since", "until":
+			t, err := parseAuditTime(value)
+			if err != nil {
+				return audit.Filter{}, err
+			}
+
+			if key == "since" {
+				filter.Since = t
+			} else {
+				filter.Until = t
+			}
+
+		case "user":
+			// Accounts that were removed since can still be queried by their user ID.
+			if user := f.getUserByIndexOrName(value); user.UserID != "" {
+				filter.UserID = user.UserID
+			} else {
+				filter.UserID = value
+			}
+
+		case "action":
+			for _, action := range strings.Split(value, ",") {
+				if !slices.Contains(audit.Actions, audit.Action(action)) {
+					return audit.Filter{}, fmt.Errorf("unknown action %q, expected one of %v", action, audit.Actions)
+				}
+
+				filter.Actions = append(filter.Actions, audit.Action(action))
+			}
+
+		case "limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 1 {
+				return audit.Filter{}, fmt.Errorf("invalid limit %q", value)
+			}
+
+			filter.Limit = limit
+
+		default:
+			return audit.Filter{}, fmt.Errorf("unknown filter %q", key)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseAuditTime parses a date, a local date and time, or a duration counted back from now.
+func parseAuditTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected a date like 2006-01-02 or a duration like 24h", value)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) listAutocryptPeers(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	mode, err := f.bridge.GetAutocryptMode(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get Autocrypt mode:", err)
+		return
+	}
+
+	collect, err := f.bridge.GetAutocryptCollect(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get Autocrypt key collection:", err)
+		return
+	}
+
+	f.Printf("Autocrypt mode is %s.\n", bold(mode.String()))
+
+	if !collect {
+		f.Println("Keys are not collected from received mail.")
+		return
+	}
+
+	peers, err := f.bridge.GetAutocryptPeers(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get Autocrypt peers:", err)
+		return
+	}
+
+	if len(peers) == 0 {
+		f.Println("No keys collected yet.")
+		return
+	}
+
+	for _, peer := range peers {
+		preference := "nopreference"
+		if peer.PreferEncrypt {
+			preference = "mutual"
+		}
+
+		f.Printf("%-40s %-12s (seen %s)\n", peer.Email, preference, peer.Timestamp.Format("2006-01-02"))
+	}
+}
+
+func (f *frontendCLI) changeAutocryptMode(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	modes := []vault.AutocryptMode{vault.AutocryptOff, vault.AutocryptNoPreference, vault.AutocryptMutual}
+
+	parseMode := func(val string) (vault.AutocryptMode, bool) {
+		idx := slices.IndexFunc(modes, func(mode vault.AutocryptMode) bool { return mode.String() == strings.ToLower(val) })
+		if idx < 0 {
+			return 0, false
+		}
+
+		return modes[idx], true
+	}
+
+	current, err := f.bridge.GetAutocryptMode(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get Autocrypt mode:", err)
+		return
+	}
+
+	f.Printf("Autocrypt mode is %s.\n", bold(current.String()))
+
+	value := f.readStringInAttempts("New mode (off, nopreference or mutual)", c.ReadLine, func(val string) bool {
+		_, ok := parseMode(val)
+		return ok
+	})
+
+	mode, ok := parseMode(value)
+	if !ok {
+		return
+	}
+
+	if err := f.bridge.SetAutocryptMode(user.UserID, mode); err != nil {
+		f.printAndLogError("Cannot change Autocrypt mode:", err)
+		return
+	}
+
+	f.Printf("Autocrypt mode changed to %s\n", mode)
+}
+
+func (f *frontendCLI) enableAutocryptCollect(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetAutocryptCollect(user.UserID, true); err != nil {
+		f.printAndLogError("Cannot enable Autocrypt key collection:", err)
+		return
+	}
+
+	f.Println("Keys will be collected from received mail.")
+}
+
+func (f *frontendCLI) disableAutocryptCollect(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	if !f.yesNoQuestion("Are you sure you want to stop collecting keys? All collected keys will be forgotten") {
+		return
+	}
+
+	if err := f.bridge.SetAutocryptCollect(user.UserID, false); err != nil {
+		f.printAndLogError("Cannot disable Autocrypt key collection:", err)
+		return
+	}
+
+	f.Println("Keys will no longer be collected from received mail.")
+}
+
+func (f *frontendCLI) forgetAutocryptPeer(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Address whose key to forget", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	if email == "" {
+		return
+	}
+
+	if err := f.bridge.RemoveAutocryptPeer(user.UserID, email); err != nil {
+		f.printAndLogError("Cannot forget key:", err)
+		return
+	}
+
+	f.Println("Key forgotten.")
+}
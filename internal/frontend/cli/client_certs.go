@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) listClientCerts(_ *ishell.Context) {
+	f.Printf("Client certificate authentication is %s.\n", bold(f.bridge.GetClientCertAuth().String()))
+
+	clientCerts := f.bridge.GetClientCerts()
+	if len(clientCerts) == 0 {
+		f.Println("No client certificates issued.")
+		return
+	}
+
+	for _, cert := range clientCerts {
+		state := "valid"
+		if cert.Revoked {
+			state = "revoked"
+		}
+
+		username := cert.UserID
+		if info, err := f.bridge.GetUserInfo(cert.UserID); err == nil {
+			username = info.Username
+		}
+
+		f.Printf("%s %-20s %-30s %-10s until %s (%s)\n",
+			cert.SerialNumber,
+			cert.Device,
+			username,
+			cert.Scope,
+			cert.NotAfter.Format("2006-01-02"),
+			state,
+		)
+	}
+}
+
+func (f *frontendCLI) issueClientCert(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	device := f.readStringInAttempts("Device name", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	if device == "" {
+		return
+	}
+
+	scopes := []vault.ClientCertScope{vault.ClientCertScopeIMAP, vault.ClientCertScopeSMTP, vault.ClientCertScopeAll}
+
+	parseScope := func(val string) (vault.ClientCertScope, bool) {
+		idx := slices.IndexFunc(scopes, func(scope vault.ClientCertScope) bool { return scope.String() == strings.ToLower(val) })
+		if idx < 0 {
+			return 0, false
+		}
+
+		return scopes[idx], true
+	}
+
+	value := f.readStringInAttempts("Scope (imap, smtp or imap+smtp)", c.ReadLine, func(val string) bool {
+		_, ok := parseScope(val)
+		return ok
+	})
+
+	scope, ok := parseScope(value)
+	if !ok {
+		return
+	}
+
+	location := f.readStringInAttempts("Enter a path to which to export the client certificate", c.ReadLine, f.isCacheLocationUsable)
+	if location == "" {
+		return
+	}
+
+	cert, key, err := f.bridge.IssueClientCert(user.UserID, strings.TrimSpace(device), scope)
+	if err != nil {
+		f.printAndLogError("Cannot issue client certificate:", err)
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(location, "client-cert.pem"), cert, 0o600); err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(location, "client-key.pem"), key, 0o600); err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	f.Println("Client certificate exported to", location)
+}
+
+func (f *frontendCLI) revokeClientCert(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	serial := ""
+	if len(c.Args) > 0 {
+		serial = c.Args[0]
+	} else {
+		serial = f.readStringInAttempts("Serial number of the certificate to revoke", c.ReadLine, func(val string) bool {
+			_, ok := f.bridge.GetClientCert(val)
+			return ok
+		})
+	}
+
+	if serial == "" {
+		return
+	}
+
+	if !f.yesNoQuestion("Are you sure you want to revoke the client certificate " + bold(serial)) {
+		return
+	}
+
+	if err := f.bridge.RevokeClientCert(serial); err != nil {
+		f.printAndLogError("Cannot revoke client certificate:", err)
+		return
+	}
+
+	f.Println("Client certificate revoked.")
+}
+
+func (f *frontendCLI) changeClientCertAuth(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	modes := []vault.ClientCertAuth{vault.ClientCertAuthDisabled, vault.ClientCertAuthOptional, vault.ClientCertAuthRequired}
+
+	parseMode := func(val string) (vault.ClientCertAuth, bool) {
+		idx := slices.IndexFunc(modes, func(mode vault.ClientCertAuth) bool { return mode.String() == strings.ToLower(val) })
+		if idx < 0 {
+			return 0, false
+		}
+
+		return modes[idx], true
+	}
+
+	f.Printf("Client certificate authentication is %s.\n", bold(f.bridge.GetClientCertAuth().String()))
+
+	value := f.readStringInAttempts("New mode (disabled, optional or required)", c.ReadLine, func(val string) bool {
+		_, ok := parseMode(val)
+		return ok
+	})
+
+	mode, ok := parseMode(value)
+	if !ok {
+		return
+	}
+
+	if !f.yesNoQuestion("Are you sure you want to change client certificate authentication to " + bold(mode.String())) {
+		return
+	}
+
+	if err := f.bridge.SetClientCertAuth(context.Background(), mode); err != nil {
+		f.printAndLogError("Cannot change client certificate authentication:", err)
+		return
+	}
+
+	f.Printf("Client certificate authentication changed to %s\n", mode)
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) listContactKeys(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	policy, err := f.bridge.GetContactKeyPolicy(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get contact key policy:", err)
+		return
+	}
+
+	f.Printf("Recipient key changes are handled with policy %s.\n", bold(policy.String()))
+
+	keys, err := f.bridge.GetContactKeys(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get contact keys:", err)
+		return
+	}
+
+	if len(keys) == 0 {
+		f.Println("No recipient keys recorded.")
+		return
+	}
+
+	for _, key := range keys {
+		f.Printf("%-40s %s (since %s)\n", key.Email, key.Fingerprint, key.FirstSeen.Format("2006-01-02"))
+
+		if key.HasPendingChange() {
+			f.Printf("%-40s changed to %s on %s, awaiting approval\n", "", bold(key.PendingFingerprint), key.ChangedAt.Format("2006-01-02"))
+		}
+	}
+}
+
+func (f *frontendCLI) approveContactKey(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	keys, err := f.bridge.GetContactKeys(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get contact keys:", err)
+		return
+	}
+
+	pending := func(email string) (vault.ContactKey, bool) {
+		idx := slices.IndexFunc(keys, func(key vault.ContactKey) bool {
+			return key.HasPendingChange() && strings.EqualFold(key.Email, email)
+		})
+		if idx < 0 {
+			return vault.ContactKey{}, false
+		}
+
+		return keys[idx], true
+	}
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Recipient whose new key to approve", c.ReadLine, func(val string) bool {
+			_, ok := pending(val)
+			return ok
+		})
+	}
+
+	key, ok := pending(email)
+	if !ok {
+		f.Println("No key change awaiting approval for this recipient.")
+		return
+	}
+
+	f.Printf("Trusted key: %s\nNew key:     %s\n", key.Fingerprint, key.PendingFingerprint)
+
+	if !f.yesNoQuestion("Are you sure you want to trust the new key of " + bold(key.Email)) {
+		return
+	}
+
+	if err := f.bridge.ApproveContactKeyChange(user.UserID, key.Email); err != nil {
+		f.printAndLogError("Cannot approve key change:", err)
+		return
+	}
+
+	f.Println("New key approved.")
+}
+
+func (f *frontendCLI) forgetContactKey(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Recipient whose key to forget", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	if email == "" {
+		return
+	}
+
+	if !f.yesNoQuestion("Are you sure you want to forget the key of " + bold(email) + "? The next key used will be trusted") {
+		return
+	}
+
+	if err := f.bridge.RemoveContactKey(user.UserID, email); err != nil {
+		f.printAndLogError("Cannot forget key:", err)
+		return
+	}
+
+	f.Println("Key forgotten.")
+}
+
+func (f *frontendCLI) changeContactKeyPolicy(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	policies := []vault.ContactKeyPolicy{vault.ContactKeyPolicyBlock, vault.ContactKeyPolicyWarn, vault.ContactKeyPolicyAllow}
+
+	parsePolicy := func(val string) (vault.ContactKeyPolicy, bool) {
+		idx := slices.IndexFunc(policies, func(policy vault.ContactKeyPolicy) bool { return policy.String() == strings.ToLower(val) })
+		if idx < 0 {
+			return 0, false
+		}
+
+		return policies[idx], true
+	}
+
+	current, err := f.bridge.GetContactKeyPolicy(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get contact key policy:", err)
+		return
+	}
+
+	f.Printf("Recipient key changes are handled with policy %s.\n", bold(current.String()))
+
+	value := f.readStringInAttempts("New policy (block, warn or allow)", c.ReadLine, func(val string) bool {
+		_, ok := parsePolicy(val)
+		return ok
+	})
+
+	policy, ok := parsePolicy(value)
+	if !ok {
+		return
+	}
+
+	if err := f.bridge.SetContactKeyPolicy(user.UserID, policy); err != nil {
+		f.printAndLogError("Cannot change contact key policy:", err)
+		return
+	}
+
+	f.Printf("Contact key policy changed to %s\n", policy)
+}
@@ -19,6 +19,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	"github.com/abiosoft/ishell"
@@ -72,3 +73,36 @@ func (f *frontendCLI) debugMailboxState(c *ishell.Context) {
 
 	c.Printf("\nMessage download finished. Data is available at %v\n", bold(location))
 }
+
+func (f *frontendCLI) debugWriteBundle(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	output := f.readStringInAttempts("Path of the zip file to write", c.ReadLine, isNotEmpty)
+	if output == "" {
+		f.printAndLogError(errors.New("failed to get bundle path"))
+		return
+	}
+
+	checkClientState := f.yesNoQuestion("Also compare the mailbox state with the server (may take a while)")
+
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec
+	if err != nil {
+		f.printAndLogError("Cannot create diagnostic bundle:", err)
+		return
+	}
+
+	if err := f.bridge.WriteDiagnosticBundle(context.Background(), file, checkClientState); err != nil {
+		_ = file.Close()
+		f.printAndLogError("Cannot write diagnostic bundle:", err)
+
+		return
+	}
+
+	if err := file.Close(); err != nil {
+		f.printAndLogError("Cannot write diagnostic bundle:", err)
+		return
+	}
+
+	f.Printf("Diagnostic bundle written to %v. It was not sent anywhere.\n", bold(output))
+}
@@ -97,11 +97,23 @@ func New(
 	}
 	changeCmd.AddCmd(&ishell.Cmd{
 		Name:      "mode",
-		Help:      "switch between combined addresses and split addresses mode for account. Use index or account name as parameter. (alias: m)",
+		Help:      "switch between combined, split and hybrid addresses mode for account. Use index or account name as parameter. (alias: m)",
 		Aliases:   []string{"m"},
 		Func:      fe.changeMode,
 		Completer: fe.completeUsernames,
 	})
+	changeCmd.AddCmd(&ishell.Cmd{
+		Name:      "message-profile",
+		Help:      "choose which headers are added to the messages of an account: compat, minimal or forensic. Use index or account name as parameter.",
+		Func:      fe.changeMessageProfile,
+		Completer: fe.completeUsernames,
+	})
+	changeCmd.AddCmd(&ishell.Cmd{
+		Name:      "trusted-auth-servers",
+		Help:      "choose the servers whose SPF, DKIM and DMARC results are trusted in the messages of an account. Use index or account name as parameter.",
+		Func:      fe.changeTrustedAuthServers,
+		Completer: fe.completeUsernames,
+	})
 	changeCmd.AddCmd(&ishell.Cmd{
 		Name: "change-location",
 		Help: "change the location of the encrypted message cache",
@@ -129,6 +141,12 @@ func New(
 		Aliases: []string{"ssl-smtp", "starttls-smtp"},
 		Func:    fe.changeSMTPSecurity,
 	})
+	changeCmd.AddCmd(&ishell.Cmd{
+		Name:      "vault-key",
+		Help:      "rotate the vault encryption key. Optionally use a keychain name as parameter to move the key to that keychain.",
+		Func:      fe.rotateVaultKey,
+		Completer: fe.completeKeychains,
+	})
 	fe.AddCmd(changeCmd)
 
 	// DoH commands.
@@ -195,6 +213,381 @@ func New(
 	})
 	fe.AddCmd(certCmd)
 
+	// Client certificate commands.
+	clientCertCmd := &ishell.Cmd{
+		Name: "client-cert",
+		Help: "manage the client certificates used to authenticate IMAP and SMTP clients",
+	}
+	clientCertCmd.AddCmd(&ishell.Cmd{
+		Name: "list",
+		Help: "print the issued client certificates",
+		Func: fe.listClientCerts,
+	})
+	clientCertCmd.AddCmd(&ishell.Cmd{
+		Name:      "issue",
+		Help:      "issue a client certificate for a device of an account. Use index or account name as parameter.",
+		Func:      fe.issueClientCert,
+		Completer: fe.completeUsernames,
+	})
+	clientCertCmd.AddCmd(&ishell.Cmd{
+		Name: "revoke",
+		Help: "revoke a client certificate. Use the serial number as parameter.",
+		Func: fe.revokeClientCert,
+	})
+	clientCertCmd.AddCmd(&ishell.Cmd{
+		Name: "mode",
+		Help: "change whether client certificates are disabled, optional or required",
+		Func: fe.changeClientCertAuth,
+	})
+	fe.AddCmd(clientCertCmd)
+
+	// Contact key commands.
+	contactKeysCmd := &ishell.Cmd{
+		Name: "contact-keys",
+		Help: "inspect the keys used to encrypt mail to recipients and approve key changes",
+	}
+	contactKeysCmd.AddCmd(&ishell.Cmd{
+		Name:      "list",
+		Help:      "print the recipient keys of an account. Use index or account name as parameter.",
+		Func:      fe.listContactKeys,
+		Completer: fe.completeUsernames,
+	})
+	contactKeysCmd.AddCmd(&ishell.Cmd{
+		Name:      "approve",
+		Help:      "trust the new key of a recipient. Use index or account name and the recipient address as parameters.",
+		Func:      fe.approveContactKey,
+		Completer: fe.completeUsernames,
+	})
+	contactKeysCmd.AddCmd(&ishell.Cmd{
+		Name:      "forget",
+		Help:      "forget the key of a recipient. Use index or account name and the recipient address as parameters.",
+		Func:      fe.forgetContactKey,
+		Completer: fe.completeUsernames,
+	})
+	contactKeysCmd.AddCmd(&ishell.Cmd{
+		Name:      "policy",
+		Help:      "change whether recipient key changes block sending, warn or are allowed. Use index or account name as parameter.",
+		Func:      fe.changeContactKeyPolicy,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(contactKeysCmd)
+
+	// Autocrypt commands.
+	autocryptCmd := &ishell.Cmd{
+		Name: "autocrypt",
+		Help: "manage the Autocrypt header of sent mail and the keys collected from received mail",
+	}
+	autocryptCmd.AddCmd(&ishell.Cmd{
+		Name:      "list",
+		Help:      "print the Autocrypt settings and collected keys of an account. Use index or account name as parameter.",
+		Func:      fe.listAutocryptPeers,
+		Completer: fe.completeUsernames,
+	})
+	autocryptCmd.AddCmd(&ishell.Cmd{
+		Name:      "mode",
+		Help:      "change whether sent mail carries an Autocrypt header and its encryption preference. Use index or account name as parameter.",
+		Func:      fe.changeAutocryptMode,
+		Completer: fe.completeUsernames,
+	})
+	autocryptCollectCmd := &ishell.Cmd{
+		Name: "collect",
+		Help: "manage the collection of keys from received mail",
+	}
+	autocryptCmd.AddCmd(autocryptCollectCmd)
+	autocryptCollectCmd.AddCmd(&ishell.Cmd{
+		Name:      "enable",
+		Help:      "collect keys from the Autocrypt headers of received mail. Use index or account name as parameter.",
+		Func:      fe.enableAutocryptCollect,
+		Completer: fe.completeUsernames,
+	})
+	autocryptCollectCmd.AddCmd(&ishell.Cmd{
+		Name:      "disable",
+		Help:      "stop collecting keys and forget the collected ones. Use index or account name as parameter.",
+		Func:      fe.disableAutocryptCollect,
+		Completer: fe.completeUsernames,
+	})
+	autocryptCmd.AddCmd(&ishell.Cmd{
+		Name:      "forget",
+		Help:      "forget the collected key of an address. Use index or account name and the address as parameters.",
+		Func:      fe.forgetAutocryptPeer,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(autocryptCmd)
+
+	// S/MIME commands.
+	smimeCmd := &ishell.Cmd{
+		Name: "smime",
+		Help: "manage the S/MIME certificates used to sign mail and the recipients who get S/MIME signed mail",
+	}
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "list",
+		Help:      "print the S/MIME certificates of an account. Use index or account name as parameter.",
+		Func:      fe.listSMIMEIdentities,
+		Completer: fe.completeUsernames,
+	})
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "import",
+		Help:      "import a PEM certificate chain and private key. Use index or account name, the certificate path and the key path as parameters.",
+		Func:      fe.importSMIMEIdentity,
+		Completer: fe.completeUsernames,
+	})
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "remove",
+		Help:      "remove the S/MIME certificate of an address. Use index or account name and the address as parameters.",
+		Func:      fe.removeSMIMEIdentity,
+		Completer: fe.completeUsernames,
+	})
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "recipients",
+		Help:      "print the recipients who get S/MIME signed mail. Use index or account name as parameter.",
+		Func:      fe.listSMIMERecipients,
+		Completer: fe.completeUsernames,
+	})
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "add-recipient",
+		Help:      "sign the mail sent to an external recipient with S/MIME. Use index or account name and the address as parameters.",
+		Func:      fe.addSMIMERecipient,
+		Completer: fe.completeUsernames,
+	})
+	smimeCmd.AddCmd(&ishell.Cmd{
+		Name:      "remove-recipient",
+		Help:      "stop signing the mail sent to a recipient with S/MIME. Use index or account name and the address as parameters.",
+		Func:      fe.removeSMIMERecipient,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(smimeCmd)
+
+	// PGP/MIME unwrapping commands.
+	unwrapPGPMIMECmd := &ishell.Cmd{
+		Name: "pgp-mime",
+		Help: "choose whether PGP/MIME messages are shown decrypted to clients without PGP support",
+	}
+	unwrapPGPMIMECmd.AddCmd(&ishell.Cmd{
+		Name:      "unwrap",
+		Help:      "show PGP/MIME messages as their decrypted MIME tree, with the original attached. Use index or account name as parameter.",
+		Func:      fe.enableUnwrapPGPMIME,
+		Completer: fe.completeUsernames,
+	})
+	unwrapPGPMIMECmd.AddCmd(&ishell.Cmd{
+		Name:      "keep",
+		Help:      "show PGP/MIME messages as signed or encrypted. Use index or account name as parameter.",
+		Func:      fe.disableUnwrapPGPMIME,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(unwrapPGPMIMECmd)
+
+	// Remote content commands.
+	remoteContentCmd := &ishell.Cmd{
+		Name: "remote-content",
+		Help: "choose whether remote images and tracking pixels are removed from HTML messages",
+	}
+	remoteContentCmd.AddCmd(&ishell.Cmd{
+		Name:      "block",
+		Help:      "replace remote images and CSS with a placeholder and strip tracking pixels. Use index or account name as parameter.",
+		Func:      fe.enableBlockRemoteContent,
+		Completer: fe.completeUsernames,
+	})
+	remoteContentCmd.AddCmd(&ishell.Cmd{
+		Name:      "allow",
+		Help:      "show HTML messages with their remote content. Use index or account name as parameter.",
+		Func:      fe.disableBlockRemoteContent,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(remoteContentCmd)
+
+	// Image proxy commands.
+	imageProxyCmd := &ishell.Cmd{
+		Name: "image-proxy",
+		Help: "load remote images of HTML messages through a local proxy which hides your address, cookies and reading time",
+	}
+	imageProxyCmd.AddCmd(&ishell.Cmd{
+		Name:      "enable",
+		Help:      "load remote images through the image proxy. Use index or account name as parameter.",
+		Func:      fe.enableImageProxy,
+		Completer: fe.completeUsernames,
+	})
+	imageProxyCmd.AddCmd(&ishell.Cmd{
+		Name:      "disable",
+		Help:      "stop loading remote images through the image proxy. Use index or account name as parameter.",
+		Func:      fe.disableImageProxy,
+		Completer: fe.completeUsernames,
+	})
+	imageProxyCmd.AddCmd(&ishell.Cmd{
+		Name: "cache-size",
+		Help: "change the size limit of the encrypted image cache, in megabytes",
+		Func: fe.changeImageProxyCacheSize,
+	})
+	fe.AddCmd(imageProxyCmd)
+
+	// Large attachment commands.
+	largeAttachmentsCmd := &ishell.Cmd{
+		Name: "large-attachments",
+		Help: "send attachments too large for Proton Mail as links to a directory you share",
+	}
+	largeAttachmentsCmd.AddCmd(&ishell.Cmd{
+		Name:      "show",
+		Help:      "show whether and where large attachments are stored. Use index or account name as parameter.",
+		Func:      fe.showLargeAttachments,
+		Completer: fe.completeUsernames,
+	})
+	largeAttachmentsCmd.AddCmd(&ishell.Cmd{
+		Name:      "enable",
+		Help:      "store attachments above a size in a directory and send links to them. Use index or account name as parameter.",
+		Func:      fe.enableLargeAttachments,
+		Completer: fe.completeUsernames,
+	})
+	largeAttachmentsCmd.AddCmd(&ishell.Cmd{
+		Name:      "disable",
+		Help:      "always send attachments with the message. Use index or account name as parameter.",
+		Func:      fe.disableLargeAttachments,
+		Completer: fe.completeUsernames,
+	})
+	fe.AddCmd(largeAttachmentsCmd)
+
+	// Metrics endpoint commands.
+	metricsCmd := &ishell.Cmd{
+		Name: "metrics",
+		Help: "serve health and sync metrics on localhost in the Prometheus format, independently of telemetry",
+	}
+	metricsCmd.AddCmd(&ishell.Cmd{
+		Name: "show",
+		Help: "show whether and where metrics are served",
+		Func: fe.showMetrics,
+	})
+	metricsCmd.AddCmd(&ishell.Cmd{
+		Name: "enable",
+		Help: "serve metrics on localhost",
+		Func: fe.enableMetrics,
+	})
+	metricsCmd.AddCmd(&ishell.Cmd{
+		Name: "disable",
+		Help: "stop serving metrics",
+		Func: fe.disableMetrics,
+	})
+	metricsCmd.AddCmd(&ishell.Cmd{
+		Name: "port",
+		Help: "change the port of the metrics endpoint",
+		Func: fe.changeMetricsPort,
+	})
+	fe.AddCmd(metricsCmd)
+
+	// IMAP session commands.
+	sessionsCmd := &ishell.Cmd{
+		Name: "sessions",
+		Help: "list the connected IMAP clients, disconnect them or block a client type",
+	}
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name: "list",
+		Help: "list the open IMAP sessions",
+		Func: fe.listIMAPSessions,
+	})
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name: "close",
+		Help: "disconnect the client of an IMAP session. Use the session number as parameter",
+		Func: fe.closeIMAPSession,
+	})
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name:      "block",
+		Help:      "disconnect and refuse the clients of a type for a while (default 1h). Example: sessions block outlook 30m",
+		Func:      fe.blockIMAPClient,
+		Completer: fe.completeClientTypes,
+	})
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name:      "unblock",
+		Help:      "accept the clients of a blocked type again",
+		Func:      fe.unblockIMAPClient,
+		Completer: fe.completeClientTypes,
+	})
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name: "limits",
+		Help: "show the maximum number of sessions per client type and the blocked client types",
+		Func: fe.showIMAPClientLimits,
+	})
+	sessionsCmd.AddCmd(&ishell.Cmd{
+		Name:      "limit",
+		Help:      "change the maximum number of sessions of a client type (0 for unlimited, default to reset). Example: sessions limit apple-mail 20",
+		Func:      fe.changeIMAPClientLimit,
+		Completer: fe.completeClientTypes,
+	})
+	fe.AddCmd(sessionsCmd)
+
+	// API rate limit commands.
+	rateLimitCmd := &ishell.Cmd{
+		Name: "ratelimit",
+		Help: "limit the rate of API requests the mail clients of each account can cause, per class (send, import, label, fetch)",
+	}
+	rateLimitCmd.AddCmd(&ishell.Cmd{
+		Name: "show",
+		Help: "show the rate limits and the throttled requests of each account",
+		Func: fe.showAPIRateLimits,
+	})
+	rateLimitCmd.AddCmd(&ishell.Cmd{
+		Name:      "set",
+		Help:      "change the rate per minute and burst of a class (rate 0 for unlimited, default to reset). Example: ratelimit set send 20 10",
+		Func:      fe.changeAPIRateLimit,
+		Completer: fe.completeRateLimitClasses,
+	})
+	fe.AddCmd(rateLimitCmd)
+
+	// Desktop notification commands.
+	notificationsCmd := &ishell.Cmd{
+		Name: "notifications",
+		Help: "show desktop notifications about new mail and messages that could not be sent",
+	}
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "show",
+		Help: "show the desktop notification settings and rules",
+		Func: fe.showDesktopNotifications,
+	})
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "newmail",
+		Help: "turn notifications about new messages on or off. Example: notifications newmail on",
+		Func: fe.changeNewMailNotifications,
+	})
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "sendfailures",
+		Help: "turn notifications about messages that could not be sent on or off. Example: notifications sendfailures on",
+		Func: fe.changeSendFailureNotifications,
+	})
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "quiet",
+		Help: "set the daily quiet hours without notifications, or off. Example: notifications quiet 22:00 07:00",
+		Func: fe.changeQuietHours,
+	})
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "rule",
+		Help: "notify or not about new messages of a mailbox (* for all), optionally for one account. Example: notifications rule Folders/Work on user@pm.me",
+		Func: fe.addDesktopNotificationRule,
+	})
+	notificationsCmd.AddCmd(&ishell.Cmd{
+		Name: "remove-rule",
+		Help: "remove a rule by its number. Example: notifications remove-rule 0",
+		Func: fe.removeDesktopNotificationRule,
+	})
+	fe.AddCmd(notificationsCmd)
+
+	// Audit log commands.
+	auditCmd := &ishell.Cmd{
+		Name: "audit",
+		Help: "record which clients logged in and which messages they moved, removed, flagged or sent",
+	}
+	auditCmd.AddCmd(&ishell.Cmd{
+		Name: "enable",
+		Help: "start recording the actions of mail clients",
+		Func: fe.enableAuditLog,
+	})
+	auditCmd.AddCmd(&ishell.Cmd{
+		Name: "disable",
+		Help: "stop recording the actions of mail clients",
+		Func: fe.disableAuditLog,
+	})
+	auditCmd.AddCmd(&ishell.Cmd{
+		Name: "show",
+		Help: "show the audit log. Filters: since=, until=, user=, action=, limit= (example: audit show since=24h action=send)",
+		Func: fe.showAuditLog,
+	})
+	fe.AddCmd(auditCmd)
+
 	// All mail visibility commands.
 	allMailCmd := &ishell.Cmd{
 		Name: "all-mail-visibility",
@@ -261,6 +654,26 @@ func New(
 		Aliases: []string{"log", "logs"},
 		Func:    fe.printLogDir,
 	})
+	logLevelCmd := &ishell.Cmd{
+		Name: "log-level",
+		Help: "show or change the log levels, globally or per component (e.g. smtp, imap, sync, gluon)",
+	}
+	logLevelCmd.AddCmd(&ishell.Cmd{
+		Name: "show",
+		Help: "show the current log levels",
+		Func: fe.showLogLevels,
+	})
+	logLevelCmd.AddCmd(&ishell.Cmd{
+		Name: "change",
+		Help: "change the log levels without restarting. Example: log-level change info,smtp=debug,sync=warn",
+		Func: fe.changeLogLevels,
+	})
+	fe.AddCmd(logLevelCmd)
+	fe.AddCmd(&ishell.Cmd{
+		Name: "locked-settings",
+		Help: "print the settings locked by the system policy, which can't be changed.",
+		Func: fe.printLockedSettings,
+	})
 	fe.AddCmd(&ishell.Cmd{
 		Name:    "manual",
 		Help:    "print URL with instructions. (alias: man)",
@@ -359,6 +772,12 @@ func New(
 		Func: fe.debugMailboxState,
 	})
 
+	dbgCmd.AddCmd(&ishell.Cmd{
+		Name: "bundle",
+		Help: "write a diagnostic bundle with logs, settings and account status to a zip file, without sending it anywhere",
+		Func: fe.debugWriteBundle,
+	})
+
 	fe.AddCmd(dbgCmd)
 
 	versionCmd := &ishell.Cmd{
@@ -511,6 +930,18 @@ func (f *frontendCLI) watchEvents(eventCh <-chan events.Event) { // nolint:gocyc
 		case events.Raise:
 			f.Printf("Hello!")
 
+		case events.ContactKeyChanged:
+			user, err := f.bridge.GetUserInfo(event.UserID)
+			if err != nil {
+				return
+			}
+
+			if event.Blocked {
+				f.Printf("The key of %s changed for %s; the message was not sent. Approve the new key with `contact-keys approve`.\n", event.Email, user.Username)
+			} else {
+				f.Printf("The key of %s changed for %s. Approve the new key with `contact-keys approve`.\n", event.Email, user.Username)
+			}
+
 		case events.UserNotification:
 			user, err := f.bridge.GetUserInfo(event.UserID)
 			if err != nil {
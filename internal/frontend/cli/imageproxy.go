@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/abiosoft/ishell"
+)
+
+const megabyte = 1024 * 1024
+
+func (f *frontendCLI) enableImageProxy(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetUseImageProxy(context.Background(), user.UserID, true); err != nil {
+		f.printAndLogError("Cannot enable image proxy:", err)
+		return
+	}
+
+	f.Printf("Remote images of account %s are loaded through the image proxy. Messages are rebuilt in the background.\n", user.Username)
+}
+
+func (f *frontendCLI) disableImageProxy(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	if err := f.bridge.SetUseImageProxy(context.Background(), user.UserID, false); err != nil {
+		f.printAndLogError("Cannot disable image proxy:", err)
+		return
+	}
+
+	f.Printf("Remote images of account %s are no longer loaded through the image proxy.\n", user.Username)
+}
+
+func (f *frontendCLI) changeImageProxyCacheSize(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	isSize := func(size string) bool {
+		if n, err := strconv.Atoi(size); err != nil || n <= 0 {
+			f.Println("Input", size, "is not a positive number of megabytes.")
+			return false
+		}
+
+		return true
+	}
+
+	newSize := f.readStringInAttempts(
+		fmt.Sprintf("Set image cache size in MB (current %v)", f.bridge.GetImageProxyCacheSize()/megabyte),
+		c.ReadLine,
+		isSize,
+	)
+	if newSize == "" {
+		f.printAndLogError(errors.New("failed to get new cache size"))
+		return
+	}
+
+	newSizeInt, err := strconv.Atoi(newSize)
+	if err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	if err := f.bridge.SetImageProxyCacheSize(int64(newSizeInt) * megabyte); err != nil {
+		f.printAndLogError(err)
+		return
+	}
+}
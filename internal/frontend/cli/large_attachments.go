@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) showLargeAttachments(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	settings, err := f.bridge.GetLargeAttachments(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get large attachment settings:", err)
+		return
+	}
+
+	if !settings.Enabled {
+		f.Printf("Attachments of account %s are always sent with the message.\n", user.Username)
+		return
+	}
+
+	f.Printf("Attachments of account %s above %v MB are sent as links.\n", user.Username, settings.Threshold/megabyte)
+	f.Println("Directory:", settings.Dir)
+	f.Println("Base URL: ", settings.BaseURL)
+}
+
+func (f *frontendCLI) enableLargeAttachments(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	settings, err := f.bridge.GetLargeAttachments(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get large attachment settings:", err)
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	dir := f.readStringInAttempts("Directory to store large attachments in", c.ReadLine, isNotEmpty)
+	if dir == "" {
+		f.printAndLogError(errors.New("failed to get large attachment directory"))
+		return
+	}
+
+	baseURL := f.readStringInAttempts("URL under which the directory is served", c.ReadLine, isNotEmpty)
+	if baseURL == "" {
+		f.printAndLogError(errors.New("failed to get large attachment base URL"))
+		return
+	}
+
+	isSize := func(size string) bool {
+		if n, err := strconv.Atoi(size); err != nil || n <= 0 {
+			f.Println("Input", size, "is not a positive number of megabytes.")
+			return false
+		}
+
+		return true
+	}
+
+	threshold := f.readStringInAttempts(
+		fmt.Sprintf("Send attachments above this size in MB as links (current %v)", settings.Threshold/megabyte),
+		c.ReadLine,
+		isSize,
+	)
+	if threshold == "" {
+		f.printAndLogError(errors.New("failed to get large attachment threshold"))
+		return
+	}
+
+	thresholdInt, err := strconv.Atoi(threshold)
+	if err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	settings.Enabled = true
+	settings.Dir = dir
+	settings.BaseURL = baseURL
+	settings.Threshold = int64(thresholdInt) * megabyte
+
+	if err := f.bridge.SetLargeAttachments(user.UserID, settings); err != nil {
+		f.printAndLogError("Cannot enable large attachments:", err)
+		return
+	}
+
+	f.Printf("Attachments of account %s above %v MB are now sent as links.\n", user.Username, thresholdInt)
+}
+
+func (f *frontendCLI) disableLargeAttachments(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	settings, err := f.bridge.GetLargeAttachments(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get large attachment settings:", err)
+		return
+	}
+
+	settings.Enabled = false
+
+	if err := f.bridge.SetLargeAttachments(user.UserID, settings); err != nil {
+		f.printAndLogError("Cannot disable large attachments:", err)
+		return
+	}
+
+	f.Printf("Attachments of account %s are always sent with the message. Messages with attachments over 25 MB are rejected.\n", user.Username)
+}
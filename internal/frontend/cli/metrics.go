@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) showMetrics(_ *ishell.Context) {
+	if !f.bridge.GetMetricsEnabled() {
+		f.Println("The metrics endpoint is disabled.")
+		return
+	}
+
+	f.Println("Metrics are served at", f.bridge.GetMetricsURL())
+}
+
+func (f *frontendCLI) enableMetrics(_ *ishell.Context) {
+	if err := f.bridge.SetMetricsEnabled(context.Background(), true); err != nil {
+		f.printAndLogError("Cannot enable the metrics endpoint:", err)
+		return
+	}
+
+	f.Println("Metrics are served at", f.bridge.GetMetricsURL())
+}
+
+func (f *frontendCLI) disableMetrics(_ *ishell.Context) {
+	if err := f.bridge.SetMetricsEnabled(context.Background(), false); err != nil {
+		f.printAndLogError("Cannot disable the metrics endpoint:", err)
+		return
+	}
+
+	f.Println("The metrics endpoint is disabled.")
+}
+
+func (f *frontendCLI) changeMetricsPort(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	newPort := f.readStringInAttempts(fmt.Sprintf("Set metrics port (current %v)", f.bridge.GetMetricsPort()), c.ReadLine, f.isPortFree)
+	if newPort == "" {
+		f.printAndLogError(errors.New("failed to get new port"))
+		return
+	}
+
+	newPortInt, err := strconv.Atoi(newPort)
+	if err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	if err := f.bridge.SetMetricsPort(newPortInt); err != nil {
+		f.printAndLogError(err)
+		return
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"strconv"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) showDesktopNotifications(_ *ishell.Context) {
+	settings := f.bridge.GetDesktopNotifications()
+
+	f.Printf("New mail:      %s\n", onOff(settings.NewMail))
+	f.Printf("Send failures: %s\n", onOff(settings.SendFailures))
+	f.Printf("Quiet hours:   %s\n", settings.QuietHours)
+
+	if len(settings.Rules) == 0 {
+		f.Println("No rules: only new messages of the inbox are notified.")
+		return
+	}
+
+	f.Println()
+
+	spacing := "%-4s %-30s %-30s %s\n"
+	f.Printf(bold(spacing), "#", "account", "mailbox", "notify")
+
+	for idx, rule := range settings.Rules {
+		account := "*"
+		if rule.UserID != "" {
+			account = rule.UserID
+			if info, err := f.bridge.GetUserInfo(rule.UserID); err == nil {
+				account = info.Username
+			}
+		}
+
+		mailbox := "*"
+		if rule.Mailbox != "" {
+			mailbox = rule.Mailbox
+		}
+
+		f.Printf(spacing, strconv.Itoa(idx), account, mailbox, onOff(rule.Notify))
+	}
+}
+
+func (f *frontendCLI) changeNewMailNotifications(c *ishell.Context) {
+	notify, ok := f.parseOnOff(c, "notifications newmail on")
+	if !ok {
+		return
+	}
+
+	f.changeDesktopNotifications(func(settings *desktopnotify.Settings) {
+		settings.NewMail = notify
+	})
+}
+
+func (f *frontendCLI) changeSendFailureNotifications(c *ishell.Context) {
+	notify, ok := f.parseOnOff(c, "notifications sendfailures on")
+	if !ok {
+		return
+	}
+
+	f.changeDesktopNotifications(func(settings *desktopnotify.Settings) {
+		settings.SendFailures = notify
+	})
+}
+
+func (f *frontendCLI) changeQuietHours(c *ishell.Context) {
+	var quietHours desktopnotify.QuietHours
+
+	switch {
+	case len(c.Args) == 1 && c.Args[0] == "off":
+
+	case len(c.Args) == 2:
+		start, err := desktopnotify.ParseTimeOfDay(c.Args[0])
+		if err != nil {
+			f.printAndLogError("Cannot change the quiet hours:", err)
+			return
+		}
+
+		end, err := desktopnotify.ParseTimeOfDay(c.Args[1])
+		if err != nil {
+			f.printAndLogError("Cannot change the quiet hours:", err)
+			return
+		}
+
+		quietHours = desktopnotify.QuietHours{Start: start, End: end}
+
+	default:
+		f.Println("Please choose a start and an end time, e.g. `notifications quiet 22:00 07:00`, or `off`.")
+		return
+	}
+
+	f.changeDesktopNotifications(func(settings *desktopnotify.Settings) {
+		settings.QuietHours = quietHours
+	})
+}
+
+func (f *frontendCLI) addDesktopNotificationRule(c *ishell.Context) {
+	if len(c.Args) != 2 && len(c.Args) != 3 {
+		f.Println("Please choose a mailbox (* for all), on or off, and optionally an account, e.g. `notifications rule Folders/Work on`.")
+		return
+	}
+
+	var rule desktopnotify.Rule
+
+	if c.Args[0] != "*" {
+		rule.Mailbox = c.Args[0]
+	}
+
+	switch c.Args[1] {
+	case "on":
+		rule.Notify = true
+
+	case "off":
+		rule.Notify = false
+
+	default:
+		f.Printf("Wrong value '%s'. Choose on or off.\n", bold(c.Args[1]))
+		return
+	}
+
+	if len(c.Args) == 3 {
+		user := f.getUserByIndexOrName(c.Args[2])
+		if user.UserID == "" {
+			f.Printf("Account '%s' does not exist.\n", bold(c.Args[2]))
+			return
+		}
+
+		rule.UserID = user.UserID
+	}
+
+	f.changeDesktopNotifications(func(settings *desktopnotify.Settings) {
+		settings.Rules = append(settings.Rules, rule)
+	})
+}
+
+func (f *frontendCLI) removeDesktopNotificationRule(c *ishell.Context) {
+	rules := f.bridge.GetDesktopNotifications().Rules
+
+	if len(c.Args) != 1 {
+		f.Println("Please choose the number of the rule to remove, as listed by `notifications show`.")
+		return
+	}
+
+	idx, err := strconv.Atoi(c.Args[0])
+	if err != nil || idx < 0 || idx >= len(rules) {
+		f.Printf("Rule '%s' does not exist.\n", bold(c.Args[0]))
+		return
+	}
+
+	f.changeDesktopNotifications(func(settings *desktopnotify.Settings) {
+		settings.Rules = append(settings.Rules[:idx], settings.Rules[idx+1:]...)
+	})
+}
+
+func (f *frontendCLI) changeDesktopNotifications(change func(*desktopnotify.Settings)) {
+	settings := f.bridge.GetDesktopNotifications()
+
+	change(&settings)
+
+	if err := f.bridge.SetDesktopNotifications(settings); err != nil {
+		f.printAndLogError("Cannot change the desktop notifications:", err)
+		return
+	}
+
+	f.showDesktopNotifications(nil)
+}
+
+func (f *frontendCLI) parseOnOff(c *ishell.Context, example string) (bool, bool) {
+	if len(c.Args) == 1 {
+		switch c.Args[0] {
+		case "on":
+			return true, true
+
+		case "off":
+			return false, true
+		}
+	}
+
+	f.Printf("Please choose on or off, e.g. `%s`.\n", example)
+
+	return false, false
+}
+
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+
+	return "off"
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+	"github.com/abiosoft/ishell"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+func (f *frontendCLI) showAPIRateLimits(_ *ishell.Context) {
+	limits := f.bridge.GetAPIRateLimits()
+
+	spacing := "%-8s %s\n"
+	f.Printf(bold(spacing), "class", "limit")
+
+	for _, class := range ratelimit.Classes {
+		f.Printf(spacing, class, limits[class])
+	}
+
+	status := f.bridge.GetAPIRateLimitStatus()
+
+	userIDs := make([]string, 0, len(status))
+	for userID := range status {
+		userIDs = append(userIDs, userID)
+	}
+
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		name := userID
+		if info, err := f.bridge.GetUserInfo(userID); err == nil {
+			name = info.Username
+		}
+
+		f.Println()
+		f.Println(bold(name))
+
+		spacing := "%-8s %-8s %-10s %-10s %s\n"
+		f.Printf(bold(spacing), "class", "tokens", "allowed", "throttled", "last throttled")
+
+		for _, classStatus := range status[userID] {
+			var lastThrottled string
+			if !classStatus.LastThrottled.IsZero() {
+				lastThrottled = classStatus.LastThrottled.Local().Format(time.DateTime)
+			}
+
+			tokens := "-"
+			if !classStatus.Limit.Unlimited() {
+				tokens = strconv.Itoa(classStatus.Tokens)
+			}
+
+			f.Printf(spacing, classStatus.Class, tokens, strconv.FormatUint(classStatus.Allowed, 10), strconv.FormatUint(classStatus.Throttled, 10), lastThrottled)
+		}
+	}
+
+	f.Println()
+}
+
+func (f *frontendCLI) changeAPIRateLimit(c *ishell.Context) {
+	if len(c.Args) != 2 && len(c.Args) != 3 {
+		f.Println("Please choose a class, a rate per minute and a burst, e.g. `ratelimit set send 20 10`. Use a rate of 0 for unlimited and `default` to reset it.")
+		return
+	}
+
+	class := ratelimit.Class(c.Args[0])
+
+	switch {
+	case len(c.Args) == 2 && c.Args[1] == "default":
+		if err := f.bridge.ResetAPIRateLimit(class); err != nil {
+			f.printAndLogError("Cannot reset the rate limit:", err)
+			return
+		}
+
+	case len(c.Args) == 3:
+		perMinute, err := strconv.Atoi(c.Args[1])
+		if err != nil {
+			f.Printf("Wrong rate '%s'. Choose a number of requests per minute.\n", bold(c.Args[1]))
+			return
+		}
+
+		burst, err := strconv.Atoi(c.Args[2])
+		if err != nil {
+			f.Printf("Wrong burst '%s'. Choose a number of requests.\n", bold(c.Args[2]))
+			return
+		}
+
+		if err := f.bridge.SetAPIRateLimit(class, ratelimit.Limit{PerMinute: perMinute, Burst: burst}); err != nil {
+			f.printAndLogError("Cannot change the rate limit:", err)
+			return
+		}
+
+	default:
+		f.Printf("Wrong rate '%s'. Choose a number of requests per minute and a burst, or `default`.\n", bold(c.Args[1]))
+		return
+	}
+
+	f.Printf("The %s rate limit is now %s.\n", bold(class), f.bridge.GetAPIRateLimits()[class])
+}
+
+func (f *frontendCLI) completeRateLimitClasses(args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+
+	return xslices.Map(ratelimit.Classes, func(class ratelimit.Class) string {
+		return string(class)
+	})
+}
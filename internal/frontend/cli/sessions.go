@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapsmtpserver"
+	"github.com/abiosoft/ishell"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+// defaultBlockDuration is how long a client type is blocked when no duration is given.
+const defaultBlockDuration = time.Hour
+
+func (f *frontendCLI) listIMAPSessions(_ *ishell.Context) {
+	sessions := f.bridge.GetIMAPSessions()
+	if len(sessions) == 0 {
+		f.Println("No open IMAP sessions.")
+		return
+	}
+
+	spacing := "%-4d %-20s %-30s %-12s %-22s %-16s %-9s %10s %10s\n"
+	f.Printf(bold(strings.ReplaceAll(spacing, "d", "s")), "#", "account", "client", "type", "remote", "mailbox", "connected", "received", "sent")
+
+	for _, session := range sessions {
+		account := session.UserID
+		if user, err := f.bridge.GetUserInfo(session.UserID); err == nil {
+			account = user.Username
+		}
+
+		f.Printf(spacing,
+			session.ID,
+			account,
+			session.Client,
+			session.ClientType,
+			session.Remote,
+			session.Mailbox,
+			time.Since(session.ConnectedAt).Round(time.Second),
+			formatBytes(session.BytesReceived),
+			formatBytes(session.BytesSent),
+		)
+	}
+
+	f.Println()
+}
+
+func (f *frontendCLI) closeIMAPSession(c *ishell.Context) {
+	if len(c.Args) != 1 {
+		f.Println("Please choose the number of the session to close. Use `sessions list` to see them.")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Args[0])
+	if err != nil {
+		f.Printf("Wrong input '%s'. Choose the number of the session to close.\n", bold(c.Args[0]))
+		return
+	}
+
+	if err := f.bridge.CloseIMAPSession(sessionID); err != nil {
+		f.printAndLogError("Cannot close the session:", err)
+		return
+	}
+
+	f.Printf("Session %d was closed.\n", sessionID)
+}
+
+func (f *frontendCLI) blockIMAPClient(c *ishell.Context) {
+	if len(c.Args) < 1 || len(c.Args) > 2 {
+		f.Println("Please choose a client type and optionally a duration, e.g. `sessions block outlook 30m`.")
+		return
+	}
+
+	duration := defaultBlockDuration
+
+	if len(c.Args) == 2 {
+		var err error
+
+		if duration, err = time.ParseDuration(c.Args[1]); err != nil {
+			f.Printf("Wrong duration '%s'. Use a duration like 30m or 2h.\n", bold(c.Args[1]))
+			return
+		}
+	}
+
+	if err := f.bridge.BlockIMAPClient(connectionlimiter.Client(c.Args[0]), duration); err != nil {
+		f.printAndLogError("Cannot block the client:", err)
+		return
+	}
+
+	f.Printf("IMAP sessions of %s clients are refused until %s.\n", bold(c.Args[0]), time.Now().Add(duration).Format(time.DateTime))
+}
+
+func (f *frontendCLI) unblockIMAPClient(c *ishell.Context) {
+	if len(c.Args) != 1 {
+		f.Println("Please choose a client type to unblock.")
+		return
+	}
+
+	if err := f.bridge.UnblockIMAPClient(connectionlimiter.Client(c.Args[0])); err != nil {
+		f.printAndLogError("Cannot unblock the client:", err)
+		return
+	}
+
+	f.Printf("IMAP sessions of %s clients are accepted again.\n", bold(c.Args[0]))
+}
+
+func (f *frontendCLI) showIMAPClientLimits(_ *ishell.Context) {
+	limits := f.bridge.GetIMAPClientLimits()
+	blocked := f.bridge.GetBlockedIMAPClients()
+
+	spacing := "%-12s %-10s %s\n"
+	f.Printf(bold(spacing), "type", "limit", "blocked until")
+
+	for _, clientType := range imapsmtpserver.ClientTypes {
+		limit := "unlimited"
+		if limits[clientType] > 0 {
+			limit = strconv.Itoa(limits[clientType])
+		}
+
+		var until string
+		if t, ok := blocked[clientType]; ok {
+			until = t.Format(time.DateTime)
+		}
+
+		f.Printf(spacing, clientType, limit, until)
+	}
+
+	f.Println()
+}
+
+func (f *frontendCLI) changeIMAPClientLimit(c *ishell.Context) {
+	if len(c.Args) != 2 {
+		f.Println("Please choose a client type and a limit, e.g. `sessions limit apple-mail 20`. Use 0 for unlimited and `default` to reset it.")
+		return
+	}
+
+	clientType := connectionlimiter.Client(c.Args[0])
+
+	if c.Args[1] == "default" {
+		if err := f.bridge.ResetIMAPClientLimit(clientType); err != nil {
+			f.printAndLogError("Cannot reset the limit:", err)
+			return
+		}
+	} else {
+		limit, err := strconv.Atoi(c.Args[1])
+		if err != nil {
+			f.Printf("Wrong limit '%s'. Choose a number of sessions.\n", bold(c.Args[1]))
+			return
+		}
+
+		if err := f.bridge.SetIMAPClientLimit(clientType, limit); err != nil {
+			f.printAndLogError("Cannot change the limit:", err)
+			return
+		}
+	}
+
+	f.Println("The new limit applies after bridge restarts.")
+}
+
+func (f *frontendCLI) completeClientTypes(args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+
+	return xslices.Map(imapsmtpserver.ClientTypes, func(clientType connectionlimiter.Client) string {
+		return string(clientType)
+	})
+}
+
+// formatBytes returns the given number of bytes in a human-readable form.
+func formatBytes(n uint64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := n / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
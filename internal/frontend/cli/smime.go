@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+	"github.com/abiosoft/ishell"
+)
+
+func (f *frontendCLI) listSMIMEIdentities(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	ids, err := f.bridge.GetSMIMEIdentities(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get S/MIME certificates:", err)
+		return
+	}
+
+	if len(ids) == 0 {
+		f.Println("No S/MIME certificate imported.")
+		return
+	}
+
+	for _, id := range ids {
+		parsed, err := smime.ParseIdentity(id.Certificate, id.Key)
+		if err != nil {
+			f.Printf("%-40s %s\n", id.Email, bold("invalid: "+err.Error()))
+			continue
+		}
+
+		cert := parsed.Certificate()
+
+		f.Printf("%-40s issued by %s, expires %s\n", id.Email, cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))
+	}
+}
+
+func (f *frontendCLI) importSMIMEIdentity(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	readPath := func(idx int, title string) string {
+		if len(c.Args) > idx {
+			return c.Args[idx]
+		}
+
+		return f.readStringInAttempts(title, c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	certPath := readPath(1, "Path of the PEM certificate chain")
+	if certPath == "" {
+		return
+	}
+
+	keyPath := readPath(2, "Path of the PEM private key")
+	if keyPath == "" {
+		return
+	}
+
+	certPEM, err := os.ReadFile(certPath) //nolint:gosec
+	if err != nil {
+		f.printAndLogError("Cannot read certificate:", err)
+		return
+	}
+
+	keyPEM, err := os.ReadFile(keyPath) //nolint:gosec
+	if err != nil {
+		f.printAndLogError("Cannot read private key:", err)
+		return
+	}
+
+	emails, err := f.bridge.ImportSMIMEIdentity(user.UserID, certPEM, keyPEM)
+	if err != nil {
+		f.printAndLogError("Cannot import S/MIME certificate:", err)
+		return
+	}
+
+	f.Printf("S/MIME certificate imported for %s.\n", strings.Join(emails, ", "))
+	f.Println("Mail to the recipients added with `smime add-recipient` will be signed with it.")
+}
+
+func (f *frontendCLI) removeSMIMEIdentity(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Address whose certificate to remove", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	if email == "" {
+		return
+	}
+
+	if err := f.bridge.RemoveSMIMEIdentity(user.UserID, email); err != nil {
+		f.printAndLogError("Cannot remove S/MIME certificate:", err)
+		return
+	}
+
+	f.Println("S/MIME certificate removed.")
+}
+
+func (f *frontendCLI) listSMIMERecipients(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	recipients, err := f.bridge.GetSMIMERecipients(user.UserID)
+	if err != nil {
+		f.printAndLogError("Cannot get S/MIME recipients:", err)
+		return
+	}
+
+	if len(recipients) == 0 {
+		f.Println("No recipient gets S/MIME signed mail.")
+		return
+	}
+
+	for _, recipient := range recipients {
+		f.Println(recipient)
+	}
+}
+
+func (f *frontendCLI) addSMIMERecipient(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Address of the recipient", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	if email == "" {
+		return
+	}
+
+	if err := f.bridge.AddSMIMERecipient(user.UserID, email); err != nil {
+		f.printAndLogError("Cannot add S/MIME recipient:", err)
+		return
+	}
+
+	f.Printf("Mail to %s will be signed with S/MIME.\n", email)
+}
+
+func (f *frontendCLI) removeSMIMERecipient(c *ishell.Context) {
+	user := f.askUserByIndexOrName(c)
+	if user.UserID == "" {
+		return
+	}
+
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	email := ""
+	if len(c.Args) > 1 {
+		email = c.Args[1]
+	} else {
+		email = f.readStringInAttempts("Address of the recipient", c.ReadLine, func(val string) bool { return strings.TrimSpace(val) != "" })
+	}
+
+	if email == "" {
+		return
+	}
+
+	if err := f.bridge.RemoveSMIMERecipient(user.UserID, email); err != nil {
+		f.printAndLogError("Cannot remove S/MIME recipient:", err)
+		return
+	}
+
+	f.Printf("Mail to %s will no longer be signed with S/MIME.\n", email)
+}
@@ -23,11 +23,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
 	"github.com/ProtonMail/proton-bridge/v3/internal/certs"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
 	"github.com/abiosoft/ishell"
 )
@@ -40,6 +43,65 @@ func (f *frontendCLI) printLogDir(_ *ishell.Context) {
 	}
 }
 
+func (f *frontendCLI) showLogLevels(_ *ishell.Context) {
+	f.Println("Log levels:", logging.GetLevels())
+}
+
+func (f *frontendCLI) changeLogLevels(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	spec := strings.Join(c.Args, ",")
+	if spec == "" {
+		spec = f.readStringInAttempts(
+			fmt.Sprintf("Set log levels, e.g. info,smtp=debug,sync=warn (current %v)", logging.GetLevels()),
+			c.ReadLine,
+			f.isValidLogLevels,
+		)
+		if spec == "" {
+			f.printAndLogError(errors.New("failed to get new log levels"))
+			return
+		}
+	}
+
+	if err := logging.SetLevels(spec); err != nil {
+		f.printAndLogError("Cannot change log levels:", err)
+		return
+	}
+
+	f.Println("Log levels:", logging.GetLevels())
+}
+
+func (f *frontendCLI) isValidLogLevels(spec string) bool {
+	if _, err := logging.ParseLevels(spec); err != nil {
+		f.Println(err)
+		return false
+	}
+
+	return true
+}
+
+func (f *frontendCLI) printLockedSettings(_ *ishell.Context) {
+	locked := f.bridge.GetLockedSettings()
+	if len(locked) == 0 {
+		f.Println("No setting is locked by the system policy.")
+		return
+	}
+
+	f.Println("Settings locked by the system policy:", strings.Join(locked, ", "))
+}
+
+// isSettingLocked tells the user when the given setting can't be changed because the system policy locks it.
+func (f *frontendCLI) isSettingLocked(name string) bool {
+	if !f.bridge.IsSettingLocked(name) {
+		return false
+	}
+
+	f.Println("This setting is locked by the system policy and can't be changed.")
+
+	return true
+}
+
 func (f *frontendCLI) printManual(_ *ishell.Context) {
 	f.Println("More instructions about the Bridge can be found at\n\n  https://proton.me/mail/bridge")
 }
@@ -51,6 +113,10 @@ func (f *frontendCLI) printCredits(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) changeIMAPSecurity(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingIMAPSSL) {
+		return
+	}
+
 	f.ShowPrompt(false)
 	defer f.ShowPrompt(true)
 
@@ -70,6 +136,10 @@ func (f *frontendCLI) changeIMAPSecurity(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) changeSMTPSecurity(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingSMTPSSL) {
+		return
+	}
+
 	f.ShowPrompt(false)
 	defer f.ShowPrompt(true)
 
@@ -89,6 +159,10 @@ func (f *frontendCLI) changeSMTPSecurity(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) changeIMAPPort(c *ishell.Context) {
+	if f.isSettingLocked(policy.SettingIMAPPort) {
+		return
+	}
+
 	f.ShowPrompt(false)
 	defer f.ShowPrompt(true)
 
@@ -111,6 +185,10 @@ func (f *frontendCLI) changeIMAPPort(c *ishell.Context) {
 }
 
 func (f *frontendCLI) changeSMTPPort(c *ishell.Context) {
+	if f.isSettingLocked(policy.SettingSMTPPort) {
+		return
+	}
+
 	f.ShowPrompt(false)
 	defer f.ShowPrompt(true)
 
@@ -132,7 +210,55 @@ func (f *frontendCLI) changeSMTPPort(c *ishell.Context) {
 	}
 }
 
+func (f *frontendCLI) rotateVaultKey(c *ishell.Context) {
+	f.ShowPrompt(false)
+	defer f.ShowPrompt(true)
+
+	helper, err := f.bridge.GetKeychainApp()
+	if err != nil {
+		f.printAndLogError(err)
+		return
+	}
+
+	if len(c.Args) > 0 {
+		helper = c.Args[0]
+	}
+
+	if helper != "" && !slices.Contains(f.bridge.GetHelpersNames(), helper) {
+		f.Println("Unknown keychain. Available keychains are:", strings.Join(f.bridge.GetHelpersNames(), ", "))
+		return
+	}
+
+	msg := "Are you sure you want to rotate the vault encryption key"
+	if helper != "" {
+		msg += fmt.Sprintf(" and store it in keychain %q", helper)
+	}
+
+	if !f.yesNoQuestion(msg) {
+		return
+	}
+
+	if err := f.bridge.RotateVaultKey(helper); err != nil {
+		f.printAndLogError("Cannot rotate vault key:", err)
+		return
+	}
+
+	f.Println("The vault encryption key was rotated.")
+}
+
+func (f *frontendCLI) completeKeychains(args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+
+	return f.bridge.GetHelpersNames()
+}
+
 func (f *frontendCLI) allowProxy(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingProxyAllowed) {
+		return
+	}
+
 	if f.bridge.GetProxyAllowed() {
 		f.Println("Bridge is already set to use alternative routing to connect to Proton if it is being blocked.")
 		return
@@ -149,6 +275,10 @@ func (f *frontendCLI) allowProxy(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) disallowProxy(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingProxyAllowed) {
+		return
+	}
+
 	if !f.bridge.GetProxyAllowed() {
 		f.Println("Bridge is already set to NOT use alternative routing to connect to Proton if it is being blocked.")
 		return
@@ -197,6 +327,10 @@ func (f *frontendCLI) showAllMail(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) enableTelemetry(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingTelemetryDisabled) {
+		return
+	}
+
 	if !f.bridge.GetTelemetryDisabled() {
 		f.Println("Usage diagnostics collection is enabled.")
 		return
@@ -213,6 +347,10 @@ func (f *frontendCLI) enableTelemetry(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) disableTelemetry(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingTelemetryDisabled) {
+		return
+	}
+
 	if f.bridge.GetTelemetryDisabled() {
 		f.Println("Usage diagnostics collection is disabled.")
 		return
@@ -229,6 +367,10 @@ func (f *frontendCLI) disableTelemetry(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) setGluonLocation(c *ishell.Context) {
+	if f.isSettingLocked(policy.SettingCacheDir) {
+		return
+	}
+
 	if gluonDir := f.bridge.GetGluonCacheDir(); gluonDir != "" {
 		f.Println("The current message cache location is:", gluonDir)
 	}
@@ -19,6 +19,7 @@ package cli
 
 import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/abiosoft/ishell"
 )
@@ -39,6 +40,10 @@ func (f *frontendCLI) checkUpdates(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) enableAutoUpdates(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingAutoUpdate) {
+		return
+	}
+
 	if f.bridge.GetAutoUpdate() {
 		f.Println("Bridge is already set to automatically install updates.")
 		return
@@ -55,6 +60,10 @@ func (f *frontendCLI) enableAutoUpdates(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) disableAutoUpdates(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingAutoUpdate) {
+		return
+	}
+
 	if !f.bridge.GetAutoUpdate() {
 		f.Println("Bridge is already set to NOT automatically install updates.")
 		return
@@ -71,6 +80,10 @@ func (f *frontendCLI) disableAutoUpdates(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) selectEarlyChannel(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingUpdateChannel) {
+		return
+	}
+
 	if f.bridge.GetUpdateChannel() == updater.EarlyChannel {
 		f.Println("Bridge is already on the early-access update channel.")
 		return
@@ -87,6 +100,10 @@ func (f *frontendCLI) selectEarlyChannel(_ *ishell.Context) {
 }
 
 func (f *frontendCLI) selectStableChannel(_ *ishell.Context) {
+	if f.isSettingLocked(policy.SettingUpdateChannel) {
+		return
+	}
+
 	if f.bridge.GetUpdateChannel() == updater.StableChannel {
 		f.Println("Bridge is already on the stable update channel.")
 		return
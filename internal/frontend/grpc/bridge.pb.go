@@ -813,6 +813,210 @@ func (x *ImapSmtpSettings) GetUseSSLForSmtp() bool {
 	return false
 }
 
+type ImapSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"` // empty until the client logs in.
+	Client        string                 `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	ClientType    string                 `protobuf:"bytes,4,opt,name=clientType,proto3" json:"clientType,omitempty"` // "apple-mail", "outlook", "thunderbird" or "unknown".
+	RemoteAddress string                 `protobuf:"bytes,5,opt,name=remoteAddress,proto3" json:"remoteAddress,omitempty"`
+	Mailbox       string                 `protobuf:"bytes,6,opt,name=mailbox,proto3" json:"mailbox,omitempty"`
+	ConnectedAt   int64                  `protobuf:"varint,7,opt,name=connectedAt,proto3" json:"connectedAt,omitempty"` // Unix time in seconds.
+	BytesReceived uint64                 `protobuf:"varint,8,opt,name=bytesReceived,proto3" json:"bytesReceived,omitempty"`
+	BytesSent     uint64                 `protobuf:"varint,9,opt,name=bytesSent,proto3" json:"bytesSent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImapSession) Reset() {
+	*x = ImapSession{}
+	mi := &file_bridge_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImapSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImapSession) ProtoMessage() {}
+
+func (x *ImapSession) ProtoReflect() protoreflect.Message {
+	mi := &file_bridge_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImapSession.ProtoReflect.Descriptor instead.
+func (*ImapSession) Descriptor() ([]byte, []int) {
+	return file_bridge_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImapSession) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ImapSession) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ImapSession) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *ImapSession) GetClientType() string {
+	if x != nil {
+		return x.ClientType
+	}
+	return ""
+}
+
+func (x *ImapSession) GetRemoteAddress() string {
+	if x != nil {
+		return x.RemoteAddress
+	}
+	return ""
+}
+
+func (x *ImapSession) GetMailbox() string {
+	if x != nil {
+		return x.Mailbox
+	}
+	return ""
+}
+
+func (x *ImapSession) GetConnectedAt() int64 {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return 0
+}
+
+func (x *ImapSession) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *ImapSession) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+type ImapSessionListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*ImapSession         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImapSessionListResponse) Reset() {
+	*x = ImapSessionListResponse{}
+	mi := &file_bridge_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImapSessionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImapSessionListResponse) ProtoMessage() {}
+
+func (x *ImapSessionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bridge_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImapSessionListResponse.ProtoReflect.Descriptor instead.
+func (*ImapSessionListResponse) Descriptor() ([]byte, []int) {
+	return file_bridge_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImapSessionListResponse) GetSessions() []*ImapSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type BlockImapClientRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ClientType      string                 `protobuf:"bytes,1,opt,name=clientType,proto3" json:"clientType,omitempty"`
+	DurationMinutes int32                  `protobuf:"varint,2,opt,name=durationMinutes,proto3" json:"durationMinutes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BlockImapClientRequest) Reset() {
+	*x = BlockImapClientRequest{}
+	mi := &file_bridge_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockImapClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockImapClientRequest) ProtoMessage() {}
+
+func (x *BlockImapClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bridge_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockImapClientRequest.ProtoReflect.Descriptor instead.
+func (*BlockImapClientRequest) Descriptor() ([]byte, []int) {
+	return file_bridge_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockImapClientRequest) GetClientType() string {
+	if x != nil {
+		return x.ClientType
+	}
+	return ""
+}
+
+func (x *BlockImapClientRequest) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
 // **********************************************************
 // Keychain related message
 // **********************************************************
@@ -825,7 +1029,7 @@ type AvailableKeychainsResponse struct {
 
 func (x *AvailableKeychainsResponse) Reset() {
 	*x = AvailableKeychainsResponse{}
-	mi := &file_bridge_proto_msgTypes[6]
+	mi := &file_bridge_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -837,7 +1041,7 @@ func (x *AvailableKeychainsResponse) String() string {
 func (*AvailableKeychainsResponse) ProtoMessage() {}
 
 func (x *AvailableKeychainsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[6]
+	mi := &file_bridge_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,7 +1054,7 @@ func (x *AvailableKeychainsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AvailableKeychainsResponse.ProtoReflect.Descriptor instead.
 func (*AvailableKeychainsResponse) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{6}
+	return file_bridge_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AvailableKeychainsResponse) GetKeychains() []string {
@@ -877,7 +1081,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_bridge_proto_msgTypes[7]
+	mi := &file_bridge_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1093,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[7]
+	mi := &file_bridge_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1106,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{7}
+	return file_bridge_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *User) GetId() string {
@@ -978,7 +1182,7 @@ type UserSplitModeRequest struct {
 
 func (x *UserSplitModeRequest) Reset() {
 	*x = UserSplitModeRequest{}
-	mi := &file_bridge_proto_msgTypes[8]
+	mi := &file_bridge_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -990,7 +1194,7 @@ func (x *UserSplitModeRequest) String() string {
 func (*UserSplitModeRequest) ProtoMessage() {}
 
 func (x *UserSplitModeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[8]
+	mi := &file_bridge_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1207,7 @@ func (x *UserSplitModeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserSplitModeRequest.ProtoReflect.Descriptor instead.
 func (*UserSplitModeRequest) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{8}
+	return file_bridge_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UserSplitModeRequest) GetUserID() string {
@@ -1030,7 +1234,7 @@ type UserBadEventFeedbackRequest struct {
 
 func (x *UserBadEventFeedbackRequest) Reset() {
 	*x = UserBadEventFeedbackRequest{}
-	mi := &file_bridge_proto_msgTypes[9]
+	mi := &file_bridge_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1042,7 +1246,7 @@ func (x *UserBadEventFeedbackRequest) String() string {
 func (*UserBadEventFeedbackRequest) ProtoMessage() {}
 
 func (x *UserBadEventFeedbackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[9]
+	mi := &file_bridge_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1259,7 @@ func (x *UserBadEventFeedbackRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserBadEventFeedbackRequest.ProtoReflect.Descriptor instead.
 func (*UserBadEventFeedbackRequest) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{9}
+	return file_bridge_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UserBadEventFeedbackRequest) GetUserID() string {
@@ -1081,7 +1285,7 @@ type UserListResponse struct {
 
 func (x *UserListResponse) Reset() {
 	*x = UserListResponse{}
-	mi := &file_bridge_proto_msgTypes[10]
+	mi := &file_bridge_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1093,7 +1297,7 @@ func (x *UserListResponse) String() string {
 func (*UserListResponse) ProtoMessage() {}
 
 func (x *UserListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[10]
+	mi := &file_bridge_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,7 +1310,7 @@ func (x *UserListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserListResponse.ProtoReflect.Descriptor instead.
 func (*UserListResponse) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{10}
+	return file_bridge_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UserListResponse) GetUsers() []*User {
@@ -1126,7 +1330,7 @@ type ConfigureAppleMailRequest struct {
 
 func (x *ConfigureAppleMailRequest) Reset() {
 	*x = ConfigureAppleMailRequest{}
-	mi := &file_bridge_proto_msgTypes[11]
+	mi := &file_bridge_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1138,7 +1342,7 @@ func (x *ConfigureAppleMailRequest) String() string {
 func (*ConfigureAppleMailRequest) ProtoMessage() {}
 
 func (x *ConfigureAppleMailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[11]
+	mi := &file_bridge_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1151,7 +1355,7 @@ func (x *ConfigureAppleMailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureAppleMailRequest.ProtoReflect.Descriptor instead.
 func (*ConfigureAppleMailRequest) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{11}
+	return file_bridge_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ConfigureAppleMailRequest) GetUserID() string {
@@ -1177,7 +1381,7 @@ type EventStreamRequest struct {
 
 func (x *EventStreamRequest) Reset() {
 	*x = EventStreamRequest{}
-	mi := &file_bridge_proto_msgTypes[12]
+	mi := &file_bridge_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1189,7 +1393,7 @@ func (x *EventStreamRequest) String() string {
 func (*EventStreamRequest) ProtoMessage() {}
 
 func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[12]
+	mi := &file_bridge_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1202,7 +1406,7 @@ func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventStreamRequest.ProtoReflect.Descriptor instead.
 func (*EventStreamRequest) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{12}
+	return file_bridge_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *EventStreamRequest) GetClientPlatform() string {
@@ -1232,7 +1436,7 @@ type StreamEvent struct {
 
 func (x *StreamEvent) Reset() {
 	*x = StreamEvent{}
-	mi := &file_bridge_proto_msgTypes[13]
+	mi := &file_bridge_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1244,7 +1448,7 @@ func (x *StreamEvent) String() string {
 func (*StreamEvent) ProtoMessage() {}
 
 func (x *StreamEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[13]
+	mi := &file_bridge_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,7 +1461,7 @@ func (x *StreamEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamEvent.ProtoReflect.Descriptor instead.
 func (*StreamEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{13}
+	return file_bridge_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *StreamEvent) GetEvent() isStreamEvent_Event {
@@ -1435,7 +1639,7 @@ type AppEvent struct {
 
 func (x *AppEvent) Reset() {
 	*x = AppEvent{}
-	mi := &file_bridge_proto_msgTypes[14]
+	mi := &file_bridge_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1447,7 +1651,7 @@ func (x *AppEvent) String() string {
 func (*AppEvent) ProtoMessage() {}
 
 func (x *AppEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[14]
+	mi := &file_bridge_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1460,7 +1664,7 @@ func (x *AppEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppEvent.ProtoReflect.Descriptor instead.
 func (*AppEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{14}
+	return file_bridge_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AppEvent) GetEvent() isAppEvent_Event {
@@ -1708,7 +1912,7 @@ type InternetStatusEvent struct {
 
 func (x *InternetStatusEvent) Reset() {
 	*x = InternetStatusEvent{}
-	mi := &file_bridge_proto_msgTypes[15]
+	mi := &file_bridge_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1720,7 +1924,7 @@ func (x *InternetStatusEvent) String() string {
 func (*InternetStatusEvent) ProtoMessage() {}
 
 func (x *InternetStatusEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[15]
+	mi := &file_bridge_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1733,7 +1937,7 @@ func (x *InternetStatusEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InternetStatusEvent.ProtoReflect.Descriptor instead.
 func (*InternetStatusEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{15}
+	return file_bridge_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *InternetStatusEvent) GetConnected() bool {
@@ -1751,7 +1955,7 @@ type ToggleAutostartFinishedEvent struct {
 
 func (x *ToggleAutostartFinishedEvent) Reset() {
 	*x = ToggleAutostartFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[16]
+	mi := &file_bridge_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1763,7 +1967,7 @@ func (x *ToggleAutostartFinishedEvent) String() string {
 func (*ToggleAutostartFinishedEvent) ProtoMessage() {}
 
 func (x *ToggleAutostartFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[16]
+	mi := &file_bridge_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1776,7 +1980,7 @@ func (x *ToggleAutostartFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleAutostartFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ToggleAutostartFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{16}
+	return file_bridge_proto_rawDescGZIP(), []int{19}
 }
 
 type ResetFinishedEvent struct {
@@ -1787,7 +1991,7 @@ type ResetFinishedEvent struct {
 
 func (x *ResetFinishedEvent) Reset() {
 	*x = ResetFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[17]
+	mi := &file_bridge_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1799,7 +2003,7 @@ func (x *ResetFinishedEvent) String() string {
 func (*ResetFinishedEvent) ProtoMessage() {}
 
 func (x *ResetFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[17]
+	mi := &file_bridge_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1812,7 +2016,7 @@ func (x *ResetFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ResetFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{17}
+	return file_bridge_proto_rawDescGZIP(), []int{20}
 }
 
 type ReportBugFinishedEvent struct {
@@ -1823,7 +2027,7 @@ type ReportBugFinishedEvent struct {
 
 func (x *ReportBugFinishedEvent) Reset() {
 	*x = ReportBugFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[18]
+	mi := &file_bridge_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1835,7 +2039,7 @@ func (x *ReportBugFinishedEvent) String() string {
 func (*ReportBugFinishedEvent) ProtoMessage() {}
 
 func (x *ReportBugFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[18]
+	mi := &file_bridge_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,7 +2052,7 @@ func (x *ReportBugFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBugFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ReportBugFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{18}
+	return file_bridge_proto_rawDescGZIP(), []int{21}
 }
 
 type ReportBugSuccessEvent struct {
@@ -1859,7 +2063,7 @@ type ReportBugSuccessEvent struct {
 
 func (x *ReportBugSuccessEvent) Reset() {
 	*x = ReportBugSuccessEvent{}
-	mi := &file_bridge_proto_msgTypes[19]
+	mi := &file_bridge_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1871,7 +2075,7 @@ func (x *ReportBugSuccessEvent) String() string {
 func (*ReportBugSuccessEvent) ProtoMessage() {}
 
 func (x *ReportBugSuccessEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[19]
+	mi := &file_bridge_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1884,7 +2088,7 @@ func (x *ReportBugSuccessEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBugSuccessEvent.ProtoReflect.Descriptor instead.
 func (*ReportBugSuccessEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{19}
+	return file_bridge_proto_rawDescGZIP(), []int{22}
 }
 
 type ReportBugErrorEvent struct {
@@ -1895,7 +2099,7 @@ type ReportBugErrorEvent struct {
 
 func (x *ReportBugErrorEvent) Reset() {
 	*x = ReportBugErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[20]
+	mi := &file_bridge_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1907,7 +2111,7 @@ func (x *ReportBugErrorEvent) String() string {
 func (*ReportBugErrorEvent) ProtoMessage() {}
 
 func (x *ReportBugErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[20]
+	mi := &file_bridge_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1920,7 +2124,7 @@ func (x *ReportBugErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBugErrorEvent.ProtoReflect.Descriptor instead.
 func (*ReportBugErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{20}
+	return file_bridge_proto_rawDescGZIP(), []int{23}
 }
 
 type ShowMainWindowEvent struct {
@@ -1931,7 +2135,7 @@ type ShowMainWindowEvent struct {
 
 func (x *ShowMainWindowEvent) Reset() {
 	*x = ShowMainWindowEvent{}
-	mi := &file_bridge_proto_msgTypes[21]
+	mi := &file_bridge_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1943,7 +2147,7 @@ func (x *ShowMainWindowEvent) String() string {
 func (*ShowMainWindowEvent) ProtoMessage() {}
 
 func (x *ShowMainWindowEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[21]
+	mi := &file_bridge_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1956,7 +2160,7 @@ func (x *ShowMainWindowEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShowMainWindowEvent.ProtoReflect.Descriptor instead.
 func (*ShowMainWindowEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{21}
+	return file_bridge_proto_rawDescGZIP(), []int{24}
 }
 
 type ReportBugFallbackEvent struct {
@@ -1967,7 +2171,7 @@ type ReportBugFallbackEvent struct {
 
 func (x *ReportBugFallbackEvent) Reset() {
 	*x = ReportBugFallbackEvent{}
-	mi := &file_bridge_proto_msgTypes[22]
+	mi := &file_bridge_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1979,7 +2183,7 @@ func (x *ReportBugFallbackEvent) String() string {
 func (*ReportBugFallbackEvent) ProtoMessage() {}
 
 func (x *ReportBugFallbackEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[22]
+	mi := &file_bridge_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1992,7 +2196,7 @@ func (x *ReportBugFallbackEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportBugFallbackEvent.ProtoReflect.Descriptor instead.
 func (*ReportBugFallbackEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{22}
+	return file_bridge_proto_rawDescGZIP(), []int{25}
 }
 
 type CertificateInstallSuccessEvent struct {
@@ -2003,7 +2207,7 @@ type CertificateInstallSuccessEvent struct {
 
 func (x *CertificateInstallSuccessEvent) Reset() {
 	*x = CertificateInstallSuccessEvent{}
-	mi := &file_bridge_proto_msgTypes[23]
+	mi := &file_bridge_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2015,7 +2219,7 @@ func (x *CertificateInstallSuccessEvent) String() string {
 func (*CertificateInstallSuccessEvent) ProtoMessage() {}
 
 func (x *CertificateInstallSuccessEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[23]
+	mi := &file_bridge_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2028,7 +2232,7 @@ func (x *CertificateInstallSuccessEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateInstallSuccessEvent.ProtoReflect.Descriptor instead.
 func (*CertificateInstallSuccessEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{23}
+	return file_bridge_proto_rawDescGZIP(), []int{26}
 }
 
 type CertificateInstallCanceledEvent struct {
@@ -2039,7 +2243,7 @@ type CertificateInstallCanceledEvent struct {
 
 func (x *CertificateInstallCanceledEvent) Reset() {
 	*x = CertificateInstallCanceledEvent{}
-	mi := &file_bridge_proto_msgTypes[24]
+	mi := &file_bridge_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2051,7 +2255,7 @@ func (x *CertificateInstallCanceledEvent) String() string {
 func (*CertificateInstallCanceledEvent) ProtoMessage() {}
 
 func (x *CertificateInstallCanceledEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[24]
+	mi := &file_bridge_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2064,7 +2268,7 @@ func (x *CertificateInstallCanceledEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateInstallCanceledEvent.ProtoReflect.Descriptor instead.
 func (*CertificateInstallCanceledEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{24}
+	return file_bridge_proto_rawDescGZIP(), []int{27}
 }
 
 type CertificateInstallFailedEvent struct {
@@ -2075,7 +2279,7 @@ type CertificateInstallFailedEvent struct {
 
 func (x *CertificateInstallFailedEvent) Reset() {
 	*x = CertificateInstallFailedEvent{}
-	mi := &file_bridge_proto_msgTypes[25]
+	mi := &file_bridge_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2087,7 +2291,7 @@ func (x *CertificateInstallFailedEvent) String() string {
 func (*CertificateInstallFailedEvent) ProtoMessage() {}
 
 func (x *CertificateInstallFailedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[25]
+	mi := &file_bridge_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2100,7 +2304,7 @@ func (x *CertificateInstallFailedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateInstallFailedEvent.ProtoReflect.Descriptor instead.
 func (*CertificateInstallFailedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{25}
+	return file_bridge_proto_rawDescGZIP(), []int{28}
 }
 
 type RepairStartedEvent struct {
@@ -2111,7 +2315,7 @@ type RepairStartedEvent struct {
 
 func (x *RepairStartedEvent) Reset() {
 	*x = RepairStartedEvent{}
-	mi := &file_bridge_proto_msgTypes[26]
+	mi := &file_bridge_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2123,7 +2327,7 @@ func (x *RepairStartedEvent) String() string {
 func (*RepairStartedEvent) ProtoMessage() {}
 
 func (x *RepairStartedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[26]
+	mi := &file_bridge_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2136,7 +2340,7 @@ func (x *RepairStartedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepairStartedEvent.ProtoReflect.Descriptor instead.
 func (*RepairStartedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{26}
+	return file_bridge_proto_rawDescGZIP(), []int{29}
 }
 
 type AllUsersLoadedEvent struct {
@@ -2147,7 +2351,7 @@ type AllUsersLoadedEvent struct {
 
 func (x *AllUsersLoadedEvent) Reset() {
 	*x = AllUsersLoadedEvent{}
-	mi := &file_bridge_proto_msgTypes[27]
+	mi := &file_bridge_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2159,7 +2363,7 @@ func (x *AllUsersLoadedEvent) String() string {
 func (*AllUsersLoadedEvent) ProtoMessage() {}
 
 func (x *AllUsersLoadedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[27]
+	mi := &file_bridge_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2172,7 +2376,7 @@ func (x *AllUsersLoadedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllUsersLoadedEvent.ProtoReflect.Descriptor instead.
 func (*AllUsersLoadedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{27}
+	return file_bridge_proto_rawDescGZIP(), []int{30}
 }
 
 type KnowledgeBaseSuggestion struct {
@@ -2185,7 +2389,7 @@ type KnowledgeBaseSuggestion struct {
 
 func (x *KnowledgeBaseSuggestion) Reset() {
 	*x = KnowledgeBaseSuggestion{}
-	mi := &file_bridge_proto_msgTypes[28]
+	mi := &file_bridge_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2197,7 +2401,7 @@ func (x *KnowledgeBaseSuggestion) String() string {
 func (*KnowledgeBaseSuggestion) ProtoMessage() {}
 
 func (x *KnowledgeBaseSuggestion) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[28]
+	mi := &file_bridge_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2210,7 +2414,7 @@ func (x *KnowledgeBaseSuggestion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KnowledgeBaseSuggestion.ProtoReflect.Descriptor instead.
 func (*KnowledgeBaseSuggestion) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{28}
+	return file_bridge_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *KnowledgeBaseSuggestion) GetUrl() string {
@@ -2236,7 +2440,7 @@ type KnowledgeBaseSuggestionsEvent struct {
 
 func (x *KnowledgeBaseSuggestionsEvent) Reset() {
 	*x = KnowledgeBaseSuggestionsEvent{}
-	mi := &file_bridge_proto_msgTypes[29]
+	mi := &file_bridge_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2248,7 +2452,7 @@ func (x *KnowledgeBaseSuggestionsEvent) String() string {
 func (*KnowledgeBaseSuggestionsEvent) ProtoMessage() {}
 
 func (x *KnowledgeBaseSuggestionsEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[29]
+	mi := &file_bridge_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2261,7 +2465,7 @@ func (x *KnowledgeBaseSuggestionsEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KnowledgeBaseSuggestionsEvent.ProtoReflect.Descriptor instead.
 func (*KnowledgeBaseSuggestionsEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{29}
+	return file_bridge_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *KnowledgeBaseSuggestionsEvent) GetSuggestions() []*KnowledgeBaseSuggestion {
@@ -2296,7 +2500,7 @@ type LoginEvent struct {
 
 func (x *LoginEvent) Reset() {
 	*x = LoginEvent{}
-	mi := &file_bridge_proto_msgTypes[30]
+	mi := &file_bridge_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2308,7 +2512,7 @@ func (x *LoginEvent) String() string {
 func (*LoginEvent) ProtoMessage() {}
 
 func (x *LoginEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[30]
+	mi := &file_bridge_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2321,7 +2525,7 @@ func (x *LoginEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginEvent.ProtoReflect.Descriptor instead.
 func (*LoginEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{30}
+	return file_bridge_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *LoginEvent) GetEvent() isLoginEvent_Event {
@@ -2510,7 +2714,7 @@ type LoginErrorEvent struct {
 
 func (x *LoginErrorEvent) Reset() {
 	*x = LoginErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[31]
+	mi := &file_bridge_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2522,7 +2726,7 @@ func (x *LoginErrorEvent) String() string {
 func (*LoginErrorEvent) ProtoMessage() {}
 
 func (x *LoginErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[31]
+	mi := &file_bridge_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2535,7 +2739,7 @@ func (x *LoginErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginErrorEvent.ProtoReflect.Descriptor instead.
 func (*LoginErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{31}
+	return file_bridge_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *LoginErrorEvent) GetType() LoginErrorType {
@@ -2561,7 +2765,7 @@ type LoginTfaRequestedEvent struct {
 
 func (x *LoginTfaRequestedEvent) Reset() {
 	*x = LoginTfaRequestedEvent{}
-	mi := &file_bridge_proto_msgTypes[32]
+	mi := &file_bridge_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2573,7 +2777,7 @@ func (x *LoginTfaRequestedEvent) String() string {
 func (*LoginTfaRequestedEvent) ProtoMessage() {}
 
 func (x *LoginTfaRequestedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[32]
+	mi := &file_bridge_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2586,7 +2790,7 @@ func (x *LoginTfaRequestedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginTfaRequestedEvent.ProtoReflect.Descriptor instead.
 func (*LoginTfaRequestedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{32}
+	return file_bridge_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *LoginTfaRequestedEvent) GetUsername() string {
@@ -2605,7 +2809,7 @@ type LoginFidoRequestedEvent struct {
 
 func (x *LoginFidoRequestedEvent) Reset() {
 	*x = LoginFidoRequestedEvent{}
-	mi := &file_bridge_proto_msgTypes[33]
+	mi := &file_bridge_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2617,7 +2821,7 @@ func (x *LoginFidoRequestedEvent) String() string {
 func (*LoginFidoRequestedEvent) ProtoMessage() {}
 
 func (x *LoginFidoRequestedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[33]
+	mi := &file_bridge_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2630,7 +2834,7 @@ func (x *LoginFidoRequestedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginFidoRequestedEvent.ProtoReflect.Descriptor instead.
 func (*LoginFidoRequestedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{33}
+	return file_bridge_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *LoginFidoRequestedEvent) GetUsername() string {
@@ -2649,7 +2853,7 @@ type LoginTfaOrFidoRequestedEvent struct {
 
 func (x *LoginTfaOrFidoRequestedEvent) Reset() {
 	*x = LoginTfaOrFidoRequestedEvent{}
-	mi := &file_bridge_proto_msgTypes[34]
+	mi := &file_bridge_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2661,7 +2865,7 @@ func (x *LoginTfaOrFidoRequestedEvent) String() string {
 func (*LoginTfaOrFidoRequestedEvent) ProtoMessage() {}
 
 func (x *LoginTfaOrFidoRequestedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[34]
+	mi := &file_bridge_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2674,7 +2878,7 @@ func (x *LoginTfaOrFidoRequestedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginTfaOrFidoRequestedEvent.ProtoReflect.Descriptor instead.
 func (*LoginTfaOrFidoRequestedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{34}
+	return file_bridge_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *LoginTfaOrFidoRequestedEvent) GetUsername() string {
@@ -2693,7 +2897,7 @@ type LoginFidoTouchEvent struct {
 
 func (x *LoginFidoTouchEvent) Reset() {
 	*x = LoginFidoTouchEvent{}
-	mi := &file_bridge_proto_msgTypes[35]
+	mi := &file_bridge_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2705,7 +2909,7 @@ func (x *LoginFidoTouchEvent) String() string {
 func (*LoginFidoTouchEvent) ProtoMessage() {}
 
 func (x *LoginFidoTouchEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[35]
+	mi := &file_bridge_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2718,7 +2922,7 @@ func (x *LoginFidoTouchEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginFidoTouchEvent.ProtoReflect.Descriptor instead.
 func (*LoginFidoTouchEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{35}
+	return file_bridge_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *LoginFidoTouchEvent) GetUsername() string {
@@ -2737,7 +2941,7 @@ type LoginFidoPinRequired struct {
 
 func (x *LoginFidoPinRequired) Reset() {
 	*x = LoginFidoPinRequired{}
-	mi := &file_bridge_proto_msgTypes[36]
+	mi := &file_bridge_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2749,7 +2953,7 @@ func (x *LoginFidoPinRequired) String() string {
 func (*LoginFidoPinRequired) ProtoMessage() {}
 
 func (x *LoginFidoPinRequired) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[36]
+	mi := &file_bridge_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2762,7 +2966,7 @@ func (x *LoginFidoPinRequired) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginFidoPinRequired.ProtoReflect.Descriptor instead.
 func (*LoginFidoPinRequired) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{36}
+	return file_bridge_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *LoginFidoPinRequired) GetUsername() string {
@@ -2781,7 +2985,7 @@ type LoginTwoPasswordsRequestedEvent struct {
 
 func (x *LoginTwoPasswordsRequestedEvent) Reset() {
 	*x = LoginTwoPasswordsRequestedEvent{}
-	mi := &file_bridge_proto_msgTypes[37]
+	mi := &file_bridge_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2793,7 +2997,7 @@ func (x *LoginTwoPasswordsRequestedEvent) String() string {
 func (*LoginTwoPasswordsRequestedEvent) ProtoMessage() {}
 
 func (x *LoginTwoPasswordsRequestedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[37]
+	mi := &file_bridge_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2806,7 +3010,7 @@ func (x *LoginTwoPasswordsRequestedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginTwoPasswordsRequestedEvent.ProtoReflect.Descriptor instead.
 func (*LoginTwoPasswordsRequestedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{37}
+	return file_bridge_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *LoginTwoPasswordsRequestedEvent) GetUsername() string {
@@ -2826,7 +3030,7 @@ type LoginFinishedEvent struct {
 
 func (x *LoginFinishedEvent) Reset() {
 	*x = LoginFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[38]
+	mi := &file_bridge_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2838,7 +3042,7 @@ func (x *LoginFinishedEvent) String() string {
 func (*LoginFinishedEvent) ProtoMessage() {}
 
 func (x *LoginFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[38]
+	mi := &file_bridge_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2851,7 +3055,7 @@ func (x *LoginFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginFinishedEvent.ProtoReflect.Descriptor instead.
 func (*LoginFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{38}
+	return file_bridge_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *LoginFinishedEvent) GetUserID() string {
@@ -2877,7 +3081,7 @@ type LoginHvRequestedEvent struct {
 
 func (x *LoginHvRequestedEvent) Reset() {
 	*x = LoginHvRequestedEvent{}
-	mi := &file_bridge_proto_msgTypes[39]
+	mi := &file_bridge_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2889,7 +3093,7 @@ func (x *LoginHvRequestedEvent) String() string {
 func (*LoginHvRequestedEvent) ProtoMessage() {}
 
 func (x *LoginHvRequestedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[39]
+	mi := &file_bridge_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2902,7 +3106,7 @@ func (x *LoginHvRequestedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginHvRequestedEvent.ProtoReflect.Descriptor instead.
 func (*LoginHvRequestedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{39}
+	return file_bridge_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *LoginHvRequestedEvent) GetHvUrl() string {
@@ -2934,7 +3138,7 @@ type UpdateEvent struct {
 
 func (x *UpdateEvent) Reset() {
 	*x = UpdateEvent{}
-	mi := &file_bridge_proto_msgTypes[40]
+	mi := &file_bridge_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2946,7 +3150,7 @@ func (x *UpdateEvent) String() string {
 func (*UpdateEvent) ProtoMessage() {}
 
 func (x *UpdateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[40]
+	mi := &file_bridge_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2959,7 +3163,7 @@ func (x *UpdateEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEvent.ProtoReflect.Descriptor instead.
 func (*UpdateEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{40}
+	return file_bridge_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *UpdateEvent) GetEvent() isUpdateEvent_Event {
@@ -3102,7 +3306,7 @@ type UpdateErrorEvent struct {
 
 func (x *UpdateErrorEvent) Reset() {
 	*x = UpdateErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[41]
+	mi := &file_bridge_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3114,7 +3318,7 @@ func (x *UpdateErrorEvent) String() string {
 func (*UpdateErrorEvent) ProtoMessage() {}
 
 func (x *UpdateErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[41]
+	mi := &file_bridge_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3127,7 +3331,7 @@ func (x *UpdateErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateErrorEvent.ProtoReflect.Descriptor instead.
 func (*UpdateErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{41}
+	return file_bridge_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *UpdateErrorEvent) GetType() UpdateErrorType {
@@ -3146,7 +3350,7 @@ type UpdateManualReadyEvent struct {
 
 func (x *UpdateManualReadyEvent) Reset() {
 	*x = UpdateManualReadyEvent{}
-	mi := &file_bridge_proto_msgTypes[42]
+	mi := &file_bridge_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3158,7 +3362,7 @@ func (x *UpdateManualReadyEvent) String() string {
 func (*UpdateManualReadyEvent) ProtoMessage() {}
 
 func (x *UpdateManualReadyEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[42]
+	mi := &file_bridge_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3171,7 +3375,7 @@ func (x *UpdateManualReadyEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateManualReadyEvent.ProtoReflect.Descriptor instead.
 func (*UpdateManualReadyEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{42}
+	return file_bridge_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *UpdateManualReadyEvent) GetVersion() string {
@@ -3189,7 +3393,7 @@ type UpdateManualRestartNeededEvent struct {
 
 func (x *UpdateManualRestartNeededEvent) Reset() {
 	*x = UpdateManualRestartNeededEvent{}
-	mi := &file_bridge_proto_msgTypes[43]
+	mi := &file_bridge_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3201,7 +3405,7 @@ func (x *UpdateManualRestartNeededEvent) String() string {
 func (*UpdateManualRestartNeededEvent) ProtoMessage() {}
 
 func (x *UpdateManualRestartNeededEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[43]
+	mi := &file_bridge_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3214,7 +3418,7 @@ func (x *UpdateManualRestartNeededEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateManualRestartNeededEvent.ProtoReflect.Descriptor instead.
 func (*UpdateManualRestartNeededEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{43}
+	return file_bridge_proto_rawDescGZIP(), []int{46}
 }
 
 type UpdateForceEvent struct {
@@ -3226,7 +3430,7 @@ type UpdateForceEvent struct {
 
 func (x *UpdateForceEvent) Reset() {
 	*x = UpdateForceEvent{}
-	mi := &file_bridge_proto_msgTypes[44]
+	mi := &file_bridge_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3238,7 +3442,7 @@ func (x *UpdateForceEvent) String() string {
 func (*UpdateForceEvent) ProtoMessage() {}
 
 func (x *UpdateForceEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[44]
+	mi := &file_bridge_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3251,7 +3455,7 @@ func (x *UpdateForceEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateForceEvent.ProtoReflect.Descriptor instead.
 func (*UpdateForceEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{44}
+	return file_bridge_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *UpdateForceEvent) GetVersion() string {
@@ -3269,7 +3473,7 @@ type UpdateSilentRestartNeeded struct {
 
 func (x *UpdateSilentRestartNeeded) Reset() {
 	*x = UpdateSilentRestartNeeded{}
-	mi := &file_bridge_proto_msgTypes[45]
+	mi := &file_bridge_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3281,7 +3485,7 @@ func (x *UpdateSilentRestartNeeded) String() string {
 func (*UpdateSilentRestartNeeded) ProtoMessage() {}
 
 func (x *UpdateSilentRestartNeeded) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[45]
+	mi := &file_bridge_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3294,7 +3498,7 @@ func (x *UpdateSilentRestartNeeded) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSilentRestartNeeded.ProtoReflect.Descriptor instead.
 func (*UpdateSilentRestartNeeded) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{45}
+	return file_bridge_proto_rawDescGZIP(), []int{48}
 }
 
 type UpdateIsLatestVersion struct {
@@ -3305,7 +3509,7 @@ type UpdateIsLatestVersion struct {
 
 func (x *UpdateIsLatestVersion) Reset() {
 	*x = UpdateIsLatestVersion{}
-	mi := &file_bridge_proto_msgTypes[46]
+	mi := &file_bridge_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3317,7 +3521,7 @@ func (x *UpdateIsLatestVersion) String() string {
 func (*UpdateIsLatestVersion) ProtoMessage() {}
 
 func (x *UpdateIsLatestVersion) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[46]
+	mi := &file_bridge_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3330,7 +3534,7 @@ func (x *UpdateIsLatestVersion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateIsLatestVersion.ProtoReflect.Descriptor instead.
 func (*UpdateIsLatestVersion) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{46}
+	return file_bridge_proto_rawDescGZIP(), []int{49}
 }
 
 type UpdateCheckFinished struct {
@@ -3341,7 +3545,7 @@ type UpdateCheckFinished struct {
 
 func (x *UpdateCheckFinished) Reset() {
 	*x = UpdateCheckFinished{}
-	mi := &file_bridge_proto_msgTypes[47]
+	mi := &file_bridge_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3353,7 +3557,7 @@ func (x *UpdateCheckFinished) String() string {
 func (*UpdateCheckFinished) ProtoMessage() {}
 
 func (x *UpdateCheckFinished) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[47]
+	mi := &file_bridge_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3366,7 +3570,7 @@ func (x *UpdateCheckFinished) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCheckFinished.ProtoReflect.Descriptor instead.
 func (*UpdateCheckFinished) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{47}
+	return file_bridge_proto_rawDescGZIP(), []int{50}
 }
 
 type UpdateVersionChanged struct {
@@ -3377,7 +3581,7 @@ type UpdateVersionChanged struct {
 
 func (x *UpdateVersionChanged) Reset() {
 	*x = UpdateVersionChanged{}
-	mi := &file_bridge_proto_msgTypes[48]
+	mi := &file_bridge_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3389,7 +3593,7 @@ func (x *UpdateVersionChanged) String() string {
 func (*UpdateVersionChanged) ProtoMessage() {}
 
 func (x *UpdateVersionChanged) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[48]
+	mi := &file_bridge_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3402,7 +3606,7 @@ func (x *UpdateVersionChanged) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateVersionChanged.ProtoReflect.Descriptor instead.
 func (*UpdateVersionChanged) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{48}
+	return file_bridge_proto_rawDescGZIP(), []int{51}
 }
 
 // **********************************************************
@@ -3422,7 +3626,7 @@ type DiskCacheEvent struct {
 
 func (x *DiskCacheEvent) Reset() {
 	*x = DiskCacheEvent{}
-	mi := &file_bridge_proto_msgTypes[49]
+	mi := &file_bridge_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3434,7 +3638,7 @@ func (x *DiskCacheEvent) String() string {
 func (*DiskCacheEvent) ProtoMessage() {}
 
 func (x *DiskCacheEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[49]
+	mi := &file_bridge_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3447,7 +3651,7 @@ func (x *DiskCacheEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskCacheEvent.ProtoReflect.Descriptor instead.
 func (*DiskCacheEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{49}
+	return file_bridge_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *DiskCacheEvent) GetEvent() isDiskCacheEvent_Event {
@@ -3515,7 +3719,7 @@ type DiskCacheErrorEvent struct {
 
 func (x *DiskCacheErrorEvent) Reset() {
 	*x = DiskCacheErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[50]
+	mi := &file_bridge_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3527,7 +3731,7 @@ func (x *DiskCacheErrorEvent) String() string {
 func (*DiskCacheErrorEvent) ProtoMessage() {}
 
 func (x *DiskCacheErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[50]
+	mi := &file_bridge_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3540,7 +3744,7 @@ func (x *DiskCacheErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskCacheErrorEvent.ProtoReflect.Descriptor instead.
 func (*DiskCacheErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{50}
+	return file_bridge_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *DiskCacheErrorEvent) GetType() DiskCacheErrorType {
@@ -3559,7 +3763,7 @@ type DiskCachePathChangedEvent struct {
 
 func (x *DiskCachePathChangedEvent) Reset() {
 	*x = DiskCachePathChangedEvent{}
-	mi := &file_bridge_proto_msgTypes[51]
+	mi := &file_bridge_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3571,7 +3775,7 @@ func (x *DiskCachePathChangedEvent) String() string {
 func (*DiskCachePathChangedEvent) ProtoMessage() {}
 
 func (x *DiskCachePathChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[51]
+	mi := &file_bridge_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3584,7 +3788,7 @@ func (x *DiskCachePathChangedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskCachePathChangedEvent.ProtoReflect.Descriptor instead.
 func (*DiskCachePathChangedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{51}
+	return file_bridge_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *DiskCachePathChangedEvent) GetPath() string {
@@ -3602,7 +3806,7 @@ type DiskCachePathChangeFinishedEvent struct {
 
 func (x *DiskCachePathChangeFinishedEvent) Reset() {
 	*x = DiskCachePathChangeFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[52]
+	mi := &file_bridge_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3614,7 +3818,7 @@ func (x *DiskCachePathChangeFinishedEvent) String() string {
 func (*DiskCachePathChangeFinishedEvent) ProtoMessage() {}
 
 func (x *DiskCachePathChangeFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[52]
+	mi := &file_bridge_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3627,7 +3831,7 @@ func (x *DiskCachePathChangeFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiskCachePathChangeFinishedEvent.ProtoReflect.Descriptor instead.
 func (*DiskCachePathChangeFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{52}
+	return file_bridge_proto_rawDescGZIP(), []int{55}
 }
 
 // **********************************************************
@@ -3647,7 +3851,7 @@ type MailServerSettingsEvent struct {
 
 func (x *MailServerSettingsEvent) Reset() {
 	*x = MailServerSettingsEvent{}
-	mi := &file_bridge_proto_msgTypes[53]
+	mi := &file_bridge_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3659,7 +3863,7 @@ func (x *MailServerSettingsEvent) String() string {
 func (*MailServerSettingsEvent) ProtoMessage() {}
 
 func (x *MailServerSettingsEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[53]
+	mi := &file_bridge_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3672,7 +3876,7 @@ func (x *MailServerSettingsEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MailServerSettingsEvent.ProtoReflect.Descriptor instead.
 func (*MailServerSettingsEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{53}
+	return file_bridge_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *MailServerSettingsEvent) GetEvent() isMailServerSettingsEvent_Event {
@@ -3740,7 +3944,7 @@ type MailServerSettingsErrorEvent struct {
 
 func (x *MailServerSettingsErrorEvent) Reset() {
 	*x = MailServerSettingsErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[54]
+	mi := &file_bridge_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3752,7 +3956,7 @@ func (x *MailServerSettingsErrorEvent) String() string {
 func (*MailServerSettingsErrorEvent) ProtoMessage() {}
 
 func (x *MailServerSettingsErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[54]
+	mi := &file_bridge_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3765,7 +3969,7 @@ func (x *MailServerSettingsErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MailServerSettingsErrorEvent.ProtoReflect.Descriptor instead.
 func (*MailServerSettingsErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{54}
+	return file_bridge_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *MailServerSettingsErrorEvent) GetType() MailServerSettingsErrorType {
@@ -3784,7 +3988,7 @@ type MailServerSettingsChangedEvent struct {
 
 func (x *MailServerSettingsChangedEvent) Reset() {
 	*x = MailServerSettingsChangedEvent{}
-	mi := &file_bridge_proto_msgTypes[55]
+	mi := &file_bridge_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3796,7 +4000,7 @@ func (x *MailServerSettingsChangedEvent) String() string {
 func (*MailServerSettingsChangedEvent) ProtoMessage() {}
 
 func (x *MailServerSettingsChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[55]
+	mi := &file_bridge_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3809,7 +4013,7 @@ func (x *MailServerSettingsChangedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MailServerSettingsChangedEvent.ProtoReflect.Descriptor instead.
 func (*MailServerSettingsChangedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{55}
+	return file_bridge_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *MailServerSettingsChangedEvent) GetSettings() *ImapSmtpSettings {
@@ -3827,7 +4031,7 @@ type ChangeMailServerSettingsFinishedEvent struct {
 
 func (x *ChangeMailServerSettingsFinishedEvent) Reset() {
 	*x = ChangeMailServerSettingsFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[56]
+	mi := &file_bridge_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3839,7 +4043,7 @@ func (x *ChangeMailServerSettingsFinishedEvent) String() string {
 func (*ChangeMailServerSettingsFinishedEvent) ProtoMessage() {}
 
 func (x *ChangeMailServerSettingsFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[56]
+	mi := &file_bridge_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3852,7 +4056,7 @@ func (x *ChangeMailServerSettingsFinishedEvent) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use ChangeMailServerSettingsFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ChangeMailServerSettingsFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{56}
+	return file_bridge_proto_rawDescGZIP(), []int{59}
 }
 
 // **********************************************************
@@ -3872,7 +4076,7 @@ type KeychainEvent struct {
 
 func (x *KeychainEvent) Reset() {
 	*x = KeychainEvent{}
-	mi := &file_bridge_proto_msgTypes[57]
+	mi := &file_bridge_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3884,7 +4088,7 @@ func (x *KeychainEvent) String() string {
 func (*KeychainEvent) ProtoMessage() {}
 
 func (x *KeychainEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[57]
+	mi := &file_bridge_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3897,7 +4101,7 @@ func (x *KeychainEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeychainEvent.ProtoReflect.Descriptor instead.
 func (*KeychainEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{57}
+	return file_bridge_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *KeychainEvent) GetEvent() isKeychainEvent_Event {
@@ -3964,7 +4168,7 @@ type ChangeKeychainFinishedEvent struct {
 
 func (x *ChangeKeychainFinishedEvent) Reset() {
 	*x = ChangeKeychainFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[58]
+	mi := &file_bridge_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3976,7 +4180,7 @@ func (x *ChangeKeychainFinishedEvent) String() string {
 func (*ChangeKeychainFinishedEvent) ProtoMessage() {}
 
 func (x *ChangeKeychainFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[58]
+	mi := &file_bridge_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3989,7 +4193,7 @@ func (x *ChangeKeychainFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangeKeychainFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ChangeKeychainFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{58}
+	return file_bridge_proto_rawDescGZIP(), []int{61}
 }
 
 type HasNoKeychainEvent struct {
@@ -4000,7 +4204,7 @@ type HasNoKeychainEvent struct {
 
 func (x *HasNoKeychainEvent) Reset() {
 	*x = HasNoKeychainEvent{}
-	mi := &file_bridge_proto_msgTypes[59]
+	mi := &file_bridge_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4012,7 +4216,7 @@ func (x *HasNoKeychainEvent) String() string {
 func (*HasNoKeychainEvent) ProtoMessage() {}
 
 func (x *HasNoKeychainEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[59]
+	mi := &file_bridge_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4025,7 +4229,7 @@ func (x *HasNoKeychainEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasNoKeychainEvent.ProtoReflect.Descriptor instead.
 func (*HasNoKeychainEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{59}
+	return file_bridge_proto_rawDescGZIP(), []int{62}
 }
 
 type RebuildKeychainEvent struct {
@@ -4036,7 +4240,7 @@ type RebuildKeychainEvent struct {
 
 func (x *RebuildKeychainEvent) Reset() {
 	*x = RebuildKeychainEvent{}
-	mi := &file_bridge_proto_msgTypes[60]
+	mi := &file_bridge_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4048,7 +4252,7 @@ func (x *RebuildKeychainEvent) String() string {
 func (*RebuildKeychainEvent) ProtoMessage() {}
 
 func (x *RebuildKeychainEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[60]
+	mi := &file_bridge_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4061,7 +4265,7 @@ func (x *RebuildKeychainEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RebuildKeychainEvent.ProtoReflect.Descriptor instead.
 func (*RebuildKeychainEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{60}
+	return file_bridge_proto_rawDescGZIP(), []int{63}
 }
 
 // **********************************************************
@@ -4081,7 +4285,7 @@ type MailEvent struct {
 
 func (x *MailEvent) Reset() {
 	*x = MailEvent{}
-	mi := &file_bridge_proto_msgTypes[61]
+	mi := &file_bridge_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4093,7 +4297,7 @@ func (x *MailEvent) String() string {
 func (*MailEvent) ProtoMessage() {}
 
 func (x *MailEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[61]
+	mi := &file_bridge_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4106,7 +4310,7 @@ func (x *MailEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MailEvent.ProtoReflect.Descriptor instead.
 func (*MailEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{61}
+	return file_bridge_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *MailEvent) GetEvent() isMailEvent_Event {
@@ -4174,7 +4378,7 @@ type AddressChangedEvent struct {
 
 func (x *AddressChangedEvent) Reset() {
 	*x = AddressChangedEvent{}
-	mi := &file_bridge_proto_msgTypes[62]
+	mi := &file_bridge_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4186,7 +4390,7 @@ func (x *AddressChangedEvent) String() string {
 func (*AddressChangedEvent) ProtoMessage() {}
 
 func (x *AddressChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[62]
+	mi := &file_bridge_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4199,7 +4403,7 @@ func (x *AddressChangedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddressChangedEvent.ProtoReflect.Descriptor instead.
 func (*AddressChangedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{62}
+	return file_bridge_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *AddressChangedEvent) GetAddress() string {
@@ -4218,7 +4422,7 @@ type AddressChangedLogoutEvent struct {
 
 func (x *AddressChangedLogoutEvent) Reset() {
 	*x = AddressChangedLogoutEvent{}
-	mi := &file_bridge_proto_msgTypes[63]
+	mi := &file_bridge_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4230,7 +4434,7 @@ func (x *AddressChangedLogoutEvent) String() string {
 func (*AddressChangedLogoutEvent) ProtoMessage() {}
 
 func (x *AddressChangedLogoutEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[63]
+	mi := &file_bridge_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4243,7 +4447,7 @@ func (x *AddressChangedLogoutEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddressChangedLogoutEvent.ProtoReflect.Descriptor instead.
 func (*AddressChangedLogoutEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{63}
+	return file_bridge_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *AddressChangedLogoutEvent) GetAddress() string {
@@ -4261,7 +4465,7 @@ type ApiCertIssueEvent struct {
 
 func (x *ApiCertIssueEvent) Reset() {
 	*x = ApiCertIssueEvent{}
-	mi := &file_bridge_proto_msgTypes[64]
+	mi := &file_bridge_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4273,7 +4477,7 @@ func (x *ApiCertIssueEvent) String() string {
 func (*ApiCertIssueEvent) ProtoMessage() {}
 
 func (x *ApiCertIssueEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[64]
+	mi := &file_bridge_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4286,7 +4490,7 @@ func (x *ApiCertIssueEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiCertIssueEvent.ProtoReflect.Descriptor instead.
 func (*ApiCertIssueEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{64}
+	return file_bridge_proto_rawDescGZIP(), []int{67}
 }
 
 type UserEvent struct {
@@ -4309,7 +4513,7 @@ type UserEvent struct {
 
 func (x *UserEvent) Reset() {
 	*x = UserEvent{}
-	mi := &file_bridge_proto_msgTypes[65]
+	mi := &file_bridge_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4321,7 +4525,7 @@ func (x *UserEvent) String() string {
 func (*UserEvent) ProtoMessage() {}
 
 func (x *UserEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[65]
+	mi := &file_bridge_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4334,7 +4538,7 @@ func (x *UserEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
 func (*UserEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{65}
+	return file_bridge_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *UserEvent) GetEvent() isUserEvent_Event {
@@ -4492,7 +4696,7 @@ type ToggleSplitModeFinishedEvent struct {
 
 func (x *ToggleSplitModeFinishedEvent) Reset() {
 	*x = ToggleSplitModeFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[66]
+	mi := &file_bridge_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4504,7 +4708,7 @@ func (x *ToggleSplitModeFinishedEvent) String() string {
 func (*ToggleSplitModeFinishedEvent) ProtoMessage() {}
 
 func (x *ToggleSplitModeFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[66]
+	mi := &file_bridge_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4517,7 +4721,7 @@ func (x *ToggleSplitModeFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleSplitModeFinishedEvent.ProtoReflect.Descriptor instead.
 func (*ToggleSplitModeFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{66}
+	return file_bridge_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *ToggleSplitModeFinishedEvent) GetUserID() string {
@@ -4536,7 +4740,7 @@ type UserDisconnectedEvent struct {
 
 func (x *UserDisconnectedEvent) Reset() {
 	*x = UserDisconnectedEvent{}
-	mi := &file_bridge_proto_msgTypes[67]
+	mi := &file_bridge_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4548,7 +4752,7 @@ func (x *UserDisconnectedEvent) String() string {
 func (*UserDisconnectedEvent) ProtoMessage() {}
 
 func (x *UserDisconnectedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[67]
+	mi := &file_bridge_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4561,7 +4765,7 @@ func (x *UserDisconnectedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserDisconnectedEvent.ProtoReflect.Descriptor instead.
 func (*UserDisconnectedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{67}
+	return file_bridge_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *UserDisconnectedEvent) GetUsername() string {
@@ -4580,7 +4784,7 @@ type UserChangedEvent struct {
 
 func (x *UserChangedEvent) Reset() {
 	*x = UserChangedEvent{}
-	mi := &file_bridge_proto_msgTypes[68]
+	mi := &file_bridge_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4592,7 +4796,7 @@ func (x *UserChangedEvent) String() string {
 func (*UserChangedEvent) ProtoMessage() {}
 
 func (x *UserChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[68]
+	mi := &file_bridge_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4605,7 +4809,7 @@ func (x *UserChangedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserChangedEvent.ProtoReflect.Descriptor instead.
 func (*UserChangedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{68}
+	return file_bridge_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *UserChangedEvent) GetUserID() string {
@@ -4625,7 +4829,7 @@ type UserBadEvent struct {
 
 func (x *UserBadEvent) Reset() {
 	*x = UserBadEvent{}
-	mi := &file_bridge_proto_msgTypes[69]
+	mi := &file_bridge_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4637,7 +4841,7 @@ func (x *UserBadEvent) String() string {
 func (*UserBadEvent) ProtoMessage() {}
 
 func (x *UserBadEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[69]
+	mi := &file_bridge_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4650,7 +4854,7 @@ func (x *UserBadEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserBadEvent.ProtoReflect.Descriptor instead.
 func (*UserBadEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{69}
+	return file_bridge_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *UserBadEvent) GetUserID() string {
@@ -4677,7 +4881,7 @@ type UsedBytesChangedEvent struct {
 
 func (x *UsedBytesChangedEvent) Reset() {
 	*x = UsedBytesChangedEvent{}
-	mi := &file_bridge_proto_msgTypes[70]
+	mi := &file_bridge_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4689,7 +4893,7 @@ func (x *UsedBytesChangedEvent) String() string {
 func (*UsedBytesChangedEvent) ProtoMessage() {}
 
 func (x *UsedBytesChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[70]
+	mi := &file_bridge_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4702,7 +4906,7 @@ func (x *UsedBytesChangedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UsedBytesChangedEvent.ProtoReflect.Descriptor instead.
 func (*UsedBytesChangedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{70}
+	return file_bridge_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *UsedBytesChangedEvent) GetUserID() string {
@@ -4728,7 +4932,7 @@ type ImapLoginFailedEvent struct {
 
 func (x *ImapLoginFailedEvent) Reset() {
 	*x = ImapLoginFailedEvent{}
-	mi := &file_bridge_proto_msgTypes[71]
+	mi := &file_bridge_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4740,7 +4944,7 @@ func (x *ImapLoginFailedEvent) String() string {
 func (*ImapLoginFailedEvent) ProtoMessage() {}
 
 func (x *ImapLoginFailedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[71]
+	mi := &file_bridge_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4753,7 +4957,7 @@ func (x *ImapLoginFailedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImapLoginFailedEvent.ProtoReflect.Descriptor instead.
 func (*ImapLoginFailedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{71}
+	return file_bridge_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *ImapLoginFailedEvent) GetUsername() string {
@@ -4772,7 +4976,7 @@ type SyncStartedEvent struct {
 
 func (x *SyncStartedEvent) Reset() {
 	*x = SyncStartedEvent{}
-	mi := &file_bridge_proto_msgTypes[72]
+	mi := &file_bridge_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4784,7 +4988,7 @@ func (x *SyncStartedEvent) String() string {
 func (*SyncStartedEvent) ProtoMessage() {}
 
 func (x *SyncStartedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[72]
+	mi := &file_bridge_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4797,7 +5001,7 @@ func (x *SyncStartedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncStartedEvent.ProtoReflect.Descriptor instead.
 func (*SyncStartedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{72}
+	return file_bridge_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *SyncStartedEvent) GetUserID() string {
@@ -4816,7 +5020,7 @@ type SyncFinishedEvent struct {
 
 func (x *SyncFinishedEvent) Reset() {
 	*x = SyncFinishedEvent{}
-	mi := &file_bridge_proto_msgTypes[73]
+	mi := &file_bridge_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4828,7 +5032,7 @@ func (x *SyncFinishedEvent) String() string {
 func (*SyncFinishedEvent) ProtoMessage() {}
 
 func (x *SyncFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[73]
+	mi := &file_bridge_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4841,7 +5045,7 @@ func (x *SyncFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncFinishedEvent.ProtoReflect.Descriptor instead.
 func (*SyncFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{73}
+	return file_bridge_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *SyncFinishedEvent) GetUserID() string {
@@ -4863,7 +5067,7 @@ type SyncProgressEvent struct {
 
 func (x *SyncProgressEvent) Reset() {
 	*x = SyncProgressEvent{}
-	mi := &file_bridge_proto_msgTypes[74]
+	mi := &file_bridge_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4875,7 +5079,7 @@ func (x *SyncProgressEvent) String() string {
 func (*SyncProgressEvent) ProtoMessage() {}
 
 func (x *SyncProgressEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[74]
+	mi := &file_bridge_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4888,7 +5092,7 @@ func (x *SyncProgressEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncProgressEvent.ProtoReflect.Descriptor instead.
 func (*SyncProgressEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{74}
+	return file_bridge_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *SyncProgressEvent) GetUserID() string {
@@ -4931,7 +5135,7 @@ type UserNotificationEvent struct {
 
 func (x *UserNotificationEvent) Reset() {
 	*x = UserNotificationEvent{}
-	mi := &file_bridge_proto_msgTypes[75]
+	mi := &file_bridge_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4943,7 +5147,7 @@ func (x *UserNotificationEvent) String() string {
 func (*UserNotificationEvent) ProtoMessage() {}
 
 func (x *UserNotificationEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[75]
+	mi := &file_bridge_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4956,7 +5160,7 @@ func (x *UserNotificationEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserNotificationEvent.ProtoReflect.Descriptor instead.
 func (*UserNotificationEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{75}
+	return file_bridge_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *UserNotificationEvent) GetTitle() string {
@@ -4996,7 +5200,7 @@ type GenericErrorEvent struct {
 
 func (x *GenericErrorEvent) Reset() {
 	*x = GenericErrorEvent{}
-	mi := &file_bridge_proto_msgTypes[76]
+	mi := &file_bridge_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5008,7 +5212,7 @@ func (x *GenericErrorEvent) String() string {
 func (*GenericErrorEvent) ProtoMessage() {}
 
 func (x *GenericErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bridge_proto_msgTypes[76]
+	mi := &file_bridge_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5021,7 +5225,7 @@ func (x *GenericErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenericErrorEvent.ProtoReflect.Descriptor instead.
 func (*GenericErrorEvent) Descriptor() ([]byte, []int) {
-	return file_bridge_proto_rawDescGZIP(), []int{76}
+	return file_bridge_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *GenericErrorEvent) GetCode() ErrorCode {
@@ -5061,7 +5265,26 @@ const file_bridge_proto_rawDesc = "" +
 	"\bimapPort\x18\x01 \x01(\x05R\bimapPort\x12\x1a\n" +
 	"\bsmtpPort\x18\x02 \x01(\x05R\bsmtpPort\x12$\n" +
 	"\ruseSSLForImap\x18\x03 \x01(\bR\ruseSSLForImap\x12$\n" +
-	"\ruseSSLForSmtp\x18\x04 \x01(\bR\ruseSSLForSmtp\":\n" +
+	"\ruseSSLForSmtp\x18\x04 \x01(\bR\ruseSSLForSmtp\"\x93\x02\n" +
+	"\vImapSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06client\x18\x03 \x01(\tR\x06client\x12\x1e\n" +
+	"\n" +
+	"clientType\x18\x04 \x01(\tR\n" +
+	"clientType\x12$\n" +
+	"\rremoteAddress\x18\x05 \x01(\tR\rremoteAddress\x12\x18\n" +
+	"\amailbox\x18\x06 \x01(\tR\amailbox\x12 \n" +
+	"\vconnectedAt\x18\a \x01(\x03R\vconnectedAt\x12$\n" +
+	"\rbytesReceived\x18\b \x01(\x04R\rbytesReceived\x12\x1c\n" +
+	"\tbytesSent\x18\t \x01(\x04R\tbytesSent\"H\n" +
+	"\x17ImapSessionListResponse\x12-\n" +
+	"\bsessions\x18\x01 \x03(\v2\x11.grpc.ImapSessionR\bsessions\"b\n" +
+	"\x16BlockImapClientRequest\x12\x1e\n" +
+	"\n" +
+	"clientType\x18\x01 \x01(\tR\n" +
+	"clientType\x12(\n" +
+	"\x0fdurationMinutes\x18\x02 \x01(\x05R\x0fdurationMinutes\":\n" +
 	"\x1aAvailableKeychainsResponse\x12\x1c\n" +
 	"\tkeychains\x18\x01 \x03(\tR\tkeychains\"\x8f\x02\n" +
 	"\x04User\x12\x0e\n" +
@@ -5319,10 +5542,12 @@ const file_bridge_proto_rawDesc = "" +
 	"\tErrorCode\x12\x11\n" +
 	"\rUNKNOWN_ERROR\x10\x00\x12\x19\n" +
 	"\x15TLS_CERT_EXPORT_ERROR\x10\x01\x12\x18\n" +
-	"\x14TLS_KEY_EXPORT_ERROR\x10\x022\xbd\"\n" +
+	"\x14TLS_KEY_EXPORT_ERROR\x10\x022\xb7&\n" +
 	"\x06Bridge\x12I\n" +
 	"\vCheckTokens\x12\x1c.google.protobuf.StringValue\x1a\x1c.google.protobuf.StringValue\x12?\n" +
-	"\vAddLogEntry\x12\x18.grpc.AddLogEntryRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\vAddLogEntry\x12\x18.grpc.AddLogEntryRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\tLogLevels\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12D\n" +
+	"\fSetLogLevels\x12\x1c.google.protobuf.StringValue\x1a\x16.google.protobuf.Empty\x12:\n" +
 	"\bGuiReady\x12\x16.google.protobuf.Empty\x1a\x16.grpc.GuiReadyResponse\x126\n" +
 	"\x04Quit\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x129\n" +
 	"\aRestart\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12C\n" +
@@ -5334,7 +5559,8 @@ const file_bridge_proto_rawDesc = "" +
 	"\x13SetIsAllMailVisible\x12\x1a.google.protobuf.BoolValue\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\x10IsAllMailVisible\x12\x16.google.protobuf.Empty\x1a\x1a.google.protobuf.BoolValue\x12L\n" +
 	"\x16SetIsTelemetryDisabled\x12\x1a.google.protobuf.BoolValue\x1a\x16.google.protobuf.Empty\x12I\n" +
-	"\x13IsTelemetryDisabled\x12\x16.google.protobuf.Empty\x1a\x1a.google.protobuf.BoolValue\x12<\n" +
+	"\x13IsTelemetryDisabled\x12\x16.google.protobuf.Empty\x1a\x1a.google.protobuf.BoolValue\x12K\n" +
+	"\x0fIsSettingLocked\x12\x1c.google.protobuf.StringValue\x1a\x1a.google.protobuf.BoolValue\x12<\n" +
 	"\x04GoOs\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12>\n" +
 	"\fTriggerReset\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12?\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12@\n" +
@@ -5369,7 +5595,11 @@ const file_bridge_proto_rawDesc = "" +
 	"\x15SetMailServerSettings\x12\x16.grpc.ImapSmtpSettings\x1a\x16.google.protobuf.Empty\x12@\n" +
 	"\bHostname\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12E\n" +
 	"\n" +
-	"IsPortFree\x12\x1b.google.protobuf.Int32Value\x1a\x1a.google.protobuf.BoolValue\x12N\n" +
+	"IsPortFree\x12\x1b.google.protobuf.Int32Value\x1a\x1a.google.protobuf.BoolValue\x12E\n" +
+	"\fImapSessions\x12\x16.google.protobuf.Empty\x1a\x1d.grpc.ImapSessionListResponse\x12G\n" +
+	"\x10CloseImapSession\x12\x1b.google.protobuf.Int32Value\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x0fBlockImapClient\x12\x1c.grpc.BlockImapClientRequest\x1a\x16.google.protobuf.Empty\x12I\n" +
+	"\x11UnblockImapClient\x12\x1c.google.protobuf.StringValue\x1a\x16.google.protobuf.Empty\x12N\n" +
 	"\x12AvailableKeychains\x12\x16.google.protobuf.Empty\x1a .grpc.AvailableKeychainsResponse\x12J\n" +
 	"\x12SetCurrentKeychain\x12\x1c.google.protobuf.StringValue\x1a\x16.google.protobuf.Empty\x12G\n" +
 	"\x0fCurrentKeychain\x12\x16.google.protobuf.Empty\x1a\x1c.google.protobuf.StringValue\x12=\n" +
@@ -5403,7 +5633,7 @@ func file_bridge_proto_rawDescGZIP() []byte {
 }
 
 var file_bridge_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
+var file_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 80)
 var file_bridge_proto_goTypes = []any{
 	(LogLevel)(0),                                 // 0: grpc.LogLevel
 	(UserState)(0),                                // 1: grpc.UserState
@@ -5418,288 +5648,306 @@ var file_bridge_proto_goTypes = []any{
 	(*LoginRequest)(nil),                          // 10: grpc.LoginRequest
 	(*LoginAbortRequest)(nil),                     // 11: grpc.LoginAbortRequest
 	(*ImapSmtpSettings)(nil),                      // 12: grpc.ImapSmtpSettings
-	(*AvailableKeychainsResponse)(nil),            // 13: grpc.AvailableKeychainsResponse
-	(*User)(nil),                                  // 14: grpc.User
-	(*UserSplitModeRequest)(nil),                  // 15: grpc.UserSplitModeRequest
-	(*UserBadEventFeedbackRequest)(nil),           // 16: grpc.UserBadEventFeedbackRequest
-	(*UserListResponse)(nil),                      // 17: grpc.UserListResponse
-	(*ConfigureAppleMailRequest)(nil),             // 18: grpc.ConfigureAppleMailRequest
-	(*EventStreamRequest)(nil),                    // 19: grpc.EventStreamRequest
-	(*StreamEvent)(nil),                           // 20: grpc.StreamEvent
-	(*AppEvent)(nil),                              // 21: grpc.AppEvent
-	(*InternetStatusEvent)(nil),                   // 22: grpc.InternetStatusEvent
-	(*ToggleAutostartFinishedEvent)(nil),          // 23: grpc.ToggleAutostartFinishedEvent
-	(*ResetFinishedEvent)(nil),                    // 24: grpc.ResetFinishedEvent
-	(*ReportBugFinishedEvent)(nil),                // 25: grpc.ReportBugFinishedEvent
-	(*ReportBugSuccessEvent)(nil),                 // 26: grpc.ReportBugSuccessEvent
-	(*ReportBugErrorEvent)(nil),                   // 27: grpc.ReportBugErrorEvent
-	(*ShowMainWindowEvent)(nil),                   // 28: grpc.ShowMainWindowEvent
-	(*ReportBugFallbackEvent)(nil),                // 29: grpc.ReportBugFallbackEvent
-	(*CertificateInstallSuccessEvent)(nil),        // 30: grpc.CertificateInstallSuccessEvent
-	(*CertificateInstallCanceledEvent)(nil),       // 31: grpc.CertificateInstallCanceledEvent
-	(*CertificateInstallFailedEvent)(nil),         // 32: grpc.CertificateInstallFailedEvent
-	(*RepairStartedEvent)(nil),                    // 33: grpc.RepairStartedEvent
-	(*AllUsersLoadedEvent)(nil),                   // 34: grpc.AllUsersLoadedEvent
-	(*KnowledgeBaseSuggestion)(nil),               // 35: grpc.KnowledgeBaseSuggestion
-	(*KnowledgeBaseSuggestionsEvent)(nil),         // 36: grpc.KnowledgeBaseSuggestionsEvent
-	(*LoginEvent)(nil),                            // 37: grpc.LoginEvent
-	(*LoginErrorEvent)(nil),                       // 38: grpc.LoginErrorEvent
-	(*LoginTfaRequestedEvent)(nil),                // 39: grpc.LoginTfaRequestedEvent
-	(*LoginFidoRequestedEvent)(nil),               // 40: grpc.LoginFidoRequestedEvent
-	(*LoginTfaOrFidoRequestedEvent)(nil),          // 41: grpc.LoginTfaOrFidoRequestedEvent
-	(*LoginFidoTouchEvent)(nil),                   // 42: grpc.LoginFidoTouchEvent
-	(*LoginFidoPinRequired)(nil),                  // 43: grpc.LoginFidoPinRequired
-	(*LoginTwoPasswordsRequestedEvent)(nil),       // 44: grpc.LoginTwoPasswordsRequestedEvent
-	(*LoginFinishedEvent)(nil),                    // 45: grpc.LoginFinishedEvent
-	(*LoginHvRequestedEvent)(nil),                 // 46: grpc.LoginHvRequestedEvent
-	(*UpdateEvent)(nil),                           // 47: grpc.UpdateEvent
-	(*UpdateErrorEvent)(nil),                      // 48: grpc.UpdateErrorEvent
-	(*UpdateManualReadyEvent)(nil),                // 49: grpc.UpdateManualReadyEvent
-	(*UpdateManualRestartNeededEvent)(nil),        // 50: grpc.UpdateManualRestartNeededEvent
-	(*UpdateForceEvent)(nil),                      // 51: grpc.UpdateForceEvent
-	(*UpdateSilentRestartNeeded)(nil),             // 52: grpc.UpdateSilentRestartNeeded
-	(*UpdateIsLatestVersion)(nil),                 // 53: grpc.UpdateIsLatestVersion
-	(*UpdateCheckFinished)(nil),                   // 54: grpc.UpdateCheckFinished
-	(*UpdateVersionChanged)(nil),                  // 55: grpc.UpdateVersionChanged
-	(*DiskCacheEvent)(nil),                        // 56: grpc.DiskCacheEvent
-	(*DiskCacheErrorEvent)(nil),                   // 57: grpc.DiskCacheErrorEvent
-	(*DiskCachePathChangedEvent)(nil),             // 58: grpc.DiskCachePathChangedEvent
-	(*DiskCachePathChangeFinishedEvent)(nil),      // 59: grpc.DiskCachePathChangeFinishedEvent
-	(*MailServerSettingsEvent)(nil),               // 60: grpc.MailServerSettingsEvent
-	(*MailServerSettingsErrorEvent)(nil),          // 61: grpc.MailServerSettingsErrorEvent
-	(*MailServerSettingsChangedEvent)(nil),        // 62: grpc.MailServerSettingsChangedEvent
-	(*ChangeMailServerSettingsFinishedEvent)(nil), // 63: grpc.ChangeMailServerSettingsFinishedEvent
-	(*KeychainEvent)(nil),                         // 64: grpc.KeychainEvent
-	(*ChangeKeychainFinishedEvent)(nil),           // 65: grpc.ChangeKeychainFinishedEvent
-	(*HasNoKeychainEvent)(nil),                    // 66: grpc.HasNoKeychainEvent
-	(*RebuildKeychainEvent)(nil),                  // 67: grpc.RebuildKeychainEvent
-	(*MailEvent)(nil),                             // 68: grpc.MailEvent
-	(*AddressChangedEvent)(nil),                   // 69: grpc.AddressChangedEvent
-	(*AddressChangedLogoutEvent)(nil),             // 70: grpc.AddressChangedLogoutEvent
-	(*ApiCertIssueEvent)(nil),                     // 71: grpc.ApiCertIssueEvent
-	(*UserEvent)(nil),                             // 72: grpc.UserEvent
-	(*ToggleSplitModeFinishedEvent)(nil),          // 73: grpc.ToggleSplitModeFinishedEvent
-	(*UserDisconnectedEvent)(nil),                 // 74: grpc.UserDisconnectedEvent
-	(*UserChangedEvent)(nil),                      // 75: grpc.UserChangedEvent
-	(*UserBadEvent)(nil),                          // 76: grpc.UserBadEvent
-	(*UsedBytesChangedEvent)(nil),                 // 77: grpc.UsedBytesChangedEvent
-	(*ImapLoginFailedEvent)(nil),                  // 78: grpc.ImapLoginFailedEvent
-	(*SyncStartedEvent)(nil),                      // 79: grpc.SyncStartedEvent
-	(*SyncFinishedEvent)(nil),                     // 80: grpc.SyncFinishedEvent
-	(*SyncProgressEvent)(nil),                     // 81: grpc.SyncProgressEvent
-	(*UserNotificationEvent)(nil),                 // 82: grpc.UserNotificationEvent
-	(*GenericErrorEvent)(nil),                     // 83: grpc.GenericErrorEvent
-	(*wrapperspb.StringValue)(nil),                // 84: google.protobuf.StringValue
-	(*emptypb.Empty)(nil),                         // 85: google.protobuf.Empty
-	(*wrapperspb.BoolValue)(nil),                  // 86: google.protobuf.BoolValue
-	(*wrapperspb.Int32Value)(nil),                 // 87: google.protobuf.Int32Value
+	(*ImapSession)(nil),                           // 13: grpc.ImapSession
+	(*ImapSessionListResponse)(nil),               // 14: grpc.ImapSessionListResponse
+	(*BlockImapClientRequest)(nil),                // 15: grpc.BlockImapClientRequest
+	(*AvailableKeychainsResponse)(nil),            // 16: grpc.AvailableKeychainsResponse
+	(*User)(nil),                                  // 17: grpc.User
+	(*UserSplitModeRequest)(nil),                  // 18: grpc.UserSplitModeRequest
+	(*UserBadEventFeedbackRequest)(nil),           // 19: grpc.UserBadEventFeedbackRequest
+	(*UserListResponse)(nil),                      // 20: grpc.UserListResponse
+	(*ConfigureAppleMailRequest)(nil),             // 21: grpc.ConfigureAppleMailRequest
+	(*EventStreamRequest)(nil),                    // 22: grpc.EventStreamRequest
+	(*StreamEvent)(nil),                           // 23: grpc.StreamEvent
+	(*AppEvent)(nil),                              // 24: grpc.AppEvent
+	(*InternetStatusEvent)(nil),                   // 25: grpc.InternetStatusEvent
+	(*ToggleAutostartFinishedEvent)(nil),          // 26: grpc.ToggleAutostartFinishedEvent
+	(*ResetFinishedEvent)(nil),                    // 27: grpc.ResetFinishedEvent
+	(*ReportBugFinishedEvent)(nil),                // 28: grpc.ReportBugFinishedEvent
+	(*ReportBugSuccessEvent)(nil),                 // 29: grpc.ReportBugSuccessEvent
+	(*ReportBugErrorEvent)(nil),                   // 30: grpc.ReportBugErrorEvent
+	(*ShowMainWindowEvent)(nil),                   // 31: grpc.ShowMainWindowEvent
+	(*ReportBugFallbackEvent)(nil),                // 32: grpc.ReportBugFallbackEvent
+	(*CertificateInstallSuccessEvent)(nil),        // 33: grpc.CertificateInstallSuccessEvent
+	(*CertificateInstallCanceledEvent)(nil),       // 34: grpc.CertificateInstallCanceledEvent
+	(*CertificateInstallFailedEvent)(nil),         // 35: grpc.CertificateInstallFailedEvent
+	(*RepairStartedEvent)(nil),                    // 36: grpc.RepairStartedEvent
+	(*AllUsersLoadedEvent)(nil),                   // 37: grpc.AllUsersLoadedEvent
+	(*KnowledgeBaseSuggestion)(nil),               // 38: grpc.KnowledgeBaseSuggestion
+	(*KnowledgeBaseSuggestionsEvent)(nil),         // 39: grpc.KnowledgeBaseSuggestionsEvent
+	(*LoginEvent)(nil),                            // 40: grpc.LoginEvent
+	(*LoginErrorEvent)(nil),                       // 41: grpc.LoginErrorEvent
+	(*LoginTfaRequestedEvent)(nil),                // 42: grpc.LoginTfaRequestedEvent
+	(*LoginFidoRequestedEvent)(nil),               // 43: grpc.LoginFidoRequestedEvent
+	(*LoginTfaOrFidoRequestedEvent)(nil),          // 44: grpc.LoginTfaOrFidoRequestedEvent
+	(*LoginFidoTouchEvent)(nil),                   // 45: grpc.LoginFidoTouchEvent
+	(*LoginFidoPinRequired)(nil),                  // 46: grpc.LoginFidoPinRequired
+	(*LoginTwoPasswordsRequestedEvent)(nil),       // 47: grpc.LoginTwoPasswordsRequestedEvent
+	(*LoginFinishedEvent)(nil),                    // 48: grpc.LoginFinishedEvent
+	(*LoginHvRequestedEvent)(nil),                 // 49: grpc.LoginHvRequestedEvent
+	(*UpdateEvent)(nil),                           // 50: grpc.UpdateEvent
+	(*UpdateErrorEvent)(nil),                      // 51: grpc.UpdateErrorEvent
+	(*UpdateManualReadyEvent)(nil),                // 52: grpc.UpdateManualReadyEvent
+	(*UpdateManualRestartNeededEvent)(nil),        // 53: grpc.UpdateManualRestartNeededEvent
+	(*UpdateForceEvent)(nil),                      // 54: grpc.UpdateForceEvent
+	(*UpdateSilentRestartNeeded)(nil),             // 55: grpc.UpdateSilentRestartNeeded
+	(*UpdateIsLatestVersion)(nil),                 // 56: grpc.UpdateIsLatestVersion
+	(*UpdateCheckFinished)(nil),                   // 57: grpc.UpdateCheckFinished
+	(*UpdateVersionChanged)(nil),                  // 58: grpc.UpdateVersionChanged
+	(*DiskCacheEvent)(nil),                        // 59: grpc.DiskCacheEvent
+	(*DiskCacheErrorEvent)(nil),                   // 60: grpc.DiskCacheErrorEvent
+	(*DiskCachePathChangedEvent)(nil),             // 61: grpc.DiskCachePathChangedEvent
+	(*DiskCachePathChangeFinishedEvent)(nil),      // 62: grpc.DiskCachePathChangeFinishedEvent
+	(*MailServerSettingsEvent)(nil),               // 63: grpc.MailServerSettingsEvent
+	(*MailServerSettingsErrorEvent)(nil),          // 64: grpc.MailServerSettingsErrorEvent
+	(*MailServerSettingsChangedEvent)(nil),        // 65: grpc.MailServerSettingsChangedEvent
+	(*ChangeMailServerSettingsFinishedEvent)(nil), // 66: grpc.ChangeMailServerSettingsFinishedEvent
+	(*KeychainEvent)(nil),                         // 67: grpc.KeychainEvent
+	(*ChangeKeychainFinishedEvent)(nil),           // 68: grpc.ChangeKeychainFinishedEvent
+	(*HasNoKeychainEvent)(nil),                    // 69: grpc.HasNoKeychainEvent
+	(*RebuildKeychainEvent)(nil),                  // 70: grpc.RebuildKeychainEvent
+	(*MailEvent)(nil),                             // 71: grpc.MailEvent
+	(*AddressChangedEvent)(nil),                   // 72: grpc.AddressChangedEvent
+	(*AddressChangedLogoutEvent)(nil),             // 73: grpc.AddressChangedLogoutEvent
+	(*ApiCertIssueEvent)(nil),                     // 74: grpc.ApiCertIssueEvent
+	(*UserEvent)(nil),                             // 75: grpc.UserEvent
+	(*ToggleSplitModeFinishedEvent)(nil),          // 76: grpc.ToggleSplitModeFinishedEvent
+	(*UserDisconnectedEvent)(nil),                 // 77: grpc.UserDisconnectedEvent
+	(*UserChangedEvent)(nil),                      // 78: grpc.UserChangedEvent
+	(*UserBadEvent)(nil),                          // 79: grpc.UserBadEvent
+	(*UsedBytesChangedEvent)(nil),                 // 80: grpc.UsedBytesChangedEvent
+	(*ImapLoginFailedEvent)(nil),                  // 81: grpc.ImapLoginFailedEvent
+	(*SyncStartedEvent)(nil),                      // 82: grpc.SyncStartedEvent
+	(*SyncFinishedEvent)(nil),                     // 83: grpc.SyncFinishedEvent
+	(*SyncProgressEvent)(nil),                     // 84: grpc.SyncProgressEvent
+	(*UserNotificationEvent)(nil),                 // 85: grpc.UserNotificationEvent
+	(*GenericErrorEvent)(nil),                     // 86: grpc.GenericErrorEvent
+	(*wrapperspb.StringValue)(nil),                // 87: google.protobuf.StringValue
+	(*emptypb.Empty)(nil),                         // 88: google.protobuf.Empty
+	(*wrapperspb.BoolValue)(nil),                  // 89: google.protobuf.BoolValue
+	(*wrapperspb.Int32Value)(nil),                 // 90: google.protobuf.Int32Value
 }
 var file_bridge_proto_depIdxs = []int32{
 	0,   // 0: grpc.AddLogEntryRequest.level:type_name -> grpc.LogLevel
-	1,   // 1: grpc.User.state:type_name -> grpc.UserState
-	14,  // 2: grpc.UserListResponse.users:type_name -> grpc.User
-	21,  // 3: grpc.StreamEvent.app:type_name -> grpc.AppEvent
-	37,  // 4: grpc.StreamEvent.login:type_name -> grpc.LoginEvent
-	47,  // 5: grpc.StreamEvent.update:type_name -> grpc.UpdateEvent
-	56,  // 6: grpc.StreamEvent.cache:type_name -> grpc.DiskCacheEvent
-	60,  // 7: grpc.StreamEvent.mailServerSettings:type_name -> grpc.MailServerSettingsEvent
-	64,  // 8: grpc.StreamEvent.keychain:type_name -> grpc.KeychainEvent
-	68,  // 9: grpc.StreamEvent.mail:type_name -> grpc.MailEvent
-	72,  // 10: grpc.StreamEvent.user:type_name -> grpc.UserEvent
-	83,  // 11: grpc.StreamEvent.genericError:type_name -> grpc.GenericErrorEvent
-	22,  // 12: grpc.AppEvent.internetStatus:type_name -> grpc.InternetStatusEvent
-	23,  // 13: grpc.AppEvent.toggleAutostartFinished:type_name -> grpc.ToggleAutostartFinishedEvent
-	24,  // 14: grpc.AppEvent.resetFinished:type_name -> grpc.ResetFinishedEvent
-	25,  // 15: grpc.AppEvent.reportBugFinished:type_name -> grpc.ReportBugFinishedEvent
-	26,  // 16: grpc.AppEvent.reportBugSuccess:type_name -> grpc.ReportBugSuccessEvent
-	27,  // 17: grpc.AppEvent.reportBugError:type_name -> grpc.ReportBugErrorEvent
-	28,  // 18: grpc.AppEvent.showMainWindow:type_name -> grpc.ShowMainWindowEvent
-	29,  // 19: grpc.AppEvent.reportBugFallback:type_name -> grpc.ReportBugFallbackEvent
-	30,  // 20: grpc.AppEvent.certificateInstallSuccess:type_name -> grpc.CertificateInstallSuccessEvent
-	31,  // 21: grpc.AppEvent.certificateInstallCanceled:type_name -> grpc.CertificateInstallCanceledEvent
-	32,  // 22: grpc.AppEvent.certificateInstallFailed:type_name -> grpc.CertificateInstallFailedEvent
-	36,  // 23: grpc.AppEvent.knowledgeBaseSuggestions:type_name -> grpc.KnowledgeBaseSuggestionsEvent
-	33,  // 24: grpc.AppEvent.repairStarted:type_name -> grpc.RepairStartedEvent
-	34,  // 25: grpc.AppEvent.allUsersLoaded:type_name -> grpc.AllUsersLoadedEvent
-	82,  // 26: grpc.AppEvent.userNotification:type_name -> grpc.UserNotificationEvent
-	35,  // 27: grpc.KnowledgeBaseSuggestionsEvent.suggestions:type_name -> grpc.KnowledgeBaseSuggestion
-	38,  // 28: grpc.LoginEvent.error:type_name -> grpc.LoginErrorEvent
-	39,  // 29: grpc.LoginEvent.tfaRequested:type_name -> grpc.LoginTfaRequestedEvent
-	44,  // 30: grpc.LoginEvent.twoPasswordRequested:type_name -> grpc.LoginTwoPasswordsRequestedEvent
-	45,  // 31: grpc.LoginEvent.finished:type_name -> grpc.LoginFinishedEvent
-	45,  // 32: grpc.LoginEvent.alreadyLoggedIn:type_name -> grpc.LoginFinishedEvent
-	46,  // 33: grpc.LoginEvent.hvRequested:type_name -> grpc.LoginHvRequestedEvent
-	40,  // 34: grpc.LoginEvent.fidoRequested:type_name -> grpc.LoginFidoRequestedEvent
-	41,  // 35: grpc.LoginEvent.tfaOrFidoRequested:type_name -> grpc.LoginTfaOrFidoRequestedEvent
-	42,  // 36: grpc.LoginEvent.loginFidoTouchRequested:type_name -> grpc.LoginFidoTouchEvent
-	42,  // 37: grpc.LoginEvent.loginFidoTouchCompleted:type_name -> grpc.LoginFidoTouchEvent
-	43,  // 38: grpc.LoginEvent.loginFidoPinRequired:type_name -> grpc.LoginFidoPinRequired
-	2,   // 39: grpc.LoginErrorEvent.type:type_name -> grpc.LoginErrorType
-	48,  // 40: grpc.UpdateEvent.error:type_name -> grpc.UpdateErrorEvent
-	49,  // 41: grpc.UpdateEvent.manualReady:type_name -> grpc.UpdateManualReadyEvent
-	50,  // 42: grpc.UpdateEvent.manualRestartNeeded:type_name -> grpc.UpdateManualRestartNeededEvent
-	51,  // 43: grpc.UpdateEvent.force:type_name -> grpc.UpdateForceEvent
-	52,  // 44: grpc.UpdateEvent.silentRestartNeeded:type_name -> grpc.UpdateSilentRestartNeeded
-	53,  // 45: grpc.UpdateEvent.isLatestVersion:type_name -> grpc.UpdateIsLatestVersion
-	54,  // 46: grpc.UpdateEvent.checkFinished:type_name -> grpc.UpdateCheckFinished
-	55,  // 47: grpc.UpdateEvent.versionChanged:type_name -> grpc.UpdateVersionChanged
-	3,   // 48: grpc.UpdateErrorEvent.type:type_name -> grpc.UpdateErrorType
-	57,  // 49: grpc.DiskCacheEvent.error:type_name -> grpc.DiskCacheErrorEvent
-	58,  // 50: grpc.DiskCacheEvent.pathChanged:type_name -> grpc.DiskCachePathChangedEvent
-	59,  // 51: grpc.DiskCacheEvent.pathChangeFinished:type_name -> grpc.DiskCachePathChangeFinishedEvent
-	4,   // 52: grpc.DiskCacheErrorEvent.type:type_name -> grpc.DiskCacheErrorType
-	61,  // 53: grpc.MailServerSettingsEvent.error:type_name -> grpc.MailServerSettingsErrorEvent
-	62,  // 54: grpc.MailServerSettingsEvent.mailServerSettingsChanged:type_name -> grpc.MailServerSettingsChangedEvent
-	63,  // 55: grpc.MailServerSettingsEvent.changeMailServerSettingsFinished:type_name -> grpc.ChangeMailServerSettingsFinishedEvent
-	5,   // 56: grpc.MailServerSettingsErrorEvent.type:type_name -> grpc.MailServerSettingsErrorType
-	12,  // 57: grpc.MailServerSettingsChangedEvent.settings:type_name -> grpc.ImapSmtpSettings
-	65,  // 58: grpc.KeychainEvent.changeKeychainFinished:type_name -> grpc.ChangeKeychainFinishedEvent
-	66,  // 59: grpc.KeychainEvent.hasNoKeychain:type_name -> grpc.HasNoKeychainEvent
-	67,  // 60: grpc.KeychainEvent.rebuildKeychain:type_name -> grpc.RebuildKeychainEvent
-	69,  // 61: grpc.MailEvent.addressChanged:type_name -> grpc.AddressChangedEvent
-	70,  // 62: grpc.MailEvent.addressChangedLogout:type_name -> grpc.AddressChangedLogoutEvent
-	71,  // 63: grpc.MailEvent.apiCertIssue:type_name -> grpc.ApiCertIssueEvent
-	73,  // 64: grpc.UserEvent.toggleSplitModeFinished:type_name -> grpc.ToggleSplitModeFinishedEvent
-	74,  // 65: grpc.UserEvent.userDisconnected:type_name -> grpc.UserDisconnectedEvent
-	75,  // 66: grpc.UserEvent.userChanged:type_name -> grpc.UserChangedEvent
-	76,  // 67: grpc.UserEvent.userBadEvent:type_name -> grpc.UserBadEvent
-	77,  // 68: grpc.UserEvent.usedBytesChangedEvent:type_name -> grpc.UsedBytesChangedEvent
-	78,  // 69: grpc.UserEvent.imapLoginFailedEvent:type_name -> grpc.ImapLoginFailedEvent
-	79,  // 70: grpc.UserEvent.syncStartedEvent:type_name -> grpc.SyncStartedEvent
-	80,  // 71: grpc.UserEvent.syncFinishedEvent:type_name -> grpc.SyncFinishedEvent
-	81,  // 72: grpc.UserEvent.syncProgressEvent:type_name -> grpc.SyncProgressEvent
-	6,   // 73: grpc.GenericErrorEvent.code:type_name -> grpc.ErrorCode
-	84,  // 74: grpc.Bridge.CheckTokens:input_type -> google.protobuf.StringValue
-	7,   // 75: grpc.Bridge.AddLogEntry:input_type -> grpc.AddLogEntryRequest
-	85,  // 76: grpc.Bridge.GuiReady:input_type -> google.protobuf.Empty
-	85,  // 77: grpc.Bridge.Quit:input_type -> google.protobuf.Empty
-	85,  // 78: grpc.Bridge.Restart:input_type -> google.protobuf.Empty
-	85,  // 79: grpc.Bridge.ShowOnStartup:input_type -> google.protobuf.Empty
-	86,  // 80: grpc.Bridge.SetIsAutostartOn:input_type -> google.protobuf.BoolValue
-	85,  // 81: grpc.Bridge.IsAutostartOn:input_type -> google.protobuf.Empty
-	86,  // 82: grpc.Bridge.SetIsBetaEnabled:input_type -> google.protobuf.BoolValue
-	85,  // 83: grpc.Bridge.IsBetaEnabled:input_type -> google.protobuf.Empty
-	86,  // 84: grpc.Bridge.SetIsAllMailVisible:input_type -> google.protobuf.BoolValue
-	85,  // 85: grpc.Bridge.IsAllMailVisible:input_type -> google.protobuf.Empty
-	86,  // 86: grpc.Bridge.SetIsTelemetryDisabled:input_type -> google.protobuf.BoolValue
-	85,  // 87: grpc.Bridge.IsTelemetryDisabled:input_type -> google.protobuf.Empty
-	85,  // 88: grpc.Bridge.GoOs:input_type -> google.protobuf.Empty
-	85,  // 89: grpc.Bridge.TriggerReset:input_type -> google.protobuf.Empty
-	85,  // 90: grpc.Bridge.Version:input_type -> google.protobuf.Empty
-	85,  // 91: grpc.Bridge.LogsPath:input_type -> google.protobuf.Empty
-	85,  // 92: grpc.Bridge.LicensePath:input_type -> google.protobuf.Empty
-	85,  // 93: grpc.Bridge.ReleaseNotesPageLink:input_type -> google.protobuf.Empty
-	85,  // 94: grpc.Bridge.DependencyLicensesLink:input_type -> google.protobuf.Empty
-	85,  // 95: grpc.Bridge.LandingPageLink:input_type -> google.protobuf.Empty
-	84,  // 96: grpc.Bridge.SetColorSchemeName:input_type -> google.protobuf.StringValue
-	85,  // 97: grpc.Bridge.ColorSchemeName:input_type -> google.protobuf.Empty
-	85,  // 98: grpc.Bridge.CurrentEmailClient:input_type -> google.protobuf.Empty
-	9,   // 99: grpc.Bridge.ReportBug:input_type -> grpc.ReportBugRequest
-	84,  // 100: grpc.Bridge.ForceLauncher:input_type -> google.protobuf.StringValue
-	84,  // 101: grpc.Bridge.SetMainExecutable:input_type -> google.protobuf.StringValue
-	84,  // 102: grpc.Bridge.RequestKnowledgeBaseSuggestions:input_type -> google.protobuf.StringValue
-	10,  // 103: grpc.Bridge.Login:input_type -> grpc.LoginRequest
-	10,  // 104: grpc.Bridge.Login2FA:input_type -> grpc.LoginRequest
-	10,  // 105: grpc.Bridge.LoginFido:input_type -> grpc.LoginRequest
-	10,  // 106: grpc.Bridge.Login2Passwords:input_type -> grpc.LoginRequest
-	11,  // 107: grpc.Bridge.LoginAbort:input_type -> grpc.LoginAbortRequest
-	11,  // 108: grpc.Bridge.FidoAssertionAbort:input_type -> grpc.LoginAbortRequest
-	85,  // 109: grpc.Bridge.CheckUpdate:input_type -> google.protobuf.Empty
-	85,  // 110: grpc.Bridge.InstallUpdate:input_type -> google.protobuf.Empty
-	86,  // 111: grpc.Bridge.SetIsAutomaticUpdateOn:input_type -> google.protobuf.BoolValue
-	85,  // 112: grpc.Bridge.IsAutomaticUpdateOn:input_type -> google.protobuf.Empty
-	85,  // 113: grpc.Bridge.DiskCachePath:input_type -> google.protobuf.Empty
-	84,  // 114: grpc.Bridge.SetDiskCachePath:input_type -> google.protobuf.StringValue
-	86,  // 115: grpc.Bridge.SetIsDoHEnabled:input_type -> google.protobuf.BoolValue
-	85,  // 116: grpc.Bridge.IsDoHEnabled:input_type -> google.protobuf.Empty
-	85,  // 117: grpc.Bridge.MailServerSettings:input_type -> google.protobuf.Empty
-	12,  // 118: grpc.Bridge.SetMailServerSettings:input_type -> grpc.ImapSmtpSettings
-	85,  // 119: grpc.Bridge.Hostname:input_type -> google.protobuf.Empty
-	87,  // 120: grpc.Bridge.IsPortFree:input_type -> google.protobuf.Int32Value
-	85,  // 121: grpc.Bridge.AvailableKeychains:input_type -> google.protobuf.Empty
-	84,  // 122: grpc.Bridge.SetCurrentKeychain:input_type -> google.protobuf.StringValue
-	85,  // 123: grpc.Bridge.CurrentKeychain:input_type -> google.protobuf.Empty
-	85,  // 124: grpc.Bridge.GetUserList:input_type -> google.protobuf.Empty
-	84,  // 125: grpc.Bridge.GetUser:input_type -> google.protobuf.StringValue
-	15,  // 126: grpc.Bridge.SetUserSplitMode:input_type -> grpc.UserSplitModeRequest
-	16,  // 127: grpc.Bridge.SendBadEventUserFeedback:input_type -> grpc.UserBadEventFeedbackRequest
-	84,  // 128: grpc.Bridge.LogoutUser:input_type -> google.protobuf.StringValue
-	84,  // 129: grpc.Bridge.RemoveUser:input_type -> google.protobuf.StringValue
-	18,  // 130: grpc.Bridge.ConfigureUserAppleMail:input_type -> grpc.ConfigureAppleMailRequest
-	85,  // 131: grpc.Bridge.IsTLSCertificateInstalled:input_type -> google.protobuf.Empty
-	85,  // 132: grpc.Bridge.InstallTLSCertificate:input_type -> google.protobuf.Empty
-	84,  // 133: grpc.Bridge.ExportTLSCertificates:input_type -> google.protobuf.StringValue
-	19,  // 134: grpc.Bridge.RunEventStream:input_type -> grpc.EventStreamRequest
-	85,  // 135: grpc.Bridge.StopEventStream:input_type -> google.protobuf.Empty
-	85,  // 136: grpc.Bridge.TriggerRepair:input_type -> google.protobuf.Empty
-	84,  // 137: grpc.Bridge.CheckTokens:output_type -> google.protobuf.StringValue
-	85,  // 138: grpc.Bridge.AddLogEntry:output_type -> google.protobuf.Empty
-	8,   // 139: grpc.Bridge.GuiReady:output_type -> grpc.GuiReadyResponse
-	85,  // 140: grpc.Bridge.Quit:output_type -> google.protobuf.Empty
-	85,  // 141: grpc.Bridge.Restart:output_type -> google.protobuf.Empty
-	86,  // 142: grpc.Bridge.ShowOnStartup:output_type -> google.protobuf.BoolValue
-	85,  // 143: grpc.Bridge.SetIsAutostartOn:output_type -> google.protobuf.Empty
-	86,  // 144: grpc.Bridge.IsAutostartOn:output_type -> google.protobuf.BoolValue
-	85,  // 145: grpc.Bridge.SetIsBetaEnabled:output_type -> google.protobuf.Empty
-	86,  // 146: grpc.Bridge.IsBetaEnabled:output_type -> google.protobuf.BoolValue
-	85,  // 147: grpc.Bridge.SetIsAllMailVisible:output_type -> google.protobuf.Empty
-	86,  // 148: grpc.Bridge.IsAllMailVisible:output_type -> google.protobuf.BoolValue
-	85,  // 149: grpc.Bridge.SetIsTelemetryDisabled:output_type -> google.protobuf.Empty
-	86,  // 150: grpc.Bridge.IsTelemetryDisabled:output_type -> google.protobuf.BoolValue
-	84,  // 151: grpc.Bridge.GoOs:output_type -> google.protobuf.StringValue
-	85,  // 152: grpc.Bridge.TriggerReset:output_type -> google.protobuf.Empty
-	84,  // 153: grpc.Bridge.Version:output_type -> google.protobuf.StringValue
-	84,  // 154: grpc.Bridge.LogsPath:output_type -> google.protobuf.StringValue
-	84,  // 155: grpc.Bridge.LicensePath:output_type -> google.protobuf.StringValue
-	84,  // 156: grpc.Bridge.ReleaseNotesPageLink:output_type -> google.protobuf.StringValue
-	84,  // 157: grpc.Bridge.DependencyLicensesLink:output_type -> google.protobuf.StringValue
-	84,  // 158: grpc.Bridge.LandingPageLink:output_type -> google.protobuf.StringValue
-	85,  // 159: grpc.Bridge.SetColorSchemeName:output_type -> google.protobuf.Empty
-	84,  // 160: grpc.Bridge.ColorSchemeName:output_type -> google.protobuf.StringValue
-	84,  // 161: grpc.Bridge.CurrentEmailClient:output_type -> google.protobuf.StringValue
-	85,  // 162: grpc.Bridge.ReportBug:output_type -> google.protobuf.Empty
-	85,  // 163: grpc.Bridge.ForceLauncher:output_type -> google.protobuf.Empty
-	85,  // 164: grpc.Bridge.SetMainExecutable:output_type -> google.protobuf.Empty
-	85,  // 165: grpc.Bridge.RequestKnowledgeBaseSuggestions:output_type -> google.protobuf.Empty
-	85,  // 166: grpc.Bridge.Login:output_type -> google.protobuf.Empty
-	85,  // 167: grpc.Bridge.Login2FA:output_type -> google.protobuf.Empty
-	85,  // 168: grpc.Bridge.LoginFido:output_type -> google.protobuf.Empty
-	85,  // 169: grpc.Bridge.Login2Passwords:output_type -> google.protobuf.Empty
-	85,  // 170: grpc.Bridge.LoginAbort:output_type -> google.protobuf.Empty
-	85,  // 171: grpc.Bridge.FidoAssertionAbort:output_type -> google.protobuf.Empty
-	85,  // 172: grpc.Bridge.CheckUpdate:output_type -> google.protobuf.Empty
-	85,  // 173: grpc.Bridge.InstallUpdate:output_type -> google.protobuf.Empty
-	85,  // 174: grpc.Bridge.SetIsAutomaticUpdateOn:output_type -> google.protobuf.Empty
-	86,  // 175: grpc.Bridge.IsAutomaticUpdateOn:output_type -> google.protobuf.BoolValue
-	84,  // 176: grpc.Bridge.DiskCachePath:output_type -> google.protobuf.StringValue
-	85,  // 177: grpc.Bridge.SetDiskCachePath:output_type -> google.protobuf.Empty
-	85,  // 178: grpc.Bridge.SetIsDoHEnabled:output_type -> google.protobuf.Empty
-	86,  // 179: grpc.Bridge.IsDoHEnabled:output_type -> google.protobuf.BoolValue
-	12,  // 180: grpc.Bridge.MailServerSettings:output_type -> grpc.ImapSmtpSettings
-	85,  // 181: grpc.Bridge.SetMailServerSettings:output_type -> google.protobuf.Empty
-	84,  // 182: grpc.Bridge.Hostname:output_type -> google.protobuf.StringValue
-	86,  // 183: grpc.Bridge.IsPortFree:output_type -> google.protobuf.BoolValue
-	13,  // 184: grpc.Bridge.AvailableKeychains:output_type -> grpc.AvailableKeychainsResponse
-	85,  // 185: grpc.Bridge.SetCurrentKeychain:output_type -> google.protobuf.Empty
-	84,  // 186: grpc.Bridge.CurrentKeychain:output_type -> google.protobuf.StringValue
-	17,  // 187: grpc.Bridge.GetUserList:output_type -> grpc.UserListResponse
-	14,  // 188: grpc.Bridge.GetUser:output_type -> grpc.User
-	85,  // 189: grpc.Bridge.SetUserSplitMode:output_type -> google.protobuf.Empty
-	85,  // 190: grpc.Bridge.SendBadEventUserFeedback:output_type -> google.protobuf.Empty
-	85,  // 191: grpc.Bridge.LogoutUser:output_type -> google.protobuf.Empty
-	85,  // 192: grpc.Bridge.RemoveUser:output_type -> google.protobuf.Empty
-	85,  // 193: grpc.Bridge.ConfigureUserAppleMail:output_type -> google.protobuf.Empty
-	86,  // 194: grpc.Bridge.IsTLSCertificateInstalled:output_type -> google.protobuf.BoolValue
-	85,  // 195: grpc.Bridge.InstallTLSCertificate:output_type -> google.protobuf.Empty
-	85,  // 196: grpc.Bridge.ExportTLSCertificates:output_type -> google.protobuf.Empty
-	20,  // 197: grpc.Bridge.RunEventStream:output_type -> grpc.StreamEvent
-	85,  // 198: grpc.Bridge.StopEventStream:output_type -> google.protobuf.Empty
-	85,  // 199: grpc.Bridge.TriggerRepair:output_type -> google.protobuf.Empty
-	137, // [137:200] is the sub-list for method output_type
-	74,  // [74:137] is the sub-list for method input_type
-	74,  // [74:74] is the sub-list for extension type_name
-	74,  // [74:74] is the sub-list for extension extendee
-	0,   // [0:74] is the sub-list for field type_name
+	13,  // 1: grpc.ImapSessionListResponse.sessions:type_name -> grpc.ImapSession
+	1,   // 2: grpc.User.state:type_name -> grpc.UserState
+	17,  // 3: grpc.UserListResponse.users:type_name -> grpc.User
+	24,  // 4: grpc.StreamEvent.app:type_name -> grpc.AppEvent
+	40,  // 5: grpc.StreamEvent.login:type_name -> grpc.LoginEvent
+	50,  // 6: grpc.StreamEvent.update:type_name -> grpc.UpdateEvent
+	59,  // 7: grpc.StreamEvent.cache:type_name -> grpc.DiskCacheEvent
+	63,  // 8: grpc.StreamEvent.mailServerSettings:type_name -> grpc.MailServerSettingsEvent
+	67,  // 9: grpc.StreamEvent.keychain:type_name -> grpc.KeychainEvent
+	71,  // 10: grpc.StreamEvent.mail:type_name -> grpc.MailEvent
+	75,  // 11: grpc.StreamEvent.user:type_name -> grpc.UserEvent
+	86,  // 12: grpc.StreamEvent.genericError:type_name -> grpc.GenericErrorEvent
+	25,  // 13: grpc.AppEvent.internetStatus:type_name -> grpc.InternetStatusEvent
+	26,  // 14: grpc.AppEvent.toggleAutostartFinished:type_name -> grpc.ToggleAutostartFinishedEvent
+	27,  // 15: grpc.AppEvent.resetFinished:type_name -> grpc.ResetFinishedEvent
+	28,  // 16: grpc.AppEvent.reportBugFinished:type_name -> grpc.ReportBugFinishedEvent
+	29,  // 17: grpc.AppEvent.reportBugSuccess:type_name -> grpc.ReportBugSuccessEvent
+	30,  // 18: grpc.AppEvent.reportBugError:type_name -> grpc.ReportBugErrorEvent
+	31,  // 19: grpc.AppEvent.showMainWindow:type_name -> grpc.ShowMainWindowEvent
+	32,  // 20: grpc.AppEvent.reportBugFallback:type_name -> grpc.ReportBugFallbackEvent
+	33,  // 21: grpc.AppEvent.certificateInstallSuccess:type_name -> grpc.CertificateInstallSuccessEvent
+	34,  // 22: grpc.AppEvent.certificateInstallCanceled:type_name -> grpc.CertificateInstallCanceledEvent
+	35,  // 23: grpc.AppEvent.certificateInstallFailed:type_name -> grpc.CertificateInstallFailedEvent
+	39,  // 24: grpc.AppEvent.knowledgeBaseSuggestions:type_name -> grpc.KnowledgeBaseSuggestionsEvent
+	36,  // 25: grpc.AppEvent.repairStarted:type_name -> grpc.RepairStartedEvent
+	37,  // 26: grpc.AppEvent.allUsersLoaded:type_name -> grpc.AllUsersLoadedEvent
+	85,  // 27: grpc.AppEvent.userNotification:type_name -> grpc.UserNotificationEvent
+	38,  // 28: grpc.KnowledgeBaseSuggestionsEvent.suggestions:type_name -> grpc.KnowledgeBaseSuggestion
+	41,  // 29: grpc.LoginEvent.error:type_name -> grpc.LoginErrorEvent
+	42,  // 30: grpc.LoginEvent.tfaRequested:type_name -> grpc.LoginTfaRequestedEvent
+	47,  // 31: grpc.LoginEvent.twoPasswordRequested:type_name -> grpc.LoginTwoPasswordsRequestedEvent
+	48,  // 32: grpc.LoginEvent.finished:type_name -> grpc.LoginFinishedEvent
+	48,  // 33: grpc.LoginEvent.alreadyLoggedIn:type_name -> grpc.LoginFinishedEvent
+	49,  // 34: grpc.LoginEvent.hvRequested:type_name -> grpc.LoginHvRequestedEvent
+	43,  // 35: grpc.LoginEvent.fidoRequested:type_name -> grpc.LoginFidoRequestedEvent
+	44,  // 36: grpc.LoginEvent.tfaOrFidoRequested:type_name -> grpc.LoginTfaOrFidoRequestedEvent
+	45,  // 37: grpc.LoginEvent.loginFidoTouchRequested:type_name -> grpc.LoginFidoTouchEvent
+	45,  // 38: grpc.LoginEvent.loginFidoTouchCompleted:type_name -> grpc.LoginFidoTouchEvent
+	46,  // 39: grpc.LoginEvent.loginFidoPinRequired:type_name -> grpc.LoginFidoPinRequired
+	2,   // 40: grpc.LoginErrorEvent.type:type_name -> grpc.LoginErrorType
+	51,  // 41: grpc.UpdateEvent.error:type_name -> grpc.UpdateErrorEvent
+	52,  // 42: grpc.UpdateEvent.manualReady:type_name -> grpc.UpdateManualReadyEvent
+	53,  // 43: grpc.UpdateEvent.manualRestartNeeded:type_name -> grpc.UpdateManualRestartNeededEvent
+	54,  // 44: grpc.UpdateEvent.force:type_name -> grpc.UpdateForceEvent
+	55,  // 45: grpc.UpdateEvent.silentRestartNeeded:type_name -> grpc.UpdateSilentRestartNeeded
+	56,  // 46: grpc.UpdateEvent.isLatestVersion:type_name -> grpc.UpdateIsLatestVersion
+	57,  // 47: grpc.UpdateEvent.checkFinished:type_name -> grpc.UpdateCheckFinished
+	58,  // 48: grpc.UpdateEvent.versionChanged:type_name -> grpc.UpdateVersionChanged
+	3,   // 49: grpc.UpdateErrorEvent.type:type_name -> grpc.UpdateErrorType
+	60,  // 50: grpc.DiskCacheEvent.error:type_name -> grpc.DiskCacheErrorEvent
+	61,  // 51: grpc.DiskCacheEvent.pathChanged:type_name -> grpc.DiskCachePathChangedEvent
+	62,  // 52: grpc.DiskCacheEvent.pathChangeFinished:type_name -> grpc.DiskCachePathChangeFinishedEvent
+	4,   // 53: grpc.DiskCacheErrorEvent.type:type_name -> grpc.DiskCacheErrorType
+	64,  // 54: grpc.MailServerSettingsEvent.error:type_name -> grpc.MailServerSettingsErrorEvent
+	65,  // 55: grpc.MailServerSettingsEvent.mailServerSettingsChanged:type_name -> grpc.MailServerSettingsChangedEvent
+	66,  // 56: grpc.MailServerSettingsEvent.changeMailServerSettingsFinished:type_name -> grpc.ChangeMailServerSettingsFinishedEvent
+	5,   // 57: grpc.MailServerSettingsErrorEvent.type:type_name -> grpc.MailServerSettingsErrorType
+	12,  // 58: grpc.MailServerSettingsChangedEvent.settings:type_name -> grpc.ImapSmtpSettings
+	68,  // 59: grpc.KeychainEvent.changeKeychainFinished:type_name -> grpc.ChangeKeychainFinishedEvent
+	69,  // 60: grpc.KeychainEvent.hasNoKeychain:type_name -> grpc.HasNoKeychainEvent
+	70,  // 61: grpc.KeychainEvent.rebuildKeychain:type_name -> grpc.RebuildKeychainEvent
+	72,  // 62: grpc.MailEvent.addressChanged:type_name -> grpc.AddressChangedEvent
+	73,  // 63: grpc.MailEvent.addressChangedLogout:type_name -> grpc.AddressChangedLogoutEvent
+	74,  // 64: grpc.MailEvent.apiCertIssue:type_name -> grpc.ApiCertIssueEvent
+	76,  // 65: grpc.UserEvent.toggleSplitModeFinished:type_name -> grpc.ToggleSplitModeFinishedEvent
+	77,  // 66: grpc.UserEvent.userDisconnected:type_name -> grpc.UserDisconnectedEvent
+	78,  // 67: grpc.UserEvent.userChanged:type_name -> grpc.UserChangedEvent
+	79,  // 68: grpc.UserEvent.userBadEvent:type_name -> grpc.UserBadEvent
+	80,  // 69: grpc.UserEvent.usedBytesChangedEvent:type_name -> grpc.UsedBytesChangedEvent
+	81,  // 70: grpc.UserEvent.imapLoginFailedEvent:type_name -> grpc.ImapLoginFailedEvent
+	82,  // 71: grpc.UserEvent.syncStartedEvent:type_name -> grpc.SyncStartedEvent
+	83,  // 72: grpc.UserEvent.syncFinishedEvent:type_name -> grpc.SyncFinishedEvent
+	84,  // 73: grpc.UserEvent.syncProgressEvent:type_name -> grpc.SyncProgressEvent
+	6,   // 74: grpc.GenericErrorEvent.code:type_name -> grpc.ErrorCode
+	87,  // 75: grpc.Bridge.CheckTokens:input_type -> google.protobuf.StringValue
+	7,   // 76: grpc.Bridge.AddLogEntry:input_type -> grpc.AddLogEntryRequest
+	88,  // 77: grpc.Bridge.LogLevels:input_type -> google.protobuf.Empty
+	87,  // 78: grpc.Bridge.SetLogLevels:input_type -> google.protobuf.StringValue
+	88,  // 79: grpc.Bridge.GuiReady:input_type -> google.protobuf.Empty
+	88,  // 80: grpc.Bridge.Quit:input_type -> google.protobuf.Empty
+	88,  // 81: grpc.Bridge.Restart:input_type -> google.protobuf.Empty
+	88,  // 82: grpc.Bridge.ShowOnStartup:input_type -> google.protobuf.Empty
+	89,  // 83: grpc.Bridge.SetIsAutostartOn:input_type -> google.protobuf.BoolValue
+	88,  // 84: grpc.Bridge.IsAutostartOn:input_type -> google.protobuf.Empty
+	89,  // 85: grpc.Bridge.SetIsBetaEnabled:input_type -> google.protobuf.BoolValue
+	88,  // 86: grpc.Bridge.IsBetaEnabled:input_type -> google.protobuf.Empty
+	89,  // 87: grpc.Bridge.SetIsAllMailVisible:input_type -> google.protobuf.BoolValue
+	88,  // 88: grpc.Bridge.IsAllMailVisible:input_type -> google.protobuf.Empty
+	89,  // 89: grpc.Bridge.SetIsTelemetryDisabled:input_type -> google.protobuf.BoolValue
+	88,  // 90: grpc.Bridge.IsTelemetryDisabled:input_type -> google.protobuf.Empty
+	87,  // 91: grpc.Bridge.IsSettingLocked:input_type -> google.protobuf.StringValue
+	88,  // 92: grpc.Bridge.GoOs:input_type -> google.protobuf.Empty
+	88,  // 93: grpc.Bridge.TriggerReset:input_type -> google.protobuf.Empty
+	88,  // 94: grpc.Bridge.Version:input_type -> google.protobuf.Empty
+	88,  // 95: grpc.Bridge.LogsPath:input_type -> google.protobuf.Empty
+	88,  // 96: grpc.Bridge.LicensePath:input_type -> google.protobuf.Empty
+	88,  // 97: grpc.Bridge.ReleaseNotesPageLink:input_type -> google.protobuf.Empty
+	88,  // 98: grpc.Bridge.DependencyLicensesLink:input_type -> google.protobuf.Empty
+	88,  // 99: grpc.Bridge.LandingPageLink:input_type -> google.protobuf.Empty
+	87,  // 100: grpc.Bridge.SetColorSchemeName:input_type -> google.protobuf.StringValue
+	88,  // 101: grpc.Bridge.ColorSchemeName:input_type -> google.protobuf.Empty
+	88,  // 102: grpc.Bridge.CurrentEmailClient:input_type -> google.protobuf.Empty
+	9,   // 103: grpc.Bridge.ReportBug:input_type -> grpc.ReportBugRequest
+	87,  // 104: grpc.Bridge.ForceLauncher:input_type -> google.protobuf.StringValue
+	87,  // 105: grpc.Bridge.SetMainExecutable:input_type -> google.protobuf.StringValue
+	87,  // 106: grpc.Bridge.RequestKnowledgeBaseSuggestions:input_type -> google.protobuf.StringValue
+	10,  // 107: grpc.Bridge.Login:input_type -> grpc.LoginRequest
+	10,  // 108: grpc.Bridge.Login2FA:input_type -> grpc.LoginRequest
+	10,  // 109: grpc.Bridge.LoginFido:input_type -> grpc.LoginRequest
+	10,  // 110: grpc.Bridge.Login2Passwords:input_type -> grpc.LoginRequest
+	11,  // 111: grpc.Bridge.LoginAbort:input_type -> grpc.LoginAbortRequest
+	11,  // 112: grpc.Bridge.FidoAssertionAbort:input_type -> grpc.LoginAbortRequest
+	88,  // 113: grpc.Bridge.CheckUpdate:input_type -> google.protobuf.Empty
+	88,  // 114: grpc.Bridge.InstallUpdate:input_type -> google.protobuf.Empty
+	89,  // 115: grpc.Bridge.SetIsAutomaticUpdateOn:input_type -> google.protobuf.BoolValue
+	88,  // 116: grpc.Bridge.IsAutomaticUpdateOn:input_type -> google.protobuf.Empty
+	88,  // 117: grpc.Bridge.DiskCachePath:input_type -> google.protobuf.Empty
+	87,  // 118: grpc.Bridge.SetDiskCachePath:input_type -> google.protobuf.StringValue
+	89,  // 119: grpc.Bridge.SetIsDoHEnabled:input_type -> google.protobuf.BoolValue
+	88,  // 120: grpc.Bridge.IsDoHEnabled:input_type -> google.protobuf.Empty
+	88,  // 121: grpc.Bridge.MailServerSettings:input_type -> google.protobuf.Empty
+	12,  // 122: grpc.Bridge.SetMailServerSettings:input_type -> grpc.ImapSmtpSettings
+	88,  // 123: grpc.Bridge.Hostname:input_type -> google.protobuf.Empty
+	90,  // 124: grpc.Bridge.IsPortFree:input_type -> google.protobuf.Int32Value
+	88,  // 125: grpc.Bridge.ImapSessions:input_type -> google.protobuf.Empty
+	90,  // 126: grpc.Bridge.CloseImapSession:input_type -> google.protobuf.Int32Value
+	15,  // 127: grpc.Bridge.BlockImapClient:input_type -> grpc.BlockImapClientRequest
+	87,  // 128: grpc.Bridge.UnblockImapClient:input_type -> google.protobuf.StringValue
+	88,  // 129: grpc.Bridge.AvailableKeychains:input_type -> google.protobuf.Empty
+	87,  // 130: grpc.Bridge.SetCurrentKeychain:input_type -> google.protobuf.StringValue
+	88,  // 131: grpc.Bridge.CurrentKeychain:input_type -> google.protobuf.Empty
+	88,  // 132: grpc.Bridge.GetUserList:input_type -> google.protobuf.Empty
+	87,  // 133: grpc.Bridge.GetUser:input_type -> google.protobuf.StringValue
+	18,  // 134: grpc.Bridge.SetUserSplitMode:input_type -> grpc.UserSplitModeRequest
+	19,  // 135: grpc.Bridge.SendBadEventUserFeedback:input_type -> grpc.UserBadEventFeedbackRequest
+	87,  // 136: grpc.Bridge.LogoutUser:input_type -> google.protobuf.StringValue
+	87,  // 137: grpc.Bridge.RemoveUser:input_type -> google.protobuf.StringValue
+	21,  // 138: grpc.Bridge.ConfigureUserAppleMail:input_type -> grpc.ConfigureAppleMailRequest
+	88,  // 139: grpc.Bridge.IsTLSCertificateInstalled:input_type -> google.protobuf.Empty
+	88,  // 140: grpc.Bridge.InstallTLSCertificate:input_type -> google.protobuf.Empty
+	87,  // 141: grpc.Bridge.ExportTLSCertificates:input_type -> google.protobuf.StringValue
+	22,  // 142: grpc.Bridge.RunEventStream:input_type -> grpc.EventStreamRequest
+	88,  // 143: grpc.Bridge.StopEventStream:input_type -> google.protobuf.Empty
+	88,  // 144: grpc.Bridge.TriggerRepair:input_type -> google.protobuf.Empty
+	87,  // 145: grpc.Bridge.CheckTokens:output_type -> google.protobuf.StringValue
+	88,  // 146: grpc.Bridge.AddLogEntry:output_type -> google.protobuf.Empty
+	87,  // 147: grpc.Bridge.LogLevels:output_type -> google.protobuf.StringValue
+	88,  // 148: grpc.Bridge.SetLogLevels:output_type -> google.protobuf.Empty
+	8,   // 149: grpc.Bridge.GuiReady:output_type -> grpc.GuiReadyResponse
+	88,  // 150: grpc.Bridge.Quit:output_type -> google.protobuf.Empty
+	88,  // 151: grpc.Bridge.Restart:output_type -> google.protobuf.Empty
+	89,  // 152: grpc.Bridge.ShowOnStartup:output_type -> google.protobuf.BoolValue
+	88,  // 153: grpc.Bridge.SetIsAutostartOn:output_type -> google.protobuf.Empty
+	89,  // 154: grpc.Bridge.IsAutostartOn:output_type -> google.protobuf.BoolValue
+	88,  // 155: grpc.Bridge.SetIsBetaEnabled:output_type -> google.protobuf.Empty
+	89,  // 156: grpc.Bridge.IsBetaEnabled:output_type -> google.protobuf.BoolValue
+	88,  // 157: grpc.Bridge.SetIsAllMailVisible:output_type -> google.protobuf.Empty
+	89,  // 158: grpc.Bridge.IsAllMailVisible:output_type -> google.protobuf.BoolValue
+	88,  // 159: grpc.Bridge.SetIsTelemetryDisabled:output_type -> google.protobuf.Empty
+	89,  // 160: grpc.Bridge.IsTelemetryDisabled:output_type -> google.protobuf.BoolValue
+	89,  // 161: grpc.Bridge.IsSettingLocked:output_type -> google.protobuf.BoolValue
+	87,  // 162: grpc.Bridge.GoOs:output_type -> google.protobuf.StringValue
+	88,  // 163: grpc.Bridge.TriggerReset:output_type -> google.protobuf.Empty
+	87,  // 164: grpc.Bridge.Version:output_type -> google.protobuf.StringValue
+	87,  // 165: grpc.Bridge.LogsPath:output_type -> google.protobuf.StringValue
+	87,  // 166: grpc.Bridge.LicensePath:output_type -> google.protobuf.StringValue
+	87,  // 167: grpc.Bridge.ReleaseNotesPageLink:output_type -> google.protobuf.StringValue
+	87,  // 168: grpc.Bridge.DependencyLicensesLink:output_type -> google.protobuf.StringValue
+	87,  // 169: grpc.Bridge.LandingPageLink:output_type -> google.protobuf.StringValue
+	88,  // 170: grpc.Bridge.SetColorSchemeName:output_type -> google.protobuf.Empty
+	87,  // 171: grpc.Bridge.ColorSchemeName:output_type -> google.protobuf.StringValue
+	87,  // 172: grpc.Bridge.CurrentEmailClient:output_type -> google.protobuf.StringValue
+	88,  // 173: grpc.Bridge.ReportBug:output_type -> google.protobuf.Empty
+	88,  // 174: grpc.Bridge.ForceLauncher:output_type -> google.protobuf.Empty
+	88,  // 175: grpc.Bridge.SetMainExecutable:output_type -> google.protobuf.Empty
+	88,  // 176: grpc.Bridge.RequestKnowledgeBaseSuggestions:output_type -> google.protobuf.Empty
+	88,  // 177: grpc.Bridge.Login:output_type -> google.protobuf.Empty
+	88,  // 178: grpc.Bridge.Login2FA:output_type -> google.protobuf.Empty
+	88,  // 179: grpc.Bridge.LoginFido:output_type -> google.protobuf.Empty
+	88,  // 180: grpc.Bridge.Login2Passwords:output_type -> google.protobuf.Empty
+	88,  // 181: grpc.Bridge.LoginAbort:output_type -> google.protobuf.Empty
+	88,  // 182: grpc.Bridge.FidoAssertionAbort:output_type -> google.protobuf.Empty
+	88,  // 183: grpc.Bridge.CheckUpdate:output_type -> google.protobuf.Empty
+	88,  // 184: grpc.Bridge.InstallUpdate:output_type -> google.protobuf.Empty
+	88,  // 185: grpc.Bridge.SetIsAutomaticUpdateOn:output_type -> google.protobuf.Empty
+	89,  // 186: grpc.Bridge.IsAutomaticUpdateOn:output_type -> google.protobuf.BoolValue
+	87,  // 187: grpc.Bridge.DiskCachePath:output_type -> google.protobuf.StringValue
+	88,  // 188: grpc.Bridge.SetDiskCachePath:output_type -> google.protobuf.Empty
+	88,  // 189: grpc.Bridge.SetIsDoHEnabled:output_type -> google.protobuf.Empty
+	89,  // 190: grpc.Bridge.IsDoHEnabled:output_type -> google.protobuf.BoolValue
+	12,  // 191: grpc.Bridge.MailServerSettings:output_type -> grpc.ImapSmtpSettings
+	88,  // 192: grpc.Bridge.SetMailServerSettings:output_type -> google.protobuf.Empty
+	87,  // 193: grpc.Bridge.Hostname:output_type -> google.protobuf.StringValue
+	89,  // 194: grpc.Bridge.IsPortFree:output_type -> google.protobuf.BoolValue
+	14,  // 195: grpc.Bridge.ImapSessions:output_type -> grpc.ImapSessionListResponse
+	88,  // 196: grpc.Bridge.CloseImapSession:output_type -> google.protobuf.Empty
+	88,  // 197: grpc.Bridge.BlockImapClient:output_type -> google.protobuf.Empty
+	88,  // 198: grpc.Bridge.UnblockImapClient:output_type -> google.protobuf.Empty
+	16,  // 199: grpc.Bridge.AvailableKeychains:output_type -> grpc.AvailableKeychainsResponse
+	88,  // 200: grpc.Bridge.SetCurrentKeychain:output_type -> google.protobuf.Empty
+	87,  // 201: grpc.Bridge.CurrentKeychain:output_type -> google.protobuf.StringValue
+	20,  // 202: grpc.Bridge.GetUserList:output_type -> grpc.UserListResponse
+	17,  // 203: grpc.Bridge.GetUser:output_type -> grpc.User
+	88,  // 204: grpc.Bridge.SetUserSplitMode:output_type -> google.protobuf.Empty
+	88,  // 205: grpc.Bridge.SendBadEventUserFeedback:output_type -> google.protobuf.Empty
+	88,  // 206: grpc.Bridge.LogoutUser:output_type -> google.protobuf.Empty
+	88,  // 207: grpc.Bridge.RemoveUser:output_type -> google.protobuf.Empty
+	88,  // 208: grpc.Bridge.ConfigureUserAppleMail:output_type -> google.protobuf.Empty
+	89,  // 209: grpc.Bridge.IsTLSCertificateInstalled:output_type -> google.protobuf.BoolValue
+	88,  // 210: grpc.Bridge.InstallTLSCertificate:output_type -> google.protobuf.Empty
+	88,  // 211: grpc.Bridge.ExportTLSCertificates:output_type -> google.protobuf.Empty
+	23,  // 212: grpc.Bridge.RunEventStream:output_type -> grpc.StreamEvent
+	88,  // 213: grpc.Bridge.StopEventStream:output_type -> google.protobuf.Empty
+	88,  // 214: grpc.Bridge.TriggerRepair:output_type -> google.protobuf.Empty
+	145, // [145:215] is the sub-list for method output_type
+	75,  // [75:145] is the sub-list for method input_type
+	75,  // [75:75] is the sub-list for extension type_name
+	75,  // [75:75] is the sub-list for extension extendee
+	0,   // [0:75] is the sub-list for field type_name
 }
 
 func init() { file_bridge_proto_init() }
@@ -5708,7 +5956,7 @@ func file_bridge_proto_init() {
 		return
 	}
 	file_bridge_proto_msgTypes[3].OneofWrappers = []any{}
-	file_bridge_proto_msgTypes[13].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[16].OneofWrappers = []any{
 		(*StreamEvent_App)(nil),
 		(*StreamEvent_Login)(nil),
 		(*StreamEvent_Update)(nil),
@@ -5719,7 +5967,7 @@ func file_bridge_proto_init() {
 		(*StreamEvent_User)(nil),
 		(*StreamEvent_GenericError)(nil),
 	}
-	file_bridge_proto_msgTypes[14].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[17].OneofWrappers = []any{
 		(*AppEvent_InternetStatus)(nil),
 		(*AppEvent_ToggleAutostartFinished)(nil),
 		(*AppEvent_ResetFinished)(nil),
@@ -5736,7 +5984,7 @@ func file_bridge_proto_init() {
 		(*AppEvent_AllUsersLoaded)(nil),
 		(*AppEvent_UserNotification)(nil),
 	}
-	file_bridge_proto_msgTypes[30].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[33].OneofWrappers = []any{
 		(*LoginEvent_Error)(nil),
 		(*LoginEvent_TfaRequested)(nil),
 		(*LoginEvent_TwoPasswordRequested)(nil),
@@ -5749,7 +5997,7 @@ func file_bridge_proto_init() {
 		(*LoginEvent_LoginFidoTouchCompleted)(nil),
 		(*LoginEvent_LoginFidoPinRequired)(nil),
 	}
-	file_bridge_proto_msgTypes[40].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[43].OneofWrappers = []any{
 		(*UpdateEvent_Error)(nil),
 		(*UpdateEvent_ManualReady)(nil),
 		(*UpdateEvent_ManualRestartNeeded)(nil),
@@ -5759,27 +6007,27 @@ func file_bridge_proto_init() {
 		(*UpdateEvent_CheckFinished)(nil),
 		(*UpdateEvent_VersionChanged)(nil),
 	}
-	file_bridge_proto_msgTypes[49].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[52].OneofWrappers = []any{
 		(*DiskCacheEvent_Error)(nil),
 		(*DiskCacheEvent_PathChanged)(nil),
 		(*DiskCacheEvent_PathChangeFinished)(nil),
 	}
-	file_bridge_proto_msgTypes[53].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[56].OneofWrappers = []any{
 		(*MailServerSettingsEvent_Error)(nil),
 		(*MailServerSettingsEvent_MailServerSettingsChanged)(nil),
 		(*MailServerSettingsEvent_ChangeMailServerSettingsFinished)(nil),
 	}
-	file_bridge_proto_msgTypes[57].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[60].OneofWrappers = []any{
 		(*KeychainEvent_ChangeKeychainFinished)(nil),
 		(*KeychainEvent_HasNoKeychain)(nil),
 		(*KeychainEvent_RebuildKeychain)(nil),
 	}
-	file_bridge_proto_msgTypes[61].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[64].OneofWrappers = []any{
 		(*MailEvent_AddressChanged)(nil),
 		(*MailEvent_AddressChangedLogout)(nil),
 		(*MailEvent_ApiCertIssue)(nil),
 	}
-	file_bridge_proto_msgTypes[65].OneofWrappers = []any{
+	file_bridge_proto_msgTypes[68].OneofWrappers = []any{
 		(*UserEvent_ToggleSplitModeFinished)(nil),
 		(*UserEvent_UserDisconnected)(nil),
 		(*UserEvent_UserChanged)(nil),
@@ -5796,7 +6044,7 @@ func file_bridge_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bridge_proto_rawDesc), len(file_bridge_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   77,
+			NumMessages:   80,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -40,6 +40,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Bridge_CheckTokens_FullMethodName                     = "/grpc.Bridge/CheckTokens"
 	Bridge_AddLogEntry_FullMethodName                     = "/grpc.Bridge/AddLogEntry"
+	Bridge_LogLevels_FullMethodName                       = "/grpc.Bridge/LogLevels"
+	Bridge_SetLogLevels_FullMethodName                    = "/grpc.Bridge/SetLogLevels"
 	Bridge_GuiReady_FullMethodName                        = "/grpc.Bridge/GuiReady"
 	Bridge_Quit_FullMethodName                            = "/grpc.Bridge/Quit"
 	Bridge_Restart_FullMethodName                         = "/grpc.Bridge/Restart"
@@ -52,6 +54,7 @@ const (
 	Bridge_IsAllMailVisible_FullMethodName                = "/grpc.Bridge/IsAllMailVisible"
 	Bridge_SetIsTelemetryDisabled_FullMethodName          = "/grpc.Bridge/SetIsTelemetryDisabled"
 	Bridge_IsTelemetryDisabled_FullMethodName             = "/grpc.Bridge/IsTelemetryDisabled"
+	Bridge_IsSettingLocked_FullMethodName                 = "/grpc.Bridge/IsSettingLocked"
 	Bridge_GoOs_FullMethodName                            = "/grpc.Bridge/GoOs"
 	Bridge_TriggerReset_FullMethodName                    = "/grpc.Bridge/TriggerReset"
 	Bridge_Version_FullMethodName                         = "/grpc.Bridge/Version"
@@ -85,6 +88,10 @@ const (
 	Bridge_SetMailServerSettings_FullMethodName           = "/grpc.Bridge/SetMailServerSettings"
 	Bridge_Hostname_FullMethodName                        = "/grpc.Bridge/Hostname"
 	Bridge_IsPortFree_FullMethodName                      = "/grpc.Bridge/IsPortFree"
+	Bridge_ImapSessions_FullMethodName                    = "/grpc.Bridge/ImapSessions"
+	Bridge_CloseImapSession_FullMethodName                = "/grpc.Bridge/CloseImapSession"
+	Bridge_BlockImapClient_FullMethodName                 = "/grpc.Bridge/BlockImapClient"
+	Bridge_UnblockImapClient_FullMethodName               = "/grpc.Bridge/UnblockImapClient"
 	Bridge_AvailableKeychains_FullMethodName              = "/grpc.Bridge/AvailableKeychains"
 	Bridge_SetCurrentKeychain_FullMethodName              = "/grpc.Bridge/SetCurrentKeychain"
 	Bridge_CurrentKeychain_FullMethodName                 = "/grpc.Bridge/CurrentKeychain"
@@ -116,6 +123,8 @@ type BridgeClient interface {
 	// App related calls
 	CheckTokens(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
 	AddLogEntry(ctx context.Context, in *AddLogEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LogLevels(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
+	SetLogLevels(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GuiReady(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GuiReadyResponse, error)
 	Quit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Restart(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -128,6 +137,7 @@ type BridgeClient interface {
 	IsAllMailVisible(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
 	SetIsTelemetryDisabled(ctx context.Context, in *wrapperspb.BoolValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	IsTelemetryDisabled(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
+	IsSettingLocked(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
 	GoOs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
 	TriggerReset(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
@@ -165,6 +175,10 @@ type BridgeClient interface {
 	SetMailServerSettings(ctx context.Context, in *ImapSmtpSettings, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Hostname(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
 	IsPortFree(ctx context.Context, in *wrapperspb.Int32Value, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
+	ImapSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImapSessionListResponse, error)
+	CloseImapSession(ctx context.Context, in *wrapperspb.Int32Value, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BlockImapClient(ctx context.Context, in *BlockImapClientRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnblockImapClient(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// keychain
 	AvailableKeychains(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AvailableKeychainsResponse, error)
 	SetCurrentKeychain(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -216,6 +230,26 @@ func (c *bridgeClient) AddLogEntry(ctx context.Context, in *AddLogEntryRequest,
 	return out, nil
 }
 
+func (c *bridgeClient) LogLevels(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(wrapperspb.StringValue)
+	err := c.cc.Invoke(ctx, Bridge_LogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) SetLogLevels(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bridge_SetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bridgeClient) GuiReady(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GuiReadyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GuiReadyResponse)
@@ -336,6 +370,16 @@ func (c *bridgeClient) IsTelemetryDisabled(ctx context.Context, in *emptypb.Empt
 	return out, nil
 }
 
+func (c *bridgeClient) IsSettingLocked(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(wrapperspb.BoolValue)
+	err := c.cc.Invoke(ctx, Bridge_IsSettingLocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bridgeClient) GoOs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(wrapperspb.StringValue)
@@ -666,6 +710,46 @@ func (c *bridgeClient) IsPortFree(ctx context.Context, in *wrapperspb.Int32Value
 	return out, nil
 }
 
+func (c *bridgeClient) ImapSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImapSessionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImapSessionListResponse)
+	err := c.cc.Invoke(ctx, Bridge_ImapSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) CloseImapSession(ctx context.Context, in *wrapperspb.Int32Value, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bridge_CloseImapSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) BlockImapClient(ctx context.Context, in *BlockImapClientRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bridge_BlockImapClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) UnblockImapClient(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bridge_UnblockImapClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bridgeClient) AvailableKeychains(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AvailableKeychainsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AvailableKeychainsResponse)
@@ -848,6 +932,8 @@ type BridgeServer interface {
 	// App related calls
 	CheckTokens(context.Context, *wrapperspb.StringValue) (*wrapperspb.StringValue, error)
 	AddLogEntry(context.Context, *AddLogEntryRequest) (*emptypb.Empty, error)
+	LogLevels(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
+	SetLogLevels(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error)
 	GuiReady(context.Context, *emptypb.Empty) (*GuiReadyResponse, error)
 	Quit(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	Restart(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
@@ -860,6 +946,7 @@ type BridgeServer interface {
 	IsAllMailVisible(context.Context, *emptypb.Empty) (*wrapperspb.BoolValue, error)
 	SetIsTelemetryDisabled(context.Context, *wrapperspb.BoolValue) (*emptypb.Empty, error)
 	IsTelemetryDisabled(context.Context, *emptypb.Empty) (*wrapperspb.BoolValue, error)
+	IsSettingLocked(context.Context, *wrapperspb.StringValue) (*wrapperspb.BoolValue, error)
 	GoOs(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
 	TriggerReset(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
@@ -897,6 +984,10 @@ type BridgeServer interface {
 	SetMailServerSettings(context.Context, *ImapSmtpSettings) (*emptypb.Empty, error)
 	Hostname(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
 	IsPortFree(context.Context, *wrapperspb.Int32Value) (*wrapperspb.BoolValue, error)
+	ImapSessions(context.Context, *emptypb.Empty) (*ImapSessionListResponse, error)
+	CloseImapSession(context.Context, *wrapperspb.Int32Value) (*emptypb.Empty, error)
+	BlockImapClient(context.Context, *BlockImapClientRequest) (*emptypb.Empty, error)
+	UnblockImapClient(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error)
 	// keychain
 	AvailableKeychains(context.Context, *emptypb.Empty) (*AvailableKeychainsResponse, error)
 	SetCurrentKeychain(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error)
@@ -934,6 +1025,12 @@ func (UnimplementedBridgeServer) CheckTokens(context.Context, *wrapperspb.String
 func (UnimplementedBridgeServer) AddLogEntry(context.Context, *AddLogEntryRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddLogEntry not implemented")
 }
+func (UnimplementedBridgeServer) LogLevels(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogLevels not implemented")
+}
+func (UnimplementedBridgeServer) SetLogLevels(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevels not implemented")
+}
 func (UnimplementedBridgeServer) GuiReady(context.Context, *emptypb.Empty) (*GuiReadyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GuiReady not implemented")
 }
@@ -970,6 +1067,9 @@ func (UnimplementedBridgeServer) SetIsTelemetryDisabled(context.Context, *wrappe
 func (UnimplementedBridgeServer) IsTelemetryDisabled(context.Context, *emptypb.Empty) (*wrapperspb.BoolValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsTelemetryDisabled not implemented")
 }
+func (UnimplementedBridgeServer) IsSettingLocked(context.Context, *wrapperspb.StringValue) (*wrapperspb.BoolValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSettingLocked not implemented")
+}
 func (UnimplementedBridgeServer) GoOs(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GoOs not implemented")
 }
@@ -1069,6 +1169,18 @@ func (UnimplementedBridgeServer) Hostname(context.Context, *emptypb.Empty) (*wra
 func (UnimplementedBridgeServer) IsPortFree(context.Context, *wrapperspb.Int32Value) (*wrapperspb.BoolValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsPortFree not implemented")
 }
+func (UnimplementedBridgeServer) ImapSessions(context.Context, *emptypb.Empty) (*ImapSessionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImapSessions not implemented")
+}
+func (UnimplementedBridgeServer) CloseImapSession(context.Context, *wrapperspb.Int32Value) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseImapSession not implemented")
+}
+func (UnimplementedBridgeServer) BlockImapClient(context.Context, *BlockImapClientRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockImapClient not implemented")
+}
+func (UnimplementedBridgeServer) UnblockImapClient(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockImapClient not implemented")
+}
 func (UnimplementedBridgeServer) AvailableKeychains(context.Context, *emptypb.Empty) (*AvailableKeychainsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AvailableKeychains not implemented")
 }
@@ -1174,6 +1286,42 @@ func _Bridge_AddLogEntry_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bridge_LogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).LogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_LogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).LogLevels(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_SetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).SetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_SetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).SetLogLevels(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bridge_GuiReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1390,6 +1538,24 @@ func _Bridge_IsTelemetryDisabled_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bridge_IsSettingLocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).IsSettingLocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_IsSettingLocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).IsSettingLocked(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bridge_GoOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1984,6 +2150,78 @@ func _Bridge_IsPortFree_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bridge_ImapSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).ImapSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_ImapSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).ImapSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_CloseImapSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.Int32Value)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).CloseImapSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_CloseImapSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).CloseImapSession(ctx, req.(*wrapperspb.Int32Value))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_BlockImapClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockImapClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).BlockImapClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_BlockImapClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).BlockImapClient(ctx, req.(*BlockImapClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_UnblockImapClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).UnblockImapClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bridge_UnblockImapClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).UnblockImapClient(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bridge_AvailableKeychains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -2280,6 +2518,14 @@ var Bridge_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddLogEntry",
 			Handler:    _Bridge_AddLogEntry_Handler,
 		},
+		{
+			MethodName: "LogLevels",
+			Handler:    _Bridge_LogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevels",
+			Handler:    _Bridge_SetLogLevels_Handler,
+		},
 		{
 			MethodName: "GuiReady",
 			Handler:    _Bridge_GuiReady_Handler,
@@ -2328,6 +2574,10 @@ var Bridge_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsTelemetryDisabled",
 			Handler:    _Bridge_IsTelemetryDisabled_Handler,
 		},
+		{
+			MethodName: "IsSettingLocked",
+			Handler:    _Bridge_IsSettingLocked_Handler,
+		},
 		{
 			MethodName: "GoOs",
 			Handler:    _Bridge_GoOs_Handler,
@@ -2460,6 +2710,22 @@ var Bridge_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsPortFree",
 			Handler:    _Bridge_IsPortFree_Handler,
 		},
+		{
+			MethodName: "ImapSessions",
+			Handler:    _Bridge_ImapSessions_Handler,
+		},
+		{
+			MethodName: "CloseImapSession",
+			Handler:    _Bridge_CloseImapSession_Handler,
+		},
+		{
+			MethodName: "BlockImapClient",
+			Handler:    _Bridge_BlockImapClient_Handler,
+		},
+		{
+			MethodName: "UnblockImapClient",
+			Handler:    _Bridge_UnblockImapClient_Handler,
+		},
 		{
 			MethodName: "AvailableKeychains",
 			Handler:    _Bridge_AvailableKeychains_Handler,
@@ -18,6 +18,8 @@
 package grpc
 
 import (
+	"fmt"
+
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/kb"
 	"github.com/bradenaw/juniper/xslices"
@@ -283,6 +285,23 @@ func NewUserNotificationEvent(event events.UserNotification) *StreamEvent {
 		}}})
 }
 
+// NewContactKeyChangedEvent reports a recipient key change to the user as a notification.
+func NewContactKeyChangedEvent(event events.ContactKeyChanged) *StreamEvent {
+	body := fmt.Sprintf("The encryption key of %s has changed from %s to %s. ", event.Email, event.OldFingerprint, event.NewFingerprint)
+	if event.Blocked {
+		body += "The message was not sent. Approve the new key to send messages to this recipient."
+	} else {
+		body += "The message was sent with the new key. Approve the new key to stop this warning."
+	}
+
+	return NewUserNotificationEvent(events.UserNotification{
+		UserID:   event.UserID,
+		Title:    "Recipient key changed",
+		Subtitle: event.Email,
+		Body:     body,
+	})
+}
+
 // Event category factory functions.
 
 func appEvent(appEvent *AppEvent) *StreamEvent {
@@ -421,6 +421,9 @@ func (s *Service) watchEvents() {
 
 		case events.UserNotification:
 			_ = s.SendEvent(NewUserNotificationEvent(event))
+
+		case events.ContactKeyChanged:
+			_ = s.SendEvent(NewContactKeyChangedEvent(event))
 		}
 	}
 }
@@ -22,9 +22,11 @@ import (
 	"encoding/base64"
 	"errors"
 	"runtime"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/proton-bridge/v3/internal/bridge"
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
@@ -32,12 +34,15 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/frontend/theme"
 	"github.com/ProtonMail/proton-bridge/v3/internal/hv"
 	"github.com/ProtonMail/proton-bridge/v3/internal/kb"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
 	"github.com/ProtonMail/proton-bridge/v3/internal/platform"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
 	"github.com/ProtonMail/proton-bridge/v3/internal/service"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+	"github.com/bradenaw/juniper/xslices"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -94,6 +99,26 @@ func (s *Service) AddLogEntry(_ context.Context, request *AddLogEntryRequest) (*
 	return &emptypb.Empty{}, nil
 }
 
+// LogLevels returns the current log levels, as a specification such as "info,smtp=debug,sync=warn".
+func (s *Service) LogLevels(_ context.Context, _ *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	return wrapperspb.String(logging.GetLevels().String()), nil
+}
+
+// SetLogLevels changes the log levels without restarting.
+func (s *Service) SetLogLevels(_ context.Context, levels *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	s.log.WithField("levels", levels.Value).Debug("SetLogLevels")
+
+	if err := logging.SetLevels(levels.Value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to set log levels: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // GuiReady implement the GuiReady gRPC service call.
 func (s *Service) GuiReady(_ context.Context, _ *emptypb.Empty) (*GuiReadyResponse, error) {
 	defer async.HandlePanic(s.panicHandler)
@@ -169,7 +194,7 @@ func (s *Service) SetIsAutostartOn(_ context.Context, isOn *wrapperspb.BoolValue
 
 	if err := s.bridge.SetAutostart(isOn.Value); err != nil {
 		s.log.WithField("makeItEnabled", isOn.Value).WithError(err).Error("Autostart change failed")
-		return nil, status.Errorf(codes.Internal, "failed to set autostart: %v", err)
+		return nil, status.Errorf(settingErrorCode(err), "failed to set autostart: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -193,7 +218,7 @@ func (s *Service) SetIsBetaEnabled(_ context.Context, isEnabled *wrapperspb.Bool
 
 	if err := s.bridge.SetUpdateChannel(channel); err != nil {
 		s.log.WithError(err).Error("Failed to set update channel")
-		return nil, status.Errorf(codes.Internal, "failed to set update channel: %v", err)
+		return nil, status.Errorf(settingErrorCode(err), "failed to set update channel: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -231,7 +256,7 @@ func (s *Service) SetIsTelemetryDisabled(_ context.Context, isDisabled *wrappers
 
 	if err := s.bridge.SetTelemetryDisabled(isDisabled.Value); err != nil {
 		s.log.WithError(err).Error("Failed to set telemetry status")
-		return nil, status.Errorf(codes.Internal, "failed to set telemetry status: %v", err)
+		return nil, status.Errorf(settingErrorCode(err), "failed to set telemetry status: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -244,6 +269,14 @@ func (s *Service) IsTelemetryDisabled(_ context.Context, _ *emptypb.Empty) (*wra
 	return wrapperspb.Bool(s.bridge.GetTelemetryDisabled()), nil
 }
 
+// IsSettingLocked returns whether the given setting is locked by the system policy, so the GUI can disable its control.
+func (s *Service) IsSettingLocked(_ context.Context, name *wrapperspb.StringValue) (*wrapperspb.BoolValue, error) {
+	defer async.HandlePanic(s.panicHandler)
+	s.log.WithField("name", name.Value).Debug("IsSettingLocked")
+
+	return wrapperspb.Bool(s.bridge.IsSettingLocked(name.Value)), nil
+}
+
 func (s *Service) GoOs(_ context.Context, _ *emptypb.Empty) (*wrapperspb.StringValue, error) {
 	defer async.HandlePanic(s.panicHandler)
 	s.log.Debug("GoOs") // TO-DO We can probably get rid of this and use QSysInfo::product name
@@ -686,7 +719,7 @@ func (s *Service) SetIsAutomaticUpdateOn(_ context.Context, isOn *wrapperspb.Boo
 
 	if err := s.bridge.SetAutoUpdate(isOn.Value); err != nil {
 		s.log.WithError(err).Error("Failed to set auto update")
-		return nil, status.Errorf(codes.Internal, "failed to set auto update: %v", err)
+		return nil, status.Errorf(settingErrorCode(err), "failed to set auto update: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -747,7 +780,7 @@ func (s *Service) SetIsDoHEnabled(_ context.Context, isEnabled *wrapperspb.BoolV
 
 	if err := s.bridge.SetProxyAllowed(isEnabled.Value); err != nil {
 		s.log.WithError(err).Error("Failed to set DoH")
-		return nil, status.Errorf(codes.Internal, "failed to set DoH: %v", err)
+		return nil, status.Errorf(settingErrorCode(err), "failed to set DoH: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -785,6 +818,21 @@ func (s *Service) SetMailServerSettings(_ context.Context, settings *ImapSmtpSet
 		WithField("UseSSLForSMTP", settings.UseSSLForSmtp).
 		Debug("SetConnectionMode")
 
+	// The settings are changed asynchronously, so changes to locked settings are refused beforehand.
+	for _, setting := range []struct {
+		name    string
+		changed bool
+	}{
+		{name: policy.SettingIMAPSSL, changed: s.bridge.GetIMAPSSL() != settings.UseSSLForImap},
+		{name: policy.SettingSMTPSSL, changed: s.bridge.GetSMTPSSL() != settings.UseSSLForSmtp},
+		{name: policy.SettingIMAPPort, changed: s.bridge.GetIMAPPort() != int(settings.ImapPort)},
+		{name: policy.SettingSMTPPort, changed: s.bridge.GetSMTPPort() != int(settings.SmtpPort)},
+	} {
+		if setting.changed && s.bridge.IsSettingLocked(setting.name) {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to set %v: %v", setting.name, bridge.ErrSettingLocked)
+		}
+	}
+
 	//nolint:gosec //disable G118
 	go func() {
 		defer async.HandlePanic(s.panicHandler)
@@ -843,6 +891,68 @@ func (s *Service) IsPortFree(_ context.Context, port *wrapperspb.Int32Value) (*w
 	return wrapperspb.Bool(ports.IsPortFree(int(port.Value))), nil
 }
 
+func (s *Service) ImapSessions(_ context.Context, _ *emptypb.Empty) (*ImapSessionListResponse, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	s.log.Debug("ImapSessions")
+
+	sessions := xslices.Map(s.bridge.GetIMAPSessions(), func(session bridge.IMAPSessionInfo) *ImapSession {
+		return &ImapSession{
+			Id:            int32(session.ID), //nolint:gosec // disable G115
+			UserID:        session.UserID,
+			Client:        session.Client,
+			ClientType:    string(session.ClientType),
+			RemoteAddress: session.Remote,
+			Mailbox:       session.Mailbox,
+			ConnectedAt:   session.ConnectedAt.Unix(),
+			BytesReceived: session.BytesReceived,
+			BytesSent:     session.BytesSent,
+		}
+	})
+
+	return &ImapSessionListResponse{Sessions: sessions}, nil
+}
+
+func (s *Service) CloseImapSession(_ context.Context, sessionID *wrapperspb.Int32Value) (*emptypb.Empty, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	s.log.WithField("sessionID", sessionID.Value).Debug("CloseImapSession")
+
+	if err := s.bridge.CloseIMAPSession(int(sessionID.Value)); err != nil {
+		if errors.Is(err, bridge.ErrNoSuchIMAPSession) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to close IMAP session: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Service) BlockImapClient(_ context.Context, req *BlockImapClientRequest) (*emptypb.Empty, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	s.log.WithField("client", req.ClientType).WithField("minutes", req.DurationMinutes).Debug("BlockImapClient")
+
+	if err := s.bridge.BlockIMAPClient(connectionlimiter.Client(req.ClientType), time.Duration(req.DurationMinutes)*time.Minute); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to block IMAP client: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Service) UnblockImapClient(_ context.Context, clientType *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	defer async.HandlePanic(s.panicHandler)
+
+	s.log.WithField("client", clientType.Value).Debug("UnblockImapClient")
+
+	if err := s.bridge.UnblockIMAPClient(connectionlimiter.Client(clientType.Value)); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to unblock IMAP client: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 func (s *Service) AvailableKeychains(_ context.Context, _ *emptypb.Empty) (*AvailableKeychainsResponse, error) {
 	defer async.HandlePanic(s.panicHandler)
 
@@ -869,7 +979,8 @@ func (s *Service) SetCurrentKeychain(ctx context.Context, keychain *wrapperspb.S
 		return &emptypb.Empty{}, nil
 	}
 
-	if err := s.bridge.SetKeychainApp(keychain.Value); err != nil {
+	// Move the vault key to the new keychain so that the vault remains readable after the restart.
+	if err := s.bridge.RotateVaultKey(keychain.Value); err != nil {
 		s.log.WithError(err).Error("Failed to set keychain")
 		return nil, status.Errorf(codes.Internal, "failed to set keychain: %v", err)
 	}
@@ -924,3 +1035,12 @@ func (s *Service) getMailServerSettings() *ImapSmtpSettings {
 		UseSSLForSmtp: s.bridge.GetSMTPSSL(),
 	}
 }
+
+// settingErrorCode returns the status code of an error returned by a setter of the bridge.
+func settingErrorCode(err error) codes.Code {
+	if errors.Is(err, bridge.ErrSettingLocked) {
+		return codes.FailedPrecondition
+	}
+
+	return codes.Internal
+}
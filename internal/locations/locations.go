@@ -36,7 +36,8 @@ import (
 // - logs:     ~/.local/share/protonmail/<app>/logs
 // - updates:  ~/.local/share/protonmail/<app>/updates
 // - locks:    ~/.cache/protonmail/<app>/*.lock
-// Other OSes are similar.
+// Other OSes are similar. Profiles other than the default one use <app>-profiles/<profile> instead of <app>,
+// except for updates which are shared.
 type Locations struct {
 	// userConfig is the path to the user config directory, for storing persistent config data.
 	userConfig string
@@ -47,22 +48,42 @@ type Locations struct {
 	// userCache is the path to the user cache directory, for storing non-essential data.
 	userCache string
 
+	// sharedData is the path to the user data directory of the default profile, for storing data shared by all profiles.
+	sharedData string
+
 	configName    string
 	configGuiName string
+
+	// profile is the name of the profile these locations belong to; it is empty for the default profile.
+	profile string
 }
 
-// New returns a new locations object.
+// New returns a new locations object for the default profile.
 func New(provider Provider, configName string) *Locations {
+	return NewForProfile(provider, provider, configName, "")
+}
+
+// NewForProfile returns a new locations object for the given profile. Profile files are stored in the directories
+// of the given provider, except for updates which are shared with the default profile through the shared provider.
+func NewForProfile(provider, shared Provider, configName, profile string) *Locations {
 	return &Locations{
 		userConfig: provider.UserConfig(),
 		userData:   provider.UserData(),
 		userCache:  provider.UserCache(),
+		sharedData: shared.UserData(),
 
 		configName:    configName,
 		configGuiName: configName + "-gui",
+
+		profile: profile,
 	}
 }
 
+// GetProfile returns the name of the profile the locations belong to. It is empty for the default profile.
+func (l *Locations) GetProfile() string {
+	return l.profile
+}
+
 // GetLockFile returns the path to the bridge lock file (e.g. ~/.cache/<company>/<app>/<app>.lock).
 func (l *Locations) GetLockFile() string {
 	return filepath.Join(l.userCache, l.configName+".lock")
@@ -257,13 +278,21 @@ func (l *Locations) getGoIMAPCachePath() string {
 }
 
 func (l *Locations) getUpdatesPath() string {
-	return filepath.Join(l.userData, "updates")
+	return filepath.Join(l.sharedData, "updates")
 }
 
 func (l *Locations) getNotificationsCachePath() string {
 	return filepath.Join(l.userCache, "notifications")
 }
 
+func (l *Locations) getImageProxyCachePath() string {
+	return filepath.Join(l.userCache, "image_proxy_cache")
+}
+
+func (l *Locations) getAuditLogPath() string {
+	return filepath.Join(l.userData, "audit")
+}
+
 func (l *Locations) getUnleashCachePath() string { return filepath.Join(l.userCache, "unleash_cache") }
 
 func (l *Locations) getUnleashStartupCachePath() string {
@@ -306,3 +335,23 @@ func (l *Locations) ProvideNotificationsCachePath() (string, error) {
 
 	return l.getNotificationsCachePath(), nil
 }
+
+// ProvideImageProxyCachePath returns a location for the image proxy's encrypted cache.
+// It creates it if it doesn't already exist.
+func (l *Locations) ProvideImageProxyCachePath() (string, error) {
+	if err := os.MkdirAll(l.getImageProxyCachePath(), 0o700); err != nil {
+		return "", err
+	}
+
+	return l.getImageProxyCachePath(), nil
+}
+
+// ProvideAuditLogPath returns a location for the encrypted audit log.
+// It creates it if it doesn't already exist.
+func (l *Locations) ProvideAuditLogPath() (string, error) {
+	if err := os.MkdirAll(l.getAuditLogPath(), 0o700); err != nil {
+		return "", err
+	}
+
+	return l.getAuditLogPath(), nil
+}
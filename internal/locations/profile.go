@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package locations
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/files"
+)
+
+// profilesDirSuffix is appended to the app directory name to get the directory holding all profiles,
+// e.g. ~/.config/protonmail/bridge-v3-profiles/<profile>.
+const profilesDirSuffix = "-profiles"
+
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`) //nolint:gochecknoglobals
+
+var (
+	ErrInvalidProfileName = errors.New("profile names must be 1 to 32 lowercase letters, digits, '-' or '_'")
+	ErrProfileExists      = errors.New("profile already exists")
+	ErrNoSuchProfile      = errors.New("no such profile")
+	ErrNoFreePortOffset   = errors.New("all profile port offsets are taken")
+)
+
+// profilePortStep is the unit of the port offsets of profiles. The default IMAP, SMTP and image proxy ports differ
+// by other amounts than a multiple of it, so profiles with distinct offsets don't share ports.
+const profilePortStep = 100
+
+// profilePortSlots is the number of distinct port offsets given to profiles.
+const profilePortSlots = 50
+
+// profilePortOffsetFileName is the file of the profile config directory holding the port offset assigned to it.
+const profilePortOffsetFileName = "port_offset"
+
+// preferredPortSlot returns the port slot derived from the profile name, tried first when assigning its offset.
+func preferredPortSlot(profile string) int {
+	hash := fnv.New32a()
+
+	_, _ = hash.Write([]byte(profile))
+
+	return 1 + int(hash.Sum32()%profilePortSlots)
+}
+
+// ValidateProfileName returns an error if the given name cannot be used as a profile name.
+func ValidateProfileName(profile string) error {
+	if !profileNameRegex.MatchString(profile) {
+		return fmt.Errorf("%w: %q", ErrInvalidProfileName, profile)
+	}
+
+	return nil
+}
+
+// Profiles manages isolated app profiles. Each profile has its own config, data and cache directories,
+// stored in a sub-directory of the directories of the root provider.
+type Profiles struct {
+	root Provider
+}
+
+// NewProfiles returns the profiles stored in the directories of the given root provider.
+func NewProfiles(root Provider) *Profiles {
+	return &Profiles{root: root}
+}
+
+// NewDefaultProfiles returns the profiles of the app with the given name, stored next to its default locations.
+func NewDefaultProfiles(name string) (*Profiles, error) {
+	root, err := NewDefaultProvider(name + profilesDirSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProfiles(root), nil
+}
+
+// List returns the names of the existing profiles, sorted alphabetically.
+func (p *Profiles) List() ([]string, error) {
+	entries, err := os.ReadDir(p.root.UserConfig())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var profiles []string
+
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+
+	sort.Strings(profiles)
+
+	return profiles, nil
+}
+
+// Exists returns whether the given profile exists.
+func (p *Profiles) Exists(profile string) bool {
+	if ValidateProfileName(profile) != nil {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(p.root.UserConfig(), profile))
+
+	return err == nil && info.IsDir()
+}
+
+// Create creates the directories of a new profile.
+func (p *Profiles) Create(profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+
+	if p.Exists(profile) {
+		return fmt.Errorf("%w: %q", ErrProfileExists, profile)
+	}
+
+	if _, err := p.newProvider(profile); err != nil {
+		return err
+	}
+
+	_, err := p.PortOffset(profile)
+
+	return err
+}
+
+// PortOffset returns the offset added to the default ports of the given profile, so that profiles running side by
+// side don't compete for the same ports. It is zero for the default profile. The offset is assigned the first time
+// it is requested, preferably from the profile name, and stored with the profile; names whose slots collide are
+// given the next slot not taken by another profile.
+func (p *Profiles) PortOffset(profile string) (int, error) {
+	if profile == "" {
+		return 0, nil
+	}
+
+	if !p.Exists(profile) {
+		return 0, fmt.Errorf("%w: %q", ErrNoSuchProfile, profile)
+	}
+
+	if offset, ok := p.readPortOffset(profile); ok {
+		return offset, nil
+	}
+
+	names, err := p.List()
+	if err != nil {
+		return 0, err
+	}
+
+	taken := make(map[int]bool)
+
+	for _, name := range names {
+		if offset, ok := p.readPortOffset(name); ok && name != profile {
+			taken[offset/profilePortStep] = true
+		}
+	}
+
+	for i := 0; i < profilePortSlots; i++ {
+		slot := 1 + (preferredPortSlot(profile)-1+i)%profilePortSlots
+
+		if taken[slot] {
+			continue
+		}
+
+		offset := slot * profilePortStep
+
+		if err := os.WriteFile(p.portOffsetPath(profile), []byte(strconv.Itoa(offset)), 0o600); err != nil {
+			return 0, fmt.Errorf("could not store port offset: %w", err)
+		}
+
+		return offset, nil
+	}
+
+	return 0, ErrNoFreePortOffset
+}
+
+func (p *Profiles) readPortOffset(profile string) (int, bool) {
+	b, err := os.ReadFile(p.portOffsetPath(profile))
+	if err != nil {
+		return 0, false
+	}
+
+	offset, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || offset <= 0 || offset%profilePortStep != 0 {
+		return 0, false
+	}
+
+	return offset, true
+}
+
+func (p *Profiles) portOffsetPath(profile string) string {
+	return filepath.Join(p.root.UserConfig(), profile, profilePortOffsetFileName)
+}
+
+// Delete removes all the files of the given profile.
+func (p *Profiles) Delete(profile string) error {
+	if !p.Exists(profile) {
+		return fmt.Errorf("%w: %q", ErrNoSuchProfile, profile)
+	}
+
+	return files.Remove(
+		filepath.Join(p.root.UserConfig(), profile),
+		filepath.Join(p.root.UserData(), profile),
+		filepath.Join(p.root.UserCache(), profile),
+	).Do()
+}
+
+// Provider returns a locations provider for the directories of the given existing profile.
+func (p *Profiles) Provider(profile string) (Provider, error) {
+	if !p.Exists(profile) {
+		return nil, fmt.Errorf("%w: %q", ErrNoSuchProfile, profile)
+	}
+
+	return p.newProvider(profile)
+}
+
+func (p *Profiles) newProvider(profile string) (*DefaultProvider, error) {
+	return newProvider(
+		filepath.Join(p.root.UserConfig(), profile),
+		filepath.Join(p.root.UserData(), profile),
+		filepath.Join(p.root.UserCache(), profile),
+	)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package locations
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"work", "personal-2", "a_b", "0"} {
+		assert.NoError(t, ValidateProfileName(name), name)
+	}
+
+	for _, name := range []string{"", "Work", "../work", "work/home", "-work", "a very long profile name that is not allowed"} {
+		assert.ErrorIs(t, ValidateProfileName(name), ErrInvalidProfileName, name)
+	}
+}
+
+func TestProfiles_PortOffset(t *testing.T) {
+	profiles := NewProfiles(newFakeAppDirs(t))
+
+	offset, err := profiles.PortOffset("")
+	require.NoError(t, err)
+	require.Zero(t, offset)
+
+	require.NoError(t, profiles.Create("work"))
+
+	offset, err = profiles.PortOffset("work")
+	require.NoError(t, err)
+	require.Equal(t, preferredPortSlot("work")*profilePortStep, offset)
+
+	// A profile whose name gives the same slot is assigned another offset.
+	var other string
+
+	for i := 0; other == ""; i++ {
+		if name := fmt.Sprintf("profile-%v", i); preferredPortSlot(name) == preferredPortSlot("work") {
+			other = name
+		}
+	}
+
+	require.NoError(t, profiles.Create(other))
+
+	otherOffset, err := profiles.PortOffset(other)
+	require.NoError(t, err)
+	require.Positive(t, otherOffset)
+	require.Zero(t, otherOffset%profilePortStep)
+	require.NotEqual(t, offset, otherOffset)
+
+	// The assigned offsets are kept.
+	offset2, err := profiles.PortOffset("work")
+	require.NoError(t, err)
+	require.Equal(t, offset, offset2)
+
+	otherOffset2, err := profiles.PortOffset(other)
+	require.NoError(t, err)
+	require.Equal(t, otherOffset, otherOffset2)
+}
+
+func TestProfiles_CreateListDelete(t *testing.T) {
+	profiles := NewProfiles(newFakeAppDirs(t))
+
+	names, err := profiles.List()
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	require.NoError(t, profiles.Create("work"))
+	require.NoError(t, profiles.Create("personal"))
+	require.ErrorIs(t, profiles.Create("work"), ErrProfileExists)
+
+	names, err = profiles.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"personal", "work"}, names)
+
+	require.NoError(t, profiles.Delete("work"))
+	require.ErrorIs(t, profiles.Delete("work"), ErrNoSuchProfile)
+
+	names, err = profiles.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"personal"}, names)
+}
+
+func TestProfiles_LocationsAreIsolated(t *testing.T) {
+	shared := newFakeAppDirs(t)
+	profiles := NewProfiles(newFakeAppDirs(t))
+
+	_, err := profiles.Provider("work")
+	require.ErrorIs(t, err, ErrNoSuchProfile)
+
+	require.NoError(t, profiles.Create("work"))
+	require.NoError(t, profiles.Create("personal"))
+
+	workProvider, err := profiles.Provider("work")
+	require.NoError(t, err)
+
+	personalProvider, err := profiles.Provider("personal")
+	require.NoError(t, err)
+
+	def := New(shared, "configName")
+	work := NewForProfile(workProvider, shared, "configName", "work")
+	personal := NewForProfile(personalProvider, shared, "configName", "personal")
+
+	assert.Equal(t, "", def.GetProfile())
+	assert.Equal(t, "work", work.GetProfile())
+
+	assert.NotEqual(t, def.getSettingsPath(), work.getSettingsPath())
+	assert.NotEqual(t, work.getSettingsPath(), personal.getSettingsPath())
+	assert.NotEqual(t, work.getGluonDataPath(), personal.getGluonDataPath())
+	assert.NotEqual(t, work.getLogsPath(), personal.getLogsPath())
+	assert.NotEqual(t, work.GetLockFile(), personal.GetLockFile())
+
+	// Updates are managed by the launcher and shared by all profiles.
+	assert.Equal(t, def.getUpdatesPath(), work.getUpdatesPath())
+	assert.Equal(t, filepath.Join(shared.UserData(), "updates"), personal.getUpdatesPath())
+}
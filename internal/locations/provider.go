@@ -39,25 +39,23 @@ type DefaultProvider struct {
 }
 
 func NewDefaultProvider(name string) (*DefaultProvider, error) {
-	config, err := os.UserConfigDir()
+	config, data, cache, err := userDirs()
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := userDataDir()
-	if err != nil {
-		return nil, err
-	}
-
-	cache, err := os.UserCacheDir()
-	if err != nil {
-		return nil, err
-	}
+	return newProvider(
+		filepath.Join(config, name),
+		filepath.Join(data, name),
+		filepath.Join(cache, name),
+	)
+}
 
+func newProvider(config, data, cache string) (*DefaultProvider, error) {
 	provider := &DefaultProvider{
-		config: filepath.Join(config, name),
-		data:   filepath.Join(data, name),
-		cache:  filepath.Join(cache, name),
+		config: config,
+		data:   data,
+		cache:  cache,
 	}
 
 	if err := os.MkdirAll(provider.config, 0o700); err != nil {
@@ -93,6 +91,23 @@ func (p *DefaultProvider) UserCache() string {
 	return p.cache
 }
 
+// userDirs returns the system-default user config, data and cache directories.
+func userDirs() (config, data, cache string, err error) {
+	if config, err = os.UserConfigDir(); err != nil {
+		return "", "", "", err
+	}
+
+	if data, err = userDataDir(); err != nil {
+		return "", "", "", err
+	}
+
+	if cache, err = os.UserCacheDir(); err != nil {
+		return "", "", "", err
+	}
+
+	return config, data, cache, nil
+}
+
 // userDataDir returns a directory that can be used to store user-specific data.
 // This is necessary because os.UserDataDir() is not implemented by the Go standard library, sadly.
 // On non-linux systems, it is the same as os.UserConfigDir().
@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidLevels = errors.New("invalid log levels")
+
+// Levels is the log level configuration: a default level, and optional overrides for components.
+// A component is matched against the "pkg" field of log entries, or their "service" field if they have no "pkg", either as a prefix (e.g. "gluon" for "gluon/session")
+// or as one of its segments (e.g. "smtp" for "server/smtp"). When several components match, the longest wins.
+type Levels struct {
+	Default    logrus.Level
+	Components map[string]logrus.Level
+}
+
+// ParseLevels parses a level specification such as "info,smtp=debug,sync=warn". An entry without a component
+// sets the default level, which is debug if absent.
+func ParseLevels(spec string) (Levels, error) {
+	levels := Levels{
+		Default:    logrus.DebugLevel,
+		Components: make(map[string]logrus.Level),
+	}
+
+	hasDefault := false
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		component, levelName, found := strings.Cut(item, "=")
+		if !found {
+			if hasDefault {
+				return Levels{}, fmt.Errorf("%w: more than one default level", ErrInvalidLevels)
+			}
+
+			level, err := logrus.ParseLevel(item)
+			if err != nil {
+				return Levels{}, fmt.Errorf("%w: %w", ErrInvalidLevels, err)
+			}
+
+			levels.Default, hasDefault = level, true
+
+			continue
+		}
+
+		component = strings.ToLower(strings.Trim(strings.TrimSpace(component), "/"))
+		if component == "" {
+			return Levels{}, fmt.Errorf("%w: missing component in %q", ErrInvalidLevels, item)
+		}
+
+		level, err := logrus.ParseLevel(strings.TrimSpace(levelName))
+		if err != nil {
+			return Levels{}, fmt.Errorf("%w: %w", ErrInvalidLevels, err)
+		}
+
+		levels.Components[component] = level
+	}
+
+	return levels, nil
+}
+
+// String returns the specification of the levels, in the format accepted by ParseLevels.
+func (l Levels) String() string {
+	items := []string{l.Default.String()}
+
+	components := make([]string, 0, len(l.Components))
+	for component := range l.Components {
+		components = append(components, component)
+	}
+
+	sort.Strings(components)
+
+	for _, component := range components {
+		items = append(items, component+"="+l.Components[component].String())
+	}
+
+	return strings.Join(items, ",")
+}
+
+// maxLevel returns the most verbose of the configured levels.
+func (l Levels) maxLevel() logrus.Level {
+	level := l.Default
+
+	for _, componentLevel := range l.Components {
+		level = max(level, componentLevel)
+	}
+
+	return level
+}
+
+// levelFor returns the level that applies to log entries of the given package.
+func (l Levels) levelFor(pkg string) logrus.Level {
+	if pkg == "" || len(l.Components) == 0 {
+		return l.Default
+	}
+
+	pkg = strings.ToLower(pkg)
+	segments := strings.Split(pkg, "/")
+
+	level, matchLen := l.Default, 0
+
+	for component, componentLevel := range l.Components {
+		if len(component) <= matchLen {
+			continue
+		}
+
+		if pkg == component || strings.HasPrefix(pkg, component+"/") || containsSegment(segments, component) {
+			level, matchLen = componentLevel, len(component)
+		}
+	}
+
+	return level
+}
+
+func containsSegment(segments []string, component string) bool {
+	for _, segment := range segments {
+		if segment == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// levelFilter holds the current levels. The logrus level is kept at the most verbose configured level and
+// entries are then filtered by their package.
+type levelFilter struct {
+	levels Levels
+	lock   sync.RWMutex
+}
+
+var filter = &levelFilter{levels: Levels{Default: logrus.DebugLevel}} //nolint:gochecknoglobals
+
+func (f *levelFilter) set(levels Levels) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.levels = levels
+
+	logrus.SetLevel(levels.maxLevel())
+}
+
+func (f *levelFilter) get() Levels {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.levels
+}
+
+func (f *levelFilter) isEnabled(entry *logrus.Entry) bool {
+	pkg, ok := entry.Data["pkg"].(string)
+	if !ok {
+		pkg, _ = entry.Data["service"].(string)
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.levels.levelFor(pkg) >= entry.Level
+}
+
+// GetLevels returns the current log level configuration.
+func GetLevels() Levels {
+	return filter.get()
+}
+
+// SetLevels changes the log levels at runtime. See ParseLevels for the format of spec.
+func SetLevels(spec string) error {
+	levels, err := ParseLevels(spec)
+	if err != nil {
+		return err
+	}
+
+	filter.set(levels)
+
+	logrus.WithField("levels", levels.String()).Info("Log levels changed")
+
+	return nil
+}
+
+// filteringFormatter drops the entries disabled by the level filter before handing them to the wrapped formatter.
+type filteringFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *filteringFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !filter.isEnabled(entry) {
+		return nil, nil
+	}
+
+	return f.formatter.Format(entry)
+}
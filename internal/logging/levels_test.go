@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels_Parse(t *testing.T) {
+	levels, err := ParseLevels("")
+	require.NoError(t, err)
+	require.Equal(t, logrus.DebugLevel, levels.Default)
+	require.Empty(t, levels.Components)
+
+	levels, err = ParseLevels("info, SMTP=debug,imap=info,sync=warn")
+	require.NoError(t, err)
+	require.Equal(t, logrus.InfoLevel, levels.Default)
+	require.Equal(t, map[string]logrus.Level{
+		"smtp": logrus.DebugLevel,
+		"imap": logrus.InfoLevel,
+		"sync": logrus.WarnLevel,
+	}, levels.Components)
+	require.Equal(t, "info,imap=info,smtp=debug,sync=warning", levels.String())
+	require.Equal(t, logrus.DebugLevel, levels.maxLevel())
+
+	for _, spec := range []string{"verbose", "info,warn", "=debug", "smtp=loud"} {
+		_, err := ParseLevels(spec)
+		require.ErrorIs(t, err, ErrInvalidLevels, spec)
+	}
+}
+
+func TestLevels_LevelFor(t *testing.T) {
+	levels, err := ParseLevels("warn,imap=info,gluon=error,gluon/session=trace,server/smtp=debug")
+	require.NoError(t, err)
+
+	require.Equal(t, logrus.WarnLevel, levels.levelFor(""))
+	require.Equal(t, logrus.WarnLevel, levels.levelFor("vault"))
+	require.Equal(t, logrus.InfoLevel, levels.levelFor("server/imap"))
+	require.Equal(t, logrus.InfoLevel, levels.levelFor("log/IMAP"))
+	require.Equal(t, logrus.ErrorLevel, levels.levelFor("gluon/backend"))
+	require.Equal(t, logrus.TraceLevel, levels.levelFor("gluon/session"))
+	require.Equal(t, logrus.DebugLevel, levels.levelFor("server/smtp"))
+	require.Equal(t, logrus.WarnLevel, levels.levelFor("log/SMTP"))
+}
+
+func TestLevels_Filter(t *testing.T) {
+	defer filter.set(filter.get())
+
+	buf := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetFormatter(newFormatter(FormatText))
+
+	require.NoError(t, SetLevels("warn,smtp=debug"))
+	logger.SetLevel(logrus.GetLevel())
+
+	logger.WithField("pkg", "server/smtp").Debug("smtp debug")
+	logger.WithField("service", "smtp").Debug("smtp service debug")
+	logger.WithField("pkg", "server/imap").Info("imap info")
+	logger.WithField("pkg", "server/imap").Warn("imap warn")
+	logger.Info("default info")
+
+	require.Contains(t, buf.String(), "smtp debug")
+	require.Contains(t, buf.String(), "smtp service debug")
+	require.NotContains(t, buf.String(), "imap info")
+	require.Contains(t, buf.String(), "imap warn")
+	require.NotContains(t, buf.String(), "default info")
+
+	require.NoError(t, SetLevels("info"))
+	buf.Reset()
+
+	logger.WithField("pkg", "server/smtp").Debug("smtp debug")
+	logger.WithField("pkg", "server/imap").Info("imap info")
+
+	require.NotContains(t, buf.String(), "smtp debug")
+	require.Contains(t, buf.String(), "imap info")
+
+	require.Error(t, SetLevels("info,smtp"))
+	require.Equal(t, "info", GetLevels().String())
+}
+
+func TestFormat_Sensitive(t *testing.T) {
+	defer filter.set(filter.get())
+
+	filter.set(Levels{Default: logrus.DebugLevel})
+
+	format, err := ParseFormat("")
+	require.NoError(t, err)
+	require.Equal(t, FormatText, format)
+
+	_, err = ParseFormat("xml")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	const secret = "secret@proton.me"
+
+	for _, format := range []Format{FormatText, FormatJSON} {
+		buf := new(bytes.Buffer)
+		logger := logrus.New()
+		logger.SetOutput(buf)
+		logger.SetFormatter(newFormatter(format))
+
+		logger.WithField("pkg", "smtp").WithField("recipient", Sensitive(secret)).Info("Sending message")
+
+		if isSensitiveBuild() {
+			require.Contains(t, buf.String(), secret)
+		} else {
+			require.NotContains(t, buf.String(), secret)
+		}
+
+		if format == FormatJSON {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			require.Equal(t, "smtp", entry["pkg"])
+			require.Equal(t, "Sending message", entry["msg"])
+			require.Equal(t, Sensitive(secret), entry["recipient"])
+		}
+	}
+}
+
+func isSensitiveBuild() bool {
+	return Sensitive("x") == "x"
+}
@@ -22,6 +22,7 @@ import (
 	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -52,6 +53,54 @@ const (
 	GUIShortAppName      AppName = "gui"
 )
 
+// Format is the format of the log files.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+var ErrInvalidFormat = errors.New("invalid log format")
+
+// ParseFormat returns the log format with the given name. An empty name is the text format.
+func ParseFormat(name string) (Format, error) {
+	switch Format(name) {
+	case "", FormatText:
+		return FormatText, nil
+
+	case FormatJSON:
+		return FormatJSON, nil
+
+	default:
+		return "", fmt.Errorf("%w: %q (must be one of %s, %s)", ErrInvalidFormat, name, FormatText, FormatJSON)
+	}
+}
+
+// newFormatter returns the formatter for the log files. Values are written as they were passed to the logger, so
+// anything hidden with Sensitive stays hidden whatever the format.
+func newFormatter(format Format) logrus.Formatter {
+	var formatter logrus.Formatter
+
+	switch format {
+	case FormatJSON:
+		formatter = &logrus.JSONFormatter{
+			TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+		}
+
+	default:
+		formatter = &logrus.TextFormatter{
+			DisableColors:    true,
+			ForceQuote:       true,
+			FullTimestamp:    true,
+			QuoteEmptyFields: true,
+			TimestampFormat:  "2006-01-02 15:04:05.000",
+		}
+	}
+
+	return &filteringFormatter{formatter: formatter}
+}
+
 type coloredStdOutHook struct {
 	formatter logrus.Formatter
 }
@@ -76,6 +125,10 @@ func (cs *coloredStdOutHook) Levels() []logrus.Level {
 }
 
 func (cs *coloredStdOutHook) Fire(entry *logrus.Entry) error {
+	if !filter.isEnabled(entry) {
+		return nil
+	}
+
 	bytes, err := cs.formatter.Format(entry)
 	if err != nil {
 		return err
@@ -89,15 +142,20 @@ func (cs *coloredStdOutHook) Fire(entry *logrus.Entry) error {
 }
 
 // Init Initialize logging. Log files are rotated when their size exceeds rotationSize. if pruningSize >= 0, pruning occurs using
-// the default pruning algorithm.
-func Init(logsPath string, sessionID SessionID, appName AppName, rotationSize, pruningSize int64, level string) (io.Closer, error) {
-	logrus.SetFormatter(&logrus.TextFormatter{
-		DisableColors:    true,
-		ForceQuote:       true,
-		FullTimestamp:    true,
-		QuoteEmptyFields: true,
-		TimestampFormat:  "2006-01-02 15:04:05.000",
-	})
+// the default pruning algorithm. The level is a specification as accepted by ParseLevels, and the format is the name of a
+// Format.
+func Init(logsPath string, sessionID SessionID, appName AppName, rotationSize, pruningSize int64, level, format string) (io.Closer, error) {
+	logFormat, err := ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := ParseLevels(level)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.SetFormatter(newFormatter(logFormat))
 
 	logrus.AddHook(newColoredStdOutHook())
 
@@ -108,7 +166,9 @@ func Init(logsPath string, sessionID SessionID, appName AppName, rotationSize, p
 
 	logrus.SetOutput(rotator)
 
-	return rotator, setLevel(level)
+	setLevels(levels, logFormat)
+
+	return rotator, nil
 }
 
 // Close closes the log file. if closer is nil, no error is reported.
@@ -179,34 +239,26 @@ func getOrderedLogFileListForBugReport(logsPath string, maxSessionCount int) ([]
 	return result, nil
 }
 
-// setLevel will change the level of logging and in case of Debug or Trace
+// setLevels will change the levels of logging and in case of a default Trace
 // level it will also prevent from writing to file. Setting level to Info or
 // higher will not set writing to file again if it was previously cancelled by
-// Debug or Trace.
-func setLevel(level string) error {
-	if level == "" {
-		level = "debug"
-	}
-
-	logLevel, err := logrus.ParseLevel(level)
-	if err != nil {
-		return err
-	}
-
-	logrus.SetLevel(logLevel)
+// Trace.
+func setLevels(levels Levels, format Format) {
+	filter.set(levels)
 
 	// The hook to print panic, fatal and error to stderr is always
 	// added. We want to avoid log duplicates by replacing all hooks.
-	if logrus.GetLevel() == logrus.TraceLevel {
+	if levels.Default == logrus.TraceLevel {
 		_ = logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
 		logrus.SetOutput(os.Stderr)
-		logrus.SetFormatter(&logrus.TextFormatter{
-			FullTimestamp:   true,
-			TimestampFormat: time.StampMilli,
-		})
-	}
 
-	return nil
+		if format == FormatText {
+			logrus.SetFormatter(&filteringFormatter{formatter: &logrus.TextFormatter{
+				FullTimestamp:   true,
+				TimestampFormat: time.StampMilli,
+			}})
+		}
+	}
 }
 
 func getLogSessionID(filename string) (SessionID, error) {
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package policy reads the system-wide policy file, with which administrators of managed deployments configure Bridge
+// for all the users of a machine.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/proton-bridge/v3/internal/platform"
+)
+
+const fileName = "policy.json"
+
+// Policy is the content of the system-wide policy file.
+type Policy struct {
+	Updates  Updates  `json:"updates"`
+	Settings Settings `json:"settings"`
+}
+
+// Updates is the update policy.
+type Updates struct {
+	// Source is where updates are fetched from instead of Proton's servers: the URL of a mirror, or a local directory.
+	// Either way, it must have the same layout as the download server, and the files are verified with the same key.
+	Source string `json:"source"`
+
+	// AllowedVersions is a version constraint, such as "3.x" or "~3.21.0". Newer releases that don't satisfy it are
+	// ignored until the policy is changed to approve them.
+	AllowedVersions string `json:"allowedVersions"`
+}
+
+// GetVersionConstraint returns the parsed AllowedVersions, or nil if any version is allowed.
+func (u Updates) GetVersionConstraint() (*semver.Constraints, error) {
+	if u.AllowedVersions == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	constraint, err := semver.NewConstraint(u.AllowedVersions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed versions %q: %w", u.AllowedVersions, err)
+	}
+
+	return constraint, nil
+}
+
+// DefaultPath returns the location of the system-wide policy file, which only administrators can write:
+//   - Windows: %ProgramData%\Proton\Bridge\policy.json, usually C:\ProgramData\Proton\Bridge\policy.json
+//   - macOS: /Library/Application Support/Proton AG/Proton Mail Bridge/policy.json
+//   - Linux: /etc/proton-bridge/policy.json
+func DefaultPath() string {
+	switch runtime.GOOS {
+	case platform.WINDOWS:
+		programData := os.Getenv("ProgramData")
+		if programData == "" {
+			programData = `C:\ProgramData`
+		}
+
+		return filepath.Join(programData, "Proton", "Bridge", fileName)
+
+	case platform.MACOS:
+		return filepath.Join("/Library", "Application Support", "Proton AG", "Proton Mail Bridge", fileName)
+
+	default:
+		return filepath.Join("/etc", "proton-bridge", fileName)
+	}
+}
+
+// Load reads the policy file at the given path. A missing file is an empty policy.
+func Load(path string) (Policy, error) {
+	b, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return Policy{}, nil
+	} else if err != nil {
+		return Policy{}, fmt.Errorf("could not read policy file: %w", err)
+	}
+
+	var policy Policy
+
+	if err := json.Unmarshal(b, &policy); err != nil {
+		return Policy{}, fmt.Errorf("could not parse policy file: %w", err)
+	}
+
+	if _, err := policy.Updates.GetVersionConstraint(); err != nil {
+		return Policy{}, err
+	}
+
+	if err := policy.Settings.validate(); err != nil {
+		return Policy{}, fmt.Errorf("invalid settings policy: %w", err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	policy, err := Load(filepath.Join(t.TempDir(), "policy.json"))
+	require.NoError(t, err)
+	require.Equal(t, Policy{}, policy)
+
+	constraint, err := policy.Updates.GetVersionConstraint()
+	require.NoError(t, err)
+	require.Nil(t, constraint)
+}
+
+func TestLoad_Updates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"updates":{"source":"https://mirror.example.com/download","allowedVersions":"3.x"}}`), 0o600))
+
+	policy, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "https://mirror.example.com/download", policy.Updates.Source)
+
+	constraint, err := policy.Updates.GetVersionConstraint()
+	require.NoError(t, err)
+	require.True(t, constraint.Check(semver.MustParse("3.21.2")))
+	require.False(t, constraint.Check(semver.MustParse("4.0.0")))
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"updates":{"allowedVersions":"not a version"}}`), 0o600))
+
+	_, err := Load(path)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0o600))
+
+	_, err = Load(path)
+	require.Error(t, err)
+}
+
+func TestLoad_Settings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"settings":{"imapPort":1143,"updateChannel":"stable","locked":["telemetryDisabled","imapPort"]}}`), 0o600))
+
+	policy, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, 1143, *policy.Settings.IMAPPort)
+	require.Equal(t, updater.StableChannel, *policy.Settings.UpdateChannel)
+	require.Nil(t, policy.Settings.SMTPPort)
+
+	require.True(t, policy.Settings.IsLocked(SettingIMAPPort))
+	require.False(t, policy.Settings.IsLocked(SettingSMTPPort))
+	require.Equal(t, []string{SettingIMAPPort, SettingTelemetryDisabled}, policy.Settings.GetLocked())
+}
+
+func TestLoad_InvalidSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+
+	for _, settings := range []string{
+		`{"locked":["colorScheme"]}`,
+		`{"imapPort":0}`,
+		`{"smtpPort":65536}`,
+		`{"updateChannel":"nightly"}`,
+		`{"cacheDir":"relative/path"}`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(`{"settings":`+settings+`}`), 0o600))
+
+		_, err := Load(path)
+		require.Error(t, err, settings)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
+	"golang.org/x/exp/slices"
+)
+
+// The names of the settings a policy can manage. They are used as keys in the policy file and to report which
+// settings are locked.
+const (
+	SettingIMAPPort          = "imapPort"
+	SettingIMAPSSL           = "imapSSL"
+	SettingSMTPPort          = "smtpPort"
+	SettingSMTPSSL           = "smtpSSL"
+	SettingProxyAllowed      = "proxyAllowed"
+	SettingTelemetryDisabled = "telemetryDisabled"
+	SettingUpdateChannel     = "updateChannel"
+	SettingAutoUpdate        = "autoUpdate"
+	SettingAutostart         = "autostart"
+	SettingCacheDir          = "cacheDir"
+)
+
+// settingNames lists all the settings a policy can manage.
+var settingNames = []string{ //nolint:gochecknoglobals
+	SettingIMAPPort,
+	SettingIMAPSSL,
+	SettingSMTPPort,
+	SettingSMTPSSL,
+	SettingProxyAllowed,
+	SettingTelemetryDisabled,
+	SettingUpdateChannel,
+	SettingAutoUpdate,
+	SettingAutostart,
+	SettingCacheDir,
+}
+
+// Settings is the settings policy.
+// The values that are set are applied over the ones of the vault at every start. Settings named in Locked can't be
+// changed by the user; if a locked setting has no value, the user can't change it from what it currently is.
+type Settings struct {
+	IMAPPort          *int             `json:"imapPort"`
+	IMAPSSL           *bool            `json:"imapSSL"`
+	SMTPPort          *int             `json:"smtpPort"`
+	SMTPSSL           *bool            `json:"smtpSSL"`
+	ProxyAllowed      *bool            `json:"proxyAllowed"`
+	TelemetryDisabled *bool            `json:"telemetryDisabled"`
+	UpdateChannel     *updater.Channel `json:"updateChannel"`
+	AutoUpdate        *bool            `json:"autoUpdate"`
+	Autostart         *bool            `json:"autostart"`
+	CacheDir          *string          `json:"cacheDir"`
+
+	Locked []string `json:"locked"`
+}
+
+// IsLocked returns whether the given setting can't be changed by the user.
+func (s Settings) IsLocked(name string) bool {
+	return slices.Contains(s.Locked, name)
+}
+
+// GetLocked returns the sorted names of the locked settings.
+func (s Settings) GetLocked() []string {
+	locked := slices.Clone(s.Locked)
+
+	sort.Strings(locked)
+
+	return locked
+}
+
+func (s Settings) validate() error {
+	for _, name := range s.Locked {
+		if !slices.Contains(settingNames, name) {
+			return fmt.Errorf("unknown locked setting %q", name)
+		}
+	}
+
+	for _, port := range []*int{s.IMAPPort, s.SMTPPort} {
+		if port != nil && (*port < 1 || *port > 65535) {
+			return fmt.Errorf("invalid port %d", *port)
+		}
+	}
+
+	if s.UpdateChannel != nil && *s.UpdateChannel != updater.StableChannel && *s.UpdateChannel != updater.EarlyChannel {
+		return fmt.Errorf("invalid update channel %q", *s.UpdateChannel)
+	}
+
+	if s.CacheDir != nil && !filepath.IsAbs(*s.CacheDir) {
+		return fmt.Errorf("cache directory %q is not an absolute path", *s.CacheDir)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package ratelimit limits the rate of the API requests that mail clients cause through Bridge, with a token bucket
+// per operation class, so that a misbehaving client can't get the account rate-limited by the API.
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Class is a kind of operation whose requests are limited together.
+type Class string
+
+const (
+	ClassSend   Class = "send"
+	ClassImport Class = "import"
+	ClassLabel  Class = "label"
+	ClassFetch  Class = "fetch"
+)
+
+// Classes lists all the limited operation classes.
+var Classes = []Class{ClassSend, ClassImport, ClassLabel, ClassFetch} //nolint:gochecknoglobals
+
+// Limit is the rate at which requests of a class are allowed: up to Burst requests at once, then PerMinute requests
+// per minute. A PerMinute of 0 means unlimited.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// Unlimited reports whether the limit lets all requests through.
+func (l Limit) Unlimited() bool {
+	return l.PerMinute == 0
+}
+
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%v/min, burst %v", l.PerMinute, l.Burst)
+}
+
+// DefaultLimits are the limits of the classes the user didn't set a limit for.
+//
+//nolint:gochecknoglobals
+var DefaultLimits = map[Class]Limit{
+	ClassSend:   {PerMinute: 20, Burst: 10},
+	ClassImport: {PerMinute: 120, Burst: 60},
+	ClassLabel:  {PerMinute: 600, Burst: 300},
+	ClassFetch:  {PerMinute: 1200, Burst: 600},
+}
+
+// ErrLimited is matched by the errors returned for throttled requests.
+var ErrLimited = errors.New("too many requests")
+
+// Error is returned for a throttled request.
+type Error struct {
+	Class      Class
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("too many %v requests, retry in %v", e.Class, e.RetryAfter.Round(time.Second))
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrLimited
+}
+
+// Status is the state of the limiter of a class, as shown in diagnostics.
+type Status struct {
+	Class         Class
+	Limit         Limit
+	Tokens        int
+	Allowed       uint64
+	Throttled     uint64
+	LastThrottled time.Time `json:",omitempty"`
+}
+
+// Limiter limits the rate of the requests of one user. A nil limiter lets all requests through.
+type Limiter struct {
+	buckets map[Class]*bucket
+	lock    sync.Mutex
+
+	now func() time.Time
+}
+
+type bucket struct {
+	limit     Limit
+	tokens    float64
+	updatedAt time.Time
+
+	allowed       uint64
+	throttled     uint64
+	lastThrottled time.Time
+}
+
+// New returns a limiter with full buckets, using the given limits and the default limits for the other classes.
+func New(limits map[Class]Limit) *Limiter {
+	limiter := &Limiter{
+		buckets: make(map[Class]*bucket, len(Classes)),
+		now:     time.Now,
+	}
+
+	for _, class := range Classes {
+		limit, ok := limits[class]
+		if !ok {
+			limit = DefaultLimits[class]
+		}
+
+		limiter.buckets[class] = &bucket{limit: limit, tokens: float64(limit.Burst), updatedAt: limiter.now()}
+	}
+
+	return limiter
+}
+
+// Allow takes a token from the bucket of the class, or returns an *Error telling when to retry if it is empty.
+func (l *Limiter) Allow(class Class) error {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, ok := l.buckets[class]
+	if !ok {
+		return nil
+	}
+
+	if b.limit.Unlimited() {
+		b.allowed++
+		return nil
+	}
+
+	now := l.now()
+
+	b.refill(now)
+
+	if b.tokens < 1 {
+		b.throttled++
+		b.lastThrottled = now
+
+		return &Error{
+			Class:      class,
+			RetryAfter: time.Duration((1 - b.tokens) / float64(b.limit.PerMinute) * float64(time.Minute)),
+		}
+	}
+
+	b.tokens--
+	b.allowed++
+
+	return nil
+}
+
+// SetLimit changes the limit of the class. Raising the burst adds the extra tokens at once; lowering it drops the
+// tokens above it.
+func (l *Limiter) SetLimit(class Class, limit Limit) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, ok := l.buckets[class]
+	if !ok {
+		return
+	}
+
+	b.refill(l.now())
+	b.tokens = math.Max(0, math.Min(b.tokens+float64(limit.Burst-b.limit.Burst), float64(limit.Burst)))
+	b.limit = limit
+}
+
+// GetStatus returns the state of the limiter of each class, in the order of Classes.
+func (l *Limiter) GetStatus() []Status {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	status := make([]Status, 0, len(Classes))
+
+	for _, class := range Classes {
+		b := l.buckets[class]
+
+		b.refill(l.now())
+
+		status = append(status, Status{
+			Class:         class,
+			Limit:         b.limit,
+			Tokens:        int(b.tokens),
+			Allowed:       b.allowed,
+			Throttled:     b.throttled,
+			LastThrottled: b.lastThrottled,
+		})
+	}
+
+	return status
+}
+
+func (b *bucket) refill(now time.Time) {
+	if !b.limit.Unlimited() {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.updatedAt).Minutes()*float64(b.limit.PerMinute))
+	}
+
+	b.updatedAt = now
+}
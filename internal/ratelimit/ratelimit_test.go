@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(limits map[Class]Limit) (*Limiter, *time.Time) {
+	now := time.Now()
+
+	limiter := New(limits)
+	limiter.now = func() time.Time { return now }
+
+	for _, b := range limiter.buckets {
+		b.updatedAt = now
+	}
+
+	return limiter, &now
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	limiter, now := newTestLimiter(map[Class]Limit{ClassSend: {PerMinute: 6, Burst: 2}})
+
+	// The burst is allowed at once.
+	require.NoError(t, limiter.Allow(ClassSend))
+	require.NoError(t, limiter.Allow(ClassSend))
+
+	// Then requests are throttled until a token is added.
+	err := limiter.Allow(ClassSend)
+	require.ErrorIs(t, err, ErrLimited)
+
+	var limitErr *Error
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, ClassSend, limitErr.Class)
+	require.Equal(t, 10*time.Second, limitErr.RetryAfter)
+
+	*now = now.Add(10 * time.Second)
+	require.NoError(t, limiter.Allow(ClassSend))
+	require.ErrorIs(t, limiter.Allow(ClassSend), ErrLimited)
+
+	// Other classes have their own bucket.
+	require.NoError(t, limiter.Allow(ClassFetch))
+
+	// The bucket doesn't fill above the burst.
+	*now = now.Add(time.Hour)
+	require.NoError(t, limiter.Allow(ClassSend))
+	require.NoError(t, limiter.Allow(ClassSend))
+	require.ErrorIs(t, limiter.Allow(ClassSend), ErrLimited)
+
+	status := limiter.GetStatus()
+	require.Len(t, status, len(Classes))
+	require.Equal(t, Status{
+		Class:         ClassSend,
+		Limit:         Limit{PerMinute: 6, Burst: 2},
+		Tokens:        0,
+		Allowed:       5,
+		Throttled:     3,
+		LastThrottled: *now,
+	}, status[0])
+	require.Equal(t, DefaultLimits[ClassFetch], status[3].Limit)
+	require.Equal(t, uint64(1), status[3].Allowed)
+}
+
+func TestLimiter_SetLimit(t *testing.T) {
+	limiter, _ := newTestLimiter(map[Class]Limit{ClassLabel: {PerMinute: 60, Burst: 10}})
+
+	// Lowering the burst drops the extra tokens.
+	limiter.SetLimit(ClassLabel, Limit{PerMinute: 60, Burst: 1})
+	require.NoError(t, limiter.Allow(ClassLabel))
+	require.ErrorIs(t, limiter.Allow(ClassLabel), ErrLimited)
+
+	// Raising the burst adds the extra tokens at once.
+	limiter.SetLimit(ClassLabel, Limit{PerMinute: 60, Burst: 3})
+	require.NoError(t, limiter.Allow(ClassLabel))
+	require.NoError(t, limiter.Allow(ClassLabel))
+	require.ErrorIs(t, limiter.Allow(ClassLabel), ErrLimited)
+
+	// An unlimited class lets all requests through.
+	limiter.SetLimit(ClassLabel, Limit{})
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Allow(ClassLabel))
+	}
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var limiter *Limiter
+
+	require.NoError(t, limiter.Allow(ClassSend))
+}
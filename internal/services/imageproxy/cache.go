@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imageproxy
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var errInvalidCacheEntry = errors.New("invalid cache entry")
+
+// cache stores fetched images on disk, encrypted, in a directory per user which is removed when the user logs out.
+// Entries are named after a keyed hash of their URL, so that the cache doesn't reveal which URLs were fetched.
+// Their user and URL are authenticated along with the image, so an entry can't be served for another URL or user.
+// When the cache grows over its size limit, the least recently used entries of all users are removed.
+type cache struct {
+	dir     string
+	gcm     cipher.AEAD
+	nameKey []byte
+
+	maxSize     int64
+	maxSizeLock sync.RWMutex
+
+	lock sync.Mutex
+}
+
+func newCache(dir string, gcm cipher.AEAD, nameKey []byte, maxSize int64) *cache {
+	return &cache{
+		dir:     dir,
+		gcm:     gcm,
+		nameKey: nameKey,
+		maxSize: maxSize,
+	}
+}
+
+func (c *cache) setMaxSize(maxSize int64) {
+	c.maxSizeLock.Lock()
+	defer c.maxSizeLock.Unlock()
+
+	c.maxSize = maxSize
+}
+
+func (c *cache) getMaxSize() int64 {
+	c.maxSizeLock.RLock()
+	defer c.maxSizeLock.RUnlock()
+
+	return c.maxSize
+}
+
+// get returns the cached image for the given user and URL, if any.
+func (c *cache) get(userID, url string) (string, []byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	path := c.path(userID, url)
+
+	enc, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return "", nil, false
+	}
+
+	contentType, data, err := c.decrypt(userID, url, enc)
+	if err != nil {
+		logrus.WithError(err).Warn("Removing unreadable image cache entry")
+		_ = os.Remove(path)
+
+		return "", nil, false
+	}
+
+	// Mark the entry as recently used.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return contentType, data, true
+}
+
+// put stores the image for the given user and URL, then removes old entries if the cache is over its size limit.
+func (c *cache) put(userID, url, contentType string, data []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	maxSize := c.getMaxSize()
+
+	if int64(len(data)) > maxSize {
+		return nil
+	}
+
+	enc, err := c.encrypt(userID, url, contentType, data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.userDir(userID), 0o700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.path(userID, url), enc, 0o600); err != nil {
+		return err
+	}
+
+	return c.evict(maxSize)
+}
+
+// remove removes the cached images of the given user.
+func (c *cache) remove(userID string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return os.RemoveAll(c.userDir(userID))
+}
+
+// evict removes the least recently used entries of all users until the cache fits in the given size.
+func (c *cache) evict(maxSize int64) error {
+	type cacheEntry struct {
+		path string
+		info os.FileInfo
+	}
+
+	userDirs, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	var (
+		entries []cacheEntry
+		size    int64
+	)
+
+	for _, userDir := range userDirs {
+		if !userDir.IsDir() {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(c.dir, userDir.Name()))
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+
+			entries = append(entries, cacheEntry{path: filepath.Join(c.dir, userDir.Name(), file.Name()), info: info})
+			size += info.Size()
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b cacheEntry) int {
+		return a.info.ModTime().Compare(b.info.ModTime())
+	})
+
+	for _, entry := range entries {
+		if size <= maxSize {
+			break
+		}
+
+		if err := os.Remove(entry.path); err != nil {
+			return err
+		}
+
+		size -= entry.info.Size()
+	}
+
+	return nil
+}
+
+func (c *cache) userDir(userID string) string {
+	return filepath.Join(c.dir, filepath.Base(userID))
+}
+
+func (c *cache) path(userID, url string) string {
+	mac := hmac.New(sha256.New, c.nameKey)
+	mac.Write([]byte(url))
+
+	return filepath.Join(c.userDir(userID), hex.EncodeToString(mac.Sum(nil)))
+}
+
+// encrypt seals the content type and data of an image. The content type is stored first, terminated by a newline.
+// The user and URL of the image are authenticated as additional data.
+func (c *cache) encrypt(userID, url, contentType string, data []byte) ([]byte, error) {
+	nonce := make([]byte, c.gcm.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, 0, len(contentType)+1+len(data))
+	plain = append(plain, contentType...)
+	plain = append(plain, '\n')
+	plain = append(plain, data...)
+
+	return c.gcm.Seal(nonce, nonce, plain, additionalData(userID, url)), nil
+}
+
+func (c *cache) decrypt(userID, url string, enc []byte) (string, []byte, error) {
+	if len(enc) < c.gcm.NonceSize() {
+		return "", nil, errInvalidCacheEntry
+	}
+
+	plain, err := c.gcm.Open(nil, enc[:c.gcm.NonceSize()], enc[c.gcm.NonceSize():], additionalData(userID, url))
+	if err != nil {
+		return "", nil, err
+	}
+
+	contentType, data, ok := bytes.Cut(plain, []byte{'\n'})
+	if !ok {
+		return "", nil, errInvalidCacheEntry
+	}
+
+	return string(contentType), data, nil
+}
+
+func additionalData(userID, url string) []byte {
+	return []byte(userID + "\x00" + url)
+}
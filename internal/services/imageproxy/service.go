@@ -0,0 +1,384 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package imageproxy implements a localhost HTTP proxy for the remote images of messages.
+//
+// An image is fetched by Bridge the first time the client displays it, from the user's own IP address.
+// The remote server therefore still learns that, and when, the message was first read.
+// What it doesn't get is the client's cookies, referrer, user agent or other headers, nor any later reads:
+// images are then served from a cache, encrypted on disk and kept separate for each user.
+// Images are only fetched from public addresses, so that messages can't make Bridge reach the local network.
+package imageproxy
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxImageSize is the size of the largest image the proxy fetches.
+	maxImageSize = 10 * 1024 * 1024
+
+	// maxRedirects is the number of redirects followed when fetching an image.
+	maxRedirects = 5
+
+	// userAgent is sent instead of the client's, so that all Bridge users look the same.
+	userAgent = "Mozilla/5.0"
+)
+
+var (
+	errNotAnImage    = errors.New("remote content is not an image")
+	errImageTooLarge = errors.New("remote image is too large")
+)
+
+// Service serves the remote images of messages on localhost.
+// The URLs it serves are signed, so it only fetches URLs that were rewritten by Bridge,
+// and only for users who enabled the proxy.
+type Service struct {
+	port      int
+	isEnabled func(userID string) bool
+
+	client *http.Client
+	urlKey []byte
+	cache  *cache
+
+	server     *http.Server
+	serverLock sync.Mutex
+
+	panicHandler async.PanicHandler
+
+	log *logrus.Entry
+}
+
+// NewService returns a new image proxy for the given port. It doesn't listen until Start is called.
+// Images are fetched with the given transport, which should only connect to public addresses. The key signs URLs and encrypts the cache; it must stay the same
+// across restarts, otherwise URLs in messages that were already built no longer work.
+func NewService(
+	transport http.RoundTripper,
+	getCachePath func() (string, error),
+	key []byte,
+	port int,
+	maxCacheSize int64,
+	isEnabled func(userID string) bool,
+	panicHandler async.PanicHandler,
+) (*Service, error) {
+	gcm, err := newGCM(deriveKey(key, "cache"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+
+	log := logrus.WithField("pkg", "imageproxy")
+
+	cachePath, err := getCachePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache path: %w", err)
+	}
+
+	return &Service{
+		port:      port,
+		isEnabled: isEnabled,
+
+		client: &http.Client{
+			Transport:     transport,
+			CheckRedirect: checkRedirect,
+			Timeout:       time.Minute,
+		},
+		urlKey: deriveKey(key, "url"),
+		cache:  newCache(cachePath, gcm, deriveKey(key, "name"), maxCacheSize),
+
+		panicHandler: panicHandler,
+
+		log: log,
+	}, nil
+}
+
+// URL returns the proxy URL of the given remote image for the given user.
+// It only depends on its arguments and the key, so messages are built the same way every time.
+func (s *Service) URL(userID, remote string) string {
+	if strings.HasPrefix(remote, "//") {
+		remote = "https:" + remote
+	}
+
+	return fmt.Sprintf("http://%v/image/%v/%v?url=%v",
+		net.JoinHostPort(constants.Host, strconv.Itoa(s.port)),
+		url.PathEscape(userID),
+		s.sign(userID, remote),
+		url.QueryEscape(remote),
+	)
+}
+
+// SetTransportTest - sets the transport images are fetched with; should only be used for tests.
+func (s *Service) SetTransportTest(transport http.RoundTripper) {
+	s.client.Transport = transport
+}
+
+// SetMaxCacheSize sets the size limit of the image cache, in bytes. It applies from the next cached image.
+func (s *Service) SetMaxCacheSize(size int64) {
+	s.cache.setMaxSize(size)
+}
+
+// RemoveUser removes the cached images of the given user.
+func (s *Service) RemoveUser(userID string) error {
+	return s.cache.remove(userID)
+}
+
+// Start starts listening on localhost. It does nothing if the proxy is already listening.
+func (s *Service) Start() error {
+	s.serverLock.Lock()
+	defer s.serverLock.Unlock()
+
+	if s.server != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(constants.Host, strconv.Itoa(s.port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %v: %w", s.port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image/", s.handleImage)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func(server *http.Server) {
+		defer async.HandlePanic(s.panicHandler)
+
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Error("Image proxy stopped")
+		}
+	}(s.server)
+
+	s.log.WithField("port", s.port).Info("Image proxy started")
+
+	return nil
+}
+
+// Stop stops listening.
+func (s *Service) Stop(ctx context.Context) error {
+	s.serverLock.Lock()
+	defer s.serverLock.Unlock()
+
+	if s.server == nil {
+		return nil
+	}
+
+	defer func() { s.server = nil }()
+	defer s.client.CloseIdleConnections()
+
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Service) handleImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, sig, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/image/"), "/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	remote := r.URL.Query().Get("url")
+
+	if !hmac.Equal([]byte(sig), []byte(s.sign(userID, remote))) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if !s.isEnabled(userID) {
+		http.NotFound(w, r)
+		return
+	}
+
+	contentType, data, err := s.getImage(r.Context(), userID, remote)
+	if err != nil {
+		s.log.WithError(err).Debug("Failed to fetch remote image")
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+
+		return
+	}
+
+	// Only our own headers are sent back; nothing from the remote server reaches the client.
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := w.Write(data); err != nil {
+		s.log.WithError(err).Debug("Failed to write image")
+	}
+}
+
+// getImage returns the image at the given URL, from the user's cache if possible.
+func (s *Service) getImage(ctx context.Context, userID, remote string) (string, []byte, error) {
+	if contentType, data, ok := s.cache.get(userID, remote); ok {
+		return contentType, data, nil
+	}
+
+	contentType, data, err := s.fetchImage(ctx, remote)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := s.cache.put(userID, remote, contentType, data); err != nil {
+		s.log.WithError(err).Warn("Failed to cache image")
+	}
+
+	return contentType, data, nil
+}
+
+// fetchImage fetches the image at the given URL. The request is built from scratch:
+// it carries no cookies, no referrer and none of the client's headers.
+func (s *Service) fetchImage(ctx context.Context, remote string) (string, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, remote, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := checkURL(req.URL); err != nil {
+		return "", nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "image/*")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected status %v", res.Status)
+	}
+
+	contentType, err := getImageContentType(res.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxImageSize+1))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(data) > maxImageSize {
+		return "", nil, errImageTooLarge
+	}
+
+	return contentType, data, nil
+}
+
+func (s *Service) sign(userID, remote string) string {
+	mac := hmac.New(sha256.New, s.urlKey)
+	mac.Write([]byte(userID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(remote))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// getImageContentType returns the media type of an image response. SVG is refused as it can embed remote content.
+func getImageContentType(header string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return "", errNotAnImage
+	}
+
+	if !strings.HasPrefix(mediaType, "image/") || mediaType == "image/svg+xml" {
+		return "", errNotAnImage
+	}
+
+	return mediaType, nil
+}
+
+// checkURL refuses URLs which aren't plain web URLs.
+// Whether the host is public is checked by the dialer, once its address is resolved.
+func checkURL(remote *url.URL) error {
+	if remote.Scheme != "http" && remote.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", remote.Scheme)
+	}
+
+	if remote.User != nil {
+		return errors.New("URLs with credentials are not supported")
+	}
+
+	return nil
+}
+
+// checkRedirect checks every redirect like the original URL, limits their number
+// and makes sure they don't leak where the image is displayed.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.New("too many redirects")
+	}
+
+	if err := checkURL(req.URL); err != nil {
+		return err
+	}
+
+	req.Header.Del("Referer")
+	req.Header.Del("Cookie")
+
+	return nil
+}
+
+func deriveKey(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("imageproxy-" + label))
+
+	return mac.Sum(nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
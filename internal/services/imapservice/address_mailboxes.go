@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/go-proton-api"
+	"golang.org/x/exp/slices"
+)
+
+// In hybrid address mode, the unified mailboxes of combined mode are kept and every address also gets a read-only
+// view of some system mailboxes, e.g. Addresses/alias@proton.me/INBOX. These address mailboxes are not backed by a
+// label: a message is in Addresses/<email>/<mailbox> if it is in <mailbox> and belongs to the address.
+// Their IDs are built as Addresses/<addrID>/<labelID>, which never collides with API label IDs.
+const addressPrefix = "Addresses"
+
+// addressMailboxLabels are the system labels for which a per-address mailbox is created in hybrid mode.
+var addressMailboxLabels = []string{ //nolint:gochecknoglobals
+	proton.InboxLabel,
+	proton.DraftsLabel,
+	proton.SentLabel,
+	proton.ArchiveLabel,
+	proton.SpamLabel,
+	proton.TrashLabel,
+}
+
+func addressNodeMailboxID(addrID string) imap.MailboxID {
+	return imap.MailboxID(addressPrefix + "/" + addrID)
+}
+
+func addressMailboxID(addrID, labelID string) imap.MailboxID {
+	return imap.MailboxID(addressPrefix + "/" + addrID + "/" + labelID)
+}
+
+// parseAddressMailboxID returns the address ID and label ID of the given address mailbox ID.
+func parseAddressMailboxID(mboxID imap.MailboxID) (string, string, bool) {
+	rest, ok := strings.CutPrefix(string(mboxID), addressPrefix+"/")
+	if !ok {
+		return "", "", false
+	}
+
+	addrID, labelID, ok := strings.Cut(rest, "/")
+	if !ok || addrID == "" || labelID == "" {
+		return "", "", false
+	}
+
+	return addrID, labelID, true
+}
+
+// isAddressMailbox returns whether the given mailbox is one of the mailboxes of the Addresses hierarchy,
+// including the \Noselect parent mailboxes.
+func isAddressMailbox(mboxID imap.MailboxID) bool {
+	return mboxID == addressPrefix || strings.HasPrefix(string(mboxID), addressPrefix+"/")
+}
+
+// resolveAddressMailboxID returns the ID of the label backing the given mailbox.
+// Mailboxes which are not address mailboxes are returned unchanged.
+func resolveAddressMailboxID(mboxID imap.MailboxID) imap.MailboxID {
+	if _, labelID, ok := parseAddressMailboxID(mboxID); ok {
+		return imap.MailboxID(labelID)
+	}
+
+	return mboxID
+}
+
+// withAddressMailboxIDs returns the given mailbox IDs extended with the address mailboxes of the given address.
+func withAddressMailboxIDs(addrID string, mboxIDs []imap.MailboxID) []imap.MailboxID {
+	if addrID == "" {
+		return mboxIDs
+	}
+
+	result := slices.Clone(mboxIDs)
+
+	for _, labelID := range addressMailboxLabels {
+		if slices.Contains(mboxIDs, imap.MailboxID(labelID)) {
+			result = append(result, addressMailboxID(addrID, labelID))
+		}
+	}
+
+	return result
+}
+
+// addAddressMailboxIDs adds the address mailboxes of the given address to the mailboxes of the messages of the update.
+func addAddressMailboxIDs(addrID string, update imap.Update) {
+	switch update := update.(type) {
+	case *imap.MessagesCreated:
+		for _, message := range update.Messages {
+			message.MailboxIDs = withAddressMailboxIDs(addrID, message.MailboxIDs)
+		}
+
+	case *imap.MessageUpdated:
+		update.MailboxIDs = withAddressMailboxIDs(addrID, update.MailboxIDs)
+
+	case *imap.MessageMailboxesUpdated:
+		update.MailboxIDs = withAddressMailboxIDs(addrID, update.MailboxIDs)
+	}
+}
+
+// newAddressMailboxesCreatedUpdates returns the updates creating the address mailboxes of the given addresses.
+func newAddressMailboxesCreatedUpdates(labels map[string]proton.Label, addrs []proton.Address) []imap.Update {
+	updates := []imap.Update{newPlaceHolderMailboxCreatedUpdate(addressPrefix)}
+
+	for _, addr := range addrs {
+		updates = append(updates, imap.NewMailboxCreated(imap.Mailbox{
+			ID:             addressNodeMailboxID(addr.ID),
+			Name:           []string{addressPrefix, addr.Email},
+			Flags:          defaultMailboxFlags(),
+			PermanentFlags: defaultMailboxPermanentFlags(),
+			Attributes:     imap.NewFlagSet(imap.AttrNoSelect),
+		}))
+
+		for _, labelID := range addressMailboxLabels {
+			label, ok := labels[labelID]
+			if !ok {
+				continue
+			}
+
+			// Reuse the system mailbox definition for the name normalization and flags, without the special-use
+			// attributes which must only be advertised by the unified mailboxes.
+			mbox := newSystemMailboxCreatedUpdate(imap.MailboxID(labelID), label.Name).Mailbox
+			mbox.ID = addressMailboxID(addr.ID, labelID)
+			mbox.Name = []string{addressPrefix, addr.Email, mbox.Name[0]}
+			mbox.Attributes = imap.NewFlagSet(imap.AttrNoInferiors)
+
+			updates = append(updates, imap.NewMailboxCreated(mbox))
+		}
+	}
+
+	return updates
+}
+
+// newAddressMailboxesDeletedUpdates returns the updates deleting the address mailboxes of the given address.
+func newAddressMailboxesDeletedUpdates(addrID string) []imap.Update {
+	updates := make([]imap.Update, 0, len(addressMailboxLabels)+1)
+
+	for _, labelID := range addressMailboxLabels {
+		updates = append(updates, imap.NewMailboxDeleted(addressMailboxID(addrID, labelID)))
+	}
+
+	return append(updates, imap.NewMailboxDeleted(addressNodeMailboxID(addrID)))
+}
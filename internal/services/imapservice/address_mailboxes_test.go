@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressMailboxID(t *testing.T) {
+	mboxID := addressMailboxID("addr-ID==", proton.InboxLabel)
+
+	addrID, labelID, ok := parseAddressMailboxID(mboxID)
+	require.True(t, ok)
+	require.Equal(t, "addr-ID==", addrID)
+	require.Equal(t, proton.InboxLabel, labelID)
+
+	require.True(t, isAddressMailbox(mboxID))
+	require.True(t, isAddressMailbox(addressNodeMailboxID("addr-ID==")))
+	require.True(t, isAddressMailbox(addressPrefix))
+	require.Equal(t, imap.MailboxID(proton.InboxLabel), resolveAddressMailboxID(mboxID))
+
+	for _, mboxID := range []imap.MailboxID{proton.InboxLabel, folderPrefix, addressNodeMailboxID("addr-ID==")} {
+		_, _, ok := parseAddressMailboxID(mboxID)
+		require.False(t, ok)
+		require.Equal(t, mboxID, resolveAddressMailboxID(mboxID))
+	}
+}
+
+func TestWithAddressMailboxIDs(t *testing.T) {
+	mboxIDs := []imap.MailboxID{proton.InboxLabel, proton.AllMailLabel, "custom-folder"}
+
+	require.Equal(t, []imap.MailboxID{
+		proton.InboxLabel,
+		proton.AllMailLabel,
+		"custom-folder",
+		addressMailboxID("addrID", proton.InboxLabel),
+	}, withAddressMailboxIDs("addrID", mboxIDs))
+
+	// The input is not modified.
+	require.Len(t, mboxIDs, 3)
+
+	require.Equal(t, mboxIDs, withAddressMailboxIDs("", mboxIDs))
+}
+
+func TestAddAddressMailboxIDs(t *testing.T) {
+	created := imap.NewMessagesCreated(true, &imap.MessageCreated{MailboxIDs: []imap.MailboxID{proton.SentLabel}})
+	addAddressMailboxIDs("addrID", created)
+	require.Equal(t, []imap.MailboxID{proton.SentLabel, addressMailboxID("addrID", proton.SentLabel)}, created.Messages[0].MailboxIDs)
+
+	updated := imap.NewMessageMailboxesUpdated("msgID", []imap.MailboxID{proton.TrashLabel, proton.StarredLabel}, imap.NewFlagSet())
+	addAddressMailboxIDs("addrID", updated)
+	require.Equal(t, []imap.MailboxID{proton.TrashLabel, proton.StarredLabel, addressMailboxID("addrID", proton.TrashLabel)}, updated.MailboxIDs)
+}
+
+func TestNewAddressMailboxesCreatedUpdates(t *testing.T) {
+	labels := map[string]proton.Label{
+		proton.InboxLabel: {ID: proton.InboxLabel, Name: "Inbox", Type: proton.LabelTypeSystem},
+		proton.SentLabel:  {ID: proton.SentLabel, Name: "Sent", Type: proton.LabelTypeSystem},
+	}
+
+	updates := newAddressMailboxesCreatedUpdates(labels, []proton.Address{{ID: "addrID", Email: "alias@proton.me"}})
+	require.Len(t, updates, 4)
+
+	mailboxes := make(map[imap.MailboxID]imap.Mailbox)
+
+	for _, update := range updates {
+		created, ok := update.(*imap.MailboxCreated)
+		require.True(t, ok)
+
+		mailboxes[created.Mailbox.ID] = created.Mailbox
+	}
+
+	require.Equal(t, []string{addressPrefix}, mailboxes[addressPrefix].Name)
+	require.True(t, mailboxes[addressPrefix].Attributes.Contains(imap.AttrNoSelect))
+
+	require.Equal(t, []string{addressPrefix, "alias@proton.me"}, mailboxes[addressNodeMailboxID("addrID")].Name)
+	require.True(t, mailboxes[addressNodeMailboxID("addrID")].Attributes.Contains(imap.AttrNoSelect))
+
+	inbox := mailboxes[addressMailboxID("addrID", proton.InboxLabel)]
+	require.Equal(t, []string{addressPrefix, "alias@proton.me", imap.Inbox}, inbox.Name)
+
+	// Special-use attributes are only advertised by the unified mailboxes.
+	sent := mailboxes[addressMailboxID("addrID", proton.SentLabel)]
+	require.Equal(t, []string{addressPrefix, "alias@proton.me", "Sent"}, sent.Name)
+	require.False(t, sent.Attributes.Contains(imap.AttrSent))
+}
@@ -37,6 +37,7 @@ type APIClient interface {
 	GetGroupedMessageCount(ctx context.Context) ([]proton.MessageGroupCount, error)
 	GetMessage(ctx context.Context, messageID string) (proton.Message, error)
 	GetMessageMetadataPage(ctx context.Context, page, pageSize int, filter proton.MessageFilter) ([]proton.MessageMetadata, error)
+	GetMessageIDs(ctx context.Context, afterID string, limit int) ([]string, error)
 	GetAllMessageIDs(ctx context.Context, afterID string) ([]string, error)
 	CreateDraft(ctx context.Context, addrKR *crypto.KeyRing, req proton.CreateDraftReq) (proton.Message, error)
 	UploadAttachment(ctx context.Context, addrKR *crypto.KeyRing, req proton.CreateAttachmentReq) (proton.Attachment, error)
@@ -49,4 +50,8 @@ type APIClient interface {
 	MarkMessagesUnread(ctx context.Context, messageIDs ...string) error
 	MarkMessagesForwarded(ctx context.Context, messageIDs ...string) error
 	MarkMessagesUnForwarded(ctx context.Context, messageIDs ...string) error
+
+	GetPublicKeys(ctx context.Context, address string) (proton.PublicKeys, proton.RecipientType, error)
+	GetAllContactEmails(ctx context.Context, email string) ([]proton.ContactEmail, error)
+	GetContact(ctx context.Context, contactID string) (proton.Contact, error)
 }
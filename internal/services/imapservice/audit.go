@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+)
+
+// AuditRecorder records the actions of mail clients in the audit log.
+type AuditRecorder interface {
+	audit.Recorder
+
+	// GetIMAPSessionRemote returns the addresses of the IMAP sessions of the user whose client reported the given name.
+	// The connector isn't told which session an action comes from, so it can't know its address otherwise.
+	GetIMAPSessionRemote(userID, client string) string
+}
+
+// recordAudit records an action of a mail client in the audit log, if there is one.
+func (s *Connector) recordAudit(ctx context.Context, action audit.Action, messageIDs []imap.MessageID, mailbox, target string) {
+	if s.auditRecorder == nil {
+		return
+	}
+
+	var client string
+
+	if id, ok := imap.GetIMAPIDFromContext(ctx); ok {
+		client = strings.TrimSpace(id.Name + " " + id.Version)
+	}
+
+	userID := s.identityState.UserID()
+
+	s.auditRecorder.Record(audit.Entry{
+		UserID:     userID,
+		Action:     action,
+		Client:     client,
+		Remote:     s.auditRecorder.GetIMAPSessionRemote(userID, client),
+		Mailbox:    mailbox,
+		Target:     target,
+		MessageIDs: usertypes.MapTo[imap.MessageID, string](messageIDs),
+	})
+}
+
+// getMailboxName returns the path of the mailbox with the given ID, or the ID if the mailbox is unknown.
+func (s *Connector) getMailboxName(mboxID imap.MailboxID) string {
+	labels := s.labels.Read()
+	defer labels.Close()
+
+	if label, ok := labels.GetLabel(string(mboxID)); ok && len(label.Path) > 0 {
+		return strings.Join(label.Path, "/")
+	}
+
+	return string(mboxID)
+}
+
+// auditFlag returns the target of a flag action: the flag name, prefixed with "un" when the flag is cleared.
+func auditFlag(name string, set bool) string {
+	if set {
+		return name
+	}
+
+	return "un" + name
+}
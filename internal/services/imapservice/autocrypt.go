@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+)
+
+// AutocryptPeerStore stores the keys of peers collected from the Autocrypt headers of received mail.
+type AutocryptPeerStore interface {
+	GetAutocryptCollect() bool
+	UpdateAutocryptPeer(email string, keyData []byte, preferEncrypt bool, timestamp time.Time) error
+}
+
+// collectAutocryptPeer records the key advertised by the sender of the given message, if collection is enabled.
+func (s *Service) collectAutocryptPeer(msg proton.Message) {
+	if !msg.Flags.Has(proton.MessageFlagReceived) || !s.autocryptPeers.GetAutocryptCollect() {
+		return
+	}
+
+	header, date, ok := message.GetAutocrypt(msg)
+	if !ok {
+		return
+	}
+
+	if err := s.autocryptPeers.UpdateAutocryptPeer(header.Addr, header.KeyData, header.PreferEncrypt, date); err != nil {
+		s.log.WithError(err).WithField("sender", logging.Sensitive(header.Addr)).Error("Failed to record Autocrypt key")
+	}
+}
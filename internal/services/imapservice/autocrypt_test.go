@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type testAutocryptPeerStore struct {
+	collect bool
+	peers   map[string][]byte
+}
+
+func (store *testAutocryptPeerStore) GetAutocryptCollect() bool {
+	return store.collect
+}
+
+func (store *testAutocryptPeerStore) UpdateAutocryptPeer(email string, keyData []byte, _ bool, _ time.Time) error {
+	store.peers[email] = keyData
+
+	return nil
+}
+
+func TestCollectAutocryptPeer(t *testing.T) {
+	key, err := crypto.GenerateKey("peer", "peer@example.com", "x25519", 0)
+	require.NoError(t, err)
+
+	kr, err := crypto.NewKeyRing(key)
+	require.NoError(t, err)
+
+	header, err := message.NewAutocrypt("peer@example.com", kr, true)
+	require.NoError(t, err)
+
+	newMessage := func(flags proton.MessageFlag) proton.Message {
+		return proton.Message{
+			MessageMetadata: proton.MessageMetadata{
+				Sender: &mail.Address{Address: "peer@example.com"},
+				Flags:  flags,
+			},
+			ParsedHeaders: proton.Headers{Values: map[string][]string{
+				message.AutocryptHeader: {header.String()},
+			}},
+		}
+	}
+
+	store := &testAutocryptPeerStore{peers: make(map[string][]byte)}
+	s := &Service{autocryptPeers: store, log: logrus.WithField("test", t.Name())}
+
+	// Nothing is collected unless enabled.
+	s.collectAutocryptPeer(newMessage(proton.MessageFlagReceived))
+	require.Empty(t, store.peers)
+
+	store.collect = true
+
+	// Sent messages carry the user's own header.
+	s.collectAutocryptPeer(newMessage(proton.MessageFlagSent))
+	require.Empty(t, store.peers)
+
+	s.collectAutocryptPeer(newMessage(proton.MessageFlagReceived))
+	require.Equal(t, header.KeyData, store.peers["peer@example.com"])
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/mail"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -35,6 +36,8 @@ import (
 	"github.com/ProtonMail/gluon/rfc822"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/sendrecorder"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
 	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
@@ -61,9 +64,13 @@ type Connector struct {
 
 	identityState sharedIdentity
 	client        APIClient
+	senderKeys    *senderKeyCache
 	reporter      reporter.Reporter
-	panicHandler  async.PanicHandler
-	sendRecorder  *sendrecorder.SendRecorder
+
+	messageSettings MessageSettingsProvider
+
+	panicHandler async.PanicHandler
+	sendRecorder *sendrecorder.SendRecorder
 
 	addressMode usertypes.AddressMode
 	labels      sharedLabels
@@ -76,7 +83,12 @@ type Connector struct {
 	mailboxCountProvider mailboxCountProvider
 	gluonIDProvider      gluonIDProvider
 
+	auditRecorder AuditRecorder
+	rateLimiter   *ratelimit.Limiter
+
 	featureFlagValueProvider unleash.FeatureFlagValueProvider
+
+	templatesLock sync.Mutex
 }
 
 var errNoSenderAddressMatch = errors.New("no matching sender found in address list")
@@ -87,6 +99,8 @@ func NewConnector(
 	labels sharedLabels,
 	identityState sharedIdentity,
 	addressMode usertypes.AddressMode,
+	senderKeys *senderKeyCache,
+	messageSettings MessageSettingsProvider,
 	sendRecorder *sendrecorder.SendRecorder,
 	panicHandler async.PanicHandler,
 	reporter reporter.Reporter,
@@ -94,6 +108,8 @@ func NewConnector(
 	syncState *SyncState,
 	mailboxCountProvider mailboxCountProvider,
 	gluonIDProvider gluonIDProvider,
+	auditRecorder AuditRecorder,
+	rateLimiter *ratelimit.Limiter,
 	featureFlagProvider unleash.FeatureFlagValueProvider,
 ) *Connector {
 	userID := identityState.UserID()
@@ -106,8 +122,12 @@ func NewConnector(
 		permFlags:     defaultMailboxPermanentFlags(),
 		attrs:         defaultMailboxAttributes(),
 
-		client:       apiClient,
-		reporter:     reporter,
+		client:     apiClient,
+		senderKeys: senderKeys,
+		reporter:   reporter,
+
+		messageSettings: messageSettings,
+
 		panicHandler: panicHandler,
 		sendRecorder: sendRecorder,
 
@@ -131,6 +151,8 @@ func NewConnector(
 
 		mailboxCountProvider:     mailboxCountProvider,
 		gluonIDProvider:          gluonIDProvider,
+		auditRecorder:            auditRecorder,
+		rateLimiter:              rateLimiter,
 		featureFlagValueProvider: featureFlagProvider,
 	}
 }
@@ -168,6 +190,10 @@ func (s *Connector) Init(ctx context.Context, cache connector.IMAPState) error {
 			}
 		}
 
+		if err := ensureTemplatesMailbox(ctx, mboxes, write); err != nil {
+			return fmt.Errorf("failed to create templates mailbox: %w", err)
+		}
+
 		// Retroactively apply the forwarded flags to existing mailboxes so that the IMAP clients can recognize
 		// that they can store these flags now.
 		if err := write.AddFlagsToAllMailboxes(ctx, imap.ForwardFlagList...); err != nil {
@@ -197,6 +223,10 @@ func (s *Connector) Authorize(_ context.Context, username string, password []byt
 }
 
 func (s *Connector) CreateMailbox(ctx context.Context, _ connector.IMAPStateWrite, name []string) (imap.Mailbox, error) {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return imap.Mailbox{}, err
+	}
+
 	if len(name) < 2 {
 		return imap.Mailbox{}, fmt.Errorf("invalid mailbox name %q: %w", name, connector.ErrOperationNotAllowed)
 	}
@@ -214,6 +244,10 @@ func (s *Connector) CreateMailbox(ctx context.Context, _ connector.IMAPStateWrit
 }
 
 func (s *Connector) GetMessageLiteral(ctx context.Context, id imap.MessageID) ([]byte, error) {
+	if err := s.allowRequest(ratelimit.ClassFetch); err != nil {
+		return nil, err
+	}
+
 	msg, err := s.client.GetFullMessage(ctx, string(id), usertypes.NewProtonAPIScheduler(s.panicHandler), proton.NewDefaultAttachmentAllocator())
 	if err != nil {
 		return nil, err
@@ -224,12 +258,20 @@ func (s *Connector) GetMessageLiteral(ctx context.Context, id imap.MessageID) ([
 		// Store the current value of the kill-switch on whether we should fall back to using `splitHeaderBody` V1 instead of the new default V2.
 		message.SplitHeaderBodyV2Disabled.Swap(s.featureFlagValueProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
 
-		l, buildErr := message.DecryptAndBuildRFC822(addrKR, msg.Message, msg.AttData, defaultMessageJobOpts())
-		if buildErr != nil {
+		buf := new(bytes.Buffer)
+
+		if buildErr := message.DecryptVerifyAndBuildRFC822Into(
+			addrKR,
+			s.senderKeys.withContext(ctx),
+			msg.Message,
+			msg.AttData,
+			messageJobOpts(s.messageSettings),
+			buf,
+		); buildErr != nil {
 			return buildErr
 		}
 
-		literal = l
+		literal = buf.Bytes()
 
 		return nil
 	})
@@ -253,7 +295,11 @@ func (s *Connector) GetMailboxVisibility(_ context.Context, mboxID imap.MailboxI
 }
 
 func (s *Connector) UpdateMailboxName(ctx context.Context, _ connector.IMAPStateWrite, mboxID imap.MailboxID, name []string) error {
-	if len(name) < 2 {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	if len(name) < 2 || isAddressMailbox(mboxID) || mboxID == templatesMailboxID {
 		return fmt.Errorf("invalid mailbox name %q: %w", name, connector.ErrOperationNotAllowed)
 	}
 
@@ -270,6 +316,14 @@ func (s *Connector) UpdateMailboxName(ctx context.Context, _ connector.IMAPState
 }
 
 func (s *Connector) DeleteMailbox(ctx context.Context, _ connector.IMAPStateWrite, mboxID imap.MailboxID) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	if isAddressMailbox(mboxID) || mboxID == templatesMailboxID {
+		return connector.ErrOperationNotAllowed
+	}
+
 	if err := s.client.DeleteLabel(ctx, string(mboxID)); err != nil {
 		return err
 	}
@@ -283,10 +337,24 @@ func (s *Connector) DeleteMailbox(ctx context.Context, _ connector.IMAPStateWrit
 }
 
 func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrite, mailboxID imap.MailboxID, literal []byte, flags imap.FlagSet, _ time.Time) (imap.Message, []byte, error) {
+	if err := s.allowRequest(ratelimit.ClassImport); err != nil {
+		return imap.Message{}, nil, err
+	}
+
+	mailboxID = resolveAddressMailboxID(mailboxID)
+
 	if mailboxID == proton.AllMailLabel {
 		return imap.Message{}, nil, connector.ErrOperationNotAllowed
 	}
 
+	// Templates are saved as drafts carrying the templates label.
+	isTemplate := mailboxID == templatesMailboxID
+
+	mailboxID, err := s.resolveTemplatesMailboxID(ctx, mailboxID)
+	if err != nil {
+		return imap.Message{}, nil, err
+	}
+
 	toList, err := getLiteralToList(literal)
 	if err != nil {
 		return imap.Message{}, nil, fmt.Errorf("failed to retrieve addresses from literal:%w", err)
@@ -316,7 +384,7 @@ func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrit
 
 			message.SplitHeaderBodyV2Disabled.Swap(s.featureFlagValueProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
 
-			if literal, err = message.DecryptAndBuildRFC822(addrKR, full.Message, full.AttData, defaultMessageJobOpts()); err != nil {
+			if literal, err = message.DecryptAndBuildRFC822(addrKR, full.Message, full.AttData, messageJobOpts(s.messageSettings)); err != nil {
 				return err
 			}
 
@@ -330,6 +398,10 @@ func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrit
 
 	wantLabelIDs := []string{string(mailboxID)}
 
+	if isTemplate {
+		wantLabelIDs = append(wantLabelIDs, proton.DraftsLabel)
+	}
+
 	if flags.Contains(imap.FlagFlagged) {
 		wantLabelIDs = append(wantLabelIDs, proton.StarredLabel)
 	}
@@ -338,7 +410,7 @@ func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrit
 
 	unread := !flags.Contains(imap.FlagSeen)
 
-	if mailboxID != proton.DraftsLabel {
+	if mailboxID != proton.DraftsLabel && !isTemplate {
 		header, err := rfc822.Parse(literal).ParseHeader()
 		if err != nil {
 			return imap.Message{}, nil, err
@@ -365,7 +437,13 @@ func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrit
 		wantFlags = wantFlags.Add(proton.MessageFlagReplied)
 	}
 
-	msg, literal, err := s.importMessage(ctx, literal, wantLabelIDs, wantFlags, unread)
+	var templatesLabelID string
+
+	if isTemplate {
+		templatesLabelID = string(mailboxID)
+	}
+
+	msg, literal, err := s.importMessage(ctx, literal, wantLabelIDs, wantFlags, unread, templatesLabelID)
 	if err != nil {
 		if errors.Is(err, proton.ErrImportSizeExceeded) {
 			// Remap error so that Gluon does not put this message in the recovery mailbox.
@@ -383,6 +461,15 @@ func (s *Connector) CreateMessage(ctx context.Context, _ connector.IMAPStateWrit
 }
 
 func (s *Connector) AddMessagesToMailbox(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, mboxID imap.MailboxID) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	mboxID, err := s.resolveTemplatesMailboxID(ctx, resolveAddressMailboxID(mboxID))
+	if err != nil {
+		return err
+	}
+
 	if isAllMailOrScheduled(mboxID) {
 		return connector.ErrOperationNotAllowed
 	}
@@ -401,11 +488,26 @@ func (s *Connector) isMailboxOfTypeLabel(mboxID string) bool {
 }
 
 func (s *Connector) RemoveMessagesFromMailbox(ctx context.Context, con connector.IMAPStateWrite, messageIDs []imap.MessageID, mboxID imap.MailboxID) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	mboxID, err := s.resolveTemplatesMailboxID(ctx, resolveAddressMailboxID(mboxID))
+	if err != nil {
+		return err
+	}
+
 	if s.featureFlagValueProvider.GetFlagValue(unleash.FolderUnlabelCallDisabled) {
-		return s.removeMessagesFromMailboxWithoutUnlabelOnFolders(ctx, con, messageIDs, mboxID)
+		err = s.removeMessagesFromMailboxWithoutUnlabelOnFolders(ctx, con, messageIDs, mboxID)
+	} else {
+		err = s.removeMessagesFromMailboxWithUnlabelCallOnFolders(ctx, con, messageIDs, mboxID)
 	}
 
-	return s.removeMessagesFromMailboxWithUnlabelCallOnFolders(ctx, con, messageIDs, mboxID)
+	if err == nil {
+		s.recordAudit(ctx, audit.ActionRemove, messageIDs, s.getMailboxName(mboxID), "")
+	}
+
+	return err
 }
 
 func (s *Connector) removeMessagesFromMailboxWithoutUnlabelOnFolders(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, mboxID imap.MailboxID) error {
@@ -613,11 +715,40 @@ func (s *Connector) removeMessagesFromMailboxWithUnlabelCallOnFolders(ctx contex
 }
 
 func (s *Connector) MoveMessages(ctx context.Context, con connector.IMAPStateWrite, messageIDs []imap.MessageID, mboxFromID, mboxToID imap.MailboxID) (bool, error) {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return false, err
+	}
+
+	mboxFromID, mboxToID = resolveAddressMailboxID(mboxFromID), resolveAddressMailboxID(mboxToID)
+
+	mboxFromID, err := s.resolveTemplatesMailboxID(ctx, mboxFromID)
+	if err != nil {
+		return false, err
+	}
+
+	mboxToID, err = s.resolveTemplatesMailboxID(ctx, mboxToID)
+	if err != nil {
+		return false, err
+	}
+
+	// Moving between an address mailbox and its unified mailbox does not change the message location.
+	if mboxFromID == mboxToID {
+		return false, nil
+	}
+
+	var shouldExpungeOldLocation bool
+
 	if s.featureFlagValueProvider.GetFlagValue(unleash.FolderUnlabelCallDisabled) {
-		return s.moveMessagesWithoutUnlabelCallOnFolders(ctx, con, messageIDs, mboxFromID, mboxToID)
+		shouldExpungeOldLocation, err = s.moveMessagesWithoutUnlabelCallOnFolders(ctx, con, messageIDs, mboxFromID, mboxToID)
+	} else {
+		shouldExpungeOldLocation, err = s.moveMessagesWithUnlabelCallOnFolders(ctx, con, messageIDs, mboxFromID, mboxToID)
 	}
 
-	return s.moveMessagesWithUnlabelCallOnFolders(ctx, con, messageIDs, mboxFromID, mboxToID)
+	if err == nil {
+		s.recordAudit(ctx, audit.ActionMove, messageIDs, s.getMailboxName(mboxFromID), s.getMailboxName(mboxToID))
+	}
+
+	return shouldExpungeOldLocation, err
 }
 
 func (s *Connector) moveMessagesWithoutUnlabelCallOnFolders(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, mboxFromID, mboxToID imap.MailboxID) (bool, error) {
@@ -695,19 +826,43 @@ func (s *Connector) moveMessagesWithUnlabelCallOnFolders(ctx context.Context, _
 }
 
 func (s *Connector) MarkMessagesSeen(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, seen bool) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	var err error
+
 	if seen {
-		return s.client.MarkMessagesRead(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+		err = s.client.MarkMessagesRead(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+	} else {
+		err = s.client.MarkMessagesUnread(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+	}
+
+	if err == nil {
+		s.recordAudit(ctx, audit.ActionFlag, messageIDs, "", auditFlag("seen", seen))
 	}
 
-	return s.client.MarkMessagesUnread(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+	return err
 }
 
 func (s *Connector) MarkMessagesFlagged(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, flagged bool) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	var err error
+
 	if flagged {
-		return s.client.LabelMessages(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs), proton.StarredLabel)
+		err = s.client.LabelMessages(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs), proton.StarredLabel)
+	} else {
+		err = s.unlabelMessages(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs), proton.StarredLabel, "MarkMessagesFlagged")
 	}
 
-	return s.unlabelMessages(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs), proton.StarredLabel, "MarkMessagesFlagged")
+	if err == nil {
+		s.recordAudit(ctx, audit.ActionFlag, messageIDs, "", auditFlag("flagged", flagged))
+	}
+
+	return err
 }
 
 func (s *Connector) unlabelMessages(ctx context.Context, messageIDs []string, labelID, reason string) error {
@@ -720,11 +875,23 @@ func (s *Connector) unlabelMessages(ctx context.Context, messageIDs []string, la
 }
 
 func (s *Connector) MarkMessagesForwarded(ctx context.Context, _ connector.IMAPStateWrite, messageIDs []imap.MessageID, flagged bool) error {
+	if err := s.allowRequest(ratelimit.ClassLabel); err != nil {
+		return err
+	}
+
+	var err error
+
 	if flagged {
-		return s.client.MarkMessagesForwarded(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+		err = s.client.MarkMessagesForwarded(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+	} else {
+		err = s.client.MarkMessagesUnForwarded(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
 	}
 
-	return s.client.MarkMessagesUnForwarded(ctx, usertypes.MapTo[imap.MessageID, string](messageIDs)...)
+	if err == nil {
+		s.recordAudit(ctx, audit.ActionFlag, messageIDs, "", auditFlag("forwarded", flagged))
+	}
+
+	return err
 }
 
 func (s *Connector) GetUpdates() <-chan imap.Update {
@@ -888,6 +1055,7 @@ func (s *Connector) importMessage(
 	labelIDs []string,
 	flags proton.MessageFlag,
 	unread bool,
+	templatesLabelID string,
 ) (imap.Message, []byte, error) {
 	var full proton.FullMessage
 
@@ -916,7 +1084,13 @@ func (s *Connector) importMessage(
 				return fmt.Errorf("failed to create draft: %w", err)
 			}
 
-			// apply labels
+			// Drafts are created in the drafts mailbox; templates additionally carry the templates label.
+			if templatesLabelID != "" {
+				if err := s.client.LabelMessages(ctx, []string{msg.ID}, templatesLabelID); err != nil {
+					return fmt.Errorf("failed to label template: %w", err)
+				}
+			}
+
 			messageID = msg.ID
 		} else {
 			// multipart body requires at least one text part to be properly encrypted.
@@ -956,7 +1130,7 @@ func (s *Connector) importMessage(
 
 		message.SplitHeaderBodyV2Disabled.Swap(s.featureFlagValueProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
 
-		if literal, err = message.DecryptAndBuildRFC822(primaryKey, full.Message, full.AttData, defaultMessageJobOpts()); err != nil {
+		if literal, err = message.DecryptAndBuildRFC822(primaryKey, full.Message, full.AttData, messageJobOpts(s.messageSettings)); err != nil {
 			return fmt.Errorf("failed to build message: %w", err)
 		}
 
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"github.com/ProtonMail/gluon/connector"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
+)
+
+// allowRequest returns an error if the user's API requests of the given class are currently throttled.
+func (s *Connector) allowRequest(class ratelimit.Class) error {
+	if err := s.rateLimiter.Allow(class); err != nil {
+		s.log.WithError(err).Warn("Request throttled")
+
+		return &limitError{err: err}
+	}
+
+	return nil
+}
+
+// limitError is returned to IMAP clients for throttled requests. Gluon writes the error after NO, so it begins with
+// the LIMIT response code of RFC 5530. It is an ErrOperationNotAllowed so that gluon doesn't report it.
+type limitError struct {
+	err error
+}
+
+func (e *limitError) Error() string {
+	return "[LIMIT] " + e.err.Error()
+}
+
+func (e *limitError) Unwrap() []error {
+	return []error{e.err, connector.ErrOperationNotAllowed}
+}
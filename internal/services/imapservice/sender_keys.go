@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/bradenaw/juniper/xslices"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// senderKeysTTL is how long the keys of a sender are cached before being fetched again.
+	senderKeysTTL = time.Hour
+
+	// senderKeysErrTTL is how long a failure to fetch the keys of a sender is cached, so that a sync or an event batch
+	// with many messages from the same sender doesn't repeat the requests.
+	senderKeysErrTTL = time.Minute
+)
+
+type cachedSenderKeys struct {
+	kr      *crypto.KeyRing
+	err     error
+	fetched time.Time
+}
+
+func (cached cachedSenderKeys) isFresh() bool {
+	if cached.err != nil {
+		return time.Since(cached.fetched) < senderKeysErrTTL
+	}
+
+	return time.Since(cached.fetched) < senderKeysTTL
+}
+
+// senderKeyCache provides the keys used to verify the signatures of messages, per sender.
+// Keys pinned in the user's contacts take precedence over the keys served by the API.
+type senderKeyCache struct {
+	client   APIClient
+	userKeys proton.Keys
+
+	userKR     *crypto.KeyRing
+	userKROnce sync.Once
+
+	keys     map[string]cachedSenderKeys
+	keysLock sync.Mutex
+}
+
+func newSenderKeyCache(client APIClient, userKeys proton.Keys) *senderKeyCache {
+	return &senderKeyCache{
+		client:   client,
+		userKeys: userKeys,
+		keys:     make(map[string]cachedSenderKeys),
+	}
+}
+
+// withContext returns a message.SenderKeyProvider using the given context for the API requests.
+func (c *senderKeyCache) withContext(ctx context.Context) *senderKeyProvider {
+	return &senderKeyProvider{ctx: ctx, cache: c}
+}
+
+func (c *senderKeyCache) getSenderKeys(ctx context.Context, sender string) (*crypto.KeyRing, error) {
+	sender = strings.ToLower(sender)
+
+	c.keysLock.Lock()
+	cached, ok := c.keys[sender]
+	c.keysLock.Unlock()
+
+	if ok && cached.isFresh() {
+		return cached.kr, cached.err
+	}
+
+	kr, err := c.fetchSenderKeys(ctx, sender)
+
+	// A cancelled request says nothing about the sender.
+	if errors.Is(err, context.Canceled) {
+		return nil, err
+	}
+
+	c.keysLock.Lock()
+	c.keys[sender] = cachedSenderKeys{kr: kr, err: err, fetched: time.Now()}
+	c.keysLock.Unlock()
+
+	return kr, err
+}
+
+func (c *senderKeyCache) fetchSenderKeys(ctx context.Context, sender string) (*crypto.KeyRing, error) {
+	pinned, err := c.getPinnedKeys(ctx, sender)
+	if err != nil {
+		if isTemporaryError(err) {
+			return nil, err
+		}
+
+		// Contact cards which can't be read don't pin any key.
+		logrus.WithError(err).Warn("Failed to get the keys pinned to the sender")
+	}
+
+	if len(pinned) > 0 {
+		kr, err := crypto.NewKeyRing(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range pinned {
+			if err := kr.AddKey(key); err != nil {
+				return nil, err
+			}
+		}
+
+		return kr, nil
+	}
+
+	pubKeys, _, err := c.client.GetPublicKeys(ctx, sender)
+	if err != nil {
+		// The API refuses addresses it doesn't know about; the sender simply has no keys.
+		if !isTemporaryError(err) {
+			return crypto.NewKeyRing(nil)
+		}
+
+		return nil, err
+	}
+
+	return pubKeys.GetKeyRing()
+}
+
+// isTemporaryError returns whether the request may succeed if retried later.
+func isTemporaryError(err error) bool {
+	if apiErr := new(proton.APIError); errors.As(err, &apiErr) {
+		return apiErr.Status == http.StatusTooManyRequests || apiErr.Status >= http.StatusInternalServerError
+	}
+
+	var netErr *proton.NetError
+
+	return errors.As(err, &netErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// getPinnedKeys returns the keys pinned to the sender in the user's signed contact cards.
+func (c *senderKeyCache) getPinnedKeys(ctx context.Context, sender string) ([]*crypto.Key, error) {
+	contacts, err := c.client.GetAllContactEmails(ctx, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := xslices.IndexFunc(contacts, func(contact proton.ContactEmail) bool {
+		return strings.EqualFold(contact.Email, sender)
+	})
+	if idx < 0 {
+		return nil, nil
+	}
+
+	contact, err := c.client.GetContact(ctx, contacts[idx].ContactID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := contact.GetSettings(c.getUserKR(), contacts[idx].Email, proton.CardTypeSigned)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings.Keys, nil
+}
+
+// getUserKR returns the public keys of the user, which sign the contact cards.
+func (c *senderKeyCache) getUserKR() *crypto.KeyRing {
+	c.userKROnce.Do(func() {
+		kr, err := crypto.NewKeyRing(nil)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, userKey := range c.userKeys {
+			key, err := crypto.NewKey(userKey.PrivateKey)
+			if err != nil {
+				continue
+			}
+
+			if pub, err := key.ToPublic(); err == nil {
+				_ = kr.AddKey(pub)
+			}
+		}
+
+		c.userKR = kr
+	})
+
+	return c.userKR
+}
+
+type senderKeyProvider struct {
+	ctx   context.Context
+	cache *senderKeyCache
+}
+
+func (p *senderKeyProvider) GetSenderKeys(sender string) (*crypto.KeyRing, error) {
+	return p.cache.getSenderKeys(p.ctx, sender)
+}
@@ -28,6 +28,7 @@ import (
 type IMAPServerManager interface {
 	AddIMAPUser(
 		ctx context.Context,
+		userID string,
 		connector connector.Connector,
 		addrID string,
 		idProvider GluonIDProvider,
@@ -51,6 +52,7 @@ type NullIMAPServerManager struct{}
 
 func (n NullIMAPServerManager) AddIMAPUser(
 	_ context.Context,
+	_ string,
 	_ connector.Connector,
 	_ string,
 	_ GluonIDProvider,
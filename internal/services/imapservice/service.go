@@ -30,6 +30,7 @@ import (
 	"github.com/ProtonMail/gluon/watcher"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/orderedtasks"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/sendrecorder"
@@ -64,6 +65,10 @@ type Service struct {
 	identityState *rwIdentity
 	labels        *rwLabels
 	addressMode   usertypes.AddressMode
+	senderKeys    *senderKeyCache
+
+	autocryptPeers  AutocryptPeerStore
+	messageSettings MessageSettingsProvider
 
 	subscription *userevents.EventChanneledSubscriber
 
@@ -92,10 +97,16 @@ type Service struct {
 	lastHandledEventID string
 	isSyncing          atomic.Bool
 
+	rebuildGeneration int                 // Incremented when a rebuild is requested, so that batches built before are dropped.
+	rebuildChanged    map[string]struct{} // The messages changed by events while a batch is rebuilt.
+
 	observabilitySender  observability.Sender
 	labelConflictManager *LabelConflictManager
 	LabelConflictChecker *LabelConflictChecker
 
+	auditRecorder AuditRecorder
+	rateLimiter   *ratelimit.Limiter
+
 	featureFlagProvider unleash.FeatureFlagValueProvider
 }
 
@@ -108,6 +119,8 @@ func NewService(
 	eventPublisher events.EventPublisher,
 	bridgePassProvider useridentity.BridgePassProvider,
 	keyPassProvider useridentity.KeyPassProvider,
+	autocryptPeers AutocryptPeerStore,
+	messageSettings MessageSettingsProvider,
 	panicHandler async.PanicHandler,
 	sendRecorder *sendrecorder.SendRecorder,
 	reporter reporter.Reporter,
@@ -117,6 +130,8 @@ func NewService(
 	maxSyncMemory uint64,
 	showAllMail bool,
 	observabilitySender observability.Sender,
+	auditRecorder AuditRecorder,
+	rateLimiter *ratelimit.Limiter,
 	featureFlagProvider unleash.FeatureFlagValueProvider,
 ) *Service {
 	subscriberName := fmt.Sprintf("imap-%v", identityState.User.ID)
@@ -129,7 +144,8 @@ func NewService(
 
 	labelConflictManager := NewLabelConflictManager(serverManager, gluonIDProvider, client, reporter, featureFlagProvider)
 	syncUpdateApplier := NewSyncUpdateApplier(labelConflictManager)
-	syncMessageBuilder := NewSyncMessageBuilder(rwIdentity, featureFlagProvider)
+	senderKeys := newSenderKeyCache(client, identityState.User.Keys)
+	syncMessageBuilder := NewSyncMessageBuilder(rwIdentity, senderKeys, messageSettings, featureFlagProvider)
 	syncReporter := newSyncReporter(identityState.User.ID, eventPublisher, time.Second)
 
 	service := &Service{
@@ -139,6 +155,10 @@ func NewService(
 		identityState: rwIdentity,
 		labels:        newRWLabels(),
 		addressMode:   addressMode,
+		senderKeys:    senderKeys,
+
+		autocryptPeers:  autocryptPeers,
+		messageSettings: messageSettings,
 
 		gluonIDProvider: gluonIDProvider,
 		serverManager:   serverManager,
@@ -166,6 +186,9 @@ func NewService(
 		observabilitySender:  observabilitySender,
 		labelConflictManager: labelConflictManager,
 
+		auditRecorder: auditRecorder,
+		rateLimiter:   rateLimiter,
+
 		featureFlagProvider: featureFlagProvider,
 	}
 
@@ -194,7 +217,7 @@ func (s *Service) Start(
 		s.client,
 		s.identityState.UserID(),
 		s.syncStateProvider,
-		s.log,
+		s.log.WithField("pkg", "sync"),
 		s.panicHandler,
 		s.reporter)
 
@@ -265,10 +288,26 @@ func (s *Service) ShowAllMail(ctx context.Context, v bool) error {
 	return err
 }
 
+// RebuildMessages rebuilds all messages in the background, after a change of the options they are built with.
+func (s *Service) RebuildMessages(ctx context.Context) error {
+	_, err := s.cpc.Send(ctx, &rebuildMessagesReq{})
+
+	return err
+}
+
 func (s *Service) GetLabels(ctx context.Context) (map[string]proton.Label, error) {
 	return cpc.SendTyped[map[string]proton.Label](ctx, s.cpc, &getLabelsReq{})
 }
 
+// GetCachedLabel returns the label with the given ID from the labels cached by the service.
+// Unlike GetLabels, it doesn't wait for the service's event loop.
+func (s *Service) GetCachedLabel(labelID string) (proton.Label, bool) {
+	labels := s.labels.Read()
+	defer labels.Close()
+
+	return labels.GetLabel(labelID)
+}
+
 func (s *Service) GetSyncFailedMessageIDs(ctx context.Context) ([]string, error) {
 	return cpc.SendTyped[[]string](ctx, s.cpc, &getSyncFailedMessagesReq{})
 }
@@ -347,6 +386,8 @@ func (s *Service) run(ctx context.Context) { //nolint gocyclo
 	s.eventProvider.Subscribe(s.subscription)
 	defer s.eventProvider.Unsubscribe(s.subscription)
 
+	go s.runMessageRebuild(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -404,6 +445,19 @@ func (s *Service) run(ctx context.Context) { //nolint gocyclo
 				req.Reply(ctx, nil, nil)
 				s.setShowAllMail(r.v)
 
+			case *rebuildMessagesReq:
+				s.log.Debug("Rebuild messages request")
+				s.rebuildGeneration++
+				err := s.syncStateProvider.SetRebuildStatus(RebuildStatus{Pending: true})
+				req.Reply(ctx, nil, err)
+
+			case *startRebuildBatchReq:
+				req.Reply(ctx, s.startRebuildBatch(), nil)
+
+			case *publishRebuildBatchReq:
+				err := s.publishRebuildBatch(ctx, r.batch)
+				req.Reply(ctx, nil, err)
+
 			case *getSyncFailedMessagesReq:
 				s.log.Debug("Get sync failed messages Request")
 				status, err := s.syncStateProvider.GetSyncStatus(ctx)
@@ -527,7 +581,7 @@ func (s *Service) run(ctx context.Context) { //nolint gocyclo
 func (s *Service) buildConnectors() (map[string]*Connector, error) {
 	connectors := make(map[string]*Connector)
 
-	if s.addressMode == usertypes.AddressModeCombined {
+	if s.addressMode != usertypes.AddressModeSplit {
 		addr, err := s.identityState.GetPrimaryAddress()
 		if err != nil {
 			return nil, fmt.Errorf("failed to build connector for combined mode: %w", err)
@@ -539,6 +593,8 @@ func (s *Service) buildConnectors() (map[string]*Connector, error) {
 			s.labels,
 			s.identityState,
 			s.addressMode,
+			s.senderKeys,
+			s.messageSettings,
 			s.sendRecorder,
 			s.panicHandler,
 			s.reporter,
@@ -546,6 +602,8 @@ func (s *Service) buildConnectors() (map[string]*Connector, error) {
 			s.syncStateProvider,
 			s.serverManager,
 			s.gluonIDProvider,
+			s.auditRecorder,
+			s.rateLimiter,
 			s.featureFlagProvider,
 		)
 
@@ -559,6 +617,8 @@ func (s *Service) buildConnectors() (map[string]*Connector, error) {
 			s.labels,
 			s.identityState,
 			s.addressMode,
+			s.senderKeys,
+			s.messageSettings,
 			s.sendRecorder,
 			s.panicHandler,
 			s.reporter,
@@ -566,6 +626,8 @@ func (s *Service) buildConnectors() (map[string]*Connector, error) {
 			s.syncStateProvider,
 			s.serverManager,
 			s.gluonIDProvider,
+			s.auditRecorder,
+			s.rateLimiter,
 			s.featureFlagProvider,
 		)
 	}
@@ -591,7 +653,7 @@ func (s *Service) rebuildConnectors() error {
 func (s *Service) addConnectorsToServer(ctx context.Context, connectors map[string]*Connector) error {
 	addedConnectors := make([]string, 0, len(connectors))
 	for _, c := range connectors {
-		if err := s.serverManager.AddIMAPUser(ctx, c, c.addrID, s.gluonIDProvider, s.syncStateProvider); err != nil {
+		if err := s.serverManager.AddIMAPUser(ctx, s.identityState.UserID(), c, c.addrID, s.gluonIDProvider, s.syncStateProvider); err != nil {
 			s.log.WithError(err).Error("Failed to add connect to imap server")
 
 			if err := s.serverManager.RemoveIMAPUser(ctx, false, s.gluonIDProvider, addedConnectors...); err != nil {
@@ -634,9 +696,15 @@ func (s *Service) setAddressMode(ctx context.Context, mode usertypes.AddressMode
 	}
 
 	s.addressMode = mode
-	if mode == usertypes.AddressModeSplit {
+
+	switch mode {
+	case usertypes.AddressModeSplit:
 		s.log.Info("Setting Split Address Mode")
-	} else {
+
+	case usertypes.AddressModeHybrid:
+		s.log.Info("Setting Hybrid Address Mode")
+
+	case usertypes.AddressModeCombined:
 		s.log.Info("Setting Combined Address Mode")
 	}
 
@@ -709,6 +777,8 @@ type setAddressModeReq struct {
 
 type getSyncFailedMessagesReq struct{}
 
+type rebuildMessagesReq struct{}
+
 func GetSyncConfigPath(path string, userID string) string {
 	return filepath.Join(path, fmt.Sprintf("sync-%v", userID))
 }
@@ -21,21 +21,25 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/useridentity"
 	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+	"github.com/bradenaw/juniper/xslices"
 	"github.com/sirupsen/logrus"
 )
 
 func (s *Service) HandleAddressEvents(ctx context.Context, events []proton.AddressEvent) error {
 	s.log.Debug("handling address event")
 
-	if s.addressMode == usertypes.AddressModeCombined {
+	if s.addressMode != usertypes.AddressModeSplit {
 		oldPrimaryAddr, err := s.identityState.GetPrimaryAddress()
 		if err != nil {
 			return fmt.Errorf("failed to get primary addr: %w", err)
 		}
 
+		oldAddrs := s.identityState.GetAddresses()
+
 		if err := s.identityState.Write(func(identity *useridentity.State) error {
 			identity.OnAddressEvents(events)
 			return nil
@@ -49,6 +53,12 @@ func (s *Service) HandleAddressEvents(ctx context.Context, events []proton.Addre
 			return fmt.Errorf("failed to get primary addr after update: %w", err)
 		}
 
+		if s.addressMode == usertypes.AddressModeHybrid {
+			if err := updateAddressMailboxes(ctx, s, oldPrimaryAddr.ID, oldAddrs, s.identityState.GetAddresses()); err != nil {
+				return err
+			}
+		}
+
 		if oldPrimaryAddr.ID == newPrimaryAddr.ID {
 			return nil
 		}
@@ -152,6 +162,8 @@ func addNewAddressSplitMode(ctx context.Context, s *Service, addrID string) erro
 		s.labels,
 		s.identityState,
 		s.addressMode,
+		s.senderKeys,
+		s.messageSettings,
 		s.sendRecorder,
 		s.panicHandler,
 		s.reporter,
@@ -159,10 +171,12 @@ func addNewAddressSplitMode(ctx context.Context, s *Service, addrID string) erro
 		s.syncStateProvider,
 		s.serverManager,
 		s.gluonIDProvider,
+		s.auditRecorder,
+		s.rateLimiter,
 		s.featureFlagProvider,
 	)
 
-	if err := s.serverManager.AddIMAPUser(ctx, connector, connector.addrID, s.gluonIDProvider, s.syncStateProvider); err != nil {
+	if err := s.serverManager.AddIMAPUser(ctx, s.identityState.UserID(), connector, connector.addrID, s.gluonIDProvider, s.syncStateProvider); err != nil {
 		return fmt.Errorf("failed to add new account to server: %w", err)
 	}
 
@@ -197,3 +211,35 @@ func removeAddressSplitMode(ctx context.Context, s *Service, addrID string) erro
 
 	return nil
 }
+
+// updateAddressMailboxes creates the hybrid mode mailboxes of the new addresses and deletes those of the removed ones.
+func updateAddressMailboxes(ctx context.Context, s *Service, connectorID string, oldAddrs, newAddrs []proton.Address) error {
+	connector, ok := s.connectors[connectorID]
+	if !ok {
+		return fmt.Errorf("could not find connector to update address mailboxes")
+	}
+
+	hasAddr := func(addrs []proton.Address, addrID string) bool {
+		return xslices.Any(addrs, func(addr proton.Address) bool { return addr.ID == addrID })
+	}
+
+	var updates []imap.Update
+
+	if added := xslices.Filter(newAddrs, func(addr proton.Address) bool { return !hasAddr(oldAddrs, addr.ID) }); len(added) > 0 {
+		updates = append(updates, newAddressMailboxesCreatedUpdates(s.labels.GetLabelMap(), added)...)
+	}
+
+	for _, addr := range oldAddrs {
+		if !hasAddr(newAddrs, addr.ID) {
+			updates = append(updates, newAddressMailboxesDeletedUpdates(addr.ID)...)
+		}
+	}
+
+	connector.publishUpdate(ctx, updates...)
+
+	if err := waitOnIMAPUpdates(ctx, updates); err != nil {
+		return fmt.Errorf("failed to update address mailboxes: %w", err)
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
 	"github.com/bradenaw/juniper/xslices"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
@@ -90,6 +91,11 @@ func onLabelCreated(ctx context.Context, s *Service, event proton.LabelEvent) ([
 
 	wr.SetLabel(event.Label.ID, event.Label, "onLabelCreated")
 
+	// The templates label is listed as the Templates mailbox rather than under Labels.
+	if usertypes.IsTemplatesLabel(event.Label) {
+		return updates, nil
+	}
+
 	labelConflictResolver := s.labelConflictManager.NewConflictResolver(maps.Values(s.connectors))
 	conflictUpdatesGenerator, err := labelConflictResolver.ResolveConflict(ctx, event.Label, make(map[string]bool))
 	if err != nil {
@@ -149,6 +155,10 @@ func onLabelUpdated(ctx context.Context, s *Service, event proton.LabelEvent) ([
 		// Update the label in the map.
 		wr.SetLabel(apiLabel.ID, apiLabel, "onLabelUpdatedApiID")
 
+		if usertypes.IsTemplatesLabel(apiLabel) {
+			continue
+		}
+
 		// Resolve potential conflicts
 		labelConflictResolver := s.labelConflictManager.NewConflictResolver(maps.Values(s.connectors))
 		conflictUpdatesGenerator, err := labelConflictResolver.ResolveConflict(ctx, apiLabel, make(map[string]bool))
@@ -41,6 +41,8 @@ import (
 func (s *Service) HandleMessageEvents(ctx context.Context, events []proton.MessageEvent) error {
 	s.log.Debug("handling message event")
 
+	s.markRebuildChanged(events)
+
 	for _, event := range events {
 		ctx = logging.WithLogrusField(ctx, "messageID", event.ID)
 
@@ -176,7 +178,7 @@ func onMessageCreated(
 
 	if err := s.identityState.WithAddrKR(message.AddressID, func(_, addrKR *crypto.KeyRing) error {
 		bmessage.SplitHeaderBodyV2Disabled.Swap(s.featureFlagProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
-		res := buildRFC822(apiLabels, full, addrKR)
+		res := buildRFC822(apiLabels, full, addrKR, s.senderKeys.withContext(ctx), messageJobOpts(s.messageSettings))
 
 		if res.err != nil {
 			s.log.WithError(err).Error("Failed to build RFC822 message")
@@ -192,6 +194,8 @@ func onMessageCreated(
 			s.log.WithError(err).Error("Failed to remove failed message ID from vault")
 		}
 
+		s.collectAutocryptPeer(full.Message)
+
 		update = imap.NewMessagesCreated(allowUnknownLabels, res.update)
 		didPublish, err := safePublishMessageUpdate(ctx, s, full.AddressID, update, duringSync)
 		if err != nil {
@@ -238,7 +242,7 @@ func onMessageUpdateDraftOrSent(ctx context.Context, s *Service, event proton.Me
 
 	if err := s.identityState.WithAddrKR(event.Message.AddressID, func(_, addrKR *crypto.KeyRing) error {
 		bmessage.SplitHeaderBodyV2Disabled.Swap(s.featureFlagProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
-		res := buildRFC822(apiLabels, full, addrKR)
+		res := buildRFC822(apiLabels, full, addrKR, s.senderKeys.withContext(ctx), messageJobOpts(s.messageSettings))
 
 		if res.err != nil {
 			logrus.WithError(err).Error("Failed to build RFC822 message")
@@ -294,7 +298,7 @@ func onMessageUpdate(ctx context.Context, s *Service, message proton.MessageMeta
 
 	update := imap.NewMessageMailboxesUpdated(
 		imap.MessageID(message.ID),
-		usertypes.MapTo[string, imap.MailboxID](wantLabels(s.labels.GetLabelMap(), message.LabelIDs)),
+		toMailboxIDs(s.labels.GetLabelMap(), message.LabelIDs),
 		flags,
 	)
 
@@ -327,12 +331,16 @@ func onMessageDeleted(ctx context.Context, s *Service, event proton.MessageEvent
 // safePublishMessageUpdate handles the rare case where the address' update channel may have been deleted in the same
 // event. This rare case can take place if in the same event fetch request there is an update for delete address and
 // create/update message.
-// If the user is in combined mode, we simply push the update to the primary address. If the user is in split mode
-// we do not publish the update as the address no longer exists.
+// If the user is in combined or hybrid mode, we simply push the update to the primary address. If the user is in split
+// mode we do not publish the update as the address no longer exists.
 func safePublishMessageUpdate(ctx context.Context, s *Service, addressID string, update imap.Update, duringSync bool) (bool, error) {
+	if s.addressMode == usertypes.AddressModeHybrid {
+		addAddressMailboxIDs(addressID, update)
+	}
+
 	v, ok := s.connectors[addressID]
 	if !ok {
-		if s.addressMode == usertypes.AddressModeCombined {
+		if s.addressMode != usertypes.AddressModeSplit {
 			primAddr, err := s.identityState.GetPrimaryAddress()
 			if err != nil {
 				return false, fmt.Errorf("failed to get primary address: %w", err)
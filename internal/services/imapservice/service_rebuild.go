@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
+	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/cpc"
+	bmessage "github.com/ProtonMail/proton-bridge/v3/pkg/message"
+)
+
+const (
+	// messageRebuildInterval is how often a batch of messages is rebuilt.
+	messageRebuildInterval = 5 * time.Second
+
+	// messageRebuildBatchSize is the number of messages rebuilt at a time.
+	messageRebuildBatchSize = 50
+)
+
+// rebuildBatch is a batch of messages built again by the rebuild worker, to be published by the event loop.
+type rebuildBatch struct {
+	generation int
+	messageIDs []string
+	results    map[string]*buildRes
+}
+
+type startRebuildBatchReq struct{}
+
+type publishRebuildBatchReq struct {
+	batch rebuildBatch
+}
+
+// runMessageRebuild rebuilds the pending messages in batches until the context is cancelled.
+// Messages are downloaded and built again here rather than in the event loop, which only publishes them.
+func (s *Service) runMessageRebuild(ctx context.Context) {
+	defer async.HandlePanic(s.panicHandler)
+
+	ticker := time.NewTicker(messageRebuildInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if err := s.rebuildMessageBatch(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				s.log.WithError(err).Warn("Failed to rebuild messages, retrying later")
+			}
+		}
+	}
+}
+
+// rebuildMessageBatch builds the next batch of messages again if a rebuild is pending and asks the event loop to publish them.
+func (s *Service) rebuildMessageBatch(ctx context.Context) error {
+	// Don't bother the event loop if there is nothing to rebuild.
+	if !s.syncStateProvider.GetRebuildStatus().Pending || s.isSyncing.Load() {
+		return nil
+	}
+
+	start, err := cpc.SendTyped[rebuildBatchStart](ctx, s.cpc, &startRebuildBatchReq{})
+	if err != nil || !start.status.Pending {
+		return err
+	}
+
+	messageIDs, err := s.client.GetMessageIDs(ctx, start.status.LastMessageID, messageRebuildBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get message IDs: %w", err)
+	}
+
+	results := make(map[string]*buildRes, len(messageIDs))
+
+	for _, messageID := range messageIDs {
+		res, err := s.rebuildMessage(ctx, messageID)
+		if err != nil {
+			return err
+		}
+
+		results[messageID] = res
+	}
+
+	_, err = s.cpc.Send(ctx, &publishRebuildBatchReq{batch: rebuildBatch{
+		generation: start.generation,
+		messageIDs: messageIDs,
+		results:    results,
+	}})
+
+	return err
+}
+
+// rebuildMessage builds the message again.
+// Messages which can't be built keep the literal they were built with; only temporary failures are returned.
+func (s *Service) rebuildMessage(ctx context.Context, messageID string) (*buildRes, error) {
+	full, err := s.client.GetFullMessage(ctx, messageID, usertypes.NewProtonAPIScheduler(s.panicHandler), proton.NewDefaultAttachmentAllocator())
+	if err != nil {
+		// The message has been deleted in the meantime.
+		if apiErr := new(proton.APIError); errors.As(err, &apiErr) && apiErr.Status == http.StatusUnprocessableEntity {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get full message: %w", err)
+	}
+
+	var res *buildRes
+
+	if err := s.identityState.WithAddrKR(full.AddressID, func(_, addrKR *crypto.KeyRing) error {
+		bmessage.SplitHeaderBodyV2Disabled.Swap(s.featureFlagProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
+		res = buildRFC822(s.labels.GetLabelMap(), full, addrKR, s.senderKeys.withContext(ctx), messageJobOpts(s.messageSettings))
+
+		return res.err
+	}); err != nil {
+		if isTemporaryError(err) {
+			return nil, err
+		}
+
+		s.log.WithError(err).WithField("messageID", messageID).Warn("Failed to rebuild message")
+
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+// rebuildBatchStart is the state a batch of messages is rebuilt from.
+type rebuildBatchStart struct {
+	generation int
+	status     RebuildStatus
+}
+
+// startRebuildBatch records the start of a batch in the event loop. Messages changed by events from now on aren't
+// published with the batch, as it may have been built before the change.
+func (s *Service) startRebuildBatch() rebuildBatchStart {
+	if s.isSyncing.Load() {
+		return rebuildBatchStart{}
+	}
+
+	status := s.syncStateProvider.GetRebuildStatus()
+	if status.Pending {
+		s.rebuildChanged = make(map[string]struct{})
+	}
+
+	return rebuildBatchStart{generation: s.rebuildGeneration, status: status}
+}
+
+// markRebuildChanged records the messages changed by events while a batch is rebuilt.
+func (s *Service) markRebuildChanged(events []proton.MessageEvent) {
+	if s.rebuildChanged == nil {
+		return
+	}
+
+	for _, event := range events {
+		s.rebuildChanged[event.ID] = struct{}{}
+	}
+}
+
+// publishRebuildBatch publishes a rebuilt batch of messages in the event loop.
+// Each message is published as a MessageUpdated update. Gluon replaces the messages whose literal changed, so that
+// their size and body structure are computed again, and leaves the others as they are.
+// The batch is dropped if it was built with options changed since, and the rebuild resumes before the first message
+// changed by an event in the meantime, so that a rebuilt message never overwrites a newer update.
+func (s *Service) publishRebuildBatch(ctx context.Context, batch rebuildBatch) error {
+	changed := s.rebuildChanged
+	s.rebuildChanged = nil
+
+	if batch.generation != s.rebuildGeneration || s.isSyncing.Load() {
+		return nil
+	}
+
+	if len(batch.messageIDs) == 0 {
+		s.log.Info("All messages rebuilt")
+		return s.syncStateProvider.SetRebuildStatus(RebuildStatus{})
+	}
+
+	status := s.syncStateProvider.GetRebuildStatus()
+	updates := make([]imap.Update, 0, len(batch.messageIDs))
+
+	for _, messageID := range batch.messageIDs {
+		if _, ok := changed[messageID]; ok {
+			break
+		}
+
+		if res := batch.results[messageID]; res != nil {
+			update := imap.NewMessageUpdated(
+				res.update.Message,
+				res.update.Literal,
+				res.update.MailboxIDs,
+				res.update.ParsedMessage,
+				false, // The message may have been deleted in the meantime.
+				false,
+			)
+
+			didPublish, err := safePublishMessageUpdate(ctx, s, res.addressID, update, false)
+			if err != nil {
+				return err
+			}
+
+			if didPublish {
+				updates = append(updates, update)
+			}
+		}
+
+		status.LastMessageID = messageID
+	}
+
+	if err := waitOnIMAPUpdates(ctx, updates); err != nil {
+		return err
+	}
+
+	return s.syncStateProvider.SetRebuildStatus(status)
+}
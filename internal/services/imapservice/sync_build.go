@@ -26,7 +26,7 @@ import (
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/gopenpgp/v2/crypto"
-	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/algo"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
 	"github.com/bradenaw/juniper/xslices"
@@ -45,19 +45,53 @@ type buildRes struct {
 	err       error
 }
 
-func defaultMessageJobOpts() message.JobOptions {
-	return message.JobOptions{
+// MessageSettingsProvider provides the settings used to build the user's messages.
+type MessageSettingsProvider interface {
+	GetMessageProfile() vault.MessageProfile
+	GetUnwrapPGPMIME() bool
+	GetBlockRemoteContent() bool
+	GetRemoteContentURL() func(string) string
+	GetTrustedAuthServIDs() []string
+}
+
+func messageJobOpts(settings MessageSettingsProvider) message.JobOptions {
+	opts := message.JobOptions{
 		IgnoreDecryptionErrors: true, // Whether to ignore decryption errors and create a "custom message" instead.
 		SanitizeDate:           true, // Whether to replace all dates before 1970 with RFC822's birthdate.
-		AddInternalID:          true, // Whether to include MessageID as X-Pm-Internal-Id.
-		AddExternalID:          true, // Whether to include ExternalID as X-Pm-External-Id.
-		AddMessageDate:         true, // Whether to include message time as X-Pm-Date.
-		AddMessageIDReference:  true, // Whether to include the MessageID in References.
 		SanitizeMBOXHeaderLine: true, // Whether to ignore header line representing MBOX delimiter
+		UnwrapPGPMIME:          settings.GetUnwrapPGPMIME(),
+		BlockRemoteContent:     settings.GetBlockRemoteContent(),
 	}
+
+	// Loading remote images through a proxy only makes sense if they are not loaded directly.
+	if rewrite := settings.GetRemoteContentURL(); rewrite != nil {
+		opts.BlockRemoteContent = true
+		opts.RemoteContentURL = rewrite
+	}
+
+	profile := settings.GetMessageProfile()
+	if profile == vault.MessageProfileMinimal {
+		return opts
+	}
+
+	opts.AddInternalID = true         // Whether to include MessageID as X-Pm-Internal-Id.
+	opts.AddExternalID = true         // Whether to include ExternalID as X-Pm-External-Id.
+	opts.AddMessageDate = true        // Whether to include message time as X-Pm-Date.
+	opts.AddMessageIDReference = true // Whether to include the MessageID in References.
+	opts.VerifySignatures = true      // Whether to include the signature verification results as X-Pm-Signature-Status and Authentication-Results.
+	opts.TrustedAuthServIDs = settings.GetTrustedAuthServIDs()
+	opts.AddMetadata = profile == vault.MessageProfileForensic
+
+	return opts
 }
 
-func buildRFC822(apiLabels map[string]proton.Label, full proton.FullMessage, addrKR *crypto.KeyRing) *buildRes {
+func buildRFC822(
+	apiLabels map[string]proton.Label,
+	full proton.FullMessage,
+	addrKR *crypto.KeyRing,
+	keys message.SenderKeyProvider,
+	opts message.JobOptions,
+) *buildRes {
 	buf := eventBuildBufferPool.Get().(*bytes.Buffer) //nolint:forcetypeassert
 	buf.Reset()
 	buf.Grow(full.Size)
@@ -67,7 +101,7 @@ func buildRFC822(apiLabels map[string]proton.Label, full proton.FullMessage, add
 		err    error
 	)
 
-	if buildErr := message.DecryptAndBuildRFC822Into(addrKR, full.Message, full.AttData, defaultMessageJobOpts(), buf); buildErr != nil {
+	if buildErr := message.DecryptVerifyAndBuildRFC822Into(addrKR, keys, full.Message, full.AttData, opts, buf); buildErr != nil {
 		update = newMessageCreatedFailedUpdate(apiLabels, full.MessageMetadata, buildErr)
 		err = buildErr
 	} else {
@@ -104,7 +138,7 @@ func newMessageCreatedUpdate(
 	return &imap.MessageCreated{
 		Message:       toIMAPMessage(message),
 		Literal:       literal,
-		MailboxIDs:    usertypes.MapTo[string, imap.MailboxID](wantLabels(apiLabels, message.LabelIDs)),
+		MailboxIDs:    toMailboxIDs(apiLabels, message.LabelIDs),
 		ParsedMessage: parsedMessage,
 	}, nil
 }
@@ -123,7 +157,7 @@ func newMessageCreatedFailedUpdate(
 
 	return &imap.MessageCreated{
 		Message:       toIMAPMessage(message),
-		MailboxIDs:    usertypes.MapTo[string, imap.MailboxID](wantLabels(apiLabels, message.LabelIDs)),
+		MailboxIDs:    toMailboxIDs(apiLabels, message.LabelIDs),
 		Literal:       literal,
 		ParsedMessage: parsedMessage,
 	}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,3 +48,62 @@ func TestNewFailedMessageLiteral(t *testing.T) {
 	require.Equal(t, `("text" "plain" () NIL NIL "base64" 114 2)`, parsed.Body)
 	require.Equal(t, `("text" "plain" () NIL NIL "base64" 114 2 NIL NIL NIL NIL)`, parsed.Structure)
 }
+
+type testMessageSettings struct {
+	profile vault.MessageProfile
+	unwrap  bool
+	block   bool
+	proxy   func(string) string
+	servIDs []string
+}
+
+func (settings testMessageSettings) GetMessageProfile() vault.MessageProfile {
+	return settings.profile
+}
+
+func (settings testMessageSettings) GetUnwrapPGPMIME() bool {
+	return settings.unwrap
+}
+
+func (settings testMessageSettings) GetBlockRemoteContent() bool {
+	return settings.block
+}
+
+func (settings testMessageSettings) GetRemoteContentURL() func(string) string {
+	return settings.proxy
+}
+
+func (settings testMessageSettings) GetTrustedAuthServIDs() []string {
+	return settings.servIDs
+}
+
+func TestMessageJobOpts(t *testing.T) {
+	compat := messageJobOpts(testMessageSettings{profile: vault.MessageProfileCompat})
+	require.True(t, compat.AddInternalID)
+	require.True(t, compat.AddMessageIDReference)
+	require.False(t, compat.AddMetadata)
+	require.False(t, compat.UnwrapPGPMIME)
+	require.False(t, compat.BlockRemoteContent)
+
+	minimal := messageJobOpts(testMessageSettings{profile: vault.MessageProfileMinimal, unwrap: true, block: true})
+	require.True(t, minimal.IgnoreDecryptionErrors)
+	require.True(t, minimal.SanitizeDate)
+	require.False(t, minimal.AddInternalID)
+	require.False(t, minimal.AddExternalID)
+	require.False(t, minimal.AddMessageDate)
+	require.False(t, minimal.AddMessageIDReference)
+	require.False(t, minimal.VerifySignatures)
+	require.True(t, minimal.UnwrapPGPMIME)
+	require.True(t, minimal.BlockRemoteContent)
+
+	forensic := messageJobOpts(testMessageSettings{profile: vault.MessageProfileForensic})
+	require.True(t, forensic.AddInternalID)
+	require.True(t, forensic.AddMetadata)
+
+	trusted := messageJobOpts(testMessageSettings{servIDs: []string{"mx.example.com"}})
+	require.Equal(t, []string{"mx.example.com"}, trusted.TrustedAuthServIDs)
+
+	proxied := messageJobOpts(testMessageSettings{proxy: func(url string) string { return url }})
+	require.True(t, proxied.BlockRemoteContent)
+	require.NotNil(t, proxied.RemoteContentURL)
+}
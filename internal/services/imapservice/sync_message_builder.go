@@ -19,6 +19,7 @@ package imapservice
 
 import (
 	"bytes"
+	"context"
 
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/gopenpgp/v2/crypto"
@@ -29,11 +30,18 @@ import (
 
 type SyncMessageBuilder struct {
 	state                    *rwIdentity
+	senderKeys               *senderKeyCache
+	messageSettings          MessageSettingsProvider
 	featureFlagValueProvider unleash.FeatureFlagValueProvider
 }
 
-func NewSyncMessageBuilder(rw *rwIdentity, featureFlagValueProvider unleash.FeatureFlagValueProvider) *SyncMessageBuilder {
-	return &SyncMessageBuilder{state: rw, featureFlagValueProvider: featureFlagValueProvider}
+func NewSyncMessageBuilder(
+	rw *rwIdentity,
+	senderKeys *senderKeyCache,
+	messageSettings MessageSettingsProvider,
+	featureFlagValueProvider unleash.FeatureFlagValueProvider,
+) *SyncMessageBuilder {
+	return &SyncMessageBuilder{state: rw, senderKeys: senderKeys, messageSettings: messageSettings, featureFlagValueProvider: featureFlagValueProvider}
 }
 
 func (s SyncMessageBuilder) WithKeys(f func(*crypto.KeyRing, map[string]*crypto.KeyRing) error) error {
@@ -41,6 +49,7 @@ func (s SyncMessageBuilder) WithKeys(f func(*crypto.KeyRing, map[string]*crypto.
 }
 
 func (s SyncMessageBuilder) BuildMessage(
+	ctx context.Context,
 	apiLabels map[string]proton.Label,
 	full proton.FullMessage,
 	addrKR *crypto.KeyRing,
@@ -49,7 +58,14 @@ func (s SyncMessageBuilder) BuildMessage(
 	buffer.Grow(full.Size)
 	message.SplitHeaderBodyV2Disabled.Swap(s.featureFlagValueProvider.GetFlagValue(unleash.SplitMessageHeaderBodyV2Disabled))
 
-	if err := message.DecryptAndBuildRFC822Into(addrKR, full.Message, full.AttData, defaultMessageJobOpts(), buffer); err != nil {
+	if err := message.DecryptVerifyAndBuildRFC822Into(
+		addrKR,
+		s.senderKeys.withContext(ctx),
+		full.Message,
+		full.AttData,
+		messageJobOpts(s.messageSettings),
+		buffer,
+	); err != nil {
 		return syncservice.BuildResult{}, err
 	}
 
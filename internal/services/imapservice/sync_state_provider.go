@@ -32,9 +32,16 @@ import (
 type SyncState struct {
 	filePath string
 	status   syncservice.Status
+	rebuild  RebuildStatus
 	lock     sync.Mutex
 }
 
+// RebuildStatus records the progress of the rebuild of the messages, see Service.RebuildMessages.
+type RebuildStatus struct {
+	Pending       bool
+	LastMessageID string
+}
+
 var ErrInvalidSyncFileVersion = errors.New("invalid sync file version")
 
 const SyncFileVersion = 1
@@ -45,7 +52,8 @@ type syncStateFile struct {
 }
 
 type syncFileVersion1 struct {
-	Status syncservice.Status
+	Status  syncservice.Status
+	Rebuild RebuildStatus
 }
 
 func NewSyncState(filePath string) (*SyncState, error) {
@@ -105,18 +113,35 @@ func (s *SyncState) ClearSyncStatus(_ context.Context) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	oldStatus := s.status
+	oldStatus, oldRebuild := s.status, s.rebuild
 
-	s.status = syncservice.DefaultStatus()
+	// A resync rebuilds all messages anyway.
+	s.status, s.rebuild = syncservice.DefaultStatus(), RebuildStatus{}
 
 	if err := s.storeUnsafe(); err != nil {
-		s.status = oldStatus
+		s.status, s.rebuild = oldStatus, oldRebuild
 		return err
 	}
 
 	return nil
 }
 
+func (s *SyncState) GetRebuildStatus() RebuildStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.rebuild
+}
+
+func (s *SyncState) SetRebuildStatus(status RebuildStatus) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.rebuild = status
+
+	return s.storeUnsafe()
+}
+
 func (s *SyncState) SetHasLabels(_ context.Context, b bool) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -156,11 +181,15 @@ func (s *SyncState) SetMessageCount(_ context.Context, i int64) error {
 }
 
 func (s *SyncState) storeUnsafe() error {
-	return storeImpl(&s.status, s.filePath)
+	return storeFileImpl(syncFileVersion1{Status: s.status, Rebuild: s.rebuild}, s.filePath)
 }
 
 func storeImpl(status *syncservice.Status, path string) error {
-	data, err := json.Marshal(syncFileVersion1{Status: *status})
+	return storeFileImpl(syncFileVersion1{Status: *status}, path)
+}
+
+func storeFileImpl(file syncFileVersion1, path string) error {
+	data, err := json.Marshal(file)
 	if err != nil {
 		return fmt.Errorf("failed to marshal sync state data: %w", err)
 	}
@@ -215,6 +244,7 @@ func (s *SyncState) loadUnsafe() error {
 	}
 
 	s.status = v1.Status
+	s.rebuild = v1.Rebuild
 
 	return nil
 }
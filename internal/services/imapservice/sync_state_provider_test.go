@@ -66,6 +66,23 @@ func TestMigrateSyncSettings_DoesNotExist(t *testing.T) {
 	require.True(t, status.HasMessages)
 }
 
+func TestSyncState_RebuildStatus(t *testing.T) {
+	testFile := GetSyncConfigPath(t.TempDir(), "test")
+
+	state, err := NewSyncState(testFile)
+	require.NoError(t, err)
+	require.NoError(t, state.SetRebuildStatus(RebuildStatus{Pending: true, LastMessageID: "msg"}))
+
+	// The progress of the rebuild is kept across restarts.
+	state, err = NewSyncState(testFile)
+	require.NoError(t, err)
+	require.Equal(t, RebuildStatus{Pending: true, LastMessageID: "msg"}, state.GetRebuildStatus())
+
+	// A resync rebuilds all messages anyway.
+	require.NoError(t, state.ClearSyncStatus(context.Background()))
+	require.Equal(t, RebuildStatus{}, state.GetRebuildStatus())
+}
+
 func generateTestState(path string) (syncservice.Status, error) {
 	status := syncservice.DefaultStatus()
 
@@ -58,7 +58,7 @@ func (s *SyncUpdateApplier) Close() {
 
 func (s *SyncUpdateApplier) ApplySyncUpdates(ctx context.Context, updates []syncservice.BuildResult) error {
 	request := func(ctx context.Context, mode usertypes.AddressMode, connectors map[string]*Connector) ([]imap.Update, error) {
-		if mode == usertypes.AddressModeCombined {
+		if mode != usertypes.AddressModeSplit {
 			if len(connectors) != 1 {
 				return nil, fmt.Errorf("unexpected connecto list state")
 			}
@@ -66,6 +66,10 @@ func (s *SyncUpdateApplier) ApplySyncUpdates(ctx context.Context, updates []sync
 			c := maps.Values(connectors)[0]
 
 			update := imap.NewMessagesCreated(true, xslices.Map(updates, func(b syncservice.BuildResult) *imap.MessageCreated {
+				if mode == usertypes.AddressModeHybrid {
+					b.Update.MailboxIDs = withAddressMailboxIDs(b.AddressID, b.Update.MailboxIDs)
+				}
+
 				return b.Update
 			})...)
 
@@ -176,6 +180,11 @@ func syncLabels(ctx context.Context, labels map[string]proton.Label, connectors
 			}
 
 		case proton.LabelTypeFolder, proton.LabelTypeLabel:
+			// The templates label is listed as the Templates mailbox created below.
+			if usertypes.IsTemplatesLabel(label) {
+				continue
+			}
+
 			conflictUpdatesGenerator, err := userLabelConflictResolver.ResolveConflict(ctx, label, make(map[string]bool))
 			if err != nil {
 				return updates, err
@@ -197,6 +206,24 @@ func syncLabels(ctx context.Context, labels map[string]proton.Label, connectors
 		}
 	}
 
+	// Create the Templates mailbox.
+	for _, updateCh := range connectors {
+		update := newTemplatesMailboxCreatedUpdate()
+		updateCh.publishUpdate(ctx, update)
+		updates = append(updates, update)
+	}
+
+	// Create the per-address mailboxes of hybrid mode.
+	for _, updateCh := range connectors {
+		if updateCh.getAddressMode() != usertypes.AddressModeHybrid {
+			continue
+		}
+
+		addrUpdates := newAddressMailboxesCreatedUpdates(labels, updateCh.identityState.GetAddresses())
+		updateCh.publishUpdate(ctx, addrUpdates...)
+		updates = append(updates, addrUpdates...)
+	}
+
 	return updates, nil
 }
 
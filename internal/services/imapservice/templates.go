@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/connector"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+	"github.com/bradenaw/juniper/xslices"
+	"golang.org/x/exp/slices"
+)
+
+// The Templates mailbox lists the message templates shared between clients. It is present in every connector but is
+// not backed by a label of its own ID: its messages are the drafts carrying the templates label, which is created when
+// the first template is saved and is not listed under Labels.
+const templatesMailboxID = imap.MailboxID("Templates")
+
+func newTemplatesMailboxCreatedUpdate() *imap.MailboxCreated {
+	return newMailboxCreatedUpdate(templatesMailboxID, []string{string(templatesMailboxID)})
+}
+
+// toMailboxIDs returns the IDs of the mailboxes the message with the given labels is in.
+func toMailboxIDs(apiLabels map[string]proton.Label, labelIDs []string) []imap.MailboxID {
+	return xslices.Map(wantLabels(apiLabels, labelIDs), func(labelID string) imap.MailboxID {
+		if usertypes.IsTemplatesLabel(apiLabels[labelID]) {
+			return templatesMailboxID
+		}
+
+		return imap.MailboxID(labelID)
+	})
+}
+
+// ensureTemplatesMailbox creates the Templates mailbox of users who synced before it existed.
+func ensureTemplatesMailbox(ctx context.Context, mboxes []imap.MailboxNoAttrib, write connector.IMAPStateWrite) error {
+	if slices.ContainsFunc(mboxes, func(mbox imap.MailboxNoAttrib) bool { return mbox.ID == templatesMailboxID }) {
+		return nil
+	}
+
+	return write.CreateMailbox(ctx, newTemplatesMailboxCreatedUpdate().Mailbox)
+}
+
+// resolveTemplatesMailboxID returns the ID of the templates label if the given mailbox is the Templates mailbox,
+// creating the label if no template was saved yet. Other mailboxes are returned unchanged.
+func (s *Connector) resolveTemplatesMailboxID(ctx context.Context, mboxID imap.MailboxID) (imap.MailboxID, error) {
+	if mboxID != templatesMailboxID {
+		return mboxID, nil
+	}
+
+	s.templatesLock.Lock()
+	defer s.templatesLock.Unlock()
+
+	if labelID, ok := getTemplatesLabelID(s.labels); ok {
+		return imap.MailboxID(labelID), nil
+	}
+
+	label, err := s.client.CreateLabel(ctx, proton.CreateLabelReq{
+		Name:  usertypes.TemplatesLabelName,
+		Color: "#8080ff",
+		Type:  proton.LabelTypeLabel,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create templates label: %w", err)
+	}
+
+	wLabels := s.labels.Write()
+	defer wLabels.Close()
+
+	wLabels.SetLabel(label.ID, label, "connectorCreateTemplatesLabel")
+
+	return imap.MailboxID(label.ID), nil
+}
+
+// GetTemplatesLabelID returns the ID of the label whose messages are listed in the Templates mailbox,
+// or false if no template was saved yet.
+func (s *Service) GetTemplatesLabelID() (string, bool) {
+	return getTemplatesLabelID(s.labels)
+}
+
+func getTemplatesLabelID(labels sharedLabels) (string, bool) {
+	rLabels := labels.Read()
+	defer rLabels.Close()
+
+	for _, label := range rLabels.GetLabels() {
+		if usertypes.IsTemplatesLabel(label) {
+			return label.ID, true
+		}
+	}
+
+	return "", false
+}
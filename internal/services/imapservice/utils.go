@@ -65,7 +65,7 @@ func getImportAddress(p *parser.Parser, isDraft bool, id string, conn connectorI
 		}
 	}
 
-	inCombinedMode := conn.getAddressMode() == usertypes.AddressModeCombined
+	inCombinedMode := conn.getAddressMode() != usertypes.AddressModeSplit
 	if !inCombinedMode {
 		return address, nil
 	}
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapsmtpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/gluon/connector"
+	imapEvents "github.com/ProtonMail/gluon/events"
+	"github.com/sirupsen/logrus"
+)
+
+// gluonSessionIDLabel is the profiler label under which gluon runs each session, with the session ID as value.
+// It is the only way for the login path to know which connection a login comes from.
+const gluonSessionIDLabel = "SessionID"
+
+// clientCertSessionTimeout bounds the wait for gluon to report the connection of a session logging in.
+const clientCertSessionTimeout = 5 * time.Second
+
+// IMAPClientCertAuthenticator binds IMAP connections to the user their TLS client certificate was issued to.
+type IMAPClientCertAuthenticator interface {
+	// AuthenticateClientCert returns the ID of the user the given client certificate was issued to.
+	AuthenticateClientCert(cert *x509.Certificate) (string, error)
+}
+
+// clientCertBindings binds the IMAP logins of connections presenting a client certificate to the user the
+// certificate was issued to. The check is made when gluon authorizes the login with the connector of a user:
+// the connection is found from the ID of the gluon session, which gluon reports along with the connection's
+// remote address when the session is added.
+type clientCertBindings struct {
+	auth     IMAPClientCertAuthenticator
+	conns    map[string]*tls.Conn
+	sessions map[int]string
+
+	lock    sync.Mutex
+	changed chan struct{}
+}
+
+func newClientCertBindings() *clientCertBindings {
+	return &clientCertBindings{
+		conns:    make(map[string]*tls.Conn),
+		sessions: make(map[int]string),
+		changed:  make(chan struct{}),
+	}
+}
+
+// bind wraps the TLS connections of the given listener so that a client presenting a certificate can only log in
+// as the user the certificate was issued to. A nil authenticator disables the binding.
+func (b *clientCertBindings) bind(listener net.Listener, auth IMAPClientCertAuthenticator) net.Listener {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.auth = auth
+
+	if auth == nil {
+		return listener
+	}
+
+	return &certBoundListener{Listener: listener, bindings: b}
+}
+
+// trackSession keeps the remote addresses of the gluon sessions up to date.
+func (b *clientCertBindings) trackSession(event imapEvents.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch event := event.(type) {
+	case imapEvents.SessionAdded:
+		b.sessions[event.SessionID] = event.RemoteAddr.String()
+
+	case imapEvents.SessionRemoved:
+		delete(b.sessions, event.SessionID)
+	}
+
+	b.notifyUnsafe()
+}
+
+// allowLogin returns whether the session of the given login context may log in as the given user.
+// Logins over connections without a client certificate are allowed; with one, only its user may log in.
+// The login is refused if its connection can't be found while the binding is enabled.
+func (b *clientCertBindings) allowLogin(ctx context.Context, userID string) bool {
+	conn, ok := b.getConn(ctx)
+	if !ok {
+		return false
+	}
+
+	if conn == nil {
+		return true
+	}
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return true
+	}
+
+	certUserID, err := b.getAuth().AuthenticateClientCert(state.PeerCertificates[0])
+	if err != nil {
+		logIMAP.WithError(err).Warn("Refusing login with an invalid client certificate")
+		return false
+	}
+
+	if certUserID != userID {
+		logIMAP.WithFields(logrus.Fields{
+			"userID":     userID,
+			"certUserID": certUserID,
+		}).Warn("Refusing login as another user than the one of the client certificate")
+
+		return false
+	}
+
+	return true
+}
+
+// getConn returns the TLS connection of the session of the given login context, or nil if the binding is disabled.
+// It returns false if the connection can't be found.
+func (b *clientCertBindings) getConn(ctx context.Context) (*tls.Conn, bool) {
+	label, ok := pprof.Label(ctx, gluonSessionIDLabel)
+	if !ok {
+		return nil, b.getAuth() == nil
+	}
+
+	sessionID, err := strconv.Atoi(label)
+	if err != nil {
+		return nil, b.getAuth() == nil
+	}
+
+	timeout := time.NewTimer(clientCertSessionTimeout)
+	defer timeout.Stop()
+
+	for {
+		b.lock.Lock()
+
+		if b.auth == nil {
+			b.lock.Unlock()
+			return nil, true
+		}
+
+		remote, known := b.sessions[sessionID]
+		conn := b.conns[remote]
+		changed := b.changed
+
+		b.lock.Unlock()
+
+		if known {
+			return conn, conn != nil
+		}
+
+		// Gluon reports the session asynchronously; it may not be known yet.
+		select {
+		case <-changed:
+		case <-timeout.C:
+			logIMAP.WithField("sessionID", sessionID).Warn("Refusing login from an unknown session")
+			return nil, false
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+func (b *clientCertBindings) getAuth() IMAPClientCertAuthenticator {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.auth
+}
+
+func (b *clientCertBindings) addConn(conn *tls.Conn) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.conns[conn.RemoteAddr().String()] = conn
+
+	b.notifyUnsafe()
+}
+
+func (b *clientCertBindings) removeConn(conn *tls.Conn) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.conns[conn.RemoteAddr().String()] == conn {
+		delete(b.conns, conn.RemoteAddr().String())
+	}
+}
+
+func (b *clientCertBindings) notifyUnsafe() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+type certBoundListener struct {
+	net.Listener
+
+	bindings *clientCertBindings
+}
+
+func (l *certBoundListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return conn, nil
+	}
+
+	l.bindings.addConn(tlsConn)
+
+	return &certBoundConn{Conn: tlsConn, tlsConn: tlsConn, bindings: l.bindings}, nil
+}
+
+type certBoundConn struct {
+	net.Conn
+
+	tlsConn   *tls.Conn
+	bindings  *clientCertBindings
+	closeOnce sync.Once
+}
+
+func (c *certBoundConn) Close() error {
+	c.closeOnce.Do(func() { c.bindings.removeConn(c.tlsConn) })
+
+	return c.Conn.Close()
+}
+
+// certBoundConnector checks the logins of the user of a connector against the client certificate of the connection.
+type certBoundConnector struct {
+	connector.Connector
+
+	userID   string
+	bindings *clientCertBindings
+}
+
+func (c *certBoundConnector) Authorize(ctx context.Context, username string, password []byte) bool {
+	if !c.bindings.allowLogin(ctx, c.userID) {
+		return false
+	}
+
+	return c.Connector.Authorize(ctx, username, password)
+}
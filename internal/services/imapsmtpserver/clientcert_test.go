@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapsmtpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"runtime/pprof"
+	"strconv"
+	"testing"
+	"time"
+
+	imapEvents "github.com/ProtonMail/gluon/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/certs"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertAuthenticator struct{}
+
+// AuthenticateClientCert returns the device name of the certificate as the user ID.
+func (testCertAuthenticator) AuthenticateClientCert(cert *x509.Certificate) (string, error) {
+	if cert.Subject.CommonName == "revoked" {
+		return "", errors.New("revoked")
+	}
+
+	return cert.Subject.CommonName, nil
+}
+
+func TestClientCertBindings_Disabled(t *testing.T) {
+	bindings := newClientCertBindings()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close() //nolint:errcheck
+
+	require.Equal(t, listener, bindings.bind(listener, nil))
+
+	// Logins are not checked, even from unknown sessions.
+	require.True(t, bindings.allowLogin(withSessionID(context.Background(), 1), "user"))
+}
+
+func TestClientCertBindings_Login(t *testing.T) {
+	bindings := newClientCertBindings()
+
+	serverCert := newTestCert(t, "server")
+
+	netListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := bindings.bind(tls.NewListener(netListener, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequestClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), testCertAuthenticator{})
+	defer listener.Close() //nolint:errcheck
+
+	// connect opens a connection with the given client certificate, if any, as gluon session sessionID.
+	connect := func(sessionID int, device string) {
+		config := &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+
+		if device != "" {
+			config.Certificates = []tls.Certificate{newTestCert(t, device)}
+		}
+
+		errCh := make(chan error)
+
+		go func() {
+			conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+			if err == nil {
+				t.Cleanup(func() { _ = conn.Close() })
+			}
+
+			errCh <- err
+		}()
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		require.NoError(t, conn.(*certBoundConn).tlsConn.Handshake())
+		require.NoError(t, <-errCh)
+
+		bindings.trackSession(imapEvents.SessionAdded{SessionID: sessionID, RemoteAddr: conn.RemoteAddr()})
+	}
+
+	connect(1, "user")
+	connect(2, "")
+	connect(3, "revoked")
+
+	// The user of the certificate can log in, but not another user.
+	require.True(t, bindings.allowLogin(withSessionID(context.Background(), 1), "user"))
+	require.False(t, bindings.allowLogin(withSessionID(context.Background(), 1), "other"))
+
+	// A connection without a certificate can log in as any user.
+	require.True(t, bindings.allowLogin(withSessionID(context.Background(), 2), "other"))
+
+	// An invalid certificate doesn't allow any login.
+	require.False(t, bindings.allowLogin(withSessionID(context.Background(), 3), "revoked"))
+
+	// Logins from sessions that can't be found are refused.
+	ctx, cancel := context.WithTimeout(withSessionID(context.Background(), 4), 100*time.Millisecond)
+	defer cancel()
+
+	require.False(t, bindings.allowLogin(ctx, "user"))
+	require.False(t, bindings.allowLogin(context.Background(), "user"))
+}
+
+func withSessionID(ctx context.Context, sessionID int) context.Context {
+	return pprof.WithLabels(ctx, pprof.Labels(gluonSessionIDLabel, strconv.Itoa(sessionID)))
+}
+
+func newTestCert(t *testing.T, device string) tls.Certificate {
+	t.Helper()
+
+	template, err := certs.NewClientTLSTemplate(device)
+	require.NoError(t, err)
+
+	template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+
+	certPEM, keyPEM, err := certs.GenerateCert(template)
+	require.NoError(t, err)
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/unleash"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
 )
 
 const (
@@ -64,6 +65,47 @@ var fallbackClientLimits = connectionlimiter.NewLimits(map[connectionlimiter.Cli
 	connectionlimiter.ClientThunderbird: 0,
 }, 0)
 
+// ClientTypes lists the client types whose IMAP sessions are limited.
+//
+//nolint:gochecknoglobals
+var ClientTypes = []connectionlimiter.Client{
+	connectionlimiter.ClientAppleMail,
+	connectionlimiter.ClientOutlook,
+	connectionlimiter.ClientThunderbird,
+	connectionlimiter.ClientUnknown,
+}
+
+// GetClientLimits returns the limits gluon applies by default and when the default limits are remotely disabled,
+// with the limits set by the user applied to both.
+func GetClientLimits(userLimits map[connectionlimiter.Client]int) (limits, fallbackLimits connectionlimiter.Limits) {
+	apply := func(base connectionlimiter.Limits) connectionlimiter.Limits {
+		limits := connectionlimiter.NewLimits(maps.Clone(base.PerClient), base.UnknownLimit)
+
+		for client, limit := range userLimits {
+			if client == connectionlimiter.ClientUnknown {
+				limits.UnknownLimit = limit
+			} else {
+				limits.PerClient[client] = limit
+			}
+		}
+
+		return limits
+	}
+
+	return apply(defaultClientLimits), apply(fallbackClientLimits)
+}
+
+// GetClientType returns the type of the client with the given IMAP ID, as gluon classifies it for its limits.
+// Gluon doesn't export the classification itself, so it is read from a connection limiter without limits,
+// which binds every client and returns its type.
+func GetClientType(id imap.IMAPID) connectionlimiter.Client {
+	noLimits := connectionlimiter.NewLimits(nil, 0)
+
+	_, client, _, _ := connectionlimiter.NewConnectionLimiter(noLimits, noLimits).TryBind(0, id, false)
+
+	return client
+}
+
 var logIMAP = logrus.WithField("pkg", "server/imap") //nolint:gochecknoglobals
 
 type IMAPSettingsProvider interface {
@@ -79,6 +121,8 @@ type IMAPSettingsProvider interface {
 	SetCacheDirectory(string) error
 	EventPublisher() IMAPEventPublisher
 	Version() *semver.Version
+	ClientLimits() map[connectionlimiter.Client]int
+	ClientCertAuthenticator() IMAPClientCertAuthenticator
 }
 
 type IMAPEventPublisher interface {
@@ -106,6 +150,7 @@ func newIMAPServer(
 	panicHandler async.PanicHandler,
 	observabilitySender observability.Sender,
 	featureFlagProvider unleash.FeatureFlagValueProvider,
+	clientLimits map[connectionlimiter.Client]int,
 ) (*gluon.Server, error) {
 	gluonCacheDir = ApplyGluonCachePathSuffix(gluonCacheDir)
 	gluonConfigDir = ApplyGluonConfigPathSuffix(gluonConfigDir)
@@ -116,6 +161,7 @@ func newIMAPServer(
 		"version":    version,
 		"logClient":  logClient,
 		"logServer":  logServer,
+		"limits":     clientLimits,
 	}).Info("Creating IMAP server")
 
 	if logClient || logServer {
@@ -153,7 +199,7 @@ func newIMAPServer(
 		gluon.WithObservabilitySender(observability.NewAdapter(observabilitySender), int(observability.GluonImapError), int(observability.GluonMessageError), int(observability.GluonOtherError)),
 		gluon.WithConnectionRollingCounter(rollingCounterConnectionLimitThreshold, rollingCounterObservabilityThreshold, rollingCounterNumberOfBuckets, rollingCounterBucketRotationInterval),
 		gluon.WithFeatureFlagProvider(featureFlagProvider),
-		gluon.WithConnectionLimiter(defaultClientLimits, fallbackClientLimits),
+		gluon.WithConnectionLimiter(GetClientLimits(clientLimits)),
 	}
 
 	if disableIMAPAuthenticate {
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapsmtpserver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/connectionlimiter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficCounter(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	counter := newTrafficCounter()
+	listener := counter.wrap(l)
+	defer listener.Close() //nolint:errcheck
+
+	client, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer client.Close() //nolint:errcheck
+
+	server, err := listener.Accept()
+	require.NoError(t, err)
+
+	remote := client.LocalAddr().String()
+
+	_, err = client.Write([]byte("a001 NOOP\r\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 11)
+	_, err = server.Read(buf)
+	require.NoError(t, err)
+
+	_, err = server.Write([]byte("a001 OK\r\n"))
+	require.NoError(t, err)
+
+	received, sent := counter.get(remote)
+	require.Equal(t, uint64(11), received)
+	require.Equal(t, uint64(9), sent)
+
+	// Closed connections are no longer counted.
+	require.NoError(t, server.Close())
+
+	received, sent = counter.get(remote)
+	require.Zero(t, received)
+	require.Zero(t, sent)
+}
+
+func TestGetClientLimits(t *testing.T) {
+	limits, fallback := GetClientLimits(map[connectionlimiter.Client]int{
+		connectionlimiter.ClientOutlook: 3,
+		connectionlimiter.ClientUnknown: 7,
+	})
+
+	require.Equal(t, 3, limits.PerClient[connectionlimiter.ClientOutlook])
+	require.Equal(t, 60, limits.PerClient[connectionlimiter.ClientAppleMail])
+	require.Equal(t, 7, limits.UnknownLimit)
+
+	require.Equal(t, 3, fallback.PerClient[connectionlimiter.ClientOutlook])
+	require.Equal(t, 25, fallback.PerClient[connectionlimiter.ClientAppleMail])
+	require.Equal(t, 7, fallback.UnknownLimit)
+
+	// The defaults are left untouched.
+	require.Equal(t, 0, defaultClientLimits.PerClient[connectionlimiter.ClientOutlook])
+}
+
+func TestGetClientType(t *testing.T) {
+	require.Equal(t, connectionlimiter.ClientOutlook, GetClientType(imap.IMAPID{Name: "Microsoft Outlook"}))
+	require.Equal(t, connectionlimiter.ClientAppleMail, GetClientType(imap.IMAPID{Name: "Mac OS X Mail"}))
+	require.Equal(t, connectionlimiter.ClientThunderbird, GetClientType(imap.IMAPID{Name: "Thunderbird"}))
+	require.Equal(t, connectionlimiter.ClientUnknown, GetClientType(imap.IMAPID{Name: "K-9 Mail"}))
+}
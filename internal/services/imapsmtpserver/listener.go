@@ -25,21 +25,21 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
 )
 
-func newListener(port int, useTLS bool, tlsConfig *tls.Config) (net.Listener, error) {
-	if useTLS {
-		tlsListener, err := tls.Listen("tcp", fmt.Sprintf("%v:%v", constants.Host, port), tlsConfig)
-		if err != nil {
-			return nil, err
-		}
-
-		return tlsListener, nil
-	}
-
+// newListener listens on the given port. If traffic is not nil, the bytes exchanged with each client are counted.
+func newListener(port int, useTLS bool, tlsConfig *tls.Config, traffic *trafficCounter) (net.Listener, error) {
 	netListener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", constants.Host, port))
 	if err != nil {
 		return nil, err
 	}
 
+	if traffic != nil {
+		netListener = traffic.wrap(netListener)
+	}
+
+	if useTLS {
+		return tls.NewListener(netListener, tlsConfig), nil
+	}
+
 	return netListener, nil
 }
 
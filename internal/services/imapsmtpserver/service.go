@@ -19,6 +19,7 @@ package imapsmtpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 	"github.com/ProtonMail/gluon"
 	"github.com/ProtonMail/gluon/async"
 	"github.com/ProtonMail/gluon/connector"
+	imapEvents "github.com/ProtonMail/gluon/events"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/logging"
 	"github.com/ProtonMail/gluon/reporter"
@@ -46,6 +48,8 @@ type Service struct {
 
 	imapServer   *gluon.Server
 	imapListener net.Listener
+	imapTraffic  *trafficCounter
+	imapCerts    *clientCertBindings
 
 	smtpServer   *smtp.Server
 	smtpListener net.Listener
@@ -82,6 +86,8 @@ func NewService(
 	return &Service{
 		requests:     cpc.NewCPC(),
 		smtpAccounts: bridgesmtp.NewAccounts(),
+		imapTraffic:  newTrafficCounter(),
+		imapCerts:    newClientCertBindings(),
 
 		panicHandler:         panicHandler,
 		reporter:             reporter,
@@ -149,15 +155,17 @@ func (sm *Service) RestartSMTP(ctx context.Context) error {
 	return err
 }
 
+// AddIMAPUser adds the connector of an address of the given user to the IMAP server.
 func (sm *Service) AddIMAPUser(
 	ctx context.Context,
+	userID string,
 	connector connector.Connector,
 	addrID string,
 	idProvider imapservice.GluonIDProvider,
 	syncStateProvider syncservice.StateProvider,
 ) error {
 	_, err := sm.requests.Send(ctx, &smRequestAddIMAPUser{
-		connector:         connector,
+		connector:         &certBoundConnector{Connector: connector, userID: userID, bindings: sm.imapCerts},
 		addrID:            addrID,
 		idProvider:        idProvider,
 		syncStateProvider: syncStateProvider,
@@ -220,6 +228,16 @@ func (sm *Service) GetRollingIMAPConnectionCount() int {
 	return sm.imapServer.GetRollingIMAPConnectionCount()
 }
 
+// CloseIMAPSession says goodbye to the client of the given IMAP session with the given reason and disconnects it.
+func (sm *Service) CloseIMAPSession(sessionID int, reason string) error {
+	return sm.imapServer.CloseSessionByID(sessionID, reason)
+}
+
+// GetIMAPSessionTraffic returns the number of bytes received from and sent to the IMAP client with the given address.
+func (sm *Service) GetIMAPSessionTraffic(remoteAddr string) (received, sent uint64) {
+	return sm.imapTraffic.get(remoteAddr)
+}
+
 func (sm *Service) run(ctx context.Context, subscription events.Subscription) {
 	eventSub := subscription.Add()
 	defer subscription.Remove(eventSub)
@@ -519,12 +537,21 @@ func (sm *Service) createIMAPServer(ctx context.Context) (*gluon.Server, error)
 		sm.panicHandler,
 		sm.observabilitySender,
 		sm.featureFlagProvider,
+		sm.imapSettings.ClientLimits(),
 	)
-	if err == nil {
-		sm.eventPublisher.PublishEvent(ctx, events.IMAPServerCreated{})
+	if err != nil {
+		return nil, err
 	}
 
-	return server, err
+	watcher := server.AddWatcher(imapEvents.SessionAdded{}, imapEvents.SessionRemoved{})
+
+	sm.tasks.Once(func(ctx context.Context) {
+		async.RangeContext(ctx, watcher, sm.imapCerts.trackSession)
+	})
+
+	sm.eventPublisher.PublishEvent(ctx, events.IMAPServerCreated{})
+
+	return server, nil
 }
 
 func (sm *Service) createSMTPServer() *smtp.Server {
@@ -624,7 +651,7 @@ func (sm *Service) serveSMTP(ctx context.Context) error {
 			"ssl":  sm.smtpSettings.UseSSL(),
 		}).Info("Starting SMTP server")
 
-		smtpListener, err := newListener(sm.smtpSettings.Port(), sm.smtpSettings.UseSSL(), sm.smtpSettings.TLSConfig())
+		smtpListener, err := newListener(sm.smtpSettings.Port(), sm.smtpSettings.UseSSL(), sm.smtpSettings.TLSConfig(), nil)
 		if err != nil {
 			return 0, fmt.Errorf("failed to create SMTP listener: %w", err)
 		}
@@ -670,12 +697,19 @@ func (sm *Service) serveIMAP(ctx context.Context) error {
 			"ssl":  sm.imapSettings.UseSSL(),
 		}).Info("Starting IMAP server")
 
-		imapListener, err := newListener(sm.imapSettings.Port(), sm.imapSettings.UseSSL(), sm.imapSettings.TLSConfig())
+		imapListener, err := newListener(sm.imapSettings.Port(), sm.imapSettings.UseSSL(), sm.imapSettings.TLSConfig(), sm.imapTraffic)
 		if err != nil {
 			return 0, fmt.Errorf("failed to create IMAP listener: %w", err)
 		}
 
-		sm.imapListener = imapListener
+		// Client certificates are only requested with implicit TLS; they can't be enabled with STARTTLS.
+		var certAuth IMAPClientCertAuthenticator
+
+		if sm.imapSettings.UseSSL() && sm.imapSettings.TLSConfig().ClientAuth != tls.NoClientCert {
+			certAuth = sm.imapSettings.ClientCertAuthenticator()
+		}
+
+		sm.imapListener = sm.imapCerts.bind(imapListener, certAuth)
 
 		if err := sm.imapServer.Serve(ctx, sm.imapListener); err != nil {
 			return 0, fmt.Errorf("failed to serve IMAP: %w", err)
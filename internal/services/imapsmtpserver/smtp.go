@@ -20,6 +20,7 @@ package imapsmtpserver
 import (
 	"crypto/tls"
 
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
 	"github.com/ProtonMail/proton-bridge/v3/internal/identifier"
 	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
@@ -38,12 +39,14 @@ type SMTPSettingsProvider interface {
 	SetPort(int) error
 	UseSSL() bool
 	Identifier() identifier.UserAgentUpdater
+	ClientCertAuthenticator() smtpservice.ClientCertAuthenticator
+	AuditRecorder() audit.Recorder
 }
 
 func newSMTPServer(accounts *smtpservice.Accounts, settings SMTPSettingsProvider) *smtp.Server {
 	logSMTP.WithField("logSMTP", settings.Log()).Info("Creating SMTP server")
 
-	smtpServer := smtp.NewServer(smtpservice.NewBackend(accounts, settings.Identifier()))
+	smtpServer := smtp.NewServer(smtpservice.NewBackend(accounts, settings.Identifier(), settings.ClientCertAuthenticator(), settings.AuditRecorder()))
 
 	smtpServer.TLSConfig = settings.TLSConfig()
 	smtpServer.Domain = constants.Host
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package imapsmtpserver
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// trafficCounter counts the bytes received from and sent to the clients of a listener, by remote address.
+type trafficCounter struct {
+	conns     map[string]*countingConn
+	connsLock sync.RWMutex
+}
+
+func newTrafficCounter() *trafficCounter {
+	return &trafficCounter{conns: make(map[string]*countingConn)}
+}
+
+// wrap returns a listener whose connections are counted until they are closed.
+func (t *trafficCounter) wrap(l net.Listener) net.Listener {
+	return &countingListener{Listener: l, counter: t}
+}
+
+// get returns the number of bytes received from and sent to the open connection with the given remote address.
+func (t *trafficCounter) get(remoteAddr string) (received, sent uint64) {
+	t.connsLock.RLock()
+	defer t.connsLock.RUnlock()
+
+	conn, ok := t.conns[remoteAddr]
+	if !ok {
+		return 0, 0
+	}
+
+	return conn.received.Load(), conn.sent.Load()
+}
+
+func (t *trafficCounter) add(conn *countingConn) {
+	t.connsLock.Lock()
+	defer t.connsLock.Unlock()
+
+	t.conns[conn.RemoteAddr().String()] = conn
+}
+
+func (t *trafficCounter) remove(conn *countingConn) {
+	t.connsLock.Lock()
+	defer t.connsLock.Unlock()
+
+	if t.conns[conn.RemoteAddr().String()] == conn {
+		delete(t.conns, conn.RemoteAddr().String())
+	}
+}
+
+type countingListener struct {
+	net.Listener
+
+	counter *trafficCounter
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	countingConn := &countingConn{Conn: conn, counter: l.counter}
+
+	l.counter.add(countingConn)
+
+	return countingConn, nil
+}
+
+type countingConn struct {
+	net.Conn
+
+	counter   *trafficCounter
+	received  atomic.Uint64
+	sent      atomic.Uint64
+	closeOnce sync.Once
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	c.received.Add(uint64(n)) //nolint:gosec // disable G115
+
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+
+	c.sent.Add(uint64(n)) //nolint:gosec // disable G115
+
+	return n, err
+}
+
+func (c *countingConn) Close() error {
+	c.closeOnce.Do(func() { c.counter.remove(c) })
+
+	return c.Conn.Close()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DirSize reports the total size of the regular files in a directory.
+// Walking a large directory is slow, so the size is only computed again once it is older than maxAge.
+type DirSize struct {
+	getPath func() (string, error)
+	maxAge  time.Duration
+
+	size    int64
+	updated time.Time
+	lock    sync.Mutex
+}
+
+func NewDirSize(getPath func() (string, error), maxAge time.Duration) *DirSize {
+	return &DirSize{
+		getPath: getPath,
+		maxAge:  maxAge,
+	}
+}
+
+// Get returns the size of the directory, in bytes. A directory that doesn't exist has size zero.
+func (d *DirSize) Get() int64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if !d.updated.IsZero() && time.Since(d.updated) < d.maxAge {
+		return d.size
+	}
+
+	d.size = 0
+	d.updated = time.Now()
+
+	path, err := d.getPath()
+	if err != nil {
+		return 0
+	}
+
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return nil //nolint:nilerr
+		}
+
+		if info, err := entry.Info(); err == nil {
+			d.size += info.Size()
+		}
+
+		return nil
+	})
+
+	return d.size
+}
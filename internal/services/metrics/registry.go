@@ -0,0 +1,374 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package metrics exposes Bridge's health and sync metrics on localhost in the Prometheus text format.
+// Metrics are recorded locally whether or not telemetry is enabled, and are never sent anywhere.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ProtonMail/go-proton-api"
+)
+
+// Type is the type of a metric.
+type Type string
+
+const (
+	TypeCounter Type = "counter"
+	TypeGauge   Type = "gauge"
+)
+
+// Labels are the label names and values of a series.
+type Labels map[string]string
+
+// Collector reports series whose values are read when the metrics are scraped.
+type Collector func(emit func(name string, labels Labels, value float64))
+
+type family struct {
+	typ    Type
+	help   string
+	series map[string]*series
+}
+
+type series struct {
+	labels Labels
+	value  float64
+}
+
+// Registry holds the metrics exposed by the endpoint.
+// Metrics that were not described are counters if their name ends with "_total", and gauges otherwise.
+type Registry struct {
+	families   map[string]*family
+	collectors []Collector
+
+	lock sync.Mutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		families: make(map[string]*family),
+	}
+}
+
+// Describe sets the type and help text of the given metric.
+func (r *Registry) Describe(name string, typ Type, help string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	f := r.getFamily(name)
+	f.typ = typ
+	f.help = help
+}
+
+// Add adds the given delta to the series of the given metric and labels.
+func (r *Registry) Add(name string, labels Labels, delta float64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.getSeries(name, labels).value += delta
+}
+
+// Set sets the value of the series of the given metric and labels.
+func (r *Registry) Set(name string, labels Labels, value float64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.getSeries(name, labels).value = value
+}
+
+// Delete removes the series of all metrics that have the given label value, e.g. those of a user who logged out.
+func (r *Registry) Delete(label, value string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, f := range r.families {
+		for key, s := range f.series {
+			if v, ok := s.labels[label]; ok && v == value {
+				delete(f.series, key)
+			}
+		}
+	}
+}
+
+// AddCollector adds a collector that is called on every scrape.
+func (r *Registry) AddCollector(collector Collector) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.collectors = append(r.collectors, collector)
+}
+
+// RecordObservabilityMetric counts the given observability metric, so that the counters sent to the API
+// (e.g. SMTP send successes and failures) are also available locally. Metrics that aren't counters are ignored.
+func (r *Registry) RecordObservabilityMetric(metric proton.ObservabilityMetric) {
+	if !strings.HasSuffix(metric.Name, "_total") {
+		return
+	}
+
+	data, ok := metric.Data.(map[string]any)
+	if !ok {
+		return
+	}
+
+	value, ok := getObservabilityValue(data["Value"])
+	if !ok {
+		return
+	}
+
+	labels := make(Labels)
+
+	switch v := data["Labels"].(type) {
+	case map[string]string:
+		for key, value := range v {
+			labels[key] = value
+		}
+
+	case map[string]any:
+		for key, value := range v {
+			labels[key] = fmt.Sprint(value)
+		}
+	}
+
+	r.Add(metric.Name, labels, value)
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	families := r.snapshot()
+
+	// Collectors may take other locks, so they are called without holding the registry's.
+	for _, collector := range r.getCollectors() {
+		collector(func(name string, labels Labels, value float64) {
+			name = sanitizeName(name)
+
+			f, ok := families[name]
+			if !ok {
+				f = &family{typ: defaultType(name), series: make(map[string]*series)}
+				families[name] = f
+			}
+
+			f.series[seriesKey(labels)] = &series{labels: sanitizeLabels(labels), value: value}
+		})
+	}
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	for _, name := range sortedKeys(families) {
+		f := families[name]
+
+		if len(f.series) == 0 {
+			continue
+		}
+
+		if f.help != "" {
+			fmt.Fprintf(cw, "# HELP %v %v\n", name, escapeHelp(f.help))
+		}
+
+		fmt.Fprintf(cw, "# TYPE %v %v\n", name, f.typ)
+
+		for _, key := range sortedKeys(f.series) {
+			s := f.series[key]
+
+			fmt.Fprintf(cw, "%v%v %v\n", name, formatLabels(s.labels), formatValue(s.value))
+		}
+	}
+
+	if err := cw.w.Flush(); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, cw.err
+}
+
+func (r *Registry) getFamily(name string) *family {
+	name = sanitizeName(name)
+
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{typ: defaultType(name), series: make(map[string]*series)}
+		r.families[name] = f
+	}
+
+	return f
+}
+
+func (r *Registry) getSeries(name string, labels Labels) *series {
+	f := r.getFamily(name)
+	key := seriesKey(labels)
+
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: sanitizeLabels(labels)}
+		f.series[key] = s
+	}
+
+	return s
+}
+
+func (r *Registry) getCollectors() []Collector {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]Collector{}, r.collectors...)
+}
+
+// snapshot returns a copy of the recorded metrics.
+func (r *Registry) snapshot() map[string]*family {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	families := make(map[string]*family, len(r.families))
+
+	for name, f := range r.families {
+		copied := make(map[string]*series, len(f.series))
+
+		for key, s := range f.series {
+			copied[key] = &series{labels: s.labels, value: s.value}
+		}
+
+		families[name] = &family{typ: f.typ, help: f.help, series: copied}
+	}
+
+	return families
+}
+
+func defaultType(name string) Type {
+	if strings.HasSuffix(name, "_total") {
+		return TypeCounter
+	}
+
+	return TypeGauge
+}
+
+func getObservabilityValue(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+
+	case int64:
+		return float64(v), true
+
+	case float64:
+		return v, true
+
+	default:
+		return 0, false
+	}
+}
+
+// seriesKey identifies a series by its sorted labels.
+func seriesKey(labels Labels) string {
+	return formatLabels(sanitizeLabels(labels))
+}
+
+func sanitizeLabels(labels Labels) Labels {
+	sanitized := make(Labels, len(labels))
+
+	for key, value := range labels {
+		sanitized[sanitizeName(key)] = value
+	}
+
+	return sanitized
+}
+
+// sanitizeName replaces the characters that aren't allowed in metric and label names.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
+
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels))
+
+	for _, key := range sortedKeys(labels) {
+		pairs = append(pairs, key+`="`+escapeLabelValue(labels[key])+`"`)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+
+	case math.IsInf(value, -1):
+		return "-Inf"
+
+	case math.IsNaN(value):
+		return "NaN"
+
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.w.Write(p)
+
+	cw.n += int64(n)
+	cw.err = err
+
+	return n, err
+}
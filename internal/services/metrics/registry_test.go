@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMetrics(t *testing.T, registry *Registry) string {
+	var b strings.Builder
+
+	_, err := registry.WriteTo(&b)
+	require.NoError(t, err)
+
+	return b.String()
+}
+
+func TestRegistry_WriteTo(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.Describe("bridge_sync_progress_ratio", TypeGauge, "Progress of the sync.")
+	registry.Set("bridge_sync_progress_ratio", Labels{"user_id": "b"}, 0.5)
+	registry.Set("bridge_sync_progress_ratio", Labels{"user_id": "a"}, 1)
+	registry.Add("bridge_sends_total", nil, 1)
+	registry.Add("bridge_sends_total", nil, 2)
+	registry.Add("bridge_odd-name_total", Labels{"quote": "say \"hi\"\n"}, 1)
+
+	registry.AddCollector(func(emit func(string, Labels, float64)) {
+		emit("bridge_open_sessions", nil, 3)
+	})
+
+	require.Equal(t, strings.Join([]string{
+		`# TYPE bridge_odd_name_total counter`,
+		`bridge_odd_name_total{quote="say \"hi\"\n"} 1`,
+		`# TYPE bridge_open_sessions gauge`,
+		`bridge_open_sessions 3`,
+		`# TYPE bridge_sends_total counter`,
+		`bridge_sends_total 3`,
+		`# HELP bridge_sync_progress_ratio Progress of the sync.`,
+		`# TYPE bridge_sync_progress_ratio gauge`,
+		`bridge_sync_progress_ratio{user_id="a"} 1`,
+		`bridge_sync_progress_ratio{user_id="b"} 0.5`,
+	}, "\n")+"\n", writeMetrics(t, registry))
+}
+
+func TestRegistry_Delete(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.Set("bridge_sync_progress_ratio", Labels{"user_id": "a"}, 1)
+	registry.Set("bridge_sync_progress_ratio", Labels{"user_id": "b"}, 1)
+	registry.Add("bridge_sync_failures_total", Labels{"user_id": "a"}, 1)
+
+	registry.Delete("user_id", "a")
+
+	out := writeMetrics(t, registry)
+	require.NotContains(t, out, `user_id="a"`)
+	require.Contains(t, out, `bridge_sync_progress_ratio{user_id="b"} 1`)
+	require.NotContains(t, out, "bridge_sync_failures_total")
+}
+
+func TestRegistry_RecordObservabilityMetric(t *testing.T) {
+	registry := NewRegistry()
+
+	metric := func(name string, labels map[string]string) proton.ObservabilityMetric {
+		return proton.ObservabilityMetric{
+			Name: name,
+			Data: map[string]interface{}{
+				"Value":  1,
+				"Labels": labels,
+			},
+		}
+	}
+
+	registry.RecordObservabilityMetric(metric("bridge_smtp_errors_total", map[string]string{"errorType": "failedSendDraft"}))
+	registry.RecordObservabilityMetric(metric("bridge_smtp_errors_total", map[string]string{"errorType": "failedSendDraft"}))
+	registry.RecordObservabilityMetric(metric("bridge_smtp_send_success_total", map[string]string{}))
+	registry.RecordObservabilityMetric(metric("bridge_heartbeat", map[string]string{}))
+
+	out := writeMetrics(t, registry)
+	require.Contains(t, out, `bridge_smtp_errors_total{errorType="failedSendDraft"} 2`)
+	require.Contains(t, out, `bridge_smtp_send_success_total 1`)
+	require.NotContains(t, out, "bridge_heartbeat")
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	registry := NewRegistry()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, registry)}
+
+	for _, path := range []string{"/", "/", "/missing"} {
+		res, err := client.Get(server.URL + path) //nolint:noctx
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+
+	_, err := client.Get("http://127.0.0.1:0/") //nolint:noctx,bodyclose
+	require.Error(t, err)
+
+	out := writeMetrics(t, registry)
+	require.Contains(t, out, `bridge_api_responses_total{code="200"} 2`)
+	require.Contains(t, out, `bridge_api_responses_total{code="404"} 1`)
+	require.Contains(t, out, `bridge_api_request_errors_total 1`)
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 5), 0o600))
+
+	size := NewDirSize(func() (string, error) { return dir, nil }, time.Hour)
+	require.Equal(t, int64(15), size.Get())
+
+	// The size is reused until it is too old.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c"), make([]byte, 5), 0o600))
+	require.Equal(t, int64(15), size.Get())
+
+	// A missing directory has size zero.
+	missing := NewDirSize(func() (string, error) { return "", errors.New("no path") }, time.Hour)
+	require.Zero(t, missing.Get())
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/sirupsen/logrus"
+)
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Service serves the metrics of a registry on localhost at /metrics.
+type Service struct {
+	registry *Registry
+
+	server     *http.Server
+	port       int
+	serverLock sync.Mutex
+
+	panicHandler async.PanicHandler
+
+	log *logrus.Entry
+}
+
+// NewService returns a new metrics endpoint for the given registry. It doesn't listen until Start is called.
+func NewService(registry *Registry, panicHandler async.PanicHandler) *Service {
+	return &Service{
+		registry:     registry,
+		panicHandler: panicHandler,
+		log:          logrus.WithField("pkg", "metrics"),
+	}
+}
+
+// Start starts listening on the given localhost port. If the endpoint is already listening on another port, it moves.
+func (s *Service) Start(port int) error {
+	s.serverLock.Lock()
+	defer s.serverLock.Unlock()
+
+	if s.server != nil {
+		if s.port == port {
+			return nil
+		}
+
+		if err := s.stop(context.Background()); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(constants.Host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %v: %w", port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.port = port
+
+	go func(server *http.Server) {
+		defer async.HandlePanic(s.panicHandler)
+
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Error("Metrics endpoint stopped")
+		}
+	}(s.server)
+
+	s.log.WithField("port", port).Info("Metrics endpoint started")
+
+	return nil
+}
+
+// Stop stops listening.
+func (s *Service) Stop(ctx context.Context) error {
+	s.serverLock.Lock()
+	defer s.serverLock.Unlock()
+
+	return s.stop(ctx)
+}
+
+func (s *Service) stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+
+	defer func() { s.server = nil }()
+
+	s.log.Info("Metrics endpoint stopped")
+
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Service) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := s.registry.WriteTo(w); err != nil {
+		s.log.WithError(err).Debug("Failed to write metrics")
+	}
+}
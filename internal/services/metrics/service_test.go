@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, port int) (*http.Response, string) {
+	res, err := http.Get(fmt.Sprintf("http://127.0.0.1:%v/metrics", port)) //nolint:noctx
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	return res, string(body)
+}
+
+func TestService(t *testing.T) {
+	registry := NewRegistry()
+	registry.Set("bridge_imap_open_sessions", nil, 2)
+
+	service := NewService(registry, async.NoopPanicHandler{})
+	defer func() { require.NoError(t, service.Stop(context.Background())) }()
+
+	port := ports.FindFreePortFrom(12445)
+	require.NoError(t, service.Start(port))
+
+	res, body := scrape(t, port)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, contentType, res.Header.Get("Content-Type"))
+	require.Contains(t, body, "bridge_imap_open_sessions 2")
+
+	// Starting on another port moves the endpoint.
+	newPort := ports.FindFreePortFrom(port + 1)
+	require.NoError(t, service.Start(newPort))
+	require.True(t, ports.IsPortFree(port))
+
+	_, body = scrape(t, newPort)
+	require.Contains(t, body, "bridge_imap_open_sessions 2")
+
+	// Once stopped, the port is free again.
+	require.NoError(t, service.Stop(context.Background()))
+	require.True(t, ports.IsPortFree(newPort))
+}
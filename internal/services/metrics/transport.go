@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	apiResponsesName = "bridge_api_responses_total"
+	apiErrorsName    = "bridge_api_request_errors_total"
+)
+
+// transport counts the API responses by status code, and the requests that got no response.
+type transport struct {
+	next     http.RoundTripper
+	registry *Registry
+}
+
+// NewTransport returns a round tripper that records the outcome of the requests made with the given one.
+// It wraps the transport rather than hooking into the API client, whose response hooks don't see error responses.
+func NewTransport(next http.RoundTripper, registry *Registry) http.RoundTripper {
+	registry.Describe(apiResponsesName, TypeCounter, "API responses by HTTP status code.")
+	registry.Describe(apiErrorsName, TypeCounter, "API requests that failed without a response, e.g. network errors.")
+
+	return &transport{next: next, registry: registry}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			t.registry.Add(apiErrorsName, nil, 1)
+		}
+
+		return nil, err
+	}
+
+	t.registry.Add(apiResponsesName, Labels{"code": strconv.Itoa(res.StatusCode)}, 1)
+
+	return res, nil
+}
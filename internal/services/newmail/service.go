@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package newmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapservice"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/orderedtasks"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/userevents"
+	"github.com/sirupsen/logrus"
+)
+
+// LabelProvider provides the user's labels cached by the IMAP service, used to name the mailboxes of new messages.
+type LabelProvider interface {
+	GetCachedLabel(labelID string) (proton.Label, bool)
+}
+
+// Service publishes an event for each message the user receives, so that bridge can notify about new mail.
+type Service struct {
+	userID string
+
+	log *logrus.Entry
+
+	eventService   userevents.Subscribable
+	subscription   *userevents.EventChanneledSubscriber
+	eventPublisher events.EventPublisher
+
+	labelProvider LabelProvider
+}
+
+func NewService(userID string, service userevents.Subscribable, eventPublisher events.EventPublisher, labelProvider LabelProvider) *Service {
+	return &Service{
+		userID: userID,
+
+		log: logrus.WithFields(logrus.Fields{
+			"user":    userID,
+			"service": "newmail",
+		}),
+
+		eventService:   service,
+		subscription:   userevents.NewEventSubscriber(fmt.Sprintf("newmail-%v", userID)),
+		eventPublisher: eventPublisher,
+
+		labelProvider: labelProvider,
+	}
+}
+
+func (s *Service) Start(ctx context.Context, group *orderedtasks.OrderedCancelGroup) {
+	group.Go(ctx, s.userID, "newmail-service", s.run)
+}
+
+func (s *Service) run(ctx context.Context) {
+	s.log.Info("Starting service main loop")
+	defer s.log.Info("Exiting service main loop")
+
+	eventHandler := userevents.EventHandler{
+		MessageHandler: s,
+	}
+
+	s.eventService.Subscribe(s.subscription)
+	defer s.eventService.Unsubscribe(s.subscription)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-s.subscription.OnEventCh():
+			if !ok {
+				continue
+			}
+			e.Consume(func(event proton.Event) error { return eventHandler.OnEvent(ctx, event) })
+		}
+	}
+}
+
+func (s *Service) HandleMessageEvents(ctx context.Context, messageEvents []proton.MessageEvent) error {
+	for _, event := range messageEvents {
+		if event.Action != proton.EventCreate || !isReceivedUnread(event.Message) {
+			continue
+		}
+
+		s.eventPublisher.PublishEvent(ctx, events.UserReceivedMessage{
+			UserID:    s.userID,
+			MessageID: event.ID,
+			Sender:    formatSender(event.Message),
+			Subject:   event.Message.Subject,
+			Mailboxes: getMailboxNames(s.labelProvider, event.Message.LabelIDs),
+		})
+	}
+
+	return nil
+}
+
+// isReceivedUnread reports whether the message was received rather than sent or drafted, and is not read yet.
+func isReceivedUnread(message proton.MessageMetadata) bool {
+	return message.Flags.Has(proton.MessageFlagReceived) && bool(message.Unread)
+}
+
+func formatSender(message proton.MessageMetadata) string {
+	if message.Sender == nil {
+		return ""
+	}
+
+	if message.Sender.Name != "" {
+		return message.Sender.Name
+	}
+
+	return message.Sender.Address
+}
+
+// getMailboxNames returns the IMAP names of the mailboxes of the given labels, skipping the unknown ones.
+func getMailboxNames(labels LabelProvider, labelIDs []string) []string {
+	var names []string
+
+	for _, labelID := range labelIDs {
+		if label, ok := labels.GetCachedLabel(labelID); ok {
+			names = append(names, strings.Join(imapservice.GetMailboxName(label), "/"))
+		}
+	}
+
+	return names
+}
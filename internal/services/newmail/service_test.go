@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package newmail
+
+import (
+	"context"
+	"net/mail"
+	"testing"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/stretchr/testify/require"
+)
+
+type testLabelProvider map[string]proton.Label
+
+func (p testLabelProvider) GetCachedLabel(labelID string) (proton.Label, bool) {
+	label, ok := p[labelID]
+
+	return label, ok
+}
+
+type testEventPublisher []events.Event
+
+func (p *testEventPublisher) PublishEvent(_ context.Context, event events.Event) {
+	*p = append(*p, event)
+}
+
+func TestService_HandleMessageEvents(t *testing.T) {
+	labels := testLabelProvider{
+		proton.InboxLabel: {ID: proton.InboxLabel, Name: "Inbox", Type: proton.LabelTypeSystem},
+		"work":            {ID: "work", Name: "Work", Path: []string{"Work"}, Type: proton.LabelTypeFolder},
+	}
+
+	var publisher testEventPublisher
+
+	service := NewService("userID", nil, &publisher, labels)
+
+	received := proton.MessageMetadata{
+		ID:       "received",
+		LabelIDs: []string{proton.InboxLabel, "work", "unknown"},
+		Subject:  "Hello",
+		Sender:   &mail.Address{Name: "Alice", Address: "alice@example.com"},
+		Flags:    proton.MessageFlagReceived,
+		Unread:   true,
+	}
+
+	read := received
+	read.ID = "read"
+	read.Unread = false
+
+	sent := received
+	sent.ID = "sent"
+	sent.Flags = proton.MessageFlagSent
+
+	require.NoError(t, service.HandleMessageEvents(context.Background(), []proton.MessageEvent{
+		{EventItem: proton.EventItem{ID: "received", Action: proton.EventCreate}, Message: received},
+		{EventItem: proton.EventItem{ID: "received", Action: proton.EventUpdate}, Message: received},
+		{EventItem: proton.EventItem{ID: "read", Action: proton.EventCreate}, Message: read},
+		{EventItem: proton.EventItem{ID: "sent", Action: proton.EventCreate}, Message: sent},
+	}))
+
+	// Only the creation of the unread received message is published.
+	require.Equal(t, testEventPublisher{events.UserReceivedMessage{
+		UserID:    "userID",
+		MessageID: "received",
+		Sender:    "Alice",
+		Subject:   "Hello",
+		Mailboxes: []string{"Inbox", "Folders/Work"},
+	}}, publisher)
+}
@@ -60,6 +60,11 @@ type BasicSender interface {
 	AddMetrics(metric ...proton.ObservabilityMetric)
 }
 
+// LocalRecorder records metrics locally. It gets every metric, whether or not it is sent to the API.
+type LocalRecorder interface {
+	RecordObservabilityMetric(metric proton.ObservabilityMetric)
+}
+
 type Service struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -85,6 +90,9 @@ type Service struct {
 	userClientStoreLock sync.Mutex
 
 	distinctionUtility *distinctionUtility
+
+	localRecorder     LocalRecorder
+	localRecorderLock sync.RWMutex
 }
 
 func newService() *Service {
@@ -425,7 +433,29 @@ func ModifyThrottlePeriod(duration time.Duration) {
 	throttleDuration = duration
 }
 
+// SetLocalRecorder sets the recorder that gets every metric, regardless of telemetry settings.
+func (s *Service) SetLocalRecorder(recorder LocalRecorder) {
+	s.localRecorderLock.Lock()
+	defer s.localRecorderLock.Unlock()
+
+	s.localRecorder = recorder
+}
+
+func (s *Service) recordLocally(metrics ...proton.ObservabilityMetric) {
+	s.localRecorderLock.RLock()
+	defer s.localRecorderLock.RUnlock()
+
+	if s.localRecorder == nil {
+		return
+	}
+
+	for _, metric := range metrics {
+		s.localRecorder.RecordObservabilityMetric(metric)
+	}
+}
+
 func (s *Service) AddMetrics(metrics ...proton.ObservabilityMetric) {
+	s.recordLocally(metrics...)
 	s.addMetrics(metrics...)
 }
 
@@ -434,6 +464,7 @@ func (s *Service) AddMetrics(metrics ...proton.ObservabilityMetric) {
 // As the binning interval is what allows us to do this we
 // should not send these if there are no logged-in users at that moment.
 func (s *Service) AddDistinctMetrics(errType DistinctionMetricTypeEnum, metrics ...proton.ObservabilityMetric) {
+	s.recordLocally(metrics...)
 	metrics = s.distinctionUtility.generateDistinctMetrics(errType, metrics...)
 	s.addMetricsIfClients(metrics...)
 }
@@ -441,6 +472,8 @@ func (s *Service) AddDistinctMetrics(errType DistinctionMetricTypeEnum, metrics
 // AddTimeLimitedMetric - schedules a metric to be sent if a metric of the same type has not been sent within some interval.
 // The interval is defined in the distinction utility.
 func (s *Service) AddTimeLimitedMetric(metricType DistinctionMetricTypeEnum, metric proton.ObservabilityMetric) {
+	s.recordLocally(metric)
+
 	if !s.distinctionUtility.checkAndUpdateLastSentMap(metricType) {
 		return
 	}
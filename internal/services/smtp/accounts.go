@@ -19,9 +19,12 @@ package smtp
 
 import (
 	"context"
+	"errors"
 	"io"
 	"sync"
 	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 )
 
 type Accounts struct {
@@ -72,6 +75,20 @@ func (s *Accounts) CheckAuth(user string, password []byte) (string, string, erro
 	return "", "", ErrNoSuchUser
 }
 
+// GetPrimaryAddrID returns the ID of the primary address of the given user.
+// It is used as the authenticated address of sessions authenticated without a username.
+func (s *Accounts) GetPrimaryAddrID(userID string) (string, error) {
+	s.accountsLock.RLock()
+	defer s.accountsLock.RUnlock()
+
+	account, ok := s.accounts[userID]
+	if !ok {
+		return "", ErrNoSuchUser
+	}
+
+	return account.service.getPrimaryAddrID(context.Background())
+}
+
 func (s *Accounts) SendMail(ctx context.Context, userID, addrID, from string, to []string, r io.Reader) error {
 	if len(to) == 0 {
 		return ErrInvalidRecipient
@@ -92,6 +109,12 @@ func (s *Accounts) SendMail(ctx context.Context, userID, addrID, from string, to
 	}
 
 	err := account.service.SendMail(ctx, addrID, from, to, r)
+
+	// Throttled sends are not failures of the client.
+	if errors.Is(err, ratelimit.ErrLimited) {
+		return err
+	}
+
 	account.handleSMTPErr(requestTime, err)
 
 	return err
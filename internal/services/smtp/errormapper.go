@@ -20,7 +20,9 @@ package smtp
 import (
 	"errors"
 
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/errmapper"
+	"github.com/emersion/go-smtp"
 )
 
 //nolint:gochecknoglobals
@@ -28,10 +30,20 @@ var smtpSharedErrMapper = errmapper.New(smtpErrRules)
 
 // mapError uses the shared error mapper to resolve a given error chain to a single error.
 // Ideally called only from the SMTP server boundary so that lower layers can log the full error chain.
+// Throttled sends get a temporary failure reply so that clients retry later rather than giving up.
 func mapError(err error) error {
 	if err == nil {
 		return nil
 	}
+
+	if errors.Is(err, ratelimit.ErrLimited) || errors.Is(err, ErrTooManyErrors) {
+		return &smtp.SMTPError{
+			Code:         451,
+			EnhancedCode: smtp.EnhancedCode{4, 4, 5},
+			Message:      smtpSharedErrMapper.Resolve(err).Error(),
+		}
+	}
+
 	return smtpSharedErrMapper.Resolve(err)
 }
 
@@ -58,6 +70,11 @@ var smtpErrRules = []errmapper.Rule{
 		errmapper.MatchAny,
 		errors.New("Too many failed send attempts. Wait a moment, then try again."), //nolint:revive,staticcheck //disable ST1005,
 	),
+	errmapper.NewRule(
+		[]error{ratelimit.ErrLimited},
+		errmapper.MatchAny,
+		errors.New("Too many messages sent in a short time. Wait a moment, then try again."), //nolint:revive,staticcheck //disable ST1005,
+	),
 	errmapper.NewRule(
 		[]error{ErrSenderAddressNotOwned},
 		errmapper.MatchAny,
@@ -68,6 +85,26 @@ var smtpErrRules = []errmapper.Rule{
 		errmapper.MatchAny,
 		errors.New("This message uses an unsupported format. Try plain text or HTML."), //nolint:revive,staticcheck //disable ST1005,
 	),
+	errmapper.NewRule(
+		[]error{ErrContactKeyChanged},
+		errmapper.MatchAny,
+		errors.New("The encryption key of a recipient has changed. Approve the new key in Bridge, then try again."), //nolint:revive,staticcheck //disable ST1005,
+	),
+	errmapper.NewRule(
+		[]error{ErrMessageTooLarge},
+		errmapper.MatchAny,
+		errors.New("The attachments of this message exceed 25 MB. Remove some attachments, or send large attachments as links in Bridge settings."), //nolint:revive,staticcheck //disable ST1005,
+	),
+	errmapper.NewRule(
+		[]error{ErrLargeAttachmentEncrypted},
+		errmapper.MatchAny,
+		errors.New("This message would be end-to-end encrypted, but large attachments are sent as unencrypted links. Remove the large attachments, or send them to these recipients separately."), //nolint:revive,staticcheck //disable ST1005,
+	),
+	errmapper.NewRule(
+		[]error{ErrNoSuchTemplate},
+		errmapper.MatchAny,
+		errors.New("The template named in the X-Pm-Template header does not exist. Save it in the Templates folder first."), //nolint:revive,staticcheck //disable ST1005,
+	),
 	errmapper.NewRule(
 		[]error{ErrInvalidRecipient, ErrInvalidReturnPath, ErrNoSuchUser},
 		errmapper.MatchAny,
@@ -38,6 +38,11 @@ var (
 	ErrCannotSendFromAddressKind = errors.New("smtp: cannot send from address")
 	ErrSenderAddressNotOwned     = errors.New("smtp: sender address not owned by user")
 	ErrUnsupportedOutgoingMIME   = errors.New("smtp: unsupported outgoing MIME type")
+	ErrContactKeyChanged         = errors.New("smtp: recipient key changed")
+	ErrNoSMIMEIdentity           = errors.New("smtp: recipient requires S/MIME but no S/MIME certificate was imported for the sender address")
+	ErrMessageTooLarge           = errors.New("smtp: attachments exceed the maximum message size")
+	ErrLargeAttachmentEncrypted  = errors.New("smtp: large attachments can't be sent as links in encrypted messages")
+	ErrNoSuchTemplate            = errors.New("smtp: no such template")
 )
 
 const errCodeAddressDoesNotExist proton.Code = 33102
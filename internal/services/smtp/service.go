@@ -28,7 +28,9 @@ import (
 	"github.com/ProtonMail/gluon/logging"
 	"github.com/ProtonMail/gluon/reporter"
 	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	bridgelogging "github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/orderedtasks"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/sendrecorder"
@@ -62,9 +64,15 @@ type Service struct {
 	bridgePassProvider useridentity.BridgePassProvider
 	keyPassProvider    useridentity.KeyPassProvider
 	identityState      *useridentity.State
+	contactKeys        ContactKeyStore
+	autocrypt          AutocryptStore
+	smime              SMIMEStore
+	largeAttachments   LargeAttachmentProvider
+	templates          TemplatesProvider
 
-	eventService userevents.Subscribable
-	subscription *userevents.EventChanneledSubscriber
+	eventService   userevents.Subscribable
+	subscription   *userevents.EventChanneledSubscriber
+	eventPublisher events.EventPublisher
 
 	addressMode   usertypes.AddressMode
 	serverManager ServerManager
@@ -72,6 +80,7 @@ type Service struct {
 	observabilitySender observability.Sender
 
 	imapSessionCountProvider imapSessionCountProvider
+	rateLimiter              *ratelimit.Limiter
 	featureFlagValueProvider unleash.FeatureFlagValueProvider
 }
 
@@ -83,12 +92,19 @@ func NewService(
 	reporter reporter.Reporter,
 	bridgePassProvider useridentity.BridgePassProvider,
 	keyPassProvider useridentity.KeyPassProvider,
+	contactKeys ContactKeyStore,
+	autocrypt AutocryptStore,
+	smime SMIMEStore,
+	largeAttachments LargeAttachmentProvider,
+	templates TemplatesProvider,
 	eventService userevents.Subscribable,
+	eventPublisher events.EventPublisher,
 	mode usertypes.AddressMode,
 	identityState *useridentity.State,
 	serverManager ServerManager,
 	observabilitySender observability.Sender,
 	imapSessionCountProvider imapSessionCountProvider,
+	rateLimiter *ratelimit.Limiter,
 	featureFlagValueProvider unleash.FeatureFlagValueProvider,
 ) *Service {
 	subscriberName := fmt.Sprintf("smpt-%v", userID)
@@ -108,7 +124,13 @@ func NewService(
 		bridgePassProvider: bridgePassProvider,
 		keyPassProvider:    keyPassProvider,
 		identityState:      identityState,
+		contactKeys:        contactKeys,
+		autocrypt:          autocrypt,
+		smime:              smime,
+		largeAttachments:   largeAttachments,
+		templates:          templates,
 		eventService:       eventService,
+		eventPublisher:     eventPublisher,
 
 		subscription: userevents.NewEventSubscriber(subscriberName),
 
@@ -117,17 +139,30 @@ func NewService(
 
 		imapSessionCountProvider: imapSessionCountProvider,
 		observabilitySender:      observabilitySender,
+		rateLimiter:              rateLimiter,
 		featureFlagValueProvider: featureFlagValueProvider,
 	}
 }
 
 func (s *Service) SendMail(ctx context.Context, authID string, from string, to []string, r io.Reader) error {
+	if err := s.rateLimiter.Allow(ratelimit.ClassSend); err != nil {
+		s.log.WithError(err).Warn("Send throttled")
+		return err
+	}
+
 	_, err := s.cpc.Send(ctx, &sendMailReq{
 		authID: authID,
 		from:   from,
 		to:     to,
 		r:      r,
 	})
+	if err != nil {
+		s.eventPublisher.PublishEvent(ctx, events.UserSendFailed{
+			UserID:     s.userID,
+			Recipients: to,
+			Error:      mapError(err).Error(),
+		})
+	}
 
 	return err
 }
@@ -157,6 +192,10 @@ func (s *Service) checkAuth(ctx context.Context, email string, password []byte)
 	})
 }
 
+func (s *Service) getPrimaryAddrID(ctx context.Context) (string, error) {
+	return cpc.SendTyped[string](ctx, s.cpc, &getPrimaryAddrIDReq{})
+}
+
 func (s *Service) Start(ctx context.Context, group *orderedtasks.OrderedCancelGroup) error {
 	s.log.Debug("Starting service")
 
@@ -238,6 +277,10 @@ func (s *Service) run(ctx context.Context) {
 				addrID, err := s.identityState.CheckAuth(r.email, r.password, s.bridgePassProvider)
 				request.Reply(ctx, addrID, err)
 
+			case *getPrimaryAddrIDReq:
+				addr, err := s.identityState.GetPrimaryAddr()
+				request.Reply(ctx, addr.ID, err)
+
 			case *resyncReq:
 				err := s.identityState.OnRefreshEvent(ctx)
 				request.Reply(ctx, nil, err)
@@ -317,6 +360,8 @@ type checkAuthReq struct {
 	password []byte
 }
 
+type getPrimaryAddrIDReq struct{}
+
 type resyncReq struct{}
 
 type onLogoutReq struct{}
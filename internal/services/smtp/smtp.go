@@ -95,6 +95,13 @@ func (s *Service) smtpSendMail(ctx context.Context, authID string, from string,
 		return fmt.Errorf("failed to create parser: %w", err)
 	}
 
+	// If the message names a template, build it from the template.
+	if parser, err = s.expandTemplate(ctx, parser, to); err != nil {
+		s.log.Debug("Message failed to send, removing from send recorder")
+		s.recorder.RemoveOnFail(hash, srID)
+		return fmt.Errorf("failed to expand template: %w", err)
+	}
+
 	// If the message contains a sender, use it instead of the one from the return path.
 	if sender, ok := getMessageSender(parser); ok {
 		from = sender
@@ -129,6 +136,19 @@ func (s *Service) smtpSendMail(ctx context.Context, authID string, from string,
 		// exists and empty text part will be added.
 		parser.AttachEmptyTextPartIfNoneExists()
 
+		// Replace the attachments that are too large to be sent with links, if the user enabled it.
+		// Links are stored unencrypted, so they are refused if the message would be encrypted for any recipient.
+		if err := s.convertLargeAttachments(ctx, parser, func(ctx context.Context, mimeType rfc822.MIMEType) (bool, error) {
+			prefs, err := s.getSendPrefs(ctx, s.client, userKR, settings, to, usertypes.SanitizeEmail(from), mimeType)
+			if err != nil {
+				return false, fmt.Errorf("%w: %w", ErrGetSendPreferencesOperation, err)
+			}
+
+			return xslices.Any(prefs, func(prefs proton.SendPreferences) bool { return prefs.Encrypt }), nil
+		}); err != nil {
+			return err
+		}
+
 		// If we have to attach the public key, do it now.
 		if settings.AttachPublicKey {
 			key, err := addrKR.GetKey(0)
@@ -148,6 +168,10 @@ func (s *Service) smtpSendMail(ctx context.Context, authID string, from string,
 			))
 		}
 
+		if err := s.setAutocryptHeader(parser, from, addrKR); err != nil {
+			return err
+		}
+
 		// Parse the message we want to send (after we have attached the public key).
 		message, err := message.ParseWithParser(parser, false)
 		if err != nil {
@@ -252,7 +276,12 @@ func (s *Service) sendWithKey(
 		return proton.Message{}, fmt.Errorf("%w: %w", ErrGetRecipientsOperation, err)
 	}
 
-	req, err := createSendReq(addrKR, message.MIMEBody, message.RichBody, message.PlainBody, recipients, attKeys)
+	smimeBody, err := s.getSMIMEBody(recipients, usertypes.SanitizeEmail(draft.Sender.Address), message.MIMEBody)
+	if err != nil {
+		return proton.Message{}, fmt.Errorf("failed to sign S/MIME message: %w", err)
+	}
+
+	req, err := createSendReq(addrKR, message.MIMEBody, smimeBody, message.RichBody, message.PlainBody, recipients, attKeys)
 	if err != nil {
 		s.observabilitySender.AddDistinctMetrics(observability.SMTPError, observabilitymetrics.GenerateFailedCreatePackages())
 		return proton.Message{}, fmt.Errorf("failed to create packages: %w", err)
@@ -527,7 +556,31 @@ func (s *Service) getRecipients(
 		return addr.Address
 	})
 
-	prefs, err := parallel.MapContext(ctx, runtime.NumCPU(), addresses, func(ctx context.Context, recipient string) (proton.SendPreferences, error) {
+	prefs, err := s.getSendPrefs(ctx, client, userKR, settings, addresses, usertypes.SanitizeEmail(draft.Sender.Address), draft.MIMEType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetSendPreferencesOperation, err)
+	}
+
+	recipients := make(recipients)
+
+	for idx, pref := range prefs {
+		recipients[addresses[idx]] = pref
+	}
+
+	return recipients, nil
+}
+
+// getSendPrefs returns the send preferences of each of the given recipients of a message from the given sender.
+func (s *Service) getSendPrefs(
+	ctx context.Context,
+	client *proton.Client,
+	userKR *crypto.KeyRing,
+	settings proton.MailSettings,
+	addresses []string,
+	sender string,
+	mimeType rfc822.MIMEType,
+) ([]proton.SendPreferences, error) {
+	return parallel.MapContext(ctx, runtime.NumCPU(), addresses, func(ctx context.Context, recipient string) (proton.SendPreferences, error) {
 		defer async.HandlePanic(s.panicHandler)
 
 		pubKeys, recType, err := client.GetPublicKeys(ctx, recipient)
@@ -545,19 +598,28 @@ func (s *Service) getRecipients(
 			return proton.SendPreferences{}, fmt.Errorf("failed to get contact settings for %v: %w", recipient, err)
 		}
 
-		return buildSendPrefs(contactSettings, settings, pubKeys, draft.MIMEType, recType == proton.RecipientTypeInternal)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrGetSendPreferencesOperation, err)
-	}
+		prefs, err := buildSendPrefs(contactSettings, settings, pubKeys, mimeType, recType == proton.RecipientTypeInternal, s.getAutocryptPeer(recipient))
+		if err != nil {
+			return proton.SendPreferences{}, err
+		}
 
-	recipients := make(recipients)
+		// External S/MIME recipients get an S/MIME signed message, unless it is encrypted with PGP.
+		if s.smime.IsSMIMERecipient(recipient) && recType != proton.RecipientTypeInternal && !prefs.Encrypt {
+			if !s.hasSMIMEIdentity(sender) {
+				return proton.SendPreferences{}, fmt.Errorf("%w: %v", ErrNoSMIMEIdentity, sender)
+			}
 
-	for idx, pref := range prefs {
-		recipients[addresses[idx]] = pref
-	}
+			prefs = smimePrefs()
+		}
 
-	return recipients, nil
+		if prefs.Encrypt && prefs.PubKey != nil {
+			if err := s.checkContactKey(ctx, recipient, contactSettings.Keys, prefs.PubKey); err != nil {
+				return proton.SendPreferences{}, err
+			}
+		}
+
+		return prefs, nil
+	})
 }
 
 func getContactSettings(
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+)
+
+// AutocryptStore provides the user's Autocrypt settings and the keys collected from the Autocrypt headers of peers.
+type AutocryptStore interface {
+	GetAutocryptMode() vault.AutocryptMode
+	GetAutocryptPeer(email string) (vault.AutocryptPeer, bool)
+}
+
+// autocryptPeer is the key collected from the Autocrypt headers of a recipient.
+type autocryptPeer struct {
+	key *crypto.Key
+
+	// Whether both the user and the recipient prefer encryption, in which case mail to the recipient is encrypted.
+	encrypt bool
+}
+
+// setAutocryptHeader advertises the given sending key in the Autocrypt header of the message, if enabled.
+// The header is part of the MIME body, which is only used for recipients receiving a PGP/MIME or clear MIME message.
+func (s *Service) setAutocryptHeader(p *parser.Parser, from string, addrKR *crypto.KeyRing) error {
+	mode := s.autocrypt.GetAutocryptMode()
+	if mode == vault.AutocryptOff || p.Root().Header.Has(message.AutocryptHeader) {
+		return nil
+	}
+
+	header, err := message.NewAutocrypt(from, addrKR, mode == vault.AutocryptMutual)
+	if err != nil {
+		return fmt.Errorf("failed to create Autocrypt header: %w", err)
+	}
+
+	p.Root().Header.Set(message.AutocryptHeader, header.String())
+
+	return nil
+}
+
+// getAutocryptPeer returns the key collected from the Autocrypt headers of the given recipient, if any.
+func (s *Service) getAutocryptPeer(email string) *autocryptPeer {
+	peer, ok := s.autocrypt.GetAutocryptPeer(email)
+	if !ok {
+		return nil
+	}
+
+	key, err := crypto.NewKey(peer.KeyData)
+	if err != nil {
+		s.log.WithError(err).WithField("recipient", logging.Sensitive(email)).Warn("Failed to load Autocrypt key")
+		return nil
+	}
+
+	return &autocryptPeer{
+		key:     key,
+		encrypt: peer.PreferEncrypt && s.autocrypt.GetAutocryptMode() == vault.AutocryptMutual,
+	}
+}
@@ -18,33 +18,61 @@
 package smtp
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
 
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/proton-bridge/v3/internal/audit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/identifier"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
 	"github.com/emersion/go-smtp"
 	"github.com/sirupsen/logrus"
 )
 
+// ClientCertAuthenticator authenticates sessions from the TLS client certificate presented by the peer.
+type ClientCertAuthenticator interface {
+	// AuthenticateClientCert returns the ID of the user the given client certificate was issued to.
+	AuthenticateClientCert(cert *x509.Certificate) (string, error)
+
+	// PasswordAuthDisabled returns whether sessions must be authenticated with a client certificate.
+	PasswordAuthDisabled() bool
+}
+
 type Backend struct {
 	accounts  *Accounts
 	userAgent identifier.UserAgentUpdater
+	certAuth  ClientCertAuthenticator
+	recorder  audit.Recorder
 }
 
-func NewBackend(accounts *Accounts, userAgent identifier.UserAgentUpdater) *Backend {
+func NewBackend(
+	accounts *Accounts,
+	userAgent identifier.UserAgentUpdater,
+	certAuth ClientCertAuthenticator,
+	recorder audit.Recorder,
+) *Backend {
 	return &Backend{
 		accounts:  accounts,
 		userAgent: userAgent,
+		certAuth:  certAuth,
+		recorder:  recorder,
 	}
 }
 
 type smtpSession struct {
 	accounts  *Accounts
 	userAgent identifier.UserAgentUpdater
+	certAuth  ClientCertAuthenticator
+	recorder  audit.Recorder
+	remote    string
+
+	// client is the name the client gave itself in its greeting, as SMTP has no other way to tell it.
+	client string
 
 	userID string
 	authID string
@@ -53,22 +81,71 @@ type smtpSession struct {
 	to   []string
 }
 
-func (be *Backend) NewSession(*smtp.Conn) (smtp.Session, error) {
-	return &smtpSession{accounts: be.accounts, userAgent: be.userAgent}, nil
+func (be *Backend) NewSession(c *smtp.Conn) (smtp.Session, error) {
+	session := &smtpSession{
+		accounts:  be.accounts,
+		userAgent: be.userAgent,
+		certAuth:  be.certAuth,
+		recorder:  be.recorder,
+		remote:    c.Conn().RemoteAddr().String(),
+		client:    c.Hostname(),
+	}
+
+	// NewSession is called again after STARTTLS, at which point the client certificate is available.
+	if state, ok := c.TLSConnectionState(); ok && len(state.PeerCertificates) > 0 && be.certAuth != nil {
+		if err := session.authClientCert(state.PeerCertificates[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
+
+func (s *smtpSession) authClientCert(cert *x509.Certificate) error {
+	userID, err := s.certAuth.AuthenticateClientCert(cert)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"device": cert.Subject.CommonName,
+			"pkg":    "smtp",
+		}).WithError(err).Error("Client certificate rejected.")
+
+		return s.loginFailed(cert.Subject.CommonName, fmt.Errorf("invalid client certificate"))
+	}
+
+	authID, err := s.accounts.GetPrimaryAddrID(userID)
+	if err != nil {
+		return s.loginFailed(cert.Subject.CommonName, fmt.Errorf("invalid client certificate"))
+	}
+
+	s.userID = userID
+	s.authID = authID
+
+	s.record(audit.Entry{Action: audit.ActionSMTPLogin, Address: cert.Subject.CommonName})
+
+	return nil
 }
 
 func (s *smtpSession) AuthPlain(username, password string) error {
+	if s.certAuth != nil && s.certAuth.PasswordAuthDisabled() {
+		return fmt.Errorf("password authentication is disabled, use a client certificate")
+	}
+
 	userID, authID, err := s.accounts.CheckAuth(username, []byte(password))
 	if err != nil {
 		if !errors.Is(err, ErrNoSuchUser) {
-			return fmt.Errorf("unknown error")
+			return s.loginFailed(username, fmt.Errorf("unknown error"))
 		}
 		logrus.WithFields(logrus.Fields{
 			"username": username,
 			"pkg":      "smtp",
 		}).Error("Incorrect login credentials.")
 
-		return fmt.Errorf("invalid username or password")
+		return s.loginFailed(username, fmt.Errorf("invalid username or password"))
+	}
+
+	// A session authenticated with a client certificate cannot switch to another user.
+	if s.userID != "" && s.userID != userID {
+		return s.loginFailed(username, fmt.Errorf("the client certificate was issued to another user"))
 	}
 
 	s.userID = userID
@@ -78,6 +155,8 @@ func (s *smtpSession) AuthPlain(username, password string) error {
 		s.userAgent.SetUserAgent(useragent.UnknownClient, useragent.DefaultVersion)
 	}
 
+	s.record(audit.Entry{Action: audit.ActionSMTPLogin, Address: username})
+
 	return nil
 }
 
@@ -105,13 +184,61 @@ func (s *smtpSession) Rcpt(to string) error {
 }
 
 func (s *smtpSession) Data(r io.Reader) error {
-	err := s.accounts.SendMail(context.Background(), s.userID, s.authID, s.from, s.to, r)
+	// The message is read here rather than by the accounts so its Message-ID can be recorded in the audit log.
+	b, err := io.ReadAll(r)
 	if err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+
+	entry := audit.Entry{Action: audit.ActionSend, Address: s.from, Recipients: s.to}
+
+	if header, err := rfc822.Parse(b).ParseHeader(); err == nil && header.Has("Message-Id") {
+		entry.MessageIDs = []string{header.Get("Message-Id")}
+	}
+
+	if err := s.accounts.SendMail(context.Background(), s.userID, s.authID, s.from, s.to, bytes.NewReader(b)); err != nil {
 		logrus.WithFields(logrus.Fields{
 			"pkg":  "smtp",
 			"user": s.userID,
 		}).WithError(err).Error("Send mail failed.")
-		return mapError(err)
+
+		err = mapError(err)
+
+		entry.Error = err.Error()
+		s.record(entry)
+
+		return err
 	}
+
+	s.record(entry)
+
 	return nil
 }
+
+// loginFailed records the failed login in the audit log, without a user, and returns the error.
+func (s *smtpSession) loginFailed(address string, err error) error {
+	if s.recorder != nil {
+		s.recorder.Record(audit.Entry{
+			Action:  audit.ActionSMTPLogin,
+			Client:  s.client,
+			Remote:  s.remote,
+			Address: address,
+			Error:   err.Error(),
+		})
+	}
+
+	return err
+}
+
+// record records an action of the session's user in the audit log.
+func (s *smtpSession) record(entry audit.Entry) {
+	if s.recorder == nil {
+		return
+	}
+
+	entry.UserID = s.userID
+	entry.Client = s.client
+	entry.Remote = s.remote
+
+	s.recorder.Record(entry)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/sirupsen/logrus"
+)
+
+// ContactKeyStore records the fingerprint of the key used to encrypt mail sent to each recipient.
+type ContactKeyStore interface {
+	GetContactKeyPolicy() vault.ContactKeyPolicy
+	GetContactKey(email string) (vault.ContactKey, bool)
+	TrustContactKey(email, fingerprint string) error
+	SetContactKeyChanged(email, fingerprint string) error
+}
+
+// checkContactKey compares the key chosen to encrypt mail to the recipient with the one trusted for them.
+// The first key seen for a recipient is trusted, unless the recipient's contact pins other keys.
+// Keys pinned in the contact are always trusted.
+func (s *Service) checkContactKey(ctx context.Context, email string, pinnedKeys []*crypto.Key, pubKey *crypto.KeyRing) error {
+	key, err := pubKey.GetKey(0)
+	if err != nil {
+		return fmt.Errorf("failed to get sending key: %w", err)
+	}
+
+	fingerprint := key.GetFingerprint()
+
+	trusted, ok := s.contactKeys.GetContactKey(email)
+
+	switch {
+	case slices.ContainsFunc(pinnedKeys, func(key *crypto.Key) bool { return key.GetFingerprint() == fingerprint }):
+		if ok && trusted.Fingerprint == fingerprint && !trusted.HasPendingChange() {
+			return nil
+		}
+
+		return s.contactKeys.TrustContactKey(email, fingerprint)
+
+	case !ok && len(pinnedKeys) > 0:
+		trusted.Fingerprint = pinnedKeys[0].GetFingerprint()
+
+		if err := s.contactKeys.TrustContactKey(email, trusted.Fingerprint); err != nil {
+			return err
+		}
+
+	case !ok:
+		return s.contactKeys.TrustContactKey(email, fingerprint)
+
+	case trusted.Fingerprint == fingerprint:
+		if !trusted.HasPendingChange() {
+			return nil
+		}
+
+		// The recipient went back to the trusted key; the pending change is no longer relevant.
+		return s.contactKeys.TrustContactKey(email, fingerprint)
+	}
+
+	return s.handleContactKeyChange(ctx, email, trusted.Fingerprint, fingerprint)
+}
+
+// handleContactKeyChange applies the contact key policy to a recipient whose key changed.
+func (s *Service) handleContactKeyChange(ctx context.Context, email, oldFingerprint, newFingerprint string) error {
+	policy := s.contactKeys.GetContactKeyPolicy()
+
+	log := s.log.WithFields(logrus.Fields{
+		"recipient":      logging.Sensitive(email),
+		"oldFingerprint": oldFingerprint,
+		"newFingerprint": newFingerprint,
+		"policy":         policy,
+	})
+
+	if policy == vault.ContactKeyPolicyAllow {
+		log.Info("Recipient key changed, trusting the new key")
+		return s.contactKeys.TrustContactKey(email, newFingerprint)
+	}
+
+	if err := s.contactKeys.SetContactKeyChanged(email, newFingerprint); err != nil {
+		return fmt.Errorf("failed to record key change: %w", err)
+	}
+
+	blocked := policy == vault.ContactKeyPolicyBlock
+
+	s.eventPublisher.PublishEvent(ctx, events.ContactKeyChanged{
+		UserID:         s.userID,
+		Email:          email,
+		OldFingerprint: oldFingerprint,
+		NewFingerprint: newFingerprint,
+		Blocked:        blocked,
+	})
+
+	if blocked {
+		log.Warn("Recipient key changed, refusing to send until the new key is approved")
+		return fmt.Errorf("%w: %v", ErrContactKeyChanged, logging.Sensitive(email))
+	}
+
+	log.Warn("Recipient key changed, sending with the new key")
+
+	return nil
+}
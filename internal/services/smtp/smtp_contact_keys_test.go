@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckContactKey(t *testing.T) {
+	key := loadKey(t, testPublicKey)
+	otherKey := loadKey(t, testOtherPublicKey)
+
+	store := newTestContactKeyStore()
+	publisher := &testEventPublisher{}
+
+	s := &Service{
+		userID:         "userID",
+		log:            logrus.WithField("service", "smtp"),
+		contactKeys:    store,
+		eventPublisher: publisher,
+	}
+
+	ctx := context.Background()
+
+	// The first key seen is trusted.
+	require.NoError(t, s.checkContactKey(ctx, "alice@example.com", nil, newKeyRing(t, key)))
+	require.Equal(t, key.GetFingerprint(), store.keys["alice@example.com"].Fingerprint)
+
+	// A key change is reported but allowed by the default warn policy.
+	require.NoError(t, s.checkContactKey(ctx, "alice@example.com", nil, newKeyRing(t, otherKey)))
+	require.Equal(t, otherKey.GetFingerprint(), store.keys["alice@example.com"].PendingFingerprint)
+	require.Len(t, publisher.events, 1)
+	require.False(t, publisher.events[0].(events.ContactKeyChanged).Blocked) //nolint:forcetypeassert
+
+	// The block policy refuses to send with the new key.
+	store.policy = vault.ContactKeyPolicyBlock
+	require.ErrorIs(t, s.checkContactKey(ctx, "alice@example.com", nil, newKeyRing(t, otherKey)), ErrContactKeyChanged)
+	require.Len(t, publisher.events, 2)
+	require.True(t, publisher.events[1].(events.ContactKeyChanged).Blocked) //nolint:forcetypeassert
+
+	// Keys pinned in the contact are trusted.
+	require.NoError(t, s.checkContactKey(ctx, "alice@example.com", []*crypto.Key{otherKey}, newKeyRing(t, otherKey)))
+	require.Equal(t, otherKey.GetFingerprint(), store.keys["alice@example.com"].Fingerprint)
+	require.False(t, store.keys["alice@example.com"].HasPendingChange())
+
+	// An API key not matching the pinned keys is a key change even on first use.
+	require.ErrorIs(t, s.checkContactKey(ctx, "bob@example.com", []*crypto.Key{key}, newKeyRing(t, otherKey)), ErrContactKeyChanged)
+	require.Equal(t, key.GetFingerprint(), store.keys["bob@example.com"].Fingerprint)
+
+	// The allow policy trusts the new key silently.
+	store.policy = vault.ContactKeyPolicyAllow
+	require.NoError(t, s.checkContactKey(ctx, "bob@example.com", []*crypto.Key{key}, newKeyRing(t, otherKey)))
+	require.Equal(t, otherKey.GetFingerprint(), store.keys["bob@example.com"].Fingerprint)
+	require.Len(t, publisher.events, 3)
+}
+
+type testContactKeyStore struct {
+	policy vault.ContactKeyPolicy
+	keys   map[string]vault.ContactKey
+}
+
+func newTestContactKeyStore() *testContactKeyStore {
+	return &testContactKeyStore{keys: make(map[string]vault.ContactKey)}
+}
+
+func (s *testContactKeyStore) GetContactKeyPolicy() vault.ContactKeyPolicy {
+	return s.policy
+}
+
+func (s *testContactKeyStore) GetContactKey(email string) (vault.ContactKey, bool) {
+	key, ok := s.keys[email]
+	return key, ok
+}
+
+func (s *testContactKeyStore) TrustContactKey(email, fingerprint string) error {
+	s.keys[email] = vault.ContactKey{Email: email, Fingerprint: fingerprint}
+	return nil
+}
+
+func (s *testContactKeyStore) SetContactKeyChanged(email, fingerprint string) error {
+	key := s.keys[email]
+	key.PendingFingerprint = fingerprint
+	s.keys[email] = key
+
+	return nil
+}
+
+type testEventPublisher struct {
+	events []events.Event
+}
+
+func (p *testEventPublisher) PublishEvent(_ context.Context, event events.Event) {
+	p.events = append(p.events, event)
+}
+
+func loadKey(t *testing.T, armored string) *crypto.Key {
+	key, err := crypto.NewKeyFromArmored(armored)
+	require.NoError(t, err)
+
+	return key
+}
+
+func newKeyRing(t *testing.T, key *crypto.Key) *crypto.KeyRing {
+	kr, err := crypto.NewKeyRing(key)
+	require.NoError(t, err)
+
+	return kr
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"mime"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/proton-bridge/v3/internal/attachmentstore"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+	pmmime "github.com/ProtonMail/proton-bridge/v3/pkg/mime"
+)
+
+// maxAttachmentsSize is the total size of attachments the API accepts for a single message.
+const maxAttachmentsSize = 25 * 1024 * 1024
+
+// LargeAttachmentProvider provides the store of the attachments too large to be sent with a message.
+type LargeAttachmentProvider interface {
+	// GetLargeAttachmentStore returns the store and the size above which attachments are uploaded to it,
+	// or false if attachments are always sent with the message.
+	GetLargeAttachmentStore() (attachmentstore.Store, int64, bool)
+}
+
+// convertLargeAttachments uploads the attachments above the user's threshold and replaces them with a link to the upload.
+// The store keeps attachments unencrypted, so it fails with ErrLargeAttachmentEncrypted before uploading anything
+// if hasEncryptedRecipient reports that the message would be end-to-end encrypted for any of its recipients.
+// It fails with ErrMessageTooLarge if the remaining attachments still exceed what the API accepts.
+func (s *Service) convertLargeAttachments(
+	ctx context.Context,
+	p *parser.Parser,
+	hasEncryptedRecipient func(context.Context, rfc822.MIMEType) (bool, error),
+) error {
+	store, threshold, enabled := s.largeAttachments.GetLargeAttachmentStore()
+
+	rich := hasRichBody(p)
+
+	if enabled && hasAttachmentAbove(p, threshold) {
+		mimeType := rfc822.TextPlain
+		if rich {
+			mimeType = rfc822.TextHTML
+		}
+
+		encrypted, err := hasEncryptedRecipient(ctx, mimeType)
+		if err != nil {
+			return err
+		}
+
+		if encrypted {
+			return ErrLargeAttachmentEncrypted
+		}
+	}
+
+	var total int64
+
+	if err := p.NewWalker().
+		RegisterContentDispositionHandler("attachment", func(part *parser.Part) error {
+			size := int64(len(part.Body))
+
+			if !enabled || size <= threshold {
+				total += size
+				return nil
+			}
+
+			name, mimeType := getAttachmentName(part)
+
+			link, err := store.Upload(ctx, name, mimeType, part.Body)
+			if err != nil {
+				return fmt.Errorf("failed to upload large attachment: %w", err)
+			}
+
+			s.log.WithField("size", size).Info("Replaced large attachment with a link")
+
+			replaceWithLink(part, name, size, link, rich)
+
+			return nil
+		}).
+		Walk(); err != nil {
+		return err
+	}
+
+	if total > maxAttachmentsSize {
+		return ErrMessageTooLarge
+	}
+
+	return nil
+}
+
+// hasAttachmentAbove returns whether the message has an attachment larger than the given size.
+func hasAttachmentAbove(p *parser.Parser, threshold int64) bool {
+	var above bool
+
+	_ = p.NewWalker().
+		RegisterContentDispositionHandler("attachment", func(part *parser.Part) error {
+			above = above || int64(len(part.Body)) > threshold
+			return nil
+		}).
+		Walk()
+
+	return above
+}
+
+// hasRichBody returns whether the message has an HTML body, in which case links are added as HTML as well.
+func hasRichBody(p *parser.Parser) bool {
+	var rich bool
+
+	_ = p.NewWalker().
+		RegisterContentDispositionHandler("attachment", func(*parser.Part) error {
+			return nil
+		}).
+		RegisterContentTypeHandler("text/html", func(*parser.Part) error {
+			rich = true
+			return nil
+		}).
+		Walk()
+
+	return rich
+}
+
+func getAttachmentName(part *parser.Part) (string, string) {
+	mimeType, typeParams, err := part.ContentType()
+	if err != nil {
+		mimeType = "application/octet-stream"
+	}
+
+	if _, dispParams, err := part.ContentDisposition(); err == nil && dispParams["filename"] != "" {
+		return decodeName(dispParams["filename"]), mimeType
+	}
+
+	if typeParams["name"] != "" {
+		return decodeName(typeParams["name"]), mimeType
+	}
+
+	return "attachment", mimeType
+}
+
+func decodeName(name string) string {
+	if dec, err := pmmime.WordDec.DecodeHeader(name); err == nil {
+		return dec
+	}
+
+	return name
+}
+
+// replaceWithLink turns the attachment part into a text part of the message body linking to the uploaded attachment.
+func replaceWithLink(part *parser.Part, name string, size int64, link string, rich bool) {
+	for _, key := range []string{"Content-Type", "Content-Disposition", "Content-Transfer-Encoding", "Content-Id", "Content-Description"} {
+		part.Header.Del(key)
+	}
+
+	sizeText := fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+
+	if rich {
+		part.Header.Set("Content-Type", mime.FormatMediaType("text/html", map[string]string{"charset": "utf-8"}))
+		part.Body = []byte(fmt.Sprintf(
+			`<div>Attachment too large to be sent with this message: <a href="%v">%v</a> (%v)</div>`,
+			html.EscapeString(link), html.EscapeString(name), sizeText,
+		))
+	} else {
+		part.Header.Set("Content-Type", mime.FormatMediaType("text/plain", map[string]string{"charset": "utf-8"}))
+		part.Body = []byte(fmt.Sprintf("\r\nAttachment too large to be sent with this message: %v (%v)\r\n%v\r\n", name, sizeText, link))
+	}
+
+	part.Header.Set("Content-Transfer-Encoding", "quoted-printable")
+}
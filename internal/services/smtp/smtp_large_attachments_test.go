@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/proton-bridge/v3/internal/attachmentstore"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertLargeAttachments(t *testing.T) {
+	const literal = "Subject: Attachments\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/html\r\n\r\n<p>Hello</p>\r\n" +
+		"--b\r\nContent-Type: application/pdf\r\nContent-Disposition: attachment; filename=\"big <1>.pdf\"\r\n\r\n0123456789\r\n" +
+		"--b\r\nContent-Type: image/png\r\nContent-Disposition: attachment; filename=small.png\r\n\r\n0123\r\n" +
+		"--b--\r\n"
+
+	store := &testAttachmentStore{}
+
+	s := &Service{
+		log:              logrus.WithField("service", "smtp"),
+		largeAttachments: &testLargeAttachmentProvider{store: store, threshold: 8, enabled: true},
+	}
+
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+	require.NoError(t, s.convertLargeAttachments(context.Background(), p, noEncryptedRecipient))
+
+	// Only the attachment above the threshold is uploaded.
+	require.Equal(t, []string{"big <1>.pdf"}, store.names)
+
+	m, err := message.ParseWithParser(p, false)
+	require.NoError(t, err)
+	require.Len(t, m.Attachments, 1)
+	require.Equal(t, "small.png", m.Attachments[0].Name)
+	require.Contains(t, string(m.RichBody), `<a href="https://files.example.com/big%20%3C1%3E.pdf">big &lt;1&gt;.pdf</a> (0.0 MB)`)
+	require.Contains(t, string(m.PlainBody), "https://files.example.com/big%20%3C1%3E.pdf")
+}
+
+func TestConvertLargeAttachmentsPlain(t *testing.T) {
+	const literal = "Subject: Attachments\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/plain\r\n\r\nHello\r\n" +
+		"--b\r\nContent-Type: application/pdf; name=big.pdf\r\nContent-Disposition: attachment\r\n\r\n0123456789\r\n" +
+		"--b--\r\n"
+
+	s := &Service{
+		log:              logrus.WithField("service", "smtp"),
+		largeAttachments: &testLargeAttachmentProvider{store: &testAttachmentStore{}, threshold: 8, enabled: true},
+	}
+
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+	require.NoError(t, s.convertLargeAttachments(context.Background(), p, noEncryptedRecipient))
+
+	m, err := message.ParseWithParser(p, false)
+	require.NoError(t, err)
+	require.Empty(t, m.Attachments)
+	require.Contains(t, string(m.PlainBody), "big.pdf (0.0 MB)\r\nhttps://files.example.com/big.pdf")
+}
+
+func TestConvertLargeAttachmentsTooLarge(t *testing.T) {
+	literal := "Subject: Attachments\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/plain\r\n\r\nHello\r\n" +
+		"--b\r\nContent-Type: application/octet-stream\r\nContent-Disposition: attachment\r\n\r\n" + strings.Repeat("a", maxAttachmentsSize+1) + "\r\n" +
+		"--b--\r\n"
+
+	provider := &testLargeAttachmentProvider{store: &testAttachmentStore{}, threshold: maxAttachmentsSize + 1}
+
+	s := &Service{
+		log:              logrus.WithField("service", "smtp"),
+		largeAttachments: provider,
+	}
+
+	// The message can't be sent while large attachments are disabled...
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+	require.ErrorIs(t, s.convertLargeAttachments(context.Background(), p, noEncryptedRecipient), ErrMessageTooLarge)
+
+	// ...nor when the attachment is below the threshold.
+	provider.enabled = true
+	require.ErrorIs(t, s.convertLargeAttachments(context.Background(), p, noEncryptedRecipient), ErrMessageTooLarge)
+
+	provider.threshold = maxAttachmentsSize
+	require.NoError(t, s.convertLargeAttachments(context.Background(), p, noEncryptedRecipient))
+}
+
+func TestConvertLargeAttachmentsEncrypted(t *testing.T) {
+	const literal = "Subject: Attachments\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/html\r\n\r\n<p>Hello</p>\r\n" +
+		"--b\r\nContent-Type: application/pdf\r\nContent-Disposition: attachment; filename=big.pdf\r\n\r\n0123456789\r\n" +
+		"--b--\r\n"
+
+	store := &testAttachmentStore{}
+
+	s := &Service{
+		log:              logrus.WithField("service", "smtp"),
+		largeAttachments: &testLargeAttachmentProvider{store: store, threshold: 8, enabled: true},
+	}
+
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+
+	var checked rfc822.MIMEType
+
+	// Nothing is uploaded if the message would be encrypted for any recipient.
+	require.ErrorIs(t, s.convertLargeAttachments(context.Background(), p, func(_ context.Context, mimeType rfc822.MIMEType) (bool, error) {
+		checked = mimeType
+		return true, nil
+	}), ErrLargeAttachmentEncrypted)
+
+	require.Equal(t, rfc822.TextHTML, checked)
+	require.Empty(t, store.names)
+
+	// Recipients aren't looked up if no attachment is above the threshold.
+	s.largeAttachments = &testLargeAttachmentProvider{store: store, threshold: 10, enabled: true}
+
+	require.NoError(t, s.convertLargeAttachments(context.Background(), p, func(context.Context, rfc822.MIMEType) (bool, error) {
+		panic("unexpected recipient lookup")
+	}))
+}
+
+func noEncryptedRecipient(context.Context, rfc822.MIMEType) (bool, error) {
+	return false, nil
+}
+
+type testLargeAttachmentProvider struct {
+	store     attachmentstore.Store
+	threshold int64
+	enabled   bool
+}
+
+func (provider *testLargeAttachmentProvider) GetLargeAttachmentStore() (attachmentstore.Store, int64, bool) {
+	return provider.store, provider.threshold, provider.enabled
+}
+
+type testAttachmentStore struct {
+	names []string
+}
+
+func (store *testAttachmentStore) Upload(_ context.Context, name, _ string, _ []byte) (string, error) {
+	store.names = append(store.names, name)
+
+	return "https://files.example.com/" + strings.NewReplacer(" ", "%20", "<", "%3C", ">", "%3E").Replace(name), nil
+}
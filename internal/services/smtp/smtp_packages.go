@@ -32,19 +32,29 @@ import (
 
 func createSendReq(
 	kr *crypto.KeyRing,
-	mimeBody message.MIMEBody,
+	mimeBody, smimeBody message.MIMEBody,
 	richBody, plainBody message.Body,
 	recipients recipients,
 	attKeys map[string]*crypto.SessionKey,
 ) (proton.SendDraftReq, error) {
 	var req proton.SendDraftReq
 
-	if recs := recipients.scheme(proton.PGPMIMEScheme, proton.ClearMIMEScheme); len(recs) > 0 {
+	if recs := recipients.withoutSMIME().scheme(proton.PGPMIMEScheme, proton.ClearMIMEScheme); len(recs) > 0 {
 		if err := req.AddMIMEPackage(kr, string(mimeBody), recs); err != nil {
 			return proton.SendDraftReq{}, err
 		}
 	}
 
+	if recs := recipients.smime(); len(recs) > 0 {
+		if smimeBody == "" {
+			return proton.SendDraftReq{}, fmt.Errorf("missing S/MIME body for %d recipients", len(recs))
+		}
+
+		if err := addSMIMEPackage(&req, kr, string(smimeBody), recs); err != nil {
+			return proton.SendDraftReq{}, err
+		}
+	}
+
 	if recs := recipients.scheme(proton.InternalScheme, proton.ClearScheme, proton.PGPInlineScheme); len(recs) > 0 {
 		if recs := recipients.scheme(proton.PGPInlineScheme); len(recs) > 0 {
 			logrus.WithFields(logrus.Fields{"service": "smtp", "settings": "recipient"}).Warn("PGPInline scheme used. Planed to be deprecated.")
@@ -83,6 +93,28 @@ func (r recipients) scheme(scheme ...proton.EncryptionScheme) recipients {
 	return res
 }
 
+func (r recipients) smime() recipients {
+	return r.filter(isSMIMEPrefs)
+}
+
+func (r recipients) withoutSMIME() recipients {
+	return r.filter(func(prefs proton.SendPreferences) bool {
+		return !isSMIMEPrefs(prefs)
+	})
+}
+
+func (r recipients) filter(fn func(proton.SendPreferences) bool) recipients {
+	res := make(recipients)
+
+	for addr, prefs := range r {
+		if fn(prefs) {
+			res[addr] = prefs
+		}
+	}
+
+	return res
+}
+
 func (r recipients) content(mimeType ...rfc822.MIMEType) recipients {
 	res := make(recipients)
 
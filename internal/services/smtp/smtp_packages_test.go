@@ -1077,7 +1077,7 @@ func checkCreateSendReq(t *testing.T, test SendReqStruct, kr *crypto.KeyRing) {
 	}
 	rec["test@proton.local"] = pref
 
-	req, err := createSendReq(kr, mimeBody, richBody, plainBody, rec, map[string]*crypto.SessionKey{})
+	req, err := createSendReq(kr, mimeBody, "", richBody, plainBody, rec, map[string]*crypto.SessionKey{})
 	if test.wantError {
 		assert.Error(t, err)
 	} else {
@@ -1210,7 +1210,7 @@ func TestCreateSendReq_MultiRecipients(t *testing.T) {
 		MIMEType:         rfc822.TextHTML,
 	}
 
-	req, err := createSendReq(kr, mimeBody, richBody, plainBody, rec, map[string]*crypto.SessionKey{})
+	req, err := createSendReq(kr, mimeBody, "", richBody, plainBody, rec, map[string]*crypto.SessionKey{})
 	assert.NoError(t, err)
 
 	// expect 3 packages: Multipart/HTML/text
@@ -1265,3 +1265,50 @@ func TestCreateSendReq_MultiRecipients(t *testing.T) {
 	// 7 with encryption
 	assert.Equal(t, 7, totalFromSessionKey)
 }
+
+func TestCreateSendReq_SMIME(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	var smimeBody message.MIMEBody = "Content-Type: multipart/signed; protocol=\"application/pkcs7-signature\"; boundary=b\r\n\r\n--b--\r\n"
+
+	rec := recipients{
+		"smime@proton.local": smimePrefs(),
+		"clear@proton.local": proton.SendPreferences{
+			SignatureType:    proton.DetachedSignature,
+			EncryptionScheme: proton.ClearMIMEScheme,
+			MIMEType:         rfc822.MultipartMixed,
+		},
+	}
+
+	// S/MIME recipients can't be sent to without the signed body.
+	_, err := createSendReq(kr, mimeBody, "", richBody, plainBody, rec, map[string]*crypto.SessionKey{})
+	assert.Error(t, err)
+
+	req, err := createSendReq(kr, mimeBody, smimeBody, richBody, plainBody, rec, map[string]*crypto.SessionKey{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(req.Packages))
+
+	for _, pkg := range req.Packages {
+		assert.Equal(t, rfc822.MultipartMixed, pkg.MIMEType)
+		assert.Equal(t, proton.ClearMIMEScheme, pkg.Type)
+		assert.Equal(t, 1, len(pkg.Addresses))
+
+		decBody, err := base64.StdEncoding.DecodeString(pkg.Body)
+		assert.NoError(t, err)
+
+		decBodyKey, err := base64.StdEncoding.DecodeString(pkg.BodyKey.Key)
+		assert.NoError(t, err)
+
+		plain, err := (&crypto.SessionKey{Key: decBodyKey, Algo: pkg.BodyKey.Algorithm}).Decrypt(decBody)
+		assert.NoError(t, err)
+
+		if recipient, ok := pkg.Addresses["smime@proton.local"]; ok {
+			// The S/MIME signature replaces the PGP signature.
+			assert.Equal(t, proton.NoSignature, recipient.Signature)
+			assert.Equal(t, string(smimeBody), string(plain.Data))
+		} else {
+			assert.Equal(t, proton.DetachedSignature, pkg.Addresses["clear@proton.local"].Signature)
+			assert.Equal(t, string(mimeBody), string(plain.Data))
+		}
+	}
+}
@@ -42,6 +42,7 @@ type contactSettings struct {
 	Encrypt          bool
 	EncryptUntrusted bool
 	MIMEType         rfc822.MIMEType
+	Autocrypt        *autocryptPeer
 }
 
 // newContactSettings converts the API settings into our local settings.
@@ -101,10 +102,14 @@ func buildSendPrefs(
 	pubKeys []proton.PublicKey,
 	mimeType rfc822.MIMEType,
 	isInternal bool,
+	autocrypt *autocryptPeer,
 ) (proton.SendPreferences, error) {
 	builder := &sendPrefsBuilder{}
 
-	if err := builder.setPGPSettings(newContactSettings(contactSettings), pubKeys, isInternal); err != nil {
+	vCardData := newContactSettings(contactSettings)
+	vCardData.Autocrypt = autocrypt
+
+	if err := builder.setPGPSettings(vCardData, pubKeys, isInternal); err != nil {
 		return proton.SendPreferences{}, fmt.Errorf("failed to set PGP settings: %w", err)
 	}
 
@@ -349,8 +354,9 @@ func (b *sendPrefsBuilder) setInternalPGPSettings(
 //  1. If there are pinned keys in the vCard, those should be given preference
 //     (assuming the fingerprint matches one of the keys served by the API).
 //  2. If there are pinned keys in the vCard but no matching keys were served
-//     by the API, we use one of the API keys but first show a modal to the
-//     user to ask them to confirm that they trust the API key.
+//     by the API, we use one of the API keys; checkContactKey then treats it
+//     as a key change, which the user must approve or which blocks the send
+//     depending on the contact key policy.
 //     (Use case: user doesn't trust server, pins the only keys they trust to
 //     the contact, rogue server sends unknown keys, user should have option
 //     to say they don't recognise these keys and abort the mail send.)
@@ -396,7 +402,7 @@ func pickSendingKey(vCardData *contactSettings, rawAPIKeys []proton.PublicKey) (
 
 	// Case 2.
 	case len(matchedKeys) == 0 && len(contactKeys) > 0:
-		// NOTE: Here we should ask for trust confirmation.
+		// The key is checked against the contact key store before sending.
 		sendingKey = apiKeys[0]
 
 	// Case 3.
@@ -469,14 +475,20 @@ func (b *sendPrefsBuilder) setExternalPGPSettingsWithWKDKeys(
 func (b *sendPrefsBuilder) setExternalPGPSettingsWithoutWKDKeys(
 	vCardData *contactSettings,
 ) error {
-	b.withEncrypt(vCardData.Encrypt)
+	// Without keys pinned to the contact, we fall back to the key collected from
+	// the recipient's Autocrypt headers. Autocrypt only defines PGP/MIME and
+	// recommends encrypting when both sides prefer it.
+	autocrypt := len(vCardData.Keys) == 0 && vCardData.Autocrypt != nil
+	encrypt := vCardData.Encrypt || (autocrypt && vCardData.Autocrypt.encrypt)
+
+	b.withEncrypt(encrypt)
 
 	if vCardData.SignIsSet {
 		b.withSign(vCardData.Sign)
 	}
 
 	// Sign must be enabled whenever encrypt is.
-	if vCardData.Encrypt {
+	if encrypt {
 		b.withSign(true)
 	}
 
@@ -484,6 +496,8 @@ func (b *sendPrefsBuilder) setExternalPGPSettingsWithoutWKDKeys(
 	// leave it unset to allow it to be filled in with the default value later).
 	if vCardData.Scheme != "" {
 		b.withScheme(vCardData.Scheme)
+	} else if autocrypt {
+		b.withScheme(pgpMIME)
 	}
 
 	// If we are signing the message, the PGP scheme overrides the MIMEType.
@@ -516,6 +530,13 @@ func (b *sendPrefsBuilder) setExternalPGPSettingsWithoutWKDKeys(
 			return err
 		}
 
+		b.withPublicKey(kr)
+	} else if autocrypt {
+		kr, err := crypto.NewKeyRing(vCardData.Autocrypt.key)
+		if err != nil {
+			return err
+		}
+
 		b.withPublicKey(kr)
 	}
 
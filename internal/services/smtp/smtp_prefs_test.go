@@ -31,6 +31,9 @@ func TestPreferencesBuilder(t *testing.T) {
 	testContactKey := loadContactKey(t, testPublicKey)
 	testOtherContactKey := loadContactKey(t, testOtherPublicKey)
 
+	testAutocryptKey, err := crypto.NewKeyFromArmored(testPublicKey)
+	require.NoError(t, err)
+
 	tests := []struct { //nolint:maligned
 		name string
 
@@ -402,6 +405,51 @@ func TestPreferencesBuilder(t *testing.T) {
 			wantMIMEType:  "text/plain",
 			wantPublicKey: testPublicKey,
 		},
+
+		{
+			name: "external with Autocrypt key but no mutual preference",
+
+			contactMeta:  &contactSettings{Autocrypt: &autocryptPeer{key: testAutocryptKey}},
+			receivedKeys: []proton.PublicKey{},
+			isInternal:   false,
+			mailSettings: proton.MailSettings{PGPScheme: proton.PGPMIMEScheme, DraftMIMEType: "text/html"},
+
+			wantEncrypt:   false,
+			wantSign:      proton.NoSignature,
+			wantScheme:    proton.ClearScheme,
+			wantMIMEType:  "text/html",
+			wantPublicKey: testPublicKey,
+		},
+
+		{
+			name: "external with Autocrypt key and mutual preference, encrypted with PGP/MIME despite global pgp-inline",
+
+			contactMeta:  &contactSettings{Autocrypt: &autocryptPeer{key: testAutocryptKey, encrypt: true}},
+			receivedKeys: []proton.PublicKey{},
+			isInternal:   false,
+			mailSettings: proton.MailSettings{PGPScheme: proton.PGPInlineScheme, DraftMIMEType: "text/html"},
+
+			wantEncrypt:   true,
+			wantSign:      proton.DetachedSignature,
+			wantScheme:    proton.PGPMIMEScheme,
+			wantMIMEType:  "multipart/mixed",
+			wantPublicKey: testPublicKey,
+		},
+
+		{
+			name: "external with pinned contact public key takes precedence over Autocrypt key",
+
+			contactMeta:  &contactSettings{Keys: []string{testContactKey}, Autocrypt: &autocryptPeer{key: testAutocryptKey, encrypt: true}},
+			receivedKeys: []proton.PublicKey{},
+			isInternal:   false,
+			mailSettings: proton.MailSettings{PGPScheme: proton.PGPMIMEScheme, DraftMIMEType: "text/html"},
+
+			wantEncrypt:   false,
+			wantSign:      proton.NoSignature,
+			wantScheme:    proton.ClearScheme,
+			wantMIMEType:  "text/html",
+			wantPublicKey: testPublicKey,
+		},
 	}
 
 	for _, test := range tests {
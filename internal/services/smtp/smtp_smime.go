@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+)
+
+// SMIMEStore provides the S/MIME identities used to sign the mail sent from the user's addresses,
+// and the recipients who get S/MIME signed mail. Proton contacts have no S/MIME setting, so the recipients
+// are chosen in Bridge.
+type SMIMEStore interface {
+	GetSMIMEIdentity(email string) (vault.SMIMEIdentity, bool)
+	IsSMIMERecipient(email string) bool
+}
+
+// smimePrefs are the send preferences of recipients receiving S/MIME signed mail.
+// They are the only clear MIME recipients without a PGP signature; the S/MIME signature replaces it.
+func smimePrefs() proton.SendPreferences {
+	return proton.SendPreferences{
+		Encrypt:          false,
+		SignatureType:    proton.NoSignature,
+		EncryptionScheme: proton.ClearMIMEScheme,
+		MIMEType:         rfc822.MultipartMixed,
+	}
+}
+
+func isSMIMEPrefs(prefs proton.SendPreferences) bool {
+	return !prefs.Encrypt &&
+		prefs.SignatureType == proton.NoSignature &&
+		prefs.EncryptionScheme == proton.ClearMIMEScheme &&
+		prefs.MIMEType == rfc822.MultipartMixed
+}
+
+// hasSMIMEIdentity returns whether an S/MIME identity was imported for the given address.
+func (s *Service) hasSMIMEIdentity(email string) bool {
+	_, ok := s.smime.GetSMIMEIdentity(email)
+	return ok
+}
+
+// getSMIMEBody returns the MIME body signed with the S/MIME identity of the given address,
+// or an empty body if none of the recipients gets S/MIME signed mail.
+func (s *Service) getSMIMEBody(recipients recipients, email string, mimeBody message.MIMEBody) (message.MIMEBody, error) {
+	if len(recipients.smime()) == 0 {
+		return "", nil
+	}
+
+	data, ok := s.smime.GetSMIMEIdentity(email)
+	if !ok {
+		return "", fmt.Errorf("%w: %v", ErrNoSMIMEIdentity, email)
+	}
+
+	id, err := smime.ParseIdentity(data.Certificate, data.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to load S/MIME identity: %w", err)
+	}
+
+	signed, err := message.SignSMIME([]byte(mimeBody), id)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	return message.MIMEBody(signed), nil
+}
+
+// addSMIMEPackage adds a clear MIME package carrying the S/MIME signed body.
+// Unlike the packages built by AddMIMEPackage, the body is not signed with the address key,
+// so that recipients get the S/MIME message as is rather than wrapped in a PGP/MIME signature.
+func addSMIMEPackage(req *proton.SendDraftReq, kr *crypto.KeyRing, body string, recs recipients) error {
+	enc, err := kr.Encrypt(crypto.NewPlainMessageFromString(body), nil)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt S/MIME body: %w", err)
+	}
+
+	split, err := enc.SplitMessage()
+	if err != nil {
+		return fmt.Errorf("failed to split message: %w", err)
+	}
+
+	key, err := kr.DecryptSessionKey(split.GetBinaryKeyPacket())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt session key: %w", err)
+	}
+
+	pkg := &proton.MessagePackage{
+		Addresses: make(map[string]*proton.MessageRecipient),
+		MIMEType:  rfc822.MultipartMixed,
+		Type:      proton.ClearMIMEScheme,
+		Body:      base64.StdEncoding.EncodeToString(split.GetBinaryDataPacket()),
+		BodyKey: &proton.SessionKey{
+			Key:       key.GetBase64Key(),
+			Algorithm: key.Algo,
+		},
+	}
+
+	for addr := range recs {
+		pkg.Addresses[addr] = &proton.MessageRecipient{
+			Type:      proton.ClearMIMEScheme,
+			Signature: proton.NoSignature,
+		}
+	}
+
+	req.Packages = append(req.Packages, pkg)
+
+	return nil
+}
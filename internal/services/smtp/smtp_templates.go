@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gluon/rfc5322"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/usertypes"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+)
+
+// templateHeader names the template a message is expanded from, by message ID or subject.
+// Templates are the drafts saved in the Templates mailbox.
+const templateHeader = "X-Pm-Template"
+
+// templatesPageSize is the number of templates fetched at once when searching for the one named in the template header.
+const templatesPageSize = 150
+
+// TemplatesProvider provides the label of the templates listed in the Templates mailbox.
+type TemplatesProvider interface {
+	// GetTemplatesLabelID returns the ID of the templates label, or false if no template was saved yet.
+	GetTemplatesLabelID() (string, bool)
+}
+
+// expandTemplate returns the message expanded from the template named in its template header,
+// or the message itself if it has none.
+func (s *Service) expandTemplate(ctx context.Context, p *parser.Parser, to []string) (*parser.Parser, error) {
+	name := strings.TrimSpace(p.Root().Header.Get(templateHeader))
+	if name == "" {
+		return p, nil
+	}
+
+	full, err := s.getTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var literal []byte
+
+	if err := s.identityState.WithAddrKR(full.AddressID, s.keyPassProvider.KeyPass(), func(_, addrKR *crypto.KeyRing) error {
+		literal, err = message.DecryptAndBuildRFC822(addrKR, full.Message, full.AttData, message.JobOptions{SanitizeDate: true})
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to build template: %w", err)
+	}
+
+	s.log.WithField("templateID", full.ID).Info("Expanding message from template")
+
+	return applyTemplate(p, literal, full.Subject, to, time.Now())
+}
+
+// getTemplate returns the template with the given message ID or subject.
+// Templates are the messages carrying the label listed in the Templates mailbox; all of them are searched.
+func (s *Service) getTemplate(ctx context.Context, name string) (proton.FullMessage, error) {
+	labelID, ok := s.templates.GetTemplatesLabelID()
+	if !ok {
+		return proton.FullMessage{}, ErrNoSuchTemplate
+	}
+
+	for page := 0; ; page++ {
+		templates, err := s.client.GetMessageMetadataPage(ctx, page, templatesPageSize, proton.MessageFilter{LabelID: labelID})
+		if err != nil {
+			return proton.FullMessage{}, fmt.Errorf("failed to get templates: %w", err)
+		}
+
+		for _, template := range templates {
+			if template.ID != name && !strings.EqualFold(template.Subject, name) {
+				continue
+			}
+
+			return s.client.GetFullMessage(ctx, template.ID, usertypes.NewProtonAPIScheduler(s.panicHandler), proton.NewDefaultAttachmentAllocator())
+		}
+
+		if len(templates) < templatesPageSize {
+			return proton.FullMessage{}, ErrNoSuchTemplate
+		}
+	}
+}
+
+// applyTemplate returns the message with the body and attachments of the given template literal.
+// The headers of the message are kept; the subject of the template is used if the message has none.
+// The variables of the template are replaced with the values of the message.
+func applyTemplate(p *parser.Parser, literal []byte, subject string, to []string, now time.Time) (*parser.Parser, error) {
+	tp, err := parser.New(bytes.NewReader(literal))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	vars := newTemplateVars(p, to, now)
+
+	if err := tp.NewWalker().
+		RegisterContentDispositionHandler("attachment", func(*parser.Part) error {
+			return nil
+		}).
+		RegisterContentTypeHandler("text/plain", func(part *parser.Part) error {
+			part.Body = []byte(vars.replace(string(part.Body), false))
+			return nil
+		}).
+		RegisterContentTypeHandler("text/html", func(part *parser.Part) error {
+			part.Body = []byte(vars.replace(string(part.Body), true))
+			return nil
+		}).
+		Walk(); err != nil {
+		return nil, err
+	}
+
+	header := p.Root().Header.Copy()
+	header.Del(templateHeader)
+
+	for _, key := range []string{"Content-Type", "Content-Disposition", "Content-Transfer-Encoding"} {
+		header.Del(key)
+
+		if value := tp.Root().Header.Get(key); value != "" {
+			header.Set(key, value)
+		}
+	}
+
+	if strings.TrimSpace(header.Get("Subject")) == "" {
+		header.SetText("Subject", vars.replace(subject, false))
+	}
+
+	tp.Root().Header = header
+
+	return tp, nil
+}
+
+// templateVars are the values of the variables which can be used in templates, e.g. {{recipient_name}}.
+type templateVars map[string]string
+
+func newTemplateVars(p *parser.Parser, to []string, now time.Time) templateVars {
+	vars := templateVars{
+		"date": now.Format("2 January 2006"),
+	}
+
+	if from, err := rfc5322.ParseAddressList(p.Root().Header.Get("From")); err == nil && len(from) > 0 {
+		vars["sender_name"] = nameOrEmail(from[0].Name, from[0].Address)
+		vars["sender_email"] = from[0].Address
+	}
+
+	if rcpts, err := rfc5322.ParseAddressList(p.Root().Header.Get("To")); err == nil && len(rcpts) > 0 {
+		vars["recipient_name"] = nameOrEmail(rcpts[0].Name, rcpts[0].Address)
+		vars["recipient_email"] = rcpts[0].Address
+	} else if len(to) > 0 {
+		vars["recipient_name"] = to[0]
+		vars["recipient_email"] = to[0]
+	}
+
+	return vars
+}
+
+func (vars templateVars) replace(text string, escape bool) string {
+	for key, value := range vars {
+		if escape {
+			value = html.EscapeString(value)
+		}
+
+		text = strings.ReplaceAll(text, "{{"+key+"}}", value)
+	}
+
+	return text
+}
+
+func nameOrEmail(name, email string) string {
+	if name != "" {
+		return name
+	}
+
+	return email
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smtp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	const template = "Subject: Stored subject\r\nX-Pm-Internal-Id: templateID\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/html; charset=utf-8\r\n\r\n<p>Dear {{recipient_name}}, {{date}}</p><p>{{sender_name}}</p>\r\n" +
+		"--b\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Disposition: attachment; filename=notes.txt\r\n\r\n{{recipient_name}}\r\n" +
+		"--b--\r\n"
+
+	const literal = "From: Alice <alice@example.com>\r\nTo: \"Bob <B>\" <bob@example.com>\r\nX-Pm-Template: Weekly\r\nContent-Type: text/plain\r\n\r\n"
+
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	tp, err := applyTemplate(p, []byte(template), "Hello {{recipient_name}}", []string{"bob@example.com"}, now)
+	require.NoError(t, err)
+
+	// The headers of the message are kept, except for the template header.
+	header := tp.Root().Header
+	require.Equal(t, "Alice <alice@example.com>", header.Get("From"))
+	require.Empty(t, header.Get("X-Pm-Template"))
+	require.Empty(t, header.Get("X-Pm-Internal-Id"))
+
+	m, err := message.ParseWithParser(tp, false)
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob <B>", m.Subject)
+	require.Equal(t, "<p>Dear Bob &lt;B&gt;, 14 March 2026</p><p>Alice</p>", string(m.RichBody))
+
+	// Attachments are sent unchanged.
+	require.Len(t, m.Attachments, 1)
+	require.Equal(t, "{{recipient_name}}", string(bytes.TrimSpace(m.Attachments[0].Data)))
+}
+
+func TestApplyTemplateKeepsSubject(t *testing.T) {
+	const template = "Subject: Stored subject\r\nContent-Type: text/plain; charset=utf-8\r\n\r\nHi {{recipient_email}}"
+
+	const literal = "From: alice@example.com\r\nSubject: Own subject\r\nX-Pm-Template: Weekly\r\n\r\n"
+
+	p, err := parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+
+	tp, err := applyTemplate(p, []byte(template), "Stored subject", []string{"bob@example.com"}, time.Now())
+	require.NoError(t, err)
+
+	m, err := message.ParseWithParser(tp, false)
+	require.NoError(t, err)
+	require.Equal(t, "Own subject", m.Subject)
+	require.Equal(t, "Hi bob@example.com", string(m.PlainBody))
+}
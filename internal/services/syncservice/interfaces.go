@@ -75,7 +75,7 @@ type BuildResult struct {
 
 type MessageBuilder interface {
 	WithKeys(f func(*crypto.KeyRing, map[string]*crypto.KeyRing) error) error
-	BuildMessage(apiLabels map[string]proton.Label, full proton.FullMessage, addrKR *crypto.KeyRing, buffer *bytes.Buffer) (BuildResult, error)
+	BuildMessage(ctx context.Context, apiLabels map[string]proton.Label, full proton.FullMessage, addrKR *crypto.KeyRing, buffer *bytes.Buffer) (BuildResult, error)
 }
 
 type UpdateApplier interface {
@@ -572,18 +572,18 @@ func (m *MockMessageBuilder) EXPECT() *MockMessageBuilderMockRecorder {
 }
 
 // BuildMessage mocks base method.
-func (m *MockMessageBuilder) BuildMessage(arg0 map[string]proton.Label, arg1 proton.FullMessage, arg2 *crypto.KeyRing, arg3 *bytes.Buffer) (BuildResult, error) {
+func (m *MockMessageBuilder) BuildMessage(arg0 context.Context, arg1 map[string]proton.Label, arg2 proton.FullMessage, arg3 *crypto.KeyRing, arg4 *bytes.Buffer) (BuildResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuildMessage", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "BuildMessage", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(BuildResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuildMessage indicates an expected call of BuildMessage.
-func (mr *MockMessageBuilderMockRecorder) BuildMessage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockMessageBuilderMockRecorder) BuildMessage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildMessage", reflect.TypeOf((*MockMessageBuilder)(nil).BuildMessage), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildMessage", reflect.TypeOf((*MockMessageBuilder)(nil).BuildMessage), arg0, arg1, arg2, arg3, arg4)
 }
 
 // WithKeys mocks base method.
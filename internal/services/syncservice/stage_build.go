@@ -77,7 +77,7 @@ func NewBuildStage(
 		input:               input,
 		output:              output,
 		maxBuildMem:         maxBuildMem,
-		log:                 logrus.WithField("sync-stage", "build"),
+		log:                 logrus.WithFields(logrus.Fields{"pkg": "sync", "sync-stage": "build"}),
 		panicHandler:        panicHandler,
 		observabilitySender: observabilitySender,
 	}
@@ -148,7 +148,7 @@ func (b *BuildStage) run(ctx context.Context) {
 					return nil
 				}
 
-				result, err := parallel.MapContext(ctx, maxMessagesInParallel, chunk, func(_ context.Context, msg proton.FullMessage) (BuildResult, error) {
+				result, err := parallel.MapContext(ctx, maxMessagesInParallel, chunk, func(ctx context.Context, msg proton.FullMessage) (BuildResult, error) {
 					defer async.HandlePanic(b.panicHandler)
 
 					kr, ok := addrKRs[msg.AddressID]
@@ -167,7 +167,7 @@ func (b *BuildStage) run(ctx context.Context) {
 					buf.Reset()
 
 					// Note the buffer grows inside the BuildMessage function
-					res, err := req.job.messageBuilder.BuildMessage(req.job.labels, msg, kr, buf)
+					res, err := req.job.messageBuilder.BuildMessage(ctx, req.job.labels, msg, kr, buf)
 
 					buildBufferPool.Put(buf)
 
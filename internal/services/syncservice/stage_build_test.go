@@ -102,7 +102,7 @@ func TestBuildStage_SuccessRemovesFailedMessage(t *testing.T) {
 		Update:    &imap.MessageCreated{},
 	}
 
-	tj.messageBuilder.EXPECT().BuildMessage(gomock.Eq(labels), gomock.Eq(msg), gomock.Any(), gomock.Any()).Return(buildResult, nil)
+	tj.messageBuilder.EXPECT().BuildMessage(gomock.Any(), gomock.Eq(labels), gomock.Eq(msg), gomock.Any(), gomock.Any()).Return(buildResult, nil)
 	tj.state.EXPECT().RemFailedMessageID(gomock.Any(), gomock.Eq("MSG"))
 
 	observabilityService := mocks.NewMockObservabilitySender(mockCtrl)
@@ -157,7 +157,7 @@ func TestBuildStage_BuildFailureIsReportedButDoesNotCancelJob(t *testing.T) {
 
 	buildError := errors.New("it failed")
 
-	tj.messageBuilder.EXPECT().BuildMessage(gomock.Eq(labels), gomock.Eq(msg), gomock.Any(), gomock.Any()).Return(BuildResult{}, buildError)
+	tj.messageBuilder.EXPECT().BuildMessage(gomock.Any(), gomock.Eq(labels), gomock.Eq(msg), gomock.Any(), gomock.Any()).Return(BuildResult{}, buildError)
 	tj.state.EXPECT().AddFailedMessageID(gomock.Any(), gomock.Eq([]string{"MSG"}))
 
 	tj.syncReporter.EXPECT().OnProgress(gomock.Any(), gomock.Eq(int64(10)))
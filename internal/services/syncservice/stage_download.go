@@ -64,7 +64,7 @@ func NewDownloadStage(
 		output:               output,
 		maxParallelDownloads: maxParallelDownloads * 2,
 		panicHandler:         panicHandler,
-		log:                  logrus.WithField("sync-stage", "download"),
+		log:                  logrus.WithFields(logrus.Fields{"pkg": "sync", "sync-stage": "download"}),
 	}
 }
 
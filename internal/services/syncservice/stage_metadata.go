@@ -53,7 +53,7 @@ func NewMetadataStage(
 		input:          input,
 		output:         output,
 		maxDownloadMem: maxDownloadMem,
-		log:            logrus.WithField("sync-stage", "metadata"),
+		log:            logrus.WithFields(logrus.Fields{"pkg": "sync", "sync-stage": "metadata"}),
 		panicHandler:   panicHandler,
 	}
 }
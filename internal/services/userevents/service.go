@@ -63,6 +63,9 @@ type Service struct {
 	paused         uint32
 	panicHandler   async.PanicHandler
 
+	// lastPoll is the time, in Unix nanoseconds, at which the service last caught up with the API's events.
+	lastPoll atomic.Int64
+
 	subscriberList eventSubscriberList
 
 	pendingSubscriptionsLock sync.Mutex
@@ -160,6 +163,16 @@ func (s *Service) IsPaused() bool {
 	return atomic.LoadUint32(&s.paused) == 1
 }
 
+// GetLastPollTime returns when the service last caught up with the API's events.
+// It is zero if the service hasn't polled successfully yet.
+func (s *Service) GetLastPollTime() time.Time {
+	if t := s.lastPoll.Load(); t != 0 {
+		return time.Unix(0, t)
+	}
+
+	return time.Time{}
+}
+
 // RewindEventID sets the event id as the next event to be polled.
 func (s *Service) RewindEventID(ctx context.Context, id string) error {
 	_, err := s.cpc.Send(ctx, &rewindEventIDReq{eventID: id})
@@ -280,6 +293,7 @@ func (s *Service) run(ctx context.Context, lastEventID string) {
 		// If the event ID hasn't changed, there are no new events.
 		if newEvents[len(newEvents)-1].EventID == lastEventID {
 			s.log.Debugf("No new API Events")
+			s.lastPoll.Store(time.Now().UnixNano())
 			continue
 		}
 
@@ -311,6 +325,7 @@ func (s *Service) run(ctx context.Context, lastEventID string) {
 		}
 
 		lastEventID = newEventID
+		s.lastPoll.Store(time.Now().UnixNano())
 
 		if s.IsPaused() {
 			s.closePollWaiters()
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+)
+
+const (
+	// sourceTimeout bounds each download from an update source.
+	sourceTimeout = 10 * time.Minute
+
+	// maxSourceFileSize bounds the size of each file downloaded from a mirror, the update packages being the largest.
+	maxSourceFileSize = 1 << 30
+)
+
+var ErrInvalidSource = errors.New("invalid update source")
+
+// NewSource returns a Downloader that fetches the files normally downloaded from Host from the given location instead:
+// the URL of a mirror, or a local directory. The location must have the same layout as Host.
+// The files are verified against the keyring like any other update.
+func NewSource(location string) (Downloader, error) {
+	if location == "" {
+		return nil, fmt.Errorf("%w: empty location", ErrInvalidSource)
+	}
+
+	u, err := url.Parse(location)
+	if err == nil {
+		switch u.Scheme {
+		case "http", "https":
+			return &mirrorSource{
+				base:   strings.TrimSuffix(location, "/"),
+				client: &http.Client{Timeout: sourceTimeout},
+			}, nil
+
+		case "file":
+			return newDirectorySource(u.Path)
+		}
+	}
+
+	return newDirectorySource(location)
+}
+
+// mirrorSource downloads updates from an HTTP mirror of Host.
+type mirrorSource struct {
+	base   string
+	client *http.Client
+}
+
+func (s *mirrorSource) DownloadAndVerify(ctx context.Context, kr *crypto.KeyRing, fileURL, sigURL string) ([]byte, error) {
+	fb, err := s.fetch(ctx, s.base+"/"+sourcePath(fileURL))
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := s.fetch(ctx, s.base+"/"+sourcePath(sigURL))
+	if err != nil {
+		return nil, err
+	}
+
+	return verifySourceFile(kr, fb, sb)
+}
+
+func (s *mirrorSource) fetch(ctx context.Context, fileURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch %v: %v", fileURL, res.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxSourceFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > maxSourceFileSize {
+		return nil, fmt.Errorf("could not fetch %v: file is larger than %v bytes", fileURL, maxSourceFileSize)
+	}
+
+	return b, nil
+}
+
+// directorySource reads updates from a local directory with the same layout as Host, for offline installations.
+type directorySource struct {
+	dir string
+}
+
+func newDirectorySource(dir string) (*directorySource, error) {
+	if !filepath.IsAbs(dir) {
+		return nil, fmt.Errorf("%w: %q is neither an http(s) URL nor an absolute path", ErrInvalidSource, dir)
+	}
+
+	return &directorySource{dir: dir}, nil
+}
+
+func (s *directorySource) DownloadAndVerify(_ context.Context, kr *crypto.KeyRing, fileURL, sigURL string) ([]byte, error) {
+	fb, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(sourcePath(fileURL))))
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(sourcePath(sigURL))))
+	if err != nil {
+		return nil, err
+	}
+
+	return verifySourceFile(kr, fb, sb)
+}
+
+// sourcePath returns the path of a file relative to Host. Files hosted elsewhere are looked up by name at the root.
+func sourcePath(fileURL string) string {
+	if rel, ok := strings.CutPrefix(fileURL, Host+"/"); ok {
+		if rel = path.Clean(rel); !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return path.Base(fileURL)
+	}
+
+	return path.Base(u.Path)
+}
+
+func verifySourceFile(kr *crypto.KeyRing, fb, sb []byte) ([]byte, error) {
+	if err := kr.VerifyDetached(
+		crypto.NewPlainMessage(fb),
+		crypto.NewPGPSignature(sb),
+		crypto.GetUnixTime(),
+	); err != nil {
+		return nil, err
+	}
+
+	return fb, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package updater
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/versioner"
+	"github.com/ProtonMail/proton-bridge/v3/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource_Directory(t *testing.T) {
+	dir := t.TempDir()
+	kr := utils.MakeKeyRing(t)
+
+	writeSignedFile(t, kr, filepath.Join(dir, "bridge", "bridge-3.1.0.tgz"), []byte("package"))
+	writeSignedFile(t, kr, filepath.Join(dir, "other-3.1.0.tgz"), []byte("other"))
+
+	source, err := NewSource(dir)
+	require.NoError(t, err)
+
+	b, err := source.DownloadAndVerify(context.Background(), kr, Host+"/bridge/bridge-3.1.0.tgz", Host+"/bridge/bridge-3.1.0.tgz.sig")
+	require.NoError(t, err)
+	require.Equal(t, []byte("package"), b)
+
+	// Files hosted elsewhere are looked up by name.
+	b, err = source.DownloadAndVerify(context.Background(), kr, "https://cdn.example.com/other-3.1.0.tgz", "https://cdn.example.com/other-3.1.0.tgz.sig")
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), b)
+
+	// Files must be signed with the keyring.
+	_, err = source.DownloadAndVerify(context.Background(), utils.MakeKeyRing(t), Host+"/bridge/bridge-3.1.0.tgz", Host+"/bridge/bridge-3.1.0.tgz.sig")
+	require.Error(t, err)
+}
+
+func TestSource_Mirror(t *testing.T) {
+	dir := t.TempDir()
+	kr := utils.MakeKeyRing(t)
+
+	writeSignedFile(t, kr, filepath.Join(dir, "bridge", "bridge-3.1.0.tgz"), []byte("package"))
+
+	mirror := httptest.NewServer(http.StripPrefix("/mirror", http.FileServer(http.Dir(dir))))
+	defer mirror.Close()
+
+	source, err := NewSource(mirror.URL + "/mirror/")
+	require.NoError(t, err)
+
+	b, err := source.DownloadAndVerify(context.Background(), kr, Host+"/bridge/bridge-3.1.0.tgz", Host+"/bridge/bridge-3.1.0.tgz.sig")
+	require.NoError(t, err)
+	require.Equal(t, []byte("package"), b)
+
+	_, err = source.DownloadAndVerify(context.Background(), kr, Host+"/bridge/missing.tgz", Host+"/bridge/missing.tgz.sig")
+	require.Error(t, err)
+}
+
+func TestSource_Invalid(t *testing.T) {
+	for _, location := range []string{"", "relative/dir"} {
+		_, err := NewSource(location)
+		require.ErrorIs(t, err, ErrInvalidSource, location)
+	}
+}
+
+func TestUpdater_AllowedVersions(t *testing.T) {
+	dir := t.TempDir()
+	kr := utils.MakeKeyRing(t)
+
+	writeSignedFile(t, kr, filepath.Join(dir, "bridge", "linux", "x86", "v1", "version.json"), []byte(`{"Releases":[
+		{"CategoryName":"Stable","Version":"4.0.0"},
+		{"CategoryName":"Stable","Version":"3.2.0"},
+		{"CategoryName":"Stable","Version":"3.1.0"}
+	]}`))
+
+	source, err := NewSource(dir)
+	require.NoError(t, err)
+
+	allowedVersions, err := semver.NewConstraint("3.x")
+	require.NoError(t, err)
+
+	u := NewUpdater(versioner.New(t.TempDir()), kr, "bridge", "linux")
+	u.SetSource(source)
+	u.SetAllowedVersions(allowedVersions)
+
+	// The downloader passed by the caller is replaced by the source.
+	info, err := u.GetVersionInfo(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, info.Releases, 2)
+	require.Equal(t, semver.MustParse("3.2.0"), info.Releases[0].Version)
+
+	err = u.InstallUpdate(context.Background(), nil, Release{
+		Version: semver.MustParse("4.0.0"),
+		File:    []File{{URL: Host + "/bridge/bridge-4.0.0.tgz", Identifier: PackageIdentifier}},
+	})
+	require.ErrorIs(t, err, ErrVersionNotAllowed)
+}
+
+func TestUpdater_AllowedVersionsLegacy(t *testing.T) {
+	dir := t.TempDir()
+	kr := utils.MakeKeyRing(t)
+
+	writeSignedFile(t, kr, filepath.Join(dir, "bridge", "version_linux.json"), []byte(`{
+		"stable":{"Version":"3.2.0"},
+		"early":{"Version":"4.0.0"}
+	}`))
+
+	source, err := NewSource(dir)
+	require.NoError(t, err)
+
+	allowedVersions, err := semver.NewConstraint("3.x")
+	require.NoError(t, err)
+
+	u := NewUpdater(versioner.New(t.TempDir()), kr, "bridge", "linux")
+	u.SetSource(source)
+	u.SetAllowedVersions(allowedVersions)
+
+	// Early access users get the newest allowed version rather than an error.
+	info, err := u.GetVersionInfoLegacy(context.Background(), nil, EarlyChannel)
+	require.NoError(t, err)
+	require.Equal(t, semver.MustParse("3.2.0"), info.Version)
+
+	info, err = u.GetVersionInfoLegacy(context.Background(), nil, StableChannel)
+	require.NoError(t, err)
+	require.Equal(t, semver.MustParse("3.2.0"), info.Version)
+
+	// There is no allowed version when the constraint excludes all of them.
+	allowedVersions, err = semver.NewConstraint("5.x")
+	require.NoError(t, err)
+
+	u.SetAllowedVersions(allowedVersions)
+
+	_, err = u.GetVersionInfoLegacy(context.Background(), nil, EarlyChannel)
+	require.ErrorIs(t, err, ErrVersionNotAllowed)
+}
+
+func writeSignedFile(t *testing.T, kr *crypto.KeyRing, path string, content []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	sig, err := kr.SignDetached(crypto.NewPlainMessage(content))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+".sig", sig.GetBinary(), 0o600))
+}
@@ -41,6 +41,7 @@ var (
 	ErrUpdateAlreadyInstalled      = errors.New("update is already installed")
 	ErrVersionFileDownloadOrVerify = errors.New("failed to download or verify the version file")
 	ErrReleaseUpdatePackageMissing = errors.New("release update package is missing")
+	ErrVersionNotAllowed           = errors.New("the version is not allowed by the update policy")
 )
 
 type Downloader interface {
@@ -59,6 +60,12 @@ type Updater struct {
 	product   string
 	platform  string
 	version   uint
+
+	// source replaces the downloader passed by callers, if set.
+	source Downloader
+
+	// allowedVersions filters out the releases that are not allowed, if set.
+	allowedVersions *semver.Constraints
 }
 
 func NewUpdater(ver *versioner.Versioner, verifier *crypto.KeyRing, product, platform string) *Updater {
@@ -73,7 +80,7 @@ func NewUpdater(ver *versioner.Versioner, verifier *crypto.KeyRing, product, pla
 }
 
 func (u *Updater) GetVersionInfoLegacy(ctx context.Context, downloader Downloader, channel Channel) (VersionInfoLegacy, error) {
-	b, err := downloader.DownloadAndVerify(
+	b, err := u.getDownloader(downloader).DownloadAndVerify(
 		ctx,
 		u.verifier,
 		u.getVersionFileURLLegacy(),
@@ -89,16 +96,50 @@ func (u *Updater) GetVersionInfoLegacy(ctx context.Context, downloader Downloade
 		return VersionInfoLegacy{}, err
 	}
 
-	version, ok := versionMap[channel]
-	if !ok {
+	if _, ok := versionMap[channel]; !ok {
 		return VersionInfoLegacy{}, errors.New("no updates available for this channel")
 	}
 
-	return version, nil
+	return u.getNewestAllowedLegacy(versionMap, channel)
+}
+
+// getNewestAllowedLegacy returns the newest version offered on the given channel that is allowed by the update policy.
+// The legacy version file only lists the latest version of each channel, so when the one of the given channel isn't
+// allowed, the versions of the channels it includes are considered: early access users also get stable releases.
+func (u *Updater) getNewestAllowedLegacy(versionMap VersionMap, channel Channel) (VersionInfoLegacy, error) {
+	channels := []Channel{channel}
+
+	if channel == EarlyChannel {
+		channels = append(channels, StableChannel)
+	}
+
+	var newest VersionInfoLegacy
+
+	for _, channel := range channels {
+		version, ok := versionMap[channel]
+		if !ok || version.Version == nil {
+			continue
+		}
+
+		if !u.isAllowed(version.Version) {
+			logrus.WithField("version", version.Version).Info("Ignoring release not allowed by the update policy")
+			continue
+		}
+
+		if newest.Version == nil || version.Version.GreaterThan(newest.Version) {
+			newest = version
+		}
+	}
+
+	if newest.Version == nil {
+		return VersionInfoLegacy{}, fmt.Errorf("%w: %v", ErrVersionNotAllowed, versionMap[channel].Version)
+	}
+
+	return newest, nil
 }
 
 func (u *Updater) GetVersionInfo(ctx context.Context, downloader Downloader) (VersionInfo, error) {
-	b, err := downloader.DownloadAndVerify(
+	b, err := u.getDownloader(downloader).DownloadAndVerify(
 		ctx,
 		u.verifier,
 		u.getVersionFileURL(),
@@ -114,6 +155,19 @@ func (u *Updater) GetVersionInfo(ctx context.Context, downloader Downloader) (Ve
 		return VersionInfo{}, err
 	}
 
+	allowed := releases.Releases[:0]
+
+	for _, release := range releases.Releases {
+		if !u.isAllowed(release.Version) {
+			logrus.WithField("version", release.Version).Info("Ignoring release not allowed by the update policy")
+			continue
+		}
+
+		allowed = append(allowed, release)
+	}
+
+	releases.Releases = allowed
+
 	return releases, nil
 }
 
@@ -122,7 +176,11 @@ func (u *Updater) InstallUpdateLegacy(ctx context.Context, downloader Downloader
 		return ErrUpdateAlreadyInstalled
 	}
 
-	b, err := downloader.DownloadAndVerify(
+	if !u.isAllowed(update.Version) {
+		return fmt.Errorf("%w: %v", ErrVersionNotAllowed, update.Version)
+	}
+
+	b, err := u.getDownloader(downloader).DownloadAndVerify(
 		ctx,
 		u.verifier,
 		update.Package,
@@ -159,7 +217,11 @@ func (u *Updater) InstallUpdate(ctx context.Context, downloader Downloader, rele
 
 	releaseUpdatePackage := release.File[idx]
 
-	b, err := downloader.DownloadAndVerify(
+	if !u.isAllowed(release.Version) {
+		return fmt.Errorf("%w: %v", ErrVersionNotAllowed, release.Version)
+	}
+
+	b, err := u.getDownloader(downloader).DownloadAndVerify(
 		ctx,
 		u.verifier,
 		releaseUpdatePackage.URL,
@@ -181,6 +243,39 @@ func (u *Updater) RemoveOldUpdates() error {
 	return u.versioner.RemoveOldVersions()
 }
 
+// SetSource makes the updater fetch updates from the given source instead of the downloader passed by callers.
+func (u *Updater) SetSource(source Downloader) {
+	u.source = source
+}
+
+// SetAllowedVersions makes the updater ignore the releases that don't satisfy the given constraint.
+func (u *Updater) SetAllowedVersions(allowedVersions *semver.Constraints) {
+	u.allowedVersions = allowedVersions
+}
+
+func (u *Updater) getDownloader(downloader Downloader) Downloader {
+	if u.source != nil {
+		return u.source
+	}
+
+	return downloader
+}
+
+func (u *Updater) isAllowed(version *semver.Version) bool {
+	return u.allowedVersions == nil || version == nil || u.allowedVersions.Check(version)
+}
+
+// RecordCleanExit records that the given version exited normally before being verified, so that its start isn't
+// counted as failed.
+func (u *Updater) RecordCleanExit(version *semver.Version) error {
+	return u.versioner.RecordCleanExit(version)
+}
+
+// MarkVersionVerified records that the given version started successfully, so that it is kept for rollback.
+func (u *Updater) MarkVersionVerified(version *semver.Version) error {
+	return u.versioner.MarkVerified(version)
+}
+
 // getVersionFileURLLegacy returns the URL of the version file.
 // For example:
 //   - https://protonmail.com/download/bridge/version_linux.json
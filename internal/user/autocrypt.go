@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetAutocryptMode returns the Autocrypt mode used when sending the user's mail.
+func (user *User) GetAutocryptMode() vault.AutocryptMode {
+	return user.vault.GetAutocryptMode()
+}
+
+// SetAutocryptMode sets the Autocrypt mode used when sending the user's mail.
+func (user *User) SetAutocryptMode(mode vault.AutocryptMode) error {
+	user.log.WithField("mode", mode).Info("Setting Autocrypt mode")
+
+	return user.vault.SetAutocryptMode(mode)
+}
+
+// GetAutocryptCollect returns whether peer keys are collected from the Autocrypt headers of received mail.
+func (user *User) GetAutocryptCollect() bool {
+	return user.vault.GetAutocryptCollect()
+}
+
+// SetAutocryptCollect sets whether peer keys are collected from the Autocrypt headers of received mail.
+func (user *User) SetAutocryptCollect(collect bool) error {
+	user.log.WithField("collect", collect).Info("Setting Autocrypt key collection")
+
+	return user.vault.SetAutocryptCollect(collect)
+}
+
+// GetAutocryptPeers returns the peer keys collected from received mail.
+func (user *User) GetAutocryptPeers() []vault.AutocryptPeer {
+	return user.vault.GetAutocryptPeers()
+}
+
+// RemoveAutocryptPeer forgets the collected key of the given peer.
+func (user *User) RemoveAutocryptPeer(email string) error {
+	if _, ok := user.vault.GetAutocryptPeer(email); !ok {
+		return vault.ErrNoSuchAutocryptPeer
+	}
+
+	user.log.WithField("email", logging.Sensitive(email)).Info("Removing Autocrypt peer")
+
+	return user.vault.RemoveAutocryptPeer(email)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetContactKeyPolicy returns the policy applied when the key of one of the user's recipients changes.
+func (user *User) GetContactKeyPolicy() vault.ContactKeyPolicy {
+	return user.vault.GetContactKeyPolicy()
+}
+
+// SetContactKeyPolicy sets the policy applied when the key of one of the user's recipients changes.
+func (user *User) SetContactKeyPolicy(policy vault.ContactKeyPolicy) error {
+	user.log.WithField("policy", policy).Info("Setting contact key policy")
+
+	return user.vault.SetContactKeyPolicy(policy)
+}
+
+// GetContactKeys returns the key fingerprints recorded for the user's recipients.
+func (user *User) GetContactKeys() []vault.ContactKey {
+	return user.vault.GetContactKeys()
+}
+
+// ApproveContactKeyChange trusts the new key of the given recipient.
+func (user *User) ApproveContactKeyChange(email string) error {
+	user.log.WithField("email", logging.Sensitive(email)).Info("Approving contact key change")
+
+	return user.vault.ApproveContactKeyChange(email)
+}
+
+// RemoveContactKey forgets the key recorded for the given recipient.
+func (user *User) RemoveContactKey(email string) error {
+	user.log.WithField("email", logging.Sensitive(email)).Info("Removing contact key")
+
+	return user.vault.RemoveContactKey(email)
+}
@@ -80,7 +80,7 @@ func (apm DiagnosticMetadata) BuildMailboxToMessageMap(ctx context.Context, user
 	}
 
 	getAccount := func(addrID string) (AccountMailboxMap, bool) {
-		if mode == vault.CombinedMode {
+		if mode != vault.SplitMode {
 			addrID = primaryAddrID.ID
 		}
 
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+)
+
+// ImageProxy serves the remote images of messages on localhost.
+type ImageProxy interface {
+	// URL returns the proxy URL of the given remote image for the given user.
+	URL(userID, remote string) string
+}
+
+// GetUseImageProxy returns whether the remote images of the user's HTML messages are loaded through the image proxy.
+func (user *User) GetUseImageProxy() bool {
+	return user.vault.GetUseImageProxy()
+}
+
+// SetUseImageProxy sets whether the remote images of the user's HTML messages are loaded through the image proxy.
+func (user *User) SetUseImageProxy(ctx context.Context, useProxy bool) error {
+	user.log.WithField("useProxy", useProxy).Info("Setting image proxy use")
+
+	return user.setMessageOption(ctx, "image proxy use", func() error {
+		return user.vault.SetUseImageProxy(useProxy)
+	})
+}
+
+// GetRemoteContentURL returns the function rewriting remote image URLs of the user's messages to the image proxy,
+// or nil if the user doesn't use it.
+func (user *User) GetRemoteContentURL() func(string) string {
+	if user.imageProxy == nil || !user.vault.GetUseImageProxy() {
+		return nil
+	}
+
+	return func(remote string) string {
+		return user.imageProxy.URL(user.id, remote)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/attachmentstore"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+)
+
+// GetLargeAttachments returns the settings of the user's attachments too large to be sent with a message.
+func (user *User) GetLargeAttachments() vault.LargeAttachmentSettings {
+	return user.vault.GetLargeAttachments()
+}
+
+// SetLargeAttachments sets the settings of the user's attachments too large to be sent with a message.
+func (user *User) SetLargeAttachments(settings vault.LargeAttachmentSettings) error {
+	user.log.WithField("enabled", settings.Enabled).WithField("threshold", settings.Threshold).Info("Setting large attachments")
+
+	if err := user.vault.SetLargeAttachments(settings); err != nil {
+		return fmt.Errorf("failed to set large attachments: %w", err)
+	}
+
+	return nil
+}
+
+// GetLargeAttachmentStore returns the store the user's large attachments are uploaded to when sending.
+func (user *User) GetLargeAttachmentStore() (attachmentstore.Store, int64, bool) {
+	settings := user.vault.GetLargeAttachments()
+	if !settings.Enabled {
+		return nil, 0, false
+	}
+
+	// Settings saved before a base URL was required would only produce file links, which recipients can't open.
+	if settings.BaseURL == "" {
+		user.log.Warn("Large attachments are enabled without a base URL, sending attachments with the message")
+		return nil, 0, false
+	}
+
+	return attachmentstore.NewLocalDir(settings.Dir, settings.BaseURL), settings.Threshold, true
+}
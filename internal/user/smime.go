@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/logging"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+// GetSMIMEIdentities returns the S/MIME identities used to sign the user's mail.
+func (user *User) GetSMIMEIdentities() []vault.SMIMEIdentity {
+	return user.vault.GetSMIMEIdentities()
+}
+
+// ImportSMIMEIdentity imports the PEM-encoded S/MIME certificate chain and private key.
+// The identity is used for each of the user's addresses the certificate is issued for, which are returned.
+func (user *User) ImportSMIMEIdentity(certPEM, keyPEM []byte) ([]string, error) {
+	id, err := smime.ParseIdentity(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S/MIME identity: %w", err)
+	}
+
+	emails := xslices.Filter(user.Emails(), id.HasEmail)
+	if len(emails) == 0 {
+		return nil, ErrNoSuchAddress
+	}
+
+	for _, email := range emails {
+		user.log.WithField("email", logging.Sensitive(email)).Info("Importing S/MIME identity")
+
+		if err := user.vault.SetSMIMEIdentity(email, certPEM, keyPEM); err != nil {
+			return nil, err
+		}
+	}
+
+	return emails, nil
+}
+
+// RemoveSMIMEIdentity removes the S/MIME identity of the given address.
+func (user *User) RemoveSMIMEIdentity(email string) error {
+	user.log.WithField("email", logging.Sensitive(email)).Info("Removing S/MIME identity")
+
+	return user.vault.RemoveSMIMEIdentity(email)
+}
+
+// GetSMIMERecipients returns the external recipients who get S/MIME signed mail from the user.
+func (user *User) GetSMIMERecipients() []string {
+	return user.vault.GetSMIMERecipients()
+}
+
+// AddSMIMERecipient adds the given recipient to the ones who get S/MIME signed mail from the user.
+func (user *User) AddSMIMERecipient(email string) error {
+	user.log.WithField("email", logging.Sensitive(email)).Info("Adding S/MIME recipient")
+
+	return user.vault.AddSMIMERecipient(email)
+}
+
+// RemoveSMIMERecipient removes the given recipient from the ones who get S/MIME signed mail from the user.
+func (user *User) RemoveSMIMERecipient(email string) error {
+	user.log.WithField("email", logging.Sensitive(email)).Info("Removing S/MIME recipient")
+
+	return user.vault.RemoveSMIMERecipient(email)
+}
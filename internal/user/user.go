@@ -27,8 +27,10 @@ import (
 	"github.com/ProtonMail/gluon/reporter"
 	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/safe"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/imapservice"
+	"github.com/ProtonMail/proton-bridge/v3/internal/services/newmail"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/notifications"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/orderedtasks"
@@ -86,9 +88,14 @@ type User struct {
 	imapService         *imapservice.Service
 	telemetryService    *telemetryservice.Service
 	notificationService *notifications.Service
+	newMailService      *newmail.Service
 
 	observabilityService *observability.Service
 
+	imageProxy ImageProxy
+
+	rateLimiter *ratelimit.Limiter
+
 	serviceGroup *orderedtasks.OrderedCancelGroup
 }
 
@@ -110,6 +117,9 @@ func New(
 	syncConfigDir string,
 	isNew bool,
 	notificationStore *notifications.Store,
+	imageProxy ImageProxy,
+	auditRecorder imapservice.AuditRecorder,
+	rateLimiter *ratelimit.Limiter,
 	featureFlagValueProvider unleash.FeatureFlagValueProvider,
 ) (*User, error) {
 	user, err := newImpl(
@@ -130,6 +140,9 @@ func New(
 		syncConfigDir,
 		isNew,
 		notificationStore,
+		imageProxy,
+		auditRecorder,
+		rateLimiter,
 		featureFlagValueProvider,
 	)
 	if err != nil {
@@ -163,6 +176,9 @@ func newImpl(
 	syncConfigDir string,
 	isNew bool,
 	notificationStore *notifications.Store,
+	imageProxy ImageProxy,
+	auditRecorder imapservice.AuditRecorder,
+	rateLimiter *ratelimit.Limiter,
 	featureFlagValueProvider unleash.FeatureFlagValueProvider,
 ) (*User, error) {
 	logrus.WithField("userID", apiUser.ID).Info("Creating new user")
@@ -229,6 +245,10 @@ func newImpl(
 		smtpService:  nil,
 
 		observabilityService: observabilityService,
+
+		imageProxy: imageProxy,
+
+		rateLimiter: rateLimiter,
 	}
 
 	user.eventService = userevents.NewService(
@@ -258,12 +278,19 @@ func newImpl(
 		reporter,
 		encVault,
 		encVault,
+		encVault,
+		encVault,
+		encVault,
+		user,
+		user,
 		user.eventService,
+		user,
 		addressMode,
 		identityState.Clone(),
 		smtpServerManager,
 		observabilityService,
 		imapServerManager,
+		rateLimiter,
 		featureFlagValueProvider,
 	)
 
@@ -276,6 +303,8 @@ func newImpl(
 		user,
 		encVault,
 		encVault,
+		encVault,
+		user,
 		crashHandler,
 		sendRecorder,
 		reporter,
@@ -285,11 +314,15 @@ func newImpl(
 		user.maxSyncMemory,
 		showAllMail,
 		observabilityService,
+		auditRecorder,
+		rateLimiter,
 		featureFlagValueProvider,
 	)
 
 	user.notificationService = notifications.NewService(user.id, user.eventService, user, notificationStore, featureFlagValueProvider, observabilityService)
 
+	user.newMailService = newmail.NewService(user.id, user.eventService, user, user.imapService)
+
 	// When we receive an auth object, we update it in the vault.
 	// This will be used to authorize the user on the next run.
 	user.client.AddAuthHandler(func(auth proton.Auth) {
@@ -338,6 +371,9 @@ func newImpl(
 	// Start Notification service
 	user.notificationService.Start(ctx, user.serviceGroup)
 
+	// Start New Mail service
+	user.newMailService.Start(ctx, user.serviceGroup)
+
 	// Start SMTP Service
 	if err := user.smtpService.Start(ctx, user.serviceGroup); err != nil {
 		return user, fmt.Errorf("failed to start smtp service: %w", err)
@@ -456,6 +492,76 @@ func (user *User) SetAddressMode(ctx context.Context, mode vault.AddressMode) er
 	return nil
 }
 
+// GetMessageProfile returns the profile used to build the user's messages.
+func (user *User) GetMessageProfile() vault.MessageProfile {
+	return user.vault.GetMessageProfile()
+}
+
+// SetMessageProfile sets the profile used to build the user's messages.
+func (user *User) SetMessageProfile(ctx context.Context, profile vault.MessageProfile) error {
+	user.log.WithField("profile", profile).Info("Setting message profile")
+
+	return user.setMessageOption(ctx, "message profile", func() error {
+		return user.vault.SetMessageProfile(profile)
+	})
+}
+
+// GetUnwrapPGPMIME returns whether the user's PGP/MIME messages are built as their decrypted MIME tree.
+func (user *User) GetUnwrapPGPMIME() bool {
+	return user.vault.GetUnwrapPGPMIME()
+}
+
+// SetUnwrapPGPMIME sets whether the user's PGP/MIME messages are built as their decrypted MIME tree.
+func (user *User) SetUnwrapPGPMIME(ctx context.Context, unwrap bool) error {
+	user.log.WithField("unwrap", unwrap).Info("Setting PGP/MIME unwrapping")
+
+	return user.setMessageOption(ctx, "PGP/MIME unwrapping", func() error {
+		return user.vault.SetUnwrapPGPMIME(unwrap)
+	})
+}
+
+// GetTrustedAuthServIDs returns the servers whose Authentication-Results headers are trusted in the user's messages.
+func (user *User) GetTrustedAuthServIDs() []string {
+	return user.vault.GetTrustedAuthServIDs()
+}
+
+// SetTrustedAuthServIDs sets the servers whose Authentication-Results headers are trusted in the user's messages.
+func (user *User) SetTrustedAuthServIDs(ctx context.Context, servIDs []string) error {
+	user.log.WithField("servIDs", servIDs).Info("Setting trusted authentication servers")
+
+	return user.setMessageOption(ctx, "trusted authentication servers", func() error {
+		return user.vault.SetTrustedAuthServIDs(servIDs)
+	})
+}
+
+// GetBlockRemoteContent returns whether remote images and CSS are removed from the user's HTML messages.
+func (user *User) GetBlockRemoteContent() bool {
+	return user.vault.GetBlockRemoteContent()
+}
+
+// SetBlockRemoteContent sets whether remote images and CSS are removed from the user's HTML messages.
+func (user *User) SetBlockRemoteContent(ctx context.Context, block bool) error {
+	user.log.WithField("block", block).Info("Setting remote content blocking")
+
+	return user.setMessageOption(ctx, "remote content blocking", func() error {
+		return user.vault.SetBlockRemoteContent(block)
+	})
+}
+
+// setMessageOption stores an option the user's messages are built with, using the given function.
+// Messages already cached by the IMAP server are rebuilt in the background.
+func (user *User) setMessageOption(ctx context.Context, name string, set func() error) error {
+	if err := set(); err != nil {
+		return fmt.Errorf("failed to set %v: %w", name, err)
+	}
+
+	if err := user.imapService.RebuildMessages(ctx); err != nil {
+		return fmt.Errorf("failed to rebuild messages: %w", err)
+	}
+
+	return nil
+}
+
 // BadEventFeedbackResync sends user feedback whether should do message re-sync.
 func (user *User) BadEventFeedbackResync(ctx context.Context) error {
 	if err := user.imapService.OnBadEventResync(ctx); err != nil {
@@ -497,6 +603,11 @@ func (user *User) SetShowAllMail(show bool) {
 	}
 }
 
+// GetTemplatesLabelID returns the ID of the label of the user's templates, or false if no template was saved yet.
+func (user *User) GetTemplatesLabelID() (string, bool) {
+	return user.imapService.GetTemplatesLabelID()
+}
+
 // GetGluonIDs returns the users gluon IDs.
 func (user *User) GetGluonIDs() map[string]string {
 	return user.vault.GetGluonIDs()
@@ -508,12 +619,12 @@ func (user *User) GetGluonID(addrID string) (string, bool) {
 		return gluonID, true
 	}
 
-	if user.vault.AddressMode() != vault.CombinedMode {
+	if user.vault.AddressMode() == vault.SplitMode {
 		return "", false
 	}
 
 	// If there is only one address, return its gluon ID.
-	// This can happen if we are in combined mode and the primary address ID has changed.
+	// This can happen if we are in combined or hybrid mode and the primary address ID has changed.
 	if gluonIDs := maps.Values(user.vault.GetGluonIDs()); len(gluonIDs) == 1 {
 		if err := user.vault.SetGluonID(addrID, gluonIDs[0]); err != nil {
 			user.log.WithError(err).Error("Failed to set gluon ID for updated primary address")
@@ -586,6 +697,16 @@ func (user *User) GetEventCh() <-chan events.Event {
 	return user.eventCh.GetChannel()
 }
 
+// GetLastEventPollTime returns when the user's event loop last caught up with the API's events.
+func (user *User) GetLastEventPollTime() time.Time {
+	return user.eventService.GetLastPollTime()
+}
+
+// GetRateLimiter returns the limiter of the API requests caused by the user's clients.
+func (user *User) GetRateLimiter() *ratelimit.Limiter {
+	return user.rateLimiter
+}
+
 // CheckAuth returns whether the given email and password can be used to authenticate over IMAP or SMTP with this user.
 // It returns the address ID of the authenticated address.
 func (user *User) CheckAuth(email string, password []byte) (string, error) {
@@ -174,6 +174,9 @@ func withUser(tb testing.TB, ctx context.Context, _ *server.Server, m *proton.Ma
 		notifications.NewStore(func() (string, error) {
 			return "", nil
 		}),
+		nil,
+		nil,
+		nil,
 		nullUnleashService,
 	)
 	require.NoError(tb, err)
@@ -24,14 +24,25 @@ type AddressMode int
 const (
 	AddressModeCombined AddressMode = iota
 	AddressModeSplit
+
+	// AddressModeHybrid behaves like AddressModeCombined but also exposes per-address views of the system mailboxes.
+	AddressModeHybrid
 )
 
 func VaultToAddressMode(mode vault.AddressMode) AddressMode {
 	var smtpAddressMode AddressMode
 
-	if mode == vault.SplitMode {
+	switch mode {
+	case vault.SplitMode:
 		smtpAddressMode = AddressModeSplit
-	} else {
+
+	case vault.HybridMode:
+		smtpAddressMode = AddressModeHybrid
+
+	case vault.CombinedMode:
+		fallthrough
+
+	default:
 		smtpAddressMode = AddressModeCombined
 	}
 
@@ -39,9 +50,17 @@ func VaultToAddressMode(mode vault.AddressMode) AddressMode {
 }
 
 func AddressModeToVault(mode AddressMode) vault.AddressMode {
-	if mode == AddressModeCombined {
+	switch mode {
+	case AddressModeCombined:
 		return vault.CombinedMode
-	}
 
-	return vault.SplitMode
+	case AddressModeHybrid:
+		return vault.HybridMode
+
+	case AddressModeSplit:
+		fallthrough
+
+	default:
+		return vault.SplitMode
+	}
 }
@@ -0,0 +1,29 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package usertypes
+
+import "github.com/ProtonMail/go-proton-api"
+
+// TemplatesLabelName is the name of the label marking the drafts used as message templates.
+const TemplatesLabelName = "Bridge Templates"
+
+// IsTemplatesLabel returns whether the given label marks message templates.
+// A label renamed by the user no longer marks templates and is listed like any other label.
+func IsTemplatesLabel(label proton.Label) bool {
+	return label.Type == proton.LabelTypeLabel && label.ParentID == "" && label.Name == TemplatesLabelName
+}
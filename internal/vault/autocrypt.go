@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrNoSuchAutocryptPeer = errors.New("no such Autocrypt peer")
+
+// GetAutocryptMode returns whether the user's outgoing mail carries an Autocrypt header.
+func (user *User) GetAutocryptMode() AutocryptMode {
+	return user.vault.getUser(user.userID).AutocryptMode
+}
+
+// SetAutocryptMode sets whether the user's outgoing mail carries an Autocrypt header.
+func (user *User) SetAutocryptMode(mode AutocryptMode) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.AutocryptMode = mode
+	})
+}
+
+// GetAutocryptCollect returns whether peer keys are collected from the Autocrypt headers of received mail.
+func (user *User) GetAutocryptCollect() bool {
+	return user.vault.getUser(user.userID).AutocryptCollect
+}
+
+// SetAutocryptCollect sets whether peer keys are collected from the Autocrypt headers of received mail.
+// Disabling the collection forgets the keys collected so far.
+func (user *User) SetAutocryptCollect(collect bool) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.AutocryptCollect = collect
+
+		if !collect {
+			data.AutocryptPeers = nil
+		}
+	})
+}
+
+// GetAutocryptPeers returns the collected peer keys.
+func (user *User) GetAutocryptPeers() []AutocryptPeer {
+	return slices.Clone(user.vault.getUser(user.userID).AutocryptPeers)
+}
+
+// GetAutocryptPeer returns the collected key of the given peer.
+func (user *User) GetAutocryptPeer(email string) (AutocryptPeer, bool) {
+	peers := user.vault.getUser(user.userID).AutocryptPeers
+
+	idx := indexAutocryptPeer(peers, email)
+	if idx < 0 {
+		return AutocryptPeer{}, false
+	}
+
+	return peers[idx], true
+}
+
+// UpdateAutocryptPeer records the key of the given peer, unless a key was already taken from a more recent message.
+func (user *User) UpdateAutocryptPeer(email string, keyData []byte, preferEncrypt bool, timestamp time.Time) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		peer := AutocryptPeer{
+			Email:         strings.ToLower(email),
+			KeyData:       keyData,
+			PreferEncrypt: preferEncrypt,
+			Timestamp:     timestamp,
+		}
+
+		if idx := indexAutocryptPeer(data.AutocryptPeers, email); idx < 0 {
+			data.AutocryptPeers = append(data.AutocryptPeers, peer)
+		} else if timestamp.After(data.AutocryptPeers[idx].Timestamp) {
+			data.AutocryptPeers[idx] = peer
+		}
+	})
+}
+
+// RemoveAutocryptPeer forgets the collected key of the given peer.
+func (user *User) RemoveAutocryptPeer(email string) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.AutocryptPeers = slices.DeleteFunc(data.AutocryptPeers, func(peer AutocryptPeer) bool {
+			return strings.EqualFold(peer.Email, email)
+		})
+	})
+}
+
+func indexAutocryptPeer(peers []AutocryptPeer, email string) int {
+	return slices.IndexFunc(peers, func(peer AutocryptPeer) bool {
+		return strings.EqualFold(peer.Email, email)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_Autocrypt(t *testing.T) {
+	s := newVault(t)
+
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// Autocrypt is off by default.
+	require.Equal(t, vault.AutocryptOff, user.GetAutocryptMode())
+	require.False(t, user.GetAutocryptCollect())
+
+	require.NoError(t, user.SetAutocryptMode(vault.AutocryptMutual))
+	require.Equal(t, vault.AutocryptMutual, user.GetAutocryptMode())
+	require.NoError(t, user.SetAutocryptCollect(true))
+
+	now := time.Now()
+
+	require.NoError(t, user.UpdateAutocryptPeer("Alice@Example.com", []byte("key1"), true, now))
+
+	peer, ok := user.GetAutocryptPeer("alice@example.com")
+	require.True(t, ok)
+	require.Equal(t, []byte("key1"), peer.KeyData)
+	require.True(t, peer.PreferEncrypt)
+
+	// Keys from older messages don't replace the ones from newer messages.
+	require.NoError(t, user.UpdateAutocryptPeer("alice@example.com", []byte("key0"), false, now.Add(-time.Hour)))
+	require.NoError(t, user.UpdateAutocryptPeer("bob@example.com", []byte("key2"), false, now))
+
+	peer, _ = user.GetAutocryptPeer("alice@example.com")
+	require.Equal(t, []byte("key1"), peer.KeyData)
+
+	require.NoError(t, user.UpdateAutocryptPeer("alice@example.com", []byte("key3"), false, now.Add(time.Hour)))
+
+	peer, _ = user.GetAutocryptPeer("alice@example.com")
+	require.Equal(t, []byte("key3"), peer.KeyData)
+	require.False(t, peer.PreferEncrypt)
+
+	require.Len(t, user.GetAutocryptPeers(), 2)
+	require.NoError(t, user.RemoveAutocryptPeer("bob@example.com"))
+	require.Len(t, user.GetAutocryptPeers(), 1)
+
+	// Disabling the collection forgets the collected keys.
+	require.NoError(t, user.SetAutocryptCollect(false))
+	require.Empty(t, user.GetAutocryptPeers())
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/certs"
+)
+
+var ErrNoSuchClientCert = errors.New("no such client certificate")
+
+// GetClientCA returns the PEM-encoded certificate and key of the client CA, generating them if needed.
+func (vault *Vault) GetClientCA() ([]byte, []byte, error) {
+	if ca := vault.getSafe().Certs.ClientCA; len(ca.Cert) > 0 {
+		return ca.Cert, ca.Key, nil
+	}
+
+	template, err := certs.NewClientCATemplate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, keyPEM, err := certs.GenerateCert(template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ca Cert
+
+	// Another caller may have created the CA in the meantime; the first one created is kept.
+	if err := vault.modSafe(func(data *Data) {
+		if len(data.Certs.ClientCA.Cert) == 0 {
+			data.Certs.ClientCA = Cert{Cert: certPEM, Key: keyPEM}
+		}
+
+		ca = data.Certs.ClientCA
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return ca.Cert, ca.Key, nil
+}
+
+// GetClientCerts returns the issued client certificates, including the revoked ones.
+func (vault *Vault) GetClientCerts() []ClientCert {
+	return slices.Clone(vault.getSafe().Certs.ClientCerts)
+}
+
+// GetClientCert returns the issued client certificate with the given serial number.
+func (vault *Vault) GetClientCert(serialNumber string) (ClientCert, bool) {
+	certs := vault.getSafe().Certs.ClientCerts
+
+	idx := slices.IndexFunc(certs, func(cert ClientCert) bool { return cert.SerialNumber == serialNumber })
+	if idx < 0 {
+		return ClientCert{}, false
+	}
+
+	return certs[idx], true
+}
+
+// AddClientCert records a newly issued client certificate.
+func (vault *Vault) AddClientCert(cert ClientCert) error {
+	return vault.modSafe(func(data *Data) {
+		data.Certs.ClientCerts = append(data.Certs.ClientCerts, cert)
+	})
+}
+
+// RevokeClientCert marks the client certificate with the given serial number as revoked.
+func (vault *Vault) RevokeClientCert(serialNumber string) error {
+	if _, ok := vault.GetClientCert(serialNumber); !ok {
+		return ErrNoSuchClientCert
+	}
+
+	return vault.modSafe(func(data *Data) {
+		for idx := range data.Certs.ClientCerts {
+			if data.Certs.ClientCerts[idx].SerialNumber == serialNumber {
+				data.Certs.ClientCerts[idx].Revoked = true
+			}
+		}
+	})
+}
+
+// RevokeUserClientCerts marks all the client certificates issued to the given user as revoked.
+func (vault *Vault) RevokeUserClientCerts(userID string) error {
+	return vault.modSafe(func(data *Data) {
+		for idx := range data.Certs.ClientCerts {
+			if data.Certs.ClientCerts[idx].UserID == userID {
+				data.Certs.ClientCerts[idx].Revoked = true
+			}
+		}
+	})
+}
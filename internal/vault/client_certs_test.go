@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault_ClientCA(t *testing.T) {
+	s := newVault(t)
+
+	// The client CA is generated on first use and then reused.
+	cert, key, err := s.GetClientCA()
+	require.NoError(t, err)
+	require.NotEmpty(t, cert)
+	require.NotEmpty(t, key)
+
+	cert2, key2, err := s.GetClientCA()
+	require.NoError(t, err)
+	require.Equal(t, cert, cert2)
+	require.Equal(t, key, key2)
+}
+
+func TestVault_ClientCA_Concurrent(t *testing.T) {
+	s := newVault(t)
+
+	// Concurrent first uses all get the same CA.
+	certs := make([][]byte, 4)
+
+	var wg sync.WaitGroup
+
+	for i := range certs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			cert, _, err := s.GetClientCA()
+			require.NoError(t, err)
+
+			certs[i] = cert
+		}(i)
+	}
+
+	wg.Wait()
+
+	cert, _, err := s.GetClientCA()
+	require.NoError(t, err)
+
+	for _, other := range certs {
+		require.Equal(t, cert, other)
+	}
+}
+
+func TestVault_ClientCerts(t *testing.T) {
+	s := newVault(t)
+
+	require.Equal(t, vault.ClientCertAuthDisabled, s.GetClientCertAuth())
+	require.NoError(t, s.SetClientCertAuth(vault.ClientCertAuthRequired))
+	require.Equal(t, vault.ClientCertAuthRequired, s.GetClientCertAuth())
+
+	require.NoError(t, s.AddClientCert(vault.ClientCert{SerialNumber: "01", Device: "laptop", UserID: "user1", Scope: vault.ClientCertScopeAll}))
+	require.NoError(t, s.AddClientCert(vault.ClientCert{SerialNumber: "02", Device: "phone", UserID: "user1", Scope: vault.ClientCertScopeIMAP}))
+	require.NoError(t, s.AddClientCert(vault.ClientCert{SerialNumber: "03", Device: "desktop", UserID: "user2", Scope: vault.ClientCertScopeSMTP}))
+	require.Len(t, s.GetClientCerts(), 3)
+
+	cert, ok := s.GetClientCert("02")
+	require.True(t, ok)
+	require.Equal(t, "phone", cert.Device)
+	require.True(t, cert.Scope.Has(vault.ClientCertScopeIMAP))
+	require.False(t, cert.Scope.Has(vault.ClientCertScopeSMTP))
+
+	require.NoError(t, s.RevokeClientCert("01"))
+	require.ErrorIs(t, s.RevokeClientCert("04"), vault.ErrNoSuchClientCert)
+
+	cert, ok = s.GetClientCert("01")
+	require.True(t, ok)
+	require.True(t, cert.Revoked)
+
+	require.NoError(t, s.RevokeUserClientCerts("user1"))
+
+	cert, _ = s.GetClientCert("02")
+	require.True(t, cert.Revoked)
+
+	cert, _ = s.GetClientCert("03")
+	require.False(t, cert.Revoked)
+}
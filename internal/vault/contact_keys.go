@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoSuchContactKey    = errors.New("no such contact key")
+	ErrNoPendingContactKey = errors.New("no pending contact key change")
+)
+
+// GetContactKeyPolicy returns the policy applied when a recipient's key changes.
+func (user *User) GetContactKeyPolicy() ContactKeyPolicy {
+	return user.vault.getUser(user.userID).ContactKeyPolicy
+}
+
+// SetContactKeyPolicy sets the policy applied when a recipient's key changes.
+func (user *User) SetContactKeyPolicy(policy ContactKeyPolicy) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.ContactKeyPolicy = policy
+	})
+}
+
+// GetContactKeys returns the key fingerprints recorded for the user's recipients.
+func (user *User) GetContactKeys() []ContactKey {
+	return slices.Clone(user.vault.getUser(user.userID).ContactKeys)
+}
+
+// GetContactKey returns the key fingerprint recorded for the given recipient.
+func (user *User) GetContactKey(email string) (ContactKey, bool) {
+	keys := user.vault.getUser(user.userID).ContactKeys
+
+	idx := indexContactKey(keys, email)
+	if idx < 0 {
+		return ContactKey{}, false
+	}
+
+	return keys[idx], true
+}
+
+// TrustContactKey records the given fingerprint as the trusted key of the recipient, discarding any pending change.
+func (user *User) TrustContactKey(email, fingerprint string) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		if idx := indexContactKey(data.ContactKeys, email); idx >= 0 {
+			data.ContactKeys[idx].Fingerprint = fingerprint
+			data.ContactKeys[idx].PendingFingerprint = ""
+		} else {
+			data.ContactKeys = append(data.ContactKeys, ContactKey{
+				Email:       strings.ToLower(email),
+				Fingerprint: fingerprint,
+				FirstSeen:   time.Now(),
+			})
+		}
+	})
+}
+
+// SetContactKeyChanged records that the recipient now presents a key with the given fingerprint.
+// The change stays pending until it is approved with ApproveContactKeyChange.
+func (user *User) SetContactKeyChanged(email, fingerprint string) error {
+	if _, ok := user.GetContactKey(email); !ok {
+		return ErrNoSuchContactKey
+	}
+
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		if idx := indexContactKey(data.ContactKeys, email); idx >= 0 {
+			data.ContactKeys[idx].PendingFingerprint = fingerprint
+			data.ContactKeys[idx].ChangedAt = time.Now()
+		}
+	})
+}
+
+// ApproveContactKeyChange trusts the pending key of the given recipient.
+func (user *User) ApproveContactKeyChange(email string) error {
+	key, ok := user.GetContactKey(email)
+	if !ok {
+		return ErrNoSuchContactKey
+	}
+
+	if !key.HasPendingChange() {
+		return ErrNoPendingContactKey
+	}
+
+	return user.TrustContactKey(email, key.PendingFingerprint)
+}
+
+// RemoveContactKey forgets the key recorded for the given recipient; the next key seen will be trusted.
+func (user *User) RemoveContactKey(email string) error {
+	if _, ok := user.GetContactKey(email); !ok {
+		return ErrNoSuchContactKey
+	}
+
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.ContactKeys = slices.DeleteFunc(data.ContactKeys, func(key ContactKey) bool {
+			return strings.EqualFold(key.Email, email)
+		})
+	})
+}
+
+func indexContactKey(keys []ContactKey, email string) int {
+	return slices.IndexFunc(keys, func(key ContactKey) bool {
+		return strings.EqualFold(key.Email, email)
+	})
+}
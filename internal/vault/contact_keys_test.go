@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ContactKeys(t *testing.T) {
+	s := newVault(t)
+
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// The default policy is to warn about key changes.
+	require.Equal(t, vault.ContactKeyPolicyWarn, user.GetContactKeyPolicy())
+	require.NoError(t, user.SetContactKeyPolicy(vault.ContactKeyPolicyBlock))
+	require.Equal(t, vault.ContactKeyPolicyBlock, user.GetContactKeyPolicy())
+
+	// The first key seen is trusted.
+	require.NoError(t, user.TrustContactKey("Alice@Example.com", "fp1"))
+
+	key, ok := user.GetContactKey("alice@example.com")
+	require.True(t, ok)
+	require.Equal(t, "fp1", key.Fingerprint)
+	require.False(t, key.HasPendingChange())
+
+	// A key change stays pending until approved.
+	require.NoError(t, user.SetContactKeyChanged("alice@example.com", "fp2"))
+	require.ErrorIs(t, user.SetContactKeyChanged("bob@example.com", "fp2"), vault.ErrNoSuchContactKey)
+
+	key, _ = user.GetContactKey("alice@example.com")
+	require.Equal(t, "fp1", key.Fingerprint)
+	require.Equal(t, "fp2", key.PendingFingerprint)
+
+	require.NoError(t, user.ApproveContactKeyChange("alice@example.com"))
+	require.ErrorIs(t, user.ApproveContactKeyChange("alice@example.com"), vault.ErrNoPendingContactKey)
+
+	key, _ = user.GetContactKey("alice@example.com")
+	require.Equal(t, "fp2", key.Fingerprint)
+	require.False(t, key.HasPendingChange())
+
+	require.Len(t, user.GetContactKeys(), 1)
+	require.NoError(t, user.RemoveContactKey("alice@example.com"))
+	require.Empty(t, user.GetContactKeys())
+}
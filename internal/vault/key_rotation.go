@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault/storage"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	keyRotationFileName = "vault_key_rotation.json"
+
+	// pendingVaultSecretName is the keychain entry holding the new vault key until the rotation is committed.
+	pendingVaultSecretName = vaultSecretName + "-pending"
+)
+
+var ErrKeyRotationInProgress = errors.New("a vault key rotation is already in progress")
+
+// KeyRotationState is the rollback file written in the vault directory while the vault key is being rotated.
+// It holds a copy of the vault encrypted with the old key so that an interrupted rotation can always be undone.
+type KeyRotationState struct {
+	OldHelper string // The keychain helper holding the old vault key.
+	NewHelper string // The keychain helper that will hold the new vault key.
+	Vault     []byte // The vault file as it was before the rotation, encrypted with the old key.
+}
+
+var keyRotationFile = storage.NewJSONStorageFile[KeyRotationState](keyRotationFileName, "vault key rotation") //nolint:gochecknoglobals
+
+// RotateKey re-encrypts the vault with a new random key. The new key is stored in newKC and, if the helpers differ,
+// the old key is removed from oldKC. A rollback file is kept in the vault directory until the rotation is committed,
+// so that RecoverKeyRotation can complete or revert a rotation interrupted by a crash.
+func (vault *Vault) RotateKey(oldKC, newKC *keychain.Keychain, oldHelper, newHelper string) error {
+	vault.lock.Lock()
+	defer vault.lock.Unlock()
+
+	vaultDir := filepath.Dir(vault.path)
+
+	log := logrus.WithFields(logrus.Fields{"pkg": "vault", "oldHelper": oldHelper, "newHelper": newHelper})
+
+	if hasKeyRotationState(vaultDir) {
+		return ErrKeyRotationInProgress
+	}
+
+	newKey, err := crypto.RandomToken(32)
+	if err != nil {
+		return fmt.Errorf("could not generate random token: %w", err)
+	}
+
+	gcm, err := newGCM(newKey)
+	if err != nil {
+		return err
+	}
+
+	enc, err := marshalFile(gcm, vault.getUnsafe())
+	if err != nil {
+		return err
+	}
+
+	state := KeyRotationState{
+		OldHelper: oldHelper,
+		NewHelper: newHelper,
+		Vault:     vault.enc,
+	}
+
+	log.Info("Rotating vault key")
+
+	if err := keyRotationFile.Save(vaultDir, state); err != nil {
+		return fmt.Errorf("could not write vault key rotation file: %w", err)
+	}
+
+	if err := newKC.Put(pendingVaultSecretName, base64.StdEncoding.EncodeToString(newKey)); err != nil {
+		removeKeyRotationState(vaultDir)
+		return fmt.Errorf("could not put new vault key in keychain: %w", err)
+	}
+
+	if err := writeVaultFile(vault.path, enc); err != nil {
+		if err := newKC.Delete(pendingVaultSecretName); err != nil {
+			log.WithError(err).Warn("Could not remove pending vault key from keychain")
+		}
+
+		removeKeyRotationState(vaultDir)
+
+		return err
+	}
+
+	vault.gcm = gcm
+	vault.enc = enc
+
+	if err := commitKeyRotation(vaultDir, state, oldKC, newKC, newKey); err != nil {
+		return fmt.Errorf("vault was re-encrypted but the rotation could not be committed: %w", err)
+	}
+
+	log.Info("Vault key rotated")
+
+	return nil
+}
+
+// RecoverKeyRotation completes or reverts a vault key rotation that was interrupted.
+// It does nothing if no rotation was in progress. newKeychain must return the keychain of the given helper.
+func RecoverKeyRotation(vaultDir string, newKeychain func(helper string) (*keychain.Keychain, error)) error {
+	if !hasKeyRotationState(vaultDir) {
+		return nil
+	}
+
+	state, err := keyRotationFile.Load(vaultDir)
+	if err != nil {
+		return err
+	}
+
+	log := logrus.WithFields(logrus.Fields{"pkg": "vault", "oldHelper": state.OldHelper, "newHelper": state.NewHelper})
+
+	log.Warn("Found interrupted vault key rotation")
+
+	oldKC, err := newKeychain(state.OldHelper)
+	if err != nil {
+		return fmt.Errorf("could not open old keychain: %w", err)
+	}
+
+	newKC, err := newKeychain(state.NewHelper)
+	if err != nil {
+		return fmt.Errorf("could not open new keychain: %w", err)
+	}
+
+	vaultPath := filepath.Join(vaultDir, "vault.enc")
+
+	enc, err := os.ReadFile(filepath.Clean(vaultPath))
+	if err != nil {
+		return err
+	}
+
+	newKey, err := getKey(newKC, pendingVaultSecretName)
+	if err != nil && !keychain.IsErrKeychainNoItem(err) {
+		return fmt.Errorf("could not get pending vault key: %w", err)
+	}
+
+	if newKey != nil && canDecrypt(newKey, enc) {
+		log.Info("Vault was re-encrypted with the new key, completing rotation")
+		return commitKeyRotation(vaultDir, state, oldKC, newKC, newKey)
+	}
+
+	log.Info("Vault was not re-encrypted with the new key, rolling back rotation")
+
+	if err := writeVaultFile(vaultPath, state.Vault); err != nil {
+		return err
+	}
+
+	if newKey != nil {
+		if err := newKC.Delete(pendingVaultSecretName); err != nil {
+			log.WithError(err).Warn("Could not remove pending vault key from keychain")
+		}
+	}
+
+	if err := SetHelper(vaultDir, state.OldHelper); err != nil {
+		return err
+	}
+
+	removeKeyRotationState(vaultDir)
+
+	return nil
+}
+
+// commitKeyRotation makes the new key the vault key. Once the new key and helper are stored the rotation is
+// considered complete; the removal of the old and pending keys is best effort.
+func commitKeyRotation(vaultDir string, state KeyRotationState, oldKC, newKC *keychain.Keychain, newKey []byte) error {
+	log := logrus.WithFields(logrus.Fields{"pkg": "vault", "oldHelper": state.OldHelper, "newHelper": state.NewHelper})
+
+	if err := SetVaultKey(newKC, newKey); err != nil {
+		return fmt.Errorf("could not put new vault key in keychain: %w", err)
+	}
+
+	if err := SetHelper(vaultDir, state.NewHelper); err != nil {
+		return fmt.Errorf("could not store new keychain helper: %w", err)
+	}
+
+	removeKeyRotationState(vaultDir)
+
+	if state.OldHelper != state.NewHelper {
+		if err := oldKC.Delete(vaultSecretName); err != nil {
+			log.WithError(err).Warn("Could not remove old vault key from keychain")
+		}
+	}
+
+	if err := newKC.Delete(pendingVaultSecretName); err != nil {
+		log.WithError(err).Warn("Could not remove pending vault key from keychain")
+	}
+
+	return nil
+}
+
+func getKey(kc *keychain.Keychain, secretName string) ([]byte, error) {
+	_, keyEnc, err := kc.Get(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(keyEnc)
+}
+
+func canDecrypt(key, enc []byte) bool {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return false
+	}
+
+	return unmarshalFile(gcm, enc, new(Data)) == nil
+}
+
+func hasKeyRotationState(vaultDir string) bool {
+	_, err := os.Stat(filepath.Join(vaultDir, keyRotationFileName))
+	return err == nil
+}
+
+func removeKeyRotationState(vaultDir string) {
+	if err := os.Remove(filepath.Join(vaultDir, keyRotationFileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.WithField("pkg", "vault").WithError(err).Error("Could not remove vault key rotation file")
+	}
+}
+
+// ResetKey encrypts the vault with a new random key stored in kc and writes it to vault.enc in vaultDir, which becomes
+// the vault path. It is used instead of RotateKey when the old key can't be reached, e.g. the previous keychain is broken
+// or the vault is insecure, so no rollback file is kept and the old key, if any, is left in place.
+func (vault *Vault) ResetKey(vaultDir string, kc *keychain.Keychain, helper string) error {
+	vault.lock.Lock()
+	defer vault.lock.Unlock()
+
+	if hasKeyRotationState(vaultDir) {
+		return ErrKeyRotationInProgress
+	}
+
+	newKey, err := crypto.RandomToken(32)
+	if err != nil {
+		return fmt.Errorf("could not generate random token: %w", err)
+	}
+
+	gcm, err := newGCM(newKey)
+	if err != nil {
+		return err
+	}
+
+	enc, err := marshalFile(gcm, vault.getUnsafe())
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{"pkg": "vault", "newHelper": helper}).Warn("Resetting vault key")
+
+	if err := SetVaultKey(kc, newKey); err != nil {
+		return fmt.Errorf("could not put new vault key in keychain: %w", err)
+	}
+
+	path := filepath.Join(vaultDir, "vault.enc")
+
+	if err := writeVaultFile(path, enc); err != nil {
+		return err
+	}
+
+	vault.path = path
+	vault.gcm = gcm
+	vault.enc = enc
+
+	if err := SetHelper(vaultDir, helper); err != nil {
+		return fmt.Errorf("could not store new keychain helper: %w", err)
+	}
+
+	return nil
+}
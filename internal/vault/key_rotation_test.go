@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault_RotateKey(t *testing.T) {
+	vaultDir, kcs, key := newRotationTestVault(t)
+
+	oldKC, newKC := newRotationTestKeychains(t, kcs)
+
+	s, corrupt, err := New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.NoError(t, s.SetIMAPPort(1234))
+
+	require.NoError(t, s.RotateKey(oldKC, newKC, "old", "new"))
+
+	// The running vault keeps working with the new key.
+	require.NoError(t, s.SetSMTPPort(5678))
+	require.NoError(t, s.Close())
+
+	// The old key is gone and the new helper is remembered.
+	_, err = GetVaultKey(oldKC)
+	require.True(t, keychain.IsErrKeychainNoItem(err))
+
+	helper, err := GetHelper(vaultDir)
+	require.NoError(t, err)
+	require.Equal(t, "new", helper)
+	require.False(t, hasKeyRotationState(vaultDir))
+
+	// The vault can be reopened with the new key.
+	newKey, err := GetVaultKey(newKC)
+	require.NoError(t, err)
+	require.NotEqual(t, key, newKey)
+
+	s, corrupt, err = New(vaultDir, t.TempDir(), newKey, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.Equal(t, 1234, s.GetIMAPPort())
+	require.Equal(t, 5678, s.GetSMTPPort())
+}
+
+func TestVault_RotateKey_SameHelper(t *testing.T) {
+	vaultDir, kcs, key := newRotationTestVault(t)
+
+	oldKC, _ := newRotationTestKeychains(t, kcs)
+
+	s, corrupt, err := New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+
+	require.NoError(t, s.RotateKey(oldKC, oldKC, "old", "old"))
+
+	newKey, err := GetVaultKey(oldKC)
+	require.NoError(t, err)
+	require.NotEqual(t, key, newKey)
+
+	_, _, err = oldKC.Get(pendingVaultSecretName)
+	require.True(t, keychain.IsErrKeychainNoItem(err))
+}
+
+func TestVault_ResetKey_Insecure(t *testing.T) {
+	vaultDir := t.TempDir()
+	kcs := keychain.NewTestKeychainsListWithHelpers("new")
+
+	// An insecure vault has no key and lives in its own directory.
+	s, corrupt, err := New(filepath.Join(vaultDir, "insecure"), t.TempDir(), nil, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.NoError(t, s.SetIMAPPort(1234))
+
+	kc, err := kcs.NewKeychain("new", "bridge-test")
+	require.NoError(t, err)
+
+	require.NoError(t, s.ResetKey(vaultDir, kc, "new"))
+
+	// The running vault is now written to the vault directory.
+	require.NoError(t, s.SetSMTPPort(5678))
+	require.Equal(t, filepath.Join(vaultDir, "vault.enc"), s.Path())
+	require.NoError(t, s.Close())
+
+	helper, err := GetHelper(vaultDir)
+	require.NoError(t, err)
+	require.Equal(t, "new", helper)
+
+	key, err := GetVaultKey(kc)
+	require.NoError(t, err)
+
+	s, corrupt, err = New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.Equal(t, 1234, s.GetIMAPPort())
+	require.Equal(t, 5678, s.GetSMTPPort())
+}
+
+func TestRecoverKeyRotation_RollBack(t *testing.T) {
+	vaultDir, kcs, key := newRotationTestVault(t)
+
+	oldKC, newKC := newRotationTestKeychains(t, kcs)
+
+	// Simulate a crash after the pending key was stored but before the vault was re-encrypted.
+	enc, err := os.ReadFile(filepath.Join(vaultDir, "vault.enc"))
+	require.NoError(t, err)
+	require.NoError(t, keyRotationFile.Save(vaultDir, KeyRotationState{OldHelper: "old", NewHelper: "new", Vault: enc}))
+	require.NoError(t, newKC.Put(pendingVaultSecretName, base64.StdEncoding.EncodeToString([]byte("pending key"))))
+	require.NoError(t, os.WriteFile(filepath.Join(vaultDir, "vault.enc"), []byte("garbage"), 0o600))
+
+	require.NoError(t, RecoverKeyRotation(vaultDir, func(helper string) (*keychain.Keychain, error) {
+		return kcs.NewKeychain(helper, "bridge-test")
+	}))
+
+	require.False(t, hasKeyRotationState(vaultDir))
+
+	_, _, err = newKC.Get(pendingVaultSecretName)
+	require.True(t, keychain.IsErrKeychainNoItem(err))
+
+	helper, err := GetHelper(vaultDir)
+	require.NoError(t, err)
+	require.Equal(t, "old", helper)
+
+	oldKey, err := GetVaultKey(oldKC)
+	require.NoError(t, err)
+	require.Equal(t, key, oldKey)
+
+	_, corrupt, err := New(vaultDir, t.TempDir(), oldKey, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+}
+
+func TestRecoverKeyRotation_RollForward(t *testing.T) {
+	vaultDir, kcs, key := newRotationTestVault(t)
+
+	oldKC, newKC := newRotationTestKeychains(t, kcs)
+
+	s, corrupt, err := New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.NoError(t, s.SetIMAPPort(1234))
+
+	// Simulate a crash after the vault was re-encrypted but before the rotation was committed.
+	newKey := []byte("new key")
+	gcm, err := newGCM(newKey)
+	require.NoError(t, err)
+	enc, err := marshalFile(gcm, s.getSafe())
+	require.NoError(t, err)
+	require.NoError(t, keyRotationFile.Save(vaultDir, KeyRotationState{OldHelper: "old", NewHelper: "new", Vault: s.enc}))
+	require.NoError(t, newKC.Put(pendingVaultSecretName, base64.StdEncoding.EncodeToString(newKey)))
+	require.NoError(t, writeVaultFile(filepath.Join(vaultDir, "vault.enc"), enc))
+
+	require.NoError(t, RecoverKeyRotation(vaultDir, func(helper string) (*keychain.Keychain, error) {
+		return kcs.NewKeychain(helper, "bridge-test")
+	}))
+
+	require.False(t, hasKeyRotationState(vaultDir))
+
+	_, err = GetVaultKey(oldKC)
+	require.True(t, keychain.IsErrKeychainNoItem(err))
+
+	storedKey, err := GetVaultKey(newKC)
+	require.NoError(t, err)
+	require.Equal(t, newKey, storedKey)
+
+	s, corrupt, err = New(vaultDir, t.TempDir(), storedKey, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.Equal(t, 1234, s.GetIMAPPort())
+}
+
+func TestRecoverKeyRotation_NothingToRecover(t *testing.T) {
+	require.NoError(t, RecoverKeyRotation(t.TempDir(), func(string) (*keychain.Keychain, error) {
+		panic("should not be called")
+	}))
+}
+
+func newRotationTestVault(t *testing.T) (string, *keychain.List, []byte) {
+	t.Helper()
+
+	vaultDir := t.TempDir()
+	kcs := keychain.NewTestKeychainsListWithHelpers("old", "new")
+
+	kc, err := kcs.NewKeychain("old", "bridge-test")
+	require.NoError(t, err)
+
+	key, err := NewVaultKey(kc)
+	require.NoError(t, err)
+
+	s, corrupt, err := New(vaultDir, t.TempDir(), key, async.NoopPanicHandler{})
+	require.NoError(t, err)
+	require.NoError(t, corrupt)
+	require.NoError(t, s.Close())
+
+	require.NoError(t, SetHelper(vaultDir, "old"))
+
+	return vaultDir, kcs, key
+}
+
+func newRotationTestKeychains(t *testing.T, kcs *keychain.List) (*keychain.Keychain, *keychain.Keychain) {
+	t.Helper()
+
+	oldKC, err := kcs.NewKeychain("old", "bridge-test")
+	require.NoError(t, err)
+
+	newKC, err := kcs.NewKeychain("new", "bridge-test")
+	require.NoError(t, err)
+
+	return oldKC, newKC
+}
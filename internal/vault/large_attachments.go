@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+// GetLargeAttachments returns the settings of the user's attachments too large to be sent with a message.
+func (user *User) GetLargeAttachments() LargeAttachmentSettings {
+	settings := user.vault.getUser(user.userID).LargeAttachments
+
+	if settings.Threshold == 0 {
+		settings.Threshold = DefaultLargeAttachmentThreshold
+	}
+
+	return settings
+}
+
+// SetLargeAttachments sets the settings of the user's attachments too large to be sent with a message.
+func (user *User) SetLargeAttachments(settings LargeAttachmentSettings) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.LargeAttachments = settings
+	})
+}
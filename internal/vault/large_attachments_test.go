@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_LargeAttachments(t *testing.T) {
+	s := newVault(t)
+
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users don't send attachments as links, but get the default threshold.
+	settings := user.GetLargeAttachments()
+	require.False(t, settings.Enabled)
+	require.Equal(t, int64(vault.DefaultLargeAttachmentThreshold), settings.Threshold)
+
+	require.NoError(t, user.SetLargeAttachments(vault.LargeAttachmentSettings{
+		Enabled:   true,
+		Threshold: 1024,
+		Dir:       "/srv/attachments",
+		BaseURL:   "https://files.example.com",
+	}))
+
+	settings = user.GetLargeAttachments()
+	require.True(t, settings.Enabled)
+	require.Equal(t, int64(1024), settings.Threshold)
+	require.Equal(t, "/srv/attachments", settings.Dir)
+	require.Equal(t, "https://files.example.com", settings.BaseURL)
+}
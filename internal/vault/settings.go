@@ -25,9 +25,13 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ProtonMail/proton-bridge/v3/internal/constants"
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -82,6 +86,175 @@ func (vault *Vault) SetSMTPSSL(ssl bool) error {
 	})
 }
 
+// GetClientCertAuth returns whether client certificates are used to authenticate IMAP and SMTP clients.
+func (vault *Vault) GetClientCertAuth() ClientCertAuth {
+	return vault.getSafe().Settings.ClientCertAuth
+}
+
+// SetClientCertAuth sets whether client certificates are used to authenticate IMAP and SMTP clients.
+func (vault *Vault) SetClientCertAuth(auth ClientCertAuth) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.ClientCertAuth = auth
+	})
+}
+
+// GetImageProxyPort returns the port that the image proxy should listen on.
+// It is zero for vaults created before the image proxy existed.
+func (vault *Vault) GetImageProxyPort() int {
+	return vault.getSafe().Settings.ImageProxyPort
+}
+
+// SetImageProxyPort sets the port that the image proxy should listen on.
+func (vault *Vault) SetImageProxyPort(port int) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.ImageProxyPort = port
+	})
+}
+
+// GetImageProxyKey returns the key used to sign image proxy URLs and encrypt its cache.
+// It is empty for vaults created before the image proxy existed.
+func (vault *Vault) GetImageProxyKey() []byte {
+	return vault.getSafe().Settings.ImageProxyKey
+}
+
+// ResetImageProxyKey sets a new random key for the image proxy.
+func (vault *Vault) ResetImageProxyKey() error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.ImageProxyKey = newRandomKey()
+	})
+}
+
+// GetImageProxyCacheSize returns the size limit of the image proxy cache, in bytes.
+func (vault *Vault) GetImageProxyCacheSize() int64 {
+	v := vault.getSafe().Settings.ImageProxyCacheSize
+	// can be zero if never written to vault before.
+	if v == 0 {
+		return DefaultImageProxyCacheSize
+	}
+
+	return v
+}
+
+// SetImageProxyCacheSize sets the size limit of the image proxy cache, in bytes.
+func (vault *Vault) SetImageProxyCacheSize(size int64) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.ImageProxyCacheSize = size
+	})
+}
+
+// GetMetricsEnabled returns whether the metrics endpoint is enabled.
+func (vault *Vault) GetMetricsEnabled() bool {
+	return vault.getSafe().Settings.MetricsEnabled
+}
+
+// SetMetricsEnabled sets whether the metrics endpoint is enabled.
+func (vault *Vault) SetMetricsEnabled(enabled bool) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.MetricsEnabled = enabled
+	})
+}
+
+// GetMetricsPort returns the port that the metrics endpoint should listen on.
+// It is zero until the endpoint is enabled for the first time.
+func (vault *Vault) GetMetricsPort() int {
+	return vault.getSafe().Settings.MetricsPort
+}
+
+// SetMetricsPort sets the port that the metrics endpoint should listen on.
+func (vault *Vault) SetMetricsPort(port int) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.MetricsPort = port
+	})
+}
+
+// GetAuditLogEnabled returns whether the actions of mail clients are recorded in the audit log.
+func (vault *Vault) GetAuditLogEnabled() bool {
+	return vault.getSafe().Settings.AuditLogEnabled
+}
+
+// SetAuditLogEnabled sets whether the actions of mail clients are recorded in the audit log.
+func (vault *Vault) SetAuditLogEnabled(enabled bool) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.AuditLogEnabled = enabled
+	})
+}
+
+// GetAuditLogKey returns the key the audit log is encrypted with. Keeping it in the vault rather than using the vault
+// key itself lets the log be read after the vault key is rotated.
+// It is empty for vaults created before the audit log existed.
+func (vault *Vault) GetAuditLogKey() []byte {
+	return vault.getSafe().Settings.AuditLogKey
+}
+
+// ResetAuditLogKey sets a new random key for the audit log. Entries encrypted with the previous key can't be read anymore.
+func (vault *Vault) ResetAuditLogKey() error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.AuditLogKey = newRandomKey()
+	})
+}
+
+// GetIMAPClientLimits returns the maximum number of IMAP sessions per client type set by the user.
+func (vault *Vault) GetIMAPClientLimits() map[string]int {
+	return maps.Clone(vault.getSafe().Settings.IMAPClientLimits)
+}
+
+// SetIMAPClientLimit sets the maximum number of IMAP sessions of the given client type. A limit of 0 means unlimited.
+func (vault *Vault) SetIMAPClientLimit(client string, limit int) error {
+	return vault.modSafe(func(data *Data) {
+		if data.Settings.IMAPClientLimits == nil {
+			data.Settings.IMAPClientLimits = make(map[string]int)
+		}
+
+		data.Settings.IMAPClientLimits[client] = limit
+	})
+}
+
+// ResetIMAPClientLimit removes the limit set by the user for the given client type.
+func (vault *Vault) ResetIMAPClientLimit(client string) error {
+	return vault.modSafe(func(data *Data) {
+		delete(data.Settings.IMAPClientLimits, client)
+	})
+}
+
+// GetAPIRateLimits returns the API rate limits set by the user, per operation class.
+func (vault *Vault) GetAPIRateLimits() map[string]ratelimit.Limit {
+	return maps.Clone(vault.getSafe().Settings.APIRateLimits)
+}
+
+// SetAPIRateLimit sets the API rate limit of the given operation class.
+func (vault *Vault) SetAPIRateLimit(class string, limit ratelimit.Limit) error {
+	return vault.modSafe(func(data *Data) {
+		if data.Settings.APIRateLimits == nil {
+			data.Settings.APIRateLimits = make(map[string]ratelimit.Limit)
+		}
+
+		data.Settings.APIRateLimits[class] = limit
+	})
+}
+
+// ResetAPIRateLimit removes the API rate limit set by the user for the given operation class.
+func (vault *Vault) ResetAPIRateLimit(class string) error {
+	return vault.modSafe(func(data *Data) {
+		delete(data.Settings.APIRateLimits, class)
+	})
+}
+
+// GetDesktopNotifications returns the desktop notification preferences.
+func (vault *Vault) GetDesktopNotifications() desktopnotify.Settings {
+	settings := vault.getSafe().Settings.DesktopNotifications
+
+	settings.Rules = slices.Clone(settings.Rules)
+
+	return settings
+}
+
+// SetDesktopNotifications sets the desktop notification preferences.
+func (vault *Vault) SetDesktopNotifications(settings desktopnotify.Settings) error {
+	return vault.modSafe(func(data *Data) {
+		data.Settings.DesktopNotifications = settings
+	})
+}
+
 // GetGluonCacheDir sets the directory where the gluon should store its data.
 func (vault *Vault) GetGluonCacheDir() string {
 	return vault.getSafe().Settings.GluonDir
@@ -20,9 +20,12 @@ package vault_test
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
 	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
@@ -47,6 +50,121 @@ func TestVault_Settings_IMAP(t *testing.T) {
 	require.Equal(t, true, s.GetIMAPSSL())
 }
 
+func TestVault_Settings_ImageProxy(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Check the default image proxy settings.
+	require.Equal(t, ports.FindFreePortFrom(vault.DefaultImageProxyPort), s.GetImageProxyPort())
+	require.Len(t, s.GetImageProxyKey(), 32)
+	require.Equal(t, vault.DefaultImageProxyCacheSize, s.GetImageProxyCacheSize())
+
+	// Modify the image proxy settings.
+	key := s.GetImageProxyKey()
+	require.NoError(t, s.SetImageProxyPort(1234))
+	require.NoError(t, s.ResetImageProxyKey())
+	require.NoError(t, s.SetImageProxyCacheSize(1024))
+
+	// Check the new image proxy settings.
+	require.Equal(t, 1234, s.GetImageProxyPort())
+	require.NotEqual(t, key, s.GetImageProxyKey())
+	require.Equal(t, int64(1024), s.GetImageProxyCacheSize())
+}
+
+func TestVault_Settings_Metrics(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Check the default metrics settings.
+	require.False(t, s.GetMetricsEnabled())
+	require.Zero(t, s.GetMetricsPort())
+
+	// Modify the metrics settings.
+	require.NoError(t, s.SetMetricsEnabled(true))
+	require.NoError(t, s.SetMetricsPort(1234))
+
+	// Check the new metrics settings.
+	require.True(t, s.GetMetricsEnabled())
+	require.Equal(t, 1234, s.GetMetricsPort())
+}
+
+func TestVault_Settings_AuditLog(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Check the default audit log settings.
+	require.False(t, s.GetAuditLogEnabled())
+	require.Len(t, s.GetAuditLogKey(), 32)
+
+	// Modify the audit log settings.
+	key := s.GetAuditLogKey()
+	require.NoError(t, s.SetAuditLogEnabled(true))
+	require.NoError(t, s.ResetAuditLogKey())
+
+	// Check the new audit log settings.
+	require.True(t, s.GetAuditLogEnabled())
+	require.NotEqual(t, key, s.GetAuditLogKey())
+}
+
+func TestVault_Settings_IMAPClientLimits(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// By default, no limit is set.
+	require.Empty(t, s.GetIMAPClientLimits())
+
+	// Set some limits.
+	require.NoError(t, s.SetIMAPClientLimit("apple-mail", 10))
+	require.NoError(t, s.SetIMAPClientLimit("unknown", 0))
+	require.Equal(t, map[string]int{"apple-mail": 10, "unknown": 0}, s.GetIMAPClientLimits())
+
+	// Reset one of them.
+	require.NoError(t, s.ResetIMAPClientLimit("apple-mail"))
+	require.Equal(t, map[string]int{"unknown": 0}, s.GetIMAPClientLimits())
+}
+
+func TestVault_Settings_APIRateLimits(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// By default, no limit is set.
+	require.Empty(t, s.GetAPIRateLimits())
+
+	// Set some limits.
+	require.NoError(t, s.SetAPIRateLimit("send", ratelimit.Limit{PerMinute: 5, Burst: 2}))
+	require.NoError(t, s.SetAPIRateLimit("fetch", ratelimit.Limit{}))
+	require.Equal(t, map[string]ratelimit.Limit{
+		"send":  {PerMinute: 5, Burst: 2},
+		"fetch": {},
+	}, s.GetAPIRateLimits())
+
+	// Reset one of them.
+	require.NoError(t, s.ResetAPIRateLimit("send"))
+	require.Equal(t, map[string]ratelimit.Limit{"fetch": {}}, s.GetAPIRateLimits())
+}
+
+func TestVault_Settings_DesktopNotifications(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// By default, no notification is shown.
+	require.Equal(t, desktopnotify.Settings{}, s.GetDesktopNotifications())
+
+	// Modify the settings.
+	settings := desktopnotify.Settings{
+		NewMail:      true,
+		SendFailures: true,
+		QuietHours:   desktopnotify.QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour},
+		Rules:        []desktopnotify.Rule{{UserID: "userID", Mailbox: "Folders/Work", Notify: true}},
+	}
+	require.NoError(t, s.SetDesktopNotifications(settings))
+	require.Equal(t, settings, s.GetDesktopNotifications())
+
+	// The returned rules are a copy.
+	s.GetDesktopNotifications().Rules[0].Notify = false
+	require.True(t, s.GetDesktopNotifications().Rules[0].Notify)
+}
+
 func TestVault_Settings_SMTP(t *testing.T) {
 	// Create a new test vault.
 	s := newVault(t)
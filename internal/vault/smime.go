@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrNoSuchSMIMEIdentity  = errors.New("no such S/MIME identity")
+	ErrNoSuchSMIMERecipient = errors.New("no such S/MIME recipient")
+)
+
+// GetSMIMEIdentities returns the S/MIME identities imported for the user's addresses.
+func (user *User) GetSMIMEIdentities() []SMIMEIdentity {
+	return slices.Clone(user.vault.getUser(user.userID).SMIMEIdentities)
+}
+
+// GetSMIMEIdentity returns the S/MIME identity imported for the given address.
+func (user *User) GetSMIMEIdentity(email string) (SMIMEIdentity, bool) {
+	ids := user.vault.getUser(user.userID).SMIMEIdentities
+
+	idx := indexSMIMEIdentity(ids, email)
+	if idx < 0 {
+		return SMIMEIdentity{}, false
+	}
+
+	return ids[idx], true
+}
+
+// SetSMIMEIdentity sets the S/MIME identity of the given address, replacing any previous one.
+func (user *User) SetSMIMEIdentity(email string, certificate, key []byte) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		id := SMIMEIdentity{
+			Email:       strings.ToLower(email),
+			Certificate: certificate,
+			Key:         key,
+		}
+
+		if idx := indexSMIMEIdentity(data.SMIMEIdentities, email); idx < 0 {
+			data.SMIMEIdentities = append(data.SMIMEIdentities, id)
+		} else {
+			data.SMIMEIdentities[idx] = id
+		}
+	})
+}
+
+// RemoveSMIMEIdentity removes the S/MIME identity of the given address.
+func (user *User) RemoveSMIMEIdentity(email string) error {
+	if _, ok := user.GetSMIMEIdentity(email); !ok {
+		return ErrNoSuchSMIMEIdentity
+	}
+
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.SMIMEIdentities = slices.DeleteFunc(data.SMIMEIdentities, func(id SMIMEIdentity) bool {
+			return strings.EqualFold(id.Email, email)
+		})
+	})
+}
+
+func indexSMIMEIdentity(ids []SMIMEIdentity, email string) int {
+	return slices.IndexFunc(ids, func(id SMIMEIdentity) bool {
+		return strings.EqualFold(id.Email, email)
+	})
+}
+
+// GetSMIMERecipients returns the external recipients who get S/MIME signed mail from the user.
+func (user *User) GetSMIMERecipients() []string {
+	return slices.Clone(user.vault.getUser(user.userID).SMIMERecipients)
+}
+
+// IsSMIMERecipient returns whether the given recipient gets S/MIME signed mail from the user.
+func (user *User) IsSMIMERecipient(email string) bool {
+	return slices.ContainsFunc(user.vault.getUser(user.userID).SMIMERecipients, func(recipient string) bool {
+		return strings.EqualFold(recipient, email)
+	})
+}
+
+// AddSMIMERecipient adds the given recipient to the ones who get S/MIME signed mail from the user.
+func (user *User) AddSMIMERecipient(email string) error {
+	if user.IsSMIMERecipient(email) {
+		return nil
+	}
+
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.SMIMERecipients = append(data.SMIMERecipients, strings.ToLower(email))
+	})
+}
+
+// RemoveSMIMERecipient removes the given recipient from the ones who get S/MIME signed mail from the user.
+func (user *User) RemoveSMIMERecipient(email string) error {
+	if !user.IsSMIMERecipient(email) {
+		return ErrNoSuchSMIMERecipient
+	}
+
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.SMIMERecipients = slices.DeleteFunc(data.SMIMERecipients, func(recipient string) bool {
+			return strings.EqualFold(recipient, email)
+		})
+	})
+}
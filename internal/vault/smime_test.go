@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_SMIMEIdentities(t *testing.T) {
+	s := newVault(t)
+
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	require.Empty(t, user.GetSMIMEIdentities())
+
+	require.NoError(t, user.SetSMIMEIdentity("Username@PM.me", []byte("cert1"), []byte("key1")))
+
+	id, ok := user.GetSMIMEIdentity("username@pm.me")
+	require.True(t, ok)
+	require.Equal(t, "username@pm.me", id.Email)
+	require.Equal(t, []byte("cert1"), id.Certificate)
+
+	// Importing a new identity for the same address replaces the previous one.
+	require.NoError(t, user.SetSMIMEIdentity("username@pm.me", []byte("cert2"), []byte("key2")))
+
+	id, _ = user.GetSMIMEIdentity("username@pm.me")
+	require.Equal(t, []byte("key2"), id.Key)
+	require.Len(t, user.GetSMIMEIdentities(), 1)
+
+	require.NoError(t, user.RemoveSMIMEIdentity("username@pm.me"))
+	require.ErrorIs(t, user.RemoveSMIMEIdentity("username@pm.me"), vault.ErrNoSuchSMIMEIdentity)
+	require.Empty(t, user.GetSMIMEIdentities())
+}
+
+func TestUser_SMIMERecipients(t *testing.T) {
+	s := newVault(t)
+
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	require.Empty(t, user.GetSMIMERecipients())
+	require.False(t, user.IsSMIMERecipient("smime@example.com"))
+
+	// Recipients are matched regardless of case and only added once.
+	require.NoError(t, user.AddSMIMERecipient("SMIME@example.com"))
+	require.NoError(t, user.AddSMIMERecipient("smime@example.com"))
+	require.True(t, user.IsSMIMERecipient("smime@EXAMPLE.com"))
+	require.Equal(t, []string{"smime@example.com"}, user.GetSMIMERecipients())
+
+	require.NoError(t, user.RemoveSMIMERecipient("Smime@example.com"))
+	require.ErrorIs(t, user.RemoveSMIMERecipient("smime@example.com"), vault.ErrNoSuchSMIMERecipient)
+	require.Empty(t, user.GetSMIMERecipients())
+}
@@ -17,7 +17,11 @@
 
 package vault
 
-import "github.com/ProtonMail/proton-bridge/v3/internal/certs"
+import (
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/internal/certs"
+)
 
 type Certs struct {
 	Bridge Cert
@@ -25,6 +29,82 @@ type Certs struct {
 	// If non-empty, the path to the PEM-encoded certificate file.
 	CustomCertPath string
 	CustomKeyPath  string
+
+	// ClientCA issues the client certificates of the devices allowed to connect to the IMAP and SMTP servers.
+	// It is generated the first time a client certificate is issued.
+	ClientCA    Cert
+	ClientCerts []ClientCert
+}
+
+// ClientCert is a client certificate issued by the bridge client CA.
+type ClientCert struct {
+	SerialNumber string // The hex-encoded serial number of the certificate.
+	Device       string // The common name of the certificate subject.
+	UserID       string // The user the certificate authenticates as.
+	Scope        ClientCertScope
+	NotAfter     time.Time
+	Revoked      bool
+}
+
+// ClientCertScope is the set of servers a client certificate gives access to.
+type ClientCertScope int
+
+const (
+	ClientCertScopeIMAP ClientCertScope = 1 << iota
+	ClientCertScopeSMTP
+
+	ClientCertScopeAll = ClientCertScopeIMAP | ClientCertScopeSMTP
+)
+
+func (scope ClientCertScope) Has(other ClientCertScope) bool {
+	return scope&other == other
+}
+
+func (scope ClientCertScope) String() string {
+	switch scope {
+	case ClientCertScopeIMAP:
+		return "imap"
+
+	case ClientCertScopeSMTP:
+		return "smtp"
+
+	case ClientCertScopeAll:
+		return "imap+smtp"
+
+	default:
+		return "none"
+	}
+}
+
+// ClientCertAuth is whether client certificates are used to authenticate IMAP and SMTP clients.
+type ClientCertAuth int
+
+const (
+	// ClientCertAuthDisabled only allows password authentication.
+	ClientCertAuthDisabled ClientCertAuth = iota
+
+	// ClientCertAuthOptional allows password authentication and client certificate authentication.
+	ClientCertAuthOptional
+
+	// ClientCertAuthRequired requires a client certificate on every connection and disables SMTP password
+	// authentication. IMAP clients must still log in, as the certificate is only checked during the TLS handshake.
+	ClientCertAuthRequired
+)
+
+func (auth ClientCertAuth) String() string {
+	switch auth {
+	case ClientCertAuthDisabled:
+		return "disabled"
+
+	case ClientCertAuthOptional:
+		return "optional"
+
+	case ClientCertAuthRequired:
+		return "required"
+
+	default:
+		return "unknown"
+	}
 }
 
 type Cert struct {
@@ -18,10 +18,13 @@
 package vault
 
 import (
+	crand "crypto/rand"
 	"math/rand"
 	"runtime"
 	"time"
 
+	"github.com/ProtonMail/proton-bridge/v3/internal/desktopnotify"
+	"github.com/ProtonMail/proton-bridge/v3/internal/ratelimit"
 	"github.com/ProtonMail/proton-bridge/v3/internal/updater"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
 	"github.com/ProtonMail/proton-bridge/v3/pkg/ports"
@@ -35,6 +38,26 @@ type Settings struct {
 	IMAPSSL  bool
 	SMTPSSL  bool
 
+	ClientCertAuth ClientCertAuth
+
+	ImageProxyPort      int
+	ImageProxyKey       []byte
+	ImageProxyCacheSize int64
+
+	MetricsEnabled bool
+	MetricsPort    int
+
+	AuditLogEnabled bool
+	AuditLogKey     []byte
+
+	// IMAPClientLimits overrides the maximum number of IMAP sessions per client type. A limit of 0 means unlimited.
+	IMAPClientLimits map[string]int
+
+	// APIRateLimits overrides the rate of the API requests each user's clients can cause, per operation class.
+	APIRateLimits map[string]ratelimit.Limit
+
+	DesktopNotifications desktopnotify.Settings
+
 	UpdateChannel updater.Channel
 	UpdateRollout float64
 
@@ -63,6 +86,14 @@ type Settings struct {
 
 const DefaultMaxSyncMemory = 2 * 1024 * uint64(1024*1024)
 
+const DefaultImageProxyCacheSize = 100 * int64(1024*1024)
+
+// DefaultImageProxyPort is the first port tried for the image proxy.
+const DefaultImageProxyPort = 1180
+
+// DefaultMetricsPort is the first port tried for the metrics endpoint.
+const DefaultMetricsPort = 9154
+
 func GetDefaultSyncWorkerCount() int {
 	const minSyncWorkers = 16
 
@@ -79,6 +110,7 @@ func newDefaultSettings(gluonDir string) Settings {
 	syncWorkers := GetDefaultSyncWorkerCount()
 	imapPort := ports.FindFreePortFrom(1143)
 	smtpPort := ports.FindFreePortFrom(1025, imapPort)
+	imageProxyPort := ports.FindFreePortFrom(DefaultImageProxyPort, imapPort, smtpPort)
 
 	return Settings{
 		GluonDir: gluonDir,
@@ -88,6 +120,14 @@ func newDefaultSettings(gluonDir string) Settings {
 		IMAPSSL:  false,
 		SMTPSSL:  false,
 
+		ClientCertAuth: ClientCertAuthDisabled,
+
+		ImageProxyPort:      imageProxyPort,
+		ImageProxyKey:       newRandomKey(),
+		ImageProxyCacheSize: DefaultImageProxyCacheSize,
+
+		AuditLogKey: newRandomKey(),
+
 		UpdateChannel: updater.DefaultUpdateChannel,
 		UpdateRollout: rand.Float64(), //nolint:gosec
 
@@ -111,3 +151,14 @@ func newDefaultSettings(gluonDir string) Settings {
 		PasswordArchive: PasswordArchive{},
 	}
 }
+
+// newRandomKey returns a new random key, such as those of the image proxy and of the audit log.
+func newRandomKey() []byte {
+	key := make([]byte, 32)
+
+	if _, err := crand.Read(key); err != nil {
+		panic(err)
+	}
+
+	return key
+}
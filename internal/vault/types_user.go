@@ -17,7 +17,11 @@
 
 package vault
 
-import "github.com/ProtonMail/gluon/imap"
+import (
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+)
 
 // UserData holds information about a single bridge user.
 // The user may or may not be logged in.
@@ -31,6 +35,14 @@ type UserData struct {
 	BridgePass  []byte // raw token represented as byte slice (needs to be encoded)
 	AddressMode AddressMode
 
+	MessageProfile MessageProfile
+	UnwrapPGPMIME  bool // Whether PGP/MIME messages are built as their decrypted MIME tree.
+
+	TrustedAuthServIDs []string // The servers whose Authentication-Results headers are trusted. The Proton MX servers if empty.
+
+	BlockRemoteContent bool // Whether remote images and CSS are removed from HTML messages.
+	UseImageProxy      bool // Whether remote images of HTML messages are loaded through the image proxy.
+
 	AuthUID string
 	AuthRef string
 	KeyPass []byte
@@ -42,6 +54,18 @@ type UserData struct {
 	UIDValidity map[string]imap.UID
 
 	ShouldResync bool // Whether user should re-sync on log-in (this is triggered by the `repair` button)
+
+	ContactKeys      []ContactKey
+	ContactKeyPolicy ContactKeyPolicy
+
+	AutocryptMode    AutocryptMode
+	AutocryptCollect bool // Whether to collect the keys of peers from the Autocrypt headers of received mail.
+	AutocryptPeers   []AutocryptPeer
+
+	SMIMEIdentities []SMIMEIdentity
+	SMIMERecipients []string // The external recipients who get S/MIME signed mail.
+
+	LargeAttachments LargeAttachmentSettings
 }
 
 type AddressMode int
@@ -49,6 +73,7 @@ type AddressMode int
 const (
 	CombinedMode AddressMode = iota
 	SplitMode
+	HybridMode
 )
 
 func (mode AddressMode) String() string {
@@ -59,11 +84,137 @@ func (mode AddressMode) String() string {
 	case SplitMode:
 		return "split"
 
+	case HybridMode:
+		return "hybrid"
+
+	default:
+		return "unknown"
+	}
+}
+
+// MessageProfile determines which headers bridge adds to the messages it builds for IMAP clients.
+type MessageProfile int
+
+const (
+	// MessageProfileCompat adds the internal and external IDs, the server date and the References entry.
+	MessageProfileCompat MessageProfile = iota
+
+	// MessageProfileMinimal adds no headers of its own.
+	// Clients that APPEND a copy of a message are no longer recognised as moving it.
+	MessageProfileMinimal
+
+	// MessageProfileForensic adds the raw message metadata on top of the compat headers.
+	MessageProfileForensic
+)
+
+func (profile MessageProfile) String() string {
+	switch profile {
+	case MessageProfileCompat:
+		return "compat"
+
+	case MessageProfileMinimal:
+		return "minimal"
+
+	case MessageProfileForensic:
+		return "forensic"
+
+	default:
+		return "unknown"
+	}
+}
+
+// ContactKey records the fingerprint of the key used to encrypt mail sent to a recipient.
+// The first key seen for a recipient is trusted; a later key change is kept pending until approved.
+type ContactKey struct {
+	Email              string
+	Fingerprint        string
+	PendingFingerprint string
+	FirstSeen          time.Time
+	ChangedAt          time.Time
+}
+
+// HasPendingChange returns whether a key change was detected for the recipient and not yet approved.
+func (key ContactKey) HasPendingChange() bool {
+	return key.PendingFingerprint != ""
+}
+
+// ContactKeyPolicy determines what happens when a recipient's key no longer matches the trusted one.
+type ContactKeyPolicy int
+
+const (
+	ContactKeyPolicyWarn ContactKeyPolicy = iota
+	ContactKeyPolicyBlock
+	ContactKeyPolicyAllow
+)
+
+func (policy ContactKeyPolicy) String() string {
+	switch policy {
+	case ContactKeyPolicyWarn:
+		return "warn"
+
+	case ContactKeyPolicyBlock:
+		return "block"
+
+	case ContactKeyPolicyAllow:
+		return "allow"
+
+	default:
+		return "unknown"
+	}
+}
+
+// AutocryptMode determines whether outgoing mail carries an Autocrypt header, and with which encryption preference.
+type AutocryptMode int
+
+const (
+	AutocryptOff AutocryptMode = iota
+	AutocryptNoPreference
+	AutocryptMutual
+)
+
+func (mode AutocryptMode) String() string {
+	switch mode {
+	case AutocryptOff:
+		return "off"
+
+	case AutocryptNoPreference:
+		return "nopreference"
+
+	case AutocryptMutual:
+		return "mutual"
+
 	default:
 		return "unknown"
 	}
 }
 
+// AutocryptPeer is the key of a peer collected from the Autocrypt header of a received message.
+type AutocryptPeer struct {
+	Email         string
+	KeyData       []byte
+	PreferEncrypt bool
+	Timestamp     time.Time // The effective date of the message the key was taken from.
+}
+
+// SMIMEIdentity is the certificate and private key used to sign the mail sent from one of the user's addresses.
+type SMIMEIdentity struct {
+	Email       string
+	Certificate []byte // The PEM-encoded certificate chain, signing certificate first.
+	Key         []byte // The PEM-encoded private key of the signing certificate.
+}
+
+// DefaultLargeAttachmentThreshold is the size above which attachments are sent as links if no threshold was set.
+const DefaultLargeAttachmentThreshold = 10 * 1024 * 1024
+
+// LargeAttachmentSettings determine whether and where attachments too large to be sent with a message are stored.
+// The message carries a link to the stored attachment instead.
+type LargeAttachmentSettings struct {
+	Enabled   bool
+	Threshold int64  // The size in bytes above which attachments are stored; can be zero if never written to vault before.
+	Dir       string // The directory the attachments are written to.
+	BaseURL   string // The URL under which the directory is served; required when enabled.
+}
+
 type SyncStatus struct {
 	HasLabels        bool
 	HasMessages      bool
@@ -97,6 +97,66 @@ func (user *User) SetAddressMode(mode AddressMode) error {
 	})
 }
 
+// GetMessageProfile returns the profile used to build the user's messages.
+func (user *User) GetMessageProfile() MessageProfile {
+	return user.vault.getUser(user.userID).MessageProfile
+}
+
+// SetMessageProfile sets the profile used to build the user's messages.
+func (user *User) SetMessageProfile(profile MessageProfile) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.MessageProfile = profile
+	})
+}
+
+// GetUnwrapPGPMIME returns whether the user's PGP/MIME messages are built as their decrypted MIME tree.
+func (user *User) GetUnwrapPGPMIME() bool {
+	return user.vault.getUser(user.userID).UnwrapPGPMIME
+}
+
+// SetUnwrapPGPMIME sets whether the user's PGP/MIME messages are built as their decrypted MIME tree.
+func (user *User) SetUnwrapPGPMIME(unwrap bool) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.UnwrapPGPMIME = unwrap
+	})
+}
+
+// GetTrustedAuthServIDs returns the servers whose Authentication-Results headers are trusted in the user's messages.
+func (user *User) GetTrustedAuthServIDs() []string {
+	return slices.Clone(user.vault.getUser(user.userID).TrustedAuthServIDs)
+}
+
+// SetTrustedAuthServIDs sets the servers whose Authentication-Results headers are trusted in the user's messages.
+func (user *User) SetTrustedAuthServIDs(servIDs []string) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.TrustedAuthServIDs = slices.Clone(servIDs)
+	})
+}
+
+// GetBlockRemoteContent returns whether remote images and CSS are removed from the user's HTML messages.
+func (user *User) GetBlockRemoteContent() bool {
+	return user.vault.getUser(user.userID).BlockRemoteContent
+}
+
+// SetBlockRemoteContent sets whether remote images and CSS are removed from the user's HTML messages.
+func (user *User) SetBlockRemoteContent(block bool) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.BlockRemoteContent = block
+	})
+}
+
+// GetUseImageProxy returns whether the remote images of the user's HTML messages are loaded through the image proxy.
+func (user *User) GetUseImageProxy() bool {
+	return user.vault.getUser(user.userID).UseImageProxy
+}
+
+// SetUseImageProxy sets whether the remote images of the user's HTML messages are loaded through the image proxy.
+func (user *User) SetUseImageProxy(useProxy bool) error {
+	return user.vault.modUser(user.userID, func(data *UserData) {
+		data.UseImageProxy = useProxy
+	})
+}
+
 // BridgePass returns the user's bridge password as raw token bytes (unencoded).
 func (user *User) BridgePass() []byte {
 	return user.vault.getUser(user.userID).BridgePass
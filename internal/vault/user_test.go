@@ -271,3 +271,87 @@ func TestUser_ShouldResync(t *testing.T) {
 	// Check whether it matches the correct value
 	require.True(t, user.GetShouldResync())
 }
+
+func TestUser_MessageProfile(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Create a new user.
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users build their messages with the compat profile.
+	require.Equal(t, vault.MessageProfileCompat, user.GetMessageProfile())
+
+	// Switch to the forensic profile.
+	require.NoError(t, user.SetMessageProfile(vault.MessageProfileForensic))
+	require.Equal(t, vault.MessageProfileForensic, user.GetMessageProfile())
+}
+
+func TestUser_UnwrapPGPMIME(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Create a new user.
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users keep their PGP/MIME messages wrapped.
+	require.False(t, user.GetUnwrapPGPMIME())
+
+	// Enable unwrapping.
+	require.NoError(t, user.SetUnwrapPGPMIME(true))
+	require.True(t, user.GetUnwrapPGPMIME())
+}
+
+func TestUser_BlockRemoteContent(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Create a new user.
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users get remote content.
+	require.False(t, user.GetBlockRemoteContent())
+
+	// Block remote content.
+	require.NoError(t, user.SetBlockRemoteContent(true))
+	require.True(t, user.GetBlockRemoteContent())
+}
+
+func TestUser_TrustedAuthServIDs(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Create a new user.
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users trust the default servers.
+	require.Empty(t, user.GetTrustedAuthServIDs())
+
+	// Trust another server.
+	require.NoError(t, user.SetTrustedAuthServIDs([]string{"mx.example.com"}))
+	require.Equal(t, []string{"mx.example.com"}, user.GetTrustedAuthServIDs())
+
+	// Trust the default servers again.
+	require.NoError(t, user.SetTrustedAuthServIDs(nil))
+	require.Empty(t, user.GetTrustedAuthServIDs())
+}
+
+func TestUser_UseImageProxy(t *testing.T) {
+	// Create a new test vault.
+	s := newVault(t)
+
+	// Create a new user.
+	user, err := s.AddUser("userID", "username", "username@pm.me", "authUID", "authRef", []byte("keyPass"))
+	require.NoError(t, err)
+
+	// New users don't use the image proxy.
+	require.False(t, user.GetUseImageProxy())
+
+	// Use the image proxy.
+	require.NoError(t, user.SetUseImageProxy(true))
+	require.True(t, user.GetUseImageProxy())
+}
@@ -60,14 +60,7 @@ func New(vaultDir, gluonCacheDir string, key []byte, panicHandler async.PanicHan
 		return nil, nil, err
 	}
 
-	hash256 := sha256.Sum256(key)
-
-	aes, err := aes.NewCipher(hash256[:])
-	if err != nil {
-		return nil, nil, err
-	}
-
-	gcm, err := cipher.NewGCM(aes)
+	gcm, err := newGCM(key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -86,6 +79,34 @@ func New(vaultDir, gluonCacheDir string, key []byte, panicHandler async.PanicHan
 	return vault, corrupt, nil
 }
 
+// Open opens the existing vault in the given directory, for reading its data while bridge isn't running.
+// Unlike New, it neither creates the vault nor resets it if it can't be decrypted; an error is returned instead.
+func Open(vaultDir string, key []byte) (*Vault, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(vaultDir, "vault.enc")
+
+	enc, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unmarshalFile(gcm, enc, new(Data)); err != nil {
+		return nil, err
+	}
+
+	return &Vault{
+		path:         path,
+		enc:          enc,
+		gcm:          gcm,
+		ref:          make(map[string]int),
+		panicHandler: async.NoopPanicHandler{},
+	}, nil
+}
+
 // GetUserIDs returns the user IDs and usernames of all users in the vault.
 func (vault *Vault) GetUserIDs() []string {
 	return xslices.Map(vault.getSafe().Users, func(user UserData) string {
@@ -430,13 +451,18 @@ func (vault *Vault) modUnsafe(fn func(data *Data)) error {
 
 	vault.enc = enc
 
-	tmpFile := vault.path + ".tmp"
+	return writeVaultFile(vault.path, vault.enc)
+}
+
+// writeVaultFile atomically replaces the vault file at the given path with the given encrypted data.
+func writeVaultFile(path string, enc []byte) error {
+	tmpFile := path + ".tmp"
 
-	if err := os.WriteFile(tmpFile, vault.enc, 0o600); err != nil {
+	if err := os.WriteFile(tmpFile, enc, 0o600); err != nil {
 		return fmt.Errorf("failed write new vault to disk: %w", err)
 	}
 
-	if err := os.Rename(tmpFile, vault.path); err != nil {
+	if err := os.Rename(tmpFile, path); err != nil {
 		return fmt.Errorf("failed to overwrite old vault data: %w", err)
 	}
 
@@ -490,6 +516,17 @@ func (vault *Vault) modUserUnsafe(userID string, fn func(userData *UserData)) er
 	})
 }
 
+func newGCM(key []byte) (cipher.AEAD, error) {
+	hash256 := sha256.Sum256(key)
+
+	aes, err := aes.NewCipher(hash256[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(aes)
+}
+
 func initVault(path, gluonDir string, gcm cipher.AEAD) ([]byte, error) {
 	enc, err := marshalFile(gcm, newDefaultData(gluonDir))
 	if err != nil {
@@ -18,6 +18,7 @@
 package vault_test
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -79,6 +80,38 @@ func TestVault_Corrupt_JunkData(t *testing.T) {
 	}
 }
 
+func TestVault_Open(t *testing.T) {
+	vaultDir, gluonDir := t.TempDir(), t.TempDir()
+
+	// There is no vault to open yet, and none is created.
+	_, err := vault.Open(vaultDir, []byte("my secret key"))
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	require.NoFileExists(t, filepath.Join(vaultDir, "vault.enc"))
+
+	{
+		s, corrupt, err := vault.New(vaultDir, gluonDir, []byte("my secret key"), async.NoopPanicHandler{})
+		require.NoError(t, err)
+		require.NoError(t, corrupt)
+		require.NoError(t, s.SetIMAPPort(1234))
+	}
+
+	// The existing vault is read.
+	s, err := vault.Open(vaultDir, []byte("my secret key"))
+	require.NoError(t, err)
+	require.Equal(t, 1234, s.GetIMAPPort())
+
+	enc, err := os.ReadFile(filepath.Join(vaultDir, "vault.enc"))
+	require.NoError(t, err)
+
+	// A vault that can't be decrypted is left as is.
+	_, err = vault.Open(vaultDir, []byte("bad key"))
+	require.ErrorIs(t, err, vault.ErrDecryptFailed)
+
+	after, err := os.ReadFile(filepath.Join(vaultDir, "vault.enc"))
+	require.NoError(t, err)
+	require.Equal(t, enc, after)
+}
+
 func TestVault_Reset(t *testing.T) {
 	s := newVault(t)
 
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package versioner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/allan-simon/go-singleinstance"
+)
+
+const (
+	healthFile     = "health.json"
+	healthLockFile = "health.lock"
+
+	// healthLockTimeout is how long to wait for another process, such as the launcher, to finish updating the health.
+	healthLockTimeout = 10 * time.Second
+)
+
+// DefaultMaxFailedStarts is the number of starts an update gets to become healthy before the launcher rolls it back.
+const DefaultMaxFailedStarts = 3
+
+// maxVerifiedVersions is the number of verified versions remembered, so that the previous one can be kept for rollback.
+const maxVerifiedVersions = 2
+
+var ErrNoRollback = errors.New("the running version was not installed by an update")
+
+// Health tracks whether installed updates managed to start.
+// A version is verified once it has started and served IMAP and SMTP; until then, each start is counted as failed,
+// unless the app exits normally before then, for instance because the user quit or a port was already taken.
+type Health struct {
+	// Verified holds the versions that started successfully, the most recent last.
+	Verified []string
+
+	// FailedStarts counts the starts of the versions that are not verified yet.
+	FailedStarts map[string]int
+
+	// RolledBack holds the versions that were rolled back and must not be started again.
+	RolledBack []string
+}
+
+func (h Health) IsVerified(version *semver.Version) bool {
+	return slices.Contains(h.Verified, version.String())
+}
+
+func (h Health) IsRolledBack(version *semver.Version) bool {
+	return slices.Contains(h.RolledBack, version.String())
+}
+
+// LastVerified returns the most recently verified version, if any.
+func (h Health) LastVerified() (*semver.Version, bool) {
+	if len(h.Verified) == 0 {
+		return nil, false
+	}
+
+	version, err := semver.NewVersion(h.Verified[len(h.Verified)-1])
+	if err != nil {
+		return nil, false
+	}
+
+	return version, true
+}
+
+// GetHealth returns the health of the installed updates.
+func (v *Versioner) GetHealth() (Health, error) {
+	b, err := os.ReadFile(filepath.Join(v.root, healthFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return Health{FailedStarts: make(map[string]int)}, nil
+	} else if err != nil {
+		return Health{}, err
+	}
+
+	var health Health
+
+	if err := json.Unmarshal(b, &health); err != nil {
+		return Health{}, err
+	}
+
+	if health.FailedStarts == nil {
+		health.FailedStarts = make(map[string]int)
+	}
+
+	return health, nil
+}
+
+// RecordStart counts a start of the given version, which fails unless MarkVerified or RecordCleanExit is called,
+// and returns the number of failed starts before this one.
+func (v *Versioner) RecordStart(version *semver.Version) (int, error) {
+	var failed int
+
+	if err := v.modHealth(func(health *Health) {
+		failed = health.FailedStarts[version.String()]
+		health.FailedStarts[version.String()] = failed + 1
+	}); err != nil {
+		return 0, err
+	}
+
+	return failed, nil
+}
+
+// RecordCleanExit uncounts the last start of the given version, which exited normally before being verified.
+func (v *Versioner) RecordCleanExit(version *semver.Version) error {
+	return v.modHealth(func(health *Health) {
+		if failed := health.FailedStarts[version.String()]; failed > 1 {
+			health.FailedStarts[version.String()] = failed - 1
+		} else {
+			delete(health.FailedStarts, version.String())
+		}
+	})
+}
+
+// MarkVerified records that the given version started successfully.
+func (v *Versioner) MarkVerified(version *semver.Version) error {
+	return v.modHealth(func(health *Health) {
+		delete(health.FailedStarts, version.String())
+
+		health.Verified = slices.DeleteFunc(health.Verified, func(verified string) bool { return verified == version.String() })
+		health.Verified = append(health.Verified, version.String())
+
+		if len(health.Verified) > maxVerifiedVersions {
+			health.Verified = health.Verified[len(health.Verified)-maxVerifiedVersions:]
+		}
+	})
+}
+
+// RollBack prevents the given version from being started again, so that the launcher starts the previous one.
+// The version directory is kept so that the same update is not installed again.
+func (v *Versioner) RollBack(version *semver.Version) error {
+	versions, err := v.ListVersions()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if !versions.HasVersion(version) {
+		return ErrNoRollback
+	}
+
+	return v.modHealth(func(health *Health) {
+		delete(health.FailedStarts, version.String())
+
+		health.Verified = slices.DeleteFunc(health.Verified, func(verified string) bool { return verified == version.String() })
+
+		if !slices.Contains(health.RolledBack, version.String()) {
+			health.RolledBack = append(health.RolledBack, version.String())
+		}
+	})
+}
+
+// forgetHealth drops what is known about versions that are no longer installed.
+func (v *Versioner) forgetHealth(installed Versions) error {
+	isInstalled := func(version string) bool {
+		parsed, err := semver.NewVersion(version)
+
+		return err == nil && installed.HasVersion(parsed)
+	}
+
+	return v.modHealth(func(health *Health) {
+		for version := range health.FailedStarts {
+			if !isInstalled(version) {
+				delete(health.FailedStarts, version)
+			}
+		}
+
+		health.RolledBack = slices.DeleteFunc(health.RolledBack, func(version string) bool { return !isInstalled(version) })
+	})
+}
+
+// modHealth updates the health file. The launcher and the app may both update it, so it is locked while being
+// read and written, and replaced atomically so that a crash can't leave it half written.
+func (v *Versioner) modHealth(fn func(*Health)) error {
+	if err := os.MkdirAll(v.root, 0o700); err != nil {
+		return err
+	}
+
+	lock, err := v.lockHealth()
+	if err != nil {
+		return err
+	}
+	defer lock.Close() //nolint:errcheck
+
+	health, err := v.GetHealth()
+	if err != nil {
+		return err
+	}
+
+	fn(&health)
+
+	b, err := json.Marshal(health)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(v.root, healthFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(v.root, healthFile))
+}
+
+// lockHealth waits until it gets the lock of the health file. The lock is released by closing the returned file.
+func (v *Versioner) lockHealth() (*os.File, error) {
+	deadline := time.Now().Add(healthLockTimeout)
+
+	for {
+		lock, err := singleinstance.CreateLockFile(filepath.Join(v.root, healthLockFile))
+		if err == nil {
+			return lock, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock health file: %w", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
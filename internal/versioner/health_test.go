@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package versioner
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	v := newTestVersioner(t, "myCoolApp", t.TempDir(), "2.3.5", "2.4.0")
+
+	health, err := v.GetHealth()
+	require.NoError(t, err)
+	require.False(t, health.IsVerified(semver.MustParse("2.3.5")))
+
+	_, ok := health.LastVerified()
+	require.False(t, ok)
+
+	// Starts are counted until the version is verified.
+	for i := 0; i < DefaultMaxFailedStarts; i++ {
+		failed, err := v.RecordStart(semver.MustParse("2.4.0"))
+		require.NoError(t, err)
+		require.Equal(t, i, failed)
+	}
+
+	require.NoError(t, v.MarkVerified(semver.MustParse("2.3.5")))
+	require.NoError(t, v.MarkVerified(semver.MustParse("2.4.0")))
+
+	health, err = v.GetHealth()
+	require.NoError(t, err)
+	require.True(t, health.IsVerified(semver.MustParse("2.3.5")))
+	require.True(t, health.IsVerified(semver.MustParse("2.4.0")))
+	require.Empty(t, health.FailedStarts)
+
+	last, ok := health.LastVerified()
+	require.True(t, ok)
+	require.Equal(t, semver.MustParse("2.4.0"), last)
+
+	// Only the most recent verified versions are remembered.
+	require.NoError(t, v.MarkVerified(semver.MustParse("2.5.0")))
+
+	health, err = v.GetHealth()
+	require.NoError(t, err)
+	require.False(t, health.IsVerified(semver.MustParse("2.3.5")))
+	require.True(t, health.IsVerified(semver.MustParse("2.4.0")))
+}
+
+func TestHealth_RollBack(t *testing.T) {
+	v := newTestVersioner(t, "myCoolApp", t.TempDir(), "2.3.5", "2.4.0")
+
+	require.NoError(t, v.MarkVerified(semver.MustParse("2.4.0")))
+	require.NoError(t, v.RollBack(semver.MustParse("2.4.0")))
+
+	health, err := v.GetHealth()
+	require.NoError(t, err)
+	require.True(t, health.IsRolledBack(semver.MustParse("2.4.0")))
+	require.False(t, health.IsVerified(semver.MustParse("2.4.0")))
+
+	// The directory is kept so that the update is not installed again.
+	versions, err := v.ListVersions()
+	require.NoError(t, err)
+	require.True(t, versions.HasVersion(semver.MustParse("2.4.0")))
+
+	// A version that was not installed by an update cannot be rolled back.
+	require.ErrorIs(t, v.RollBack(semver.MustParse("2.2.0")), ErrNoRollback)
+}
+
+func TestHealth_CleanExit(t *testing.T) {
+	v := newTestVersioner(t, "myCoolApp", t.TempDir(), "2.3.5", "2.4.0")
+
+	// A start followed by a normal exit isn't counted.
+	for i := 0; i < DefaultMaxFailedStarts; i++ {
+		failed, err := v.RecordStart(semver.MustParse("2.4.0"))
+		require.NoError(t, err)
+		require.Equal(t, 0, failed)
+
+		require.NoError(t, v.RecordCleanExit(semver.MustParse("2.4.0")))
+	}
+
+	health, err := v.GetHealth()
+	require.NoError(t, err)
+	require.Empty(t, health.FailedStarts)
+}
+
+func TestHealth_Concurrent(t *testing.T) {
+	v := newTestVersioner(t, "myCoolApp", t.TempDir(), "2.3.5", "2.4.0")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := v.RecordStart(semver.MustParse("2.4.0"))
+			require.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	health, err := v.GetHealth()
+	require.NoError(t, err)
+	require.Equal(t, 10, health.FailedStarts["2.4.0"])
+}
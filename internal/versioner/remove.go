@@ -28,7 +28,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// RemoveOldVersions removes all but the latest app version.
+// RemoveOldVersions removes all but the latest app version and the verified versions, which are kept for rollback.
 func (v *Versioner) RemoveOldVersions() error {
 	versions, err := v.ListVersions()
 	if err != nil {
@@ -40,13 +40,25 @@ func (v *Versioner) RemoveOldVersions() error {
 		return nil
 	}
 
+	health, err := v.GetHealth()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to read the health of app versions")
+	}
+
+	kept := Versions{versions[0]}
+
 	for _, version := range versions[1:] {
+		if health.IsVerified(version.version) {
+			kept = append(kept, version)
+			continue
+		}
+
 		if err := os.RemoveAll(version.path); err != nil {
 			logrus.WithError(err).Error("Failed to remove old app version")
 		}
 	}
 
-	return nil
+	return v.forgetHealth(kept)
 }
 
 // RemoveOtherVersions removes all but the specific provided app version.
@@ -49,3 +49,24 @@ func TestRemoveOldVersions(t *testing.T) {
 	assert.Equal(t, semver.MustParse("2.4.0"), cleanedVersions[0].version)
 	assert.Equal(t, filepath.Join(tempDir, "2.4.0"), cleanedVersions[0].path)
 }
+
+func TestRemoveOldVersions_KeepVerified(t *testing.T) {
+	v := newTestVersioner(t, "myCoolApp", t.TempDir(), "2.3.4", "2.3.5", "2.4.0")
+
+	require.NoError(t, v.MarkVerified(semver.MustParse("2.3.5")))
+
+	_, err := v.RecordStart(semver.MustParse("2.3.4"))
+	require.NoError(t, err)
+
+	require.NoError(t, v.RemoveOldVersions())
+
+	versions, err := v.ListVersions()
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, semver.MustParse("2.4.0"), versions[0].version)
+	require.Equal(t, semver.MustParse("2.3.5"), versions[1].version)
+
+	health, err := v.GetHealth()
+	require.NoError(t, err)
+	require.NotContains(t, health.FailedStarts, "2.3.4")
+}
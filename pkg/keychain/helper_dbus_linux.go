@@ -91,7 +91,10 @@ func unlock(service *secretservice.SecretService) error {
 
 // SecretServiceDBusHelper is wrapper around keybase/go-keychain/secretservice
 // library.
-type SecretServiceDBusHelper struct{}
+type SecretServiceDBusHelper struct {
+	// domain is the prefix of the server URL of the items managed by the helper. The default domain is used if empty.
+	domain string
+}
 
 // Add appends credentials to the store.
 func (s *SecretServiceDBusHelper) Add(creds *credentials.Credentials) error {
@@ -211,7 +214,10 @@ func (s *SecretServiceDBusHelper) List() (map[string]string, error) {
 		return nil, err
 	}
 
-	defaultDomain := getDomain()
+	defaultDomain := s.domain
+	if defaultDomain == "" {
+		defaultDomain = getDomain()
+	}
 
 	for _, it := range items {
 		attributes, err := service.GetAttributes(it)
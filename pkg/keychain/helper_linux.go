@@ -67,8 +67,8 @@ func listHelpers() (Helpers, string) {
 	return helpers, defaultHelper
 }
 
-func newDBusHelper(string) (credentials.Helper, error) {
-	return &SecretServiceDBusHelper{}, nil
+func newDBusHelper(url string) (credentials.Helper, error) {
+	return &SecretServiceDBusHelper{domain: url}, nil
 }
 
 func newPassHelper(string) (credentials.Helper, error) {
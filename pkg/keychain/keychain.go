@@ -55,6 +55,16 @@ var (
 	ErrPreferredKeychainNotAvailable = errors.New("preferred keychain is not available or usable")
 )
 
+// ProfileKeychainName returns the keychain name used by the given app profile, so that profiles never share
+// keychain items. The default profile (empty name) uses the keychain name unchanged.
+func ProfileKeychainName(keychainName, profile string) string {
+	if profile == "" {
+		return keychainName
+	}
+
+	return keychainName + "-" + profile
+}
+
 func IsErrKeychainNoItem(err error) bool {
 	return errors.Is(err, ErrKeychainNoItem) || credentials.IsErrCredentialsNotFound(err)
 }
@@ -90,6 +100,22 @@ func (kcl *List) GetDefaultHelper() string {
 	return kcl.defaultHelper
 }
 
+// NewKeychain creates a keychain backed by the given helper. Unlike the package level NewKeychain,
+// it never falls back to the default helper and returns ErrNoKeychain if the helper is not usable.
+func (kcl *List) NewKeychain(helper, keychainName string) (*Keychain, error) {
+	constructor, ok := kcl.GetHelpers()[helper]
+	if !ok {
+		return nil, ErrNoKeychain
+	}
+
+	credHelper, err := constructor(hostURL(keychainName))
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeychain(credHelper, hostURL(keychainName)), nil
+}
+
 func PreferredKeychainRetryError(attemptCount int) error {
 	return fmt.Errorf("%w, %d attempts remaining till vault reset", ErrPreferredKeychainNotAvailable, MaxFailedKeychainAttemptsLinux-attemptCount)
 }
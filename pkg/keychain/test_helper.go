@@ -18,6 +18,7 @@
 package keychain
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/docker/docker-credential-helpers/credentials"
@@ -33,6 +34,33 @@ func NewTestKeychainsList() *List {
 	return &list
 }
 
+// NewTestKeychainsListWithHelpers returns a list holding one independent test helper per given name.
+// The first name is used as the default helper.
+func NewTestKeychainsListWithHelpers(names ...string) *List {
+	helpers := make(Helpers)
+
+	for _, name := range names {
+		keychainHelper := NewTestHelper()
+		helpers[name] = func(string) (credentials.Helper, error) { return keychainHelper, nil }
+	}
+
+	var list = List{helpers: helpers, locker: &sync.Mutex{}}
+
+	if len(names) > 0 {
+		list.defaultHelper = names[0]
+	}
+
+	return &list
+}
+
+// ErrTestHelperBroken is returned when opening a test helper broken with BreakTestHelper.
+var ErrTestHelperBroken = errors.New("test keychain helper is broken")
+
+// BreakTestHelper makes the given helper fail to open, as a keychain which can no longer be reached.
+func (kcl *List) BreakTestHelper(name string) {
+	kcl.helpers[name] = func(string) (credentials.Helper, error) { return nil, ErrTestHelperBroken }
+}
+
 func NewTestHelper() TestHelper {
 	return make(TestHelper)
 }
@@ -48,7 +76,10 @@ func (h TestHelper) Delete(url string) error {
 }
 
 func (h TestHelper) Get(url string) (string, string, error) {
-	creds := h[url]
+	creds, ok := h[url]
+	if !ok {
+		return "", "", credentials.NewErrCredentialsNotFound()
+	}
 
 	return creds.Username, creds.Secret, nil
 }
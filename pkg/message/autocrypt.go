@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge. If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/pkg/errors"
+)
+
+// AutocryptHeader is the name of the header carrying the key of the sender of a message, as defined by Autocrypt Level 1.
+const AutocryptHeader = "Autocrypt"
+
+// autocryptKeyDataLineLen is the length of the chunks the key data is split into so that the header can be folded.
+const autocryptKeyDataLineLen = 72
+
+var ErrInvalidAutocrypt = errors.New("invalid Autocrypt header")
+
+// Autocrypt is the content of an Autocrypt header.
+type Autocrypt struct {
+	Addr          string
+	PreferEncrypt bool
+	KeyData       []byte
+}
+
+// NewAutocrypt returns the Autocrypt header advertising the first key of the given keyring for the given address.
+func NewAutocrypt(addr string, kr *crypto.KeyRing, preferEncrypt bool) (Autocrypt, error) {
+	key, err := kr.GetKey(0)
+	if err != nil {
+		return Autocrypt{}, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	keyData, err := key.GetPublicKey()
+	if err != nil {
+		return Autocrypt{}, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	return Autocrypt{Addr: addr, PreferEncrypt: preferEncrypt, KeyData: keyData}, nil
+}
+
+// String returns the value of the header.
+// The key data is split with spaces so that the header can be folded.
+func (a Autocrypt) String() string {
+	var b strings.Builder
+
+	b.WriteString("addr=" + a.Addr + "; ")
+
+	if a.PreferEncrypt {
+		b.WriteString("prefer-encrypt=mutual; ")
+	}
+
+	b.WriteString("keydata=")
+
+	keyData := base64.StdEncoding.EncodeToString(a.KeyData)
+
+	for len(keyData) > autocryptKeyDataLineLen {
+		b.WriteString(" " + keyData[:autocryptKeyDataLineLen])
+		keyData = keyData[autocryptKeyDataLineLen:]
+	}
+
+	b.WriteString(" " + keyData)
+
+	return b.String()
+}
+
+// ParseAutocrypt parses the value of an Autocrypt header.
+// Unknown attributes are only allowed if they are non-critical, i.e. start with an underscore.
+func ParseAutocrypt(value string) (Autocrypt, error) {
+	var (
+		res        Autocrypt
+		hasKeyData bool
+	)
+
+	for _, attr := range strings.Split(value, ";") {
+		if strings.TrimSpace(attr) == "" {
+			continue
+		}
+
+		name, val, ok := strings.Cut(attr, "=")
+		if !ok {
+			return Autocrypt{}, fmt.Errorf("%w: malformed attribute", ErrInvalidAutocrypt)
+		}
+
+		switch name = strings.ToLower(strings.TrimSpace(name)); {
+		case name == "addr":
+			res.Addr = strings.TrimSpace(val)
+
+		case name == "prefer-encrypt":
+			res.PreferEncrypt = strings.TrimSpace(val) == "mutual"
+
+		case name == "keydata":
+			keyData, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(val), ""))
+			if err != nil {
+				return Autocrypt{}, fmt.Errorf("%w: %w", ErrInvalidAutocrypt, err)
+			}
+
+			res.KeyData, hasKeyData = keyData, true
+
+		case strings.HasPrefix(name, "_"):
+			continue
+
+		default:
+			return Autocrypt{}, fmt.Errorf("%w: unknown critical attribute %q", ErrInvalidAutocrypt, name)
+		}
+	}
+
+	if res.Addr == "" || !hasKeyData {
+		return Autocrypt{}, fmt.Errorf("%w: missing addr or keydata", ErrInvalidAutocrypt)
+	}
+
+	return res, nil
+}
+
+// GetAutocrypt returns the Autocrypt header of the given received message and its effective date.
+// Following Autocrypt Level 1, the header is only used if it is the only one, if its address matches
+// the sender of the message, if the message is not a report and if the key can be used for encryption.
+func GetAutocrypt(msg proton.Message) (Autocrypt, time.Time, bool) {
+	if msg.Sender == nil {
+		return Autocrypt{}, time.Time{}, false
+	}
+
+	if contentType := getHeaderValues(msg.ParsedHeaders, "Content-Type"); len(contentType) > 0 {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType[0])), "multipart/report") {
+			return Autocrypt{}, time.Time{}, false
+		}
+	}
+
+	var headers []Autocrypt
+
+	for _, value := range getHeaderValues(msg.ParsedHeaders, AutocryptHeader) {
+		if header, err := ParseAutocrypt(value); err == nil && strings.EqualFold(header.Addr, msg.Sender.Address) {
+			headers = append(headers, header)
+		}
+	}
+
+	if len(headers) != 1 {
+		return Autocrypt{}, time.Time{}, false
+	}
+
+	key, err := crypto.NewKey(headers[0].KeyData)
+	if err != nil || !key.CanEncrypt() {
+		return Autocrypt{}, time.Time{}, false
+	}
+
+	// A message dated in the future must not prevent later messages from updating the key.
+	date := time.Unix(msg.Time, 0)
+	if now := time.Now(); date.After(now) {
+		date = now
+	}
+
+	return headers[0], date, true
+}
+
+func getHeaderValues(hdr proton.Headers, name string) []string {
+	var values []string
+
+	for key, keyValues := range hdr.Values {
+		if strings.EqualFold(key, name) {
+			values = append(values, keyValues...)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge. If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+	"github.com/ProtonMail/proton-bridge/v3/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutocrypt_RoundTrip(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	header, err := NewAutocrypt("alice@example.com", kr, true)
+	require.NoError(t, err)
+
+	// The header can be folded and still be parsed back.
+	p, err := parser.New(strings.NewReader("From: alice@example.com\r\n\r\nHello"))
+	require.NoError(t, err)
+
+	p.Root().Header.Set(AutocryptHeader, header.String())
+
+	literal, err := buildMIMEBody(p)
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(literal, "\r\n") {
+		require.LessOrEqual(t, len(line), 78)
+	}
+
+	p, err = parser.New(strings.NewReader(literal))
+	require.NoError(t, err)
+
+	parsed, err := ParseAutocrypt(p.Root().Header.Get(AutocryptHeader))
+	require.NoError(t, err)
+	require.Equal(t, header, parsed)
+}
+
+func TestAutocrypt_Parse(t *testing.T) {
+	header, err := ParseAutocrypt("addr=bob@example.com; _ignored=value; keydata=aGVs bG8=")
+	require.NoError(t, err)
+	require.Equal(t, Autocrypt{Addr: "bob@example.com", KeyData: []byte("hello")}, header)
+
+	_, err = ParseAutocrypt("addr=bob@example.com; critical=value; keydata=aGVsbG8=")
+	require.ErrorIs(t, err, ErrInvalidAutocrypt)
+
+	_, err = ParseAutocrypt("addr=bob@example.com")
+	require.ErrorIs(t, err, ErrInvalidAutocrypt)
+}
+
+func TestGetAutocrypt(t *testing.T) {
+	header, err := NewAutocrypt("alice@example.com", utils.MakeKeyRing(t), false)
+	require.NoError(t, err)
+
+	date := time.Now().Add(-time.Hour)
+
+	msg := newRawTestMessageWithHeaders("messageID", "addressID", "text/plain", "body", date, map[string][]string{
+		AutocryptHeader: {header.String()},
+	})
+	msg.Sender = &mail.Address{Address: "Alice@Example.com"}
+
+	got, effectiveDate, ok := GetAutocrypt(msg)
+	require.True(t, ok)
+	require.Equal(t, header, got)
+	require.Equal(t, date.Unix(), effectiveDate.Unix())
+
+	// The header is ignored if it doesn't match the sender.
+	msg.Sender = &mail.Address{Address: "mallory@example.com"}
+	_, _, ok = GetAutocrypt(msg)
+	require.False(t, ok)
+
+	// The header is ignored if there are several of them.
+	msg.Sender = &mail.Address{Address: "alice@example.com"}
+	msg.ParsedHeaders.Values[AutocryptHeader] = append(msg.ParsedHeaders.Values[AutocryptHeader], header.String())
+	_, _, ok = GetAutocrypt(msg)
+	require.False(t, ok)
+}
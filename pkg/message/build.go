@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"mime"
 	"net/mail"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -54,6 +55,10 @@ func BuildRFC822Into(kr *crypto.KeyRing, decrypted *DecryptedMessage, opts JobOp
 		}
 	}
 
+	if opts.BlockRemoteContent {
+		blockRemoteContent(decrypted, opts.RemoteContentURL)
+	}
+
 	switch {
 	case len(decrypted.Msg.Attachments) > 0:
 		return buildMultipartRFC822(decrypted, opts, buf)
@@ -75,7 +80,7 @@ func buildSimpleRFC822(decrypted *DecryptedMessage, opts JobOptions, buf *bytes.
 		return buildMultipartRFC822(decrypted, opts, buf)
 	}
 
-	hdr := getTextPartHeader(getMessageHeader(decrypted.Msg, opts), decrypted.Body.Bytes(), decrypted.Msg.MIMEType)
+	hdr := getTextPartHeader(getDecryptedMessageHeader(decrypted, opts), decrypted.Body.Bytes(), decrypted.Msg.MIMEType)
 
 	w, err := message.CreateWriter(buf, hdr)
 	if err != nil {
@@ -96,7 +101,7 @@ func buildMultipartRFC822(
 ) error {
 	boundary := newBoundary(decrypted.Msg.ID)
 
-	hdr := getMessageHeader(decrypted.Msg, opts)
+	hdr := getDecryptedMessageHeader(decrypted, opts)
 
 	hdr.SetContentType("multipart/mixed", map[string]string{"boundary": boundary.gen()})
 
@@ -218,14 +223,19 @@ func buildPGPRFC822(kr *crypto.KeyRing, decrypted *DecryptedMessage, opts JobOpt
 		return buildPGPMIMEFallbackRFC822(decrypted, opts, buf)
 	}
 
-	hdr := getMessageHeader(decrypted.Msg, opts)
+	hdr := getDecryptedMessageHeader(decrypted, opts)
+
+	if opts.UnwrapPGPMIME {
+		return writeUnwrappedPGPRFC822(hdr, decrypted, buf)
+	}
 
 	sigs, err := proton.ExtractSignatures(kr, decrypted.Msg.Body)
 	if err != nil {
 		log.WithError(err).WithField("id", decrypted.Msg.ID).Warn("Extract signature failed")
 	}
 
-	if len(sigs) > 0 {
+	// A signature over the body no longer holds once its remote content is blocked.
+	if len(sigs) > 0 && decrypted.RemoteContent.IsEmpty() {
 		return writeMultipartSignedRFC822(hdr, decrypted.Body.Bytes(), sigs[0], buf)
 	}
 
@@ -233,7 +243,7 @@ func buildPGPRFC822(kr *crypto.KeyRing, decrypted *DecryptedMessage, opts JobOpt
 }
 
 func buildPGPMIMEFallbackRFC822(decrypted *DecryptedMessage, opts JobOptions, buf *bytes.Buffer) error {
-	hdr := getMessageHeader(decrypted.Msg, opts)
+	hdr := getDecryptedMessageHeader(decrypted, opts)
 
 	hdr.SetContentType("multipart/encrypted", map[string]string{
 		"boundary": newBoundary(decrypted.Msg.ID).gen(),
@@ -365,6 +375,26 @@ func addressEmpty(address *mail.Address) bool {
 	return false
 }
 
+func getDecryptedMessageHeader(decrypted *DecryptedMessage, opts JobOptions) message.Header {
+	hdr := getMessageHeader(decrypted.Msg, opts)
+
+	// Only unwrapped messages carry this header, and only as set by bridge.
+	hdr.Del(PGPMIMEHeader)
+
+	// Likewise, the remote content header only reports what bridge blocked.
+	hdr.Del(RemoteContentHeader)
+
+	if opts.VerifySignatures {
+		setAuthenticationResults(&hdr, decrypted, opts.TrustedAuthServIDs)
+	}
+
+	if !decrypted.RemoteContent.IsEmpty() {
+		hdr.Set(RemoteContentHeader, decrypted.RemoteContent.String())
+	}
+
+	return hdr
+}
+
 func getMessageHeader(msg proton.Message, opts JobOptions) message.Header {
 	hdr := toMessageHeader(msg.ParsedHeaders)
 
@@ -439,6 +469,15 @@ func getMessageHeader(msg proton.Message, opts JobOptions) message.Header {
 		}
 	}
 
+	// Set the raw message metadata if requested.
+	// This reflects the metadata at the time the message was built; later label changes are not included.
+	if opts.AddMetadata {
+		hdr.Set("X-Pm-Label-Ids", strings.Join(msg.LabelIDs, " "))
+		hdr.Set("X-Pm-Address-Id", msg.AddressID)
+		hdr.Set("X-Pm-Flags", strconv.FormatInt(int64(msg.Flags), 10))
+		hdr.Set("X-Pm-Size", strconv.Itoa(msg.Size))
+	}
+
 	return hdr
 }
 
@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ProtonMail/go-proton-api"
 	"github.com/ProtonMail/gopenpgp/v2/crypto"
 	"github.com/ProtonMail/proton-bridge/v3/utils"
 	"github.com/golang/mock/gomock"
@@ -84,6 +85,100 @@ func TestBuildHTMLMessage(t *testing.T) {
 		expectTransferEncoding(is(`quoted-printable`))
 }
 
+func TestBuildHTMLMessageBlockRemoteContent(t *testing.T) {
+	m := gomock.NewController(t)
+	defer m.Finish()
+
+	body := `<html><body><img src="https://example.com/logo.png"><img src="https://t.example.com/open.gif" width="1" height="1">body</body></html>`
+
+	kr := utils.MakeKeyRing(t)
+	msg := newTestMessage(t, kr, "messageID", "addressID", "text/html", body, time.Now())
+	att := addTestAttachment(t, kr, &msg, "attachID", "file.png", "image/png", "attachment", "attachment")
+
+	res, err := DecryptAndBuildRFC822(kr, msg, [][]byte{att}, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectHeader(RemoteContentHeader, is(`blocked; images=1; css=0; trackers=1`))
+
+	section(t, res, 1).
+		expectContentType(is(`text/html`)).
+		expectBody(is(`<html><body><img src="` + RemoteContentPlaceholder + `">body</body></html>`))
+
+	// The message is built the same way every time.
+	again, err := DecryptAndBuildRFC822(kr, msg, [][]byte{att}, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+}
+
+func TestBuildHTMLMessageBlockRemoteContentNothingRemote(t *testing.T) {
+	m := gomock.NewController(t)
+	defer m.Finish()
+
+	kr := utils.MakeKeyRing(t)
+	msg := newTestMessage(t, kr, "messageID", "addressID", "text/html", `<html><body><img src="cid:logo">body</body></html>`, time.Now())
+
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectHeader(RemoteContentHeader, isMissing()).
+		expectBody(is(`<html><body><img src="cid:logo">body</body></html>`))
+}
+
+func TestBuildHTMLEncryptedMessageBlockRemoteContent(t *testing.T) {
+	body := "Content-Type: multipart/alternative; boundary=\"alt\"\r\n\r\n" +
+		"--alt\r\nContent-Type: text/plain\r\n\r\nHello\r\n" +
+		"--alt\r\nContent-Type: text/html\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n" +
+		"<img src=3D\"https://example.com/logo.png\">Hello\r\n" +
+		"--alt--\r\n"
+
+	kr := utils.MakeKeyRing(t)
+	msg := newTestMessage(t, kr, "messageID", "addressID", "multipart/mixed", body, time.Now())
+
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectContentType(is(`multipart/alternative`)).
+		expectHeader(RemoteContentHeader, is(`blocked; images=1; css=0; trackers=0`))
+
+	section(t, res, 1).
+		expectContentType(is(`text/plain`)).
+		expectBody(is(`Hello`))
+
+	section(t, res, 2).
+		expectContentType(is(`text/html`)).
+		expectBody(is(`<img src="` + RemoteContentPlaceholder + `">Hello`))
+
+	// The message is built the same way every time.
+	again, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+}
+
+func TestBuildForgedRemoteContentHeader(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	enc, err := kr.Encrypt(crypto.NewPlainMessageFromString("<p>body</p>"), nil)
+	require.NoError(t, err)
+
+	arm, err := enc.GetArmored()
+	require.NoError(t, err)
+
+	msg := newRawTestMessageWithHeaders("messageID", "addressID", "text/html", arm, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), map[string][]string{
+		RemoteContentHeader: {"blocked; images=3; css=0; trackers=0"},
+	})
+
+	// The header only reports the remote content blocked by bridge.
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{BlockRemoteContent: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectContentType(is(`text/html`)).
+		expectHeader(RemoteContentHeader, isMissing())
+}
+
 func TestBuildPlainEncryptedMessage(t *testing.T) {
 	m := gomock.NewController(t)
 	defer m.Finish()
@@ -379,6 +474,62 @@ func TestBuildSignedPlainEncryptedMessage(t *testing.T) {
 		expectContentDispositionParam(`filename`, is(`OpenPGP_signature`))
 }
 
+func TestBuildUnwrappedSignedPlainEncryptedMessage(t *testing.T) {
+	m := gomock.NewController(t)
+	defer m.Finish()
+
+	body := readFile(t, "pgp-mime-body-signed-plaintext.eml")
+
+	kr := utils.MakeKeyRing(t)
+	msg := newTestMessage(t, kr, "messageID", "addressID", "multipart/mixed", body, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{UnwrapPGPMIME: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectDate(is(`Wed, 01 Jan 2020 00:00:00 +0000`)).
+		expectContentType(is(`multipart/mixed`)).
+		expectHeader(PGPMIMEHeader, is(`unwrapped; signature=unverified; original-part=2`))
+
+	section(t, res, 1).
+		expectContentType(is(`multipart/mixed`)).
+		expectContentTypeParam(`protected-headers`, is(`v1`)).
+		expectHeader(`Subject`, is(`plain body no pubkey`))
+
+	section(t, res, 1, 1).
+		expectContentType(is(`text/plain`)).
+		expectBody(contains(`Why do seagulls fly over the ocean`))
+
+	section(t, res, 2).
+		expectContentType(is(`application/octet-stream`)).
+		expectContentDisposition(is(`attachment`)).
+		expectContentDispositionParam(`filename`, is(`encrypted.asc`)).
+		expectHeader(`Content-Description`, is(`Original OpenPGP encrypted message`)).
+		expectBody(is(msg.Body))
+}
+
+func TestBuildForgedPGPMIMEHeader(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	enc, err := kr.Encrypt(crypto.NewPlainMessageFromString("body"), nil)
+	require.NoError(t, err)
+
+	arm, err := enc.GetArmored()
+	require.NoError(t, err)
+
+	msg := newRawTestMessageWithHeaders("messageID", "addressID", "text/plain", arm, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), map[string][]string{
+		PGPMIMEHeader: {"unwrapped; signature=valid; original-part=2"},
+	})
+
+	// The header is only set by bridge on the messages it unwraps.
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{UnwrapPGPMIME: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectContentType(is(`text/plain`)).
+		expectHeader(PGPMIMEHeader, isMissing())
+}
+
 func TestBuildSignedHTMLEncryptedMessage(t *testing.T) {
 	m := gomock.NewController(t)
 	defer m.Finish()
@@ -806,6 +957,33 @@ func TestBuildMessageDate(t *testing.T) {
 	section(t, res).expectDate(is(`Wed, 01 Jan 2020 00:00:00 +0000`))
 }
 
+func TestBuildMessageMetadata(t *testing.T) {
+	m := gomock.NewController(t)
+	defer m.Finish()
+
+	kr := utils.MakeKeyRing(t)
+	msg := newTestMessage(t, kr, "messageID", "addressID", "text/plain", "body", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	msg.LabelIDs = []string{proton.InboxLabel, proton.AllMailLabel}
+	msg.Flags = proton.MessageFlagReceived | proton.MessageFlagE2E
+	msg.Size = 1234
+
+	res, err := DecryptAndBuildRFC822(kr, msg, nil, JobOptions{})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectHeader(`X-Pm-Label-Ids`, isMissing()).
+		expectHeader(`X-Pm-Flags`, isMissing())
+
+	res, err = DecryptAndBuildRFC822(kr, msg, nil, JobOptions{AddMetadata: true})
+	require.NoError(t, err)
+
+	section(t, res).
+		expectHeader(`X-Pm-Label-Ids`, is(proton.InboxLabel+" "+proton.AllMailLabel)).
+		expectHeader(`X-Pm-Address-Id`, is("addressID")).
+		expectHeader(`X-Pm-Flags`, is("9")).
+		expectHeader(`X-Pm-Size`, is("1234"))
+}
+
 func TestBuildMessageWithInvalidDate(t *testing.T) {
 	m := gomock.NewController(t)
 	defer m.Finish()
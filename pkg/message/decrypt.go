@@ -39,6 +39,13 @@ type DecryptedMessage struct {
 	Body        bytes.Buffer
 	BodyErr     error
 	Attachments []DecryptedAttachment
+	Signature   SignatureStatus
+
+	// SMIMESignature is the result of the verification of the S/MIME signature of the message.
+	SMIMESignature SignatureStatus
+
+	// RemoteContent records the remote content removed from the message body, if it was blocked.
+	RemoteContent RemoteContentReport
 }
 
 var ErrInvalidAttachmentPacket = errors.New("invalid attachment packet")
@@ -54,35 +61,41 @@ func DecryptMessage(kr *crypto.KeyRing, msg proton.Message, attData [][]byte) De
 		result.BodyErr = errors.Wrap(ErrDecryptionFailed, err.Error())
 	}
 
-	result.Attachments = make([]DecryptedAttachment, len(msg.Attachments))
+	result.Attachments = decryptAttachments(kr, msg, attData)
+
+	return result
+}
+
+func decryptAttachments(kr *crypto.KeyRing, msg proton.Message, attData [][]byte) []DecryptedAttachment {
+	attachments := make([]DecryptedAttachment, len(msg.Attachments))
 
 	for i, attachment := range msg.Attachments {
-		result.Attachments[i].Encrypted = attData[i]
+		attachments[i].Encrypted = attData[i]
 
 		kps, err := base64.StdEncoding.DecodeString(attachment.KeyPackets)
 		if err != nil {
-			result.Attachments[i].Err = errors.Wrap(ErrInvalidAttachmentPacket, err.Error())
+			attachments[i].Err = errors.Wrap(ErrInvalidAttachmentPacket, err.Error())
 			continue
 		}
 
-		result.Attachments[i].Packet = kps
+		attachments[i].Packet = kps
 
 		// Use io.Multi
 		attachmentReader := io.MultiReader(bytes.NewReader(kps), bytes.NewReader(attData[i]))
 
 		stream, err := kr.DecryptStream(attachmentReader, nil, crypto.GetUnixTime())
 		if err != nil {
-			result.Attachments[i].Err = errors.Wrap(ErrDecryptionFailed, err.Error())
+			attachments[i].Err = errors.Wrap(ErrDecryptionFailed, err.Error())
 			continue
 		}
 
-		result.Attachments[i].Data.Grow(len(kps) + len(attData[i]))
+		attachments[i].Data.Grow(len(kps) + len(attData[i]))
 
-		if _, err := result.Attachments[i].Data.ReadFrom(stream); err != nil {
-			result.Attachments[i].Err = errors.Wrap(ErrDecryptionFailed, err.Error())
+		if _, err := attachments[i].Data.ReadFrom(stream); err != nil {
+			attachments[i].Err = errors.Wrap(ErrDecryptionFailed, err.Error())
 			continue
 		}
 	}
 
-	return result
+	return attachments
 }
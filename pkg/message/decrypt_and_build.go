@@ -38,3 +38,23 @@ func DecryptAndBuildRFC822Into(kr *crypto.KeyRing, msg proton.Message, attData [
 
 	return BuildRFC822Into(kr, &decrypted, opts, buf)
 }
+
+// DecryptVerifyAndBuildRFC822Into is like DecryptAndBuildRFC822Into but verifies the signature of the message
+// against the keys of its sender if opts.VerifySignatures or opts.UnwrapPGPMIME is set.
+// If the keys of the sender can't be looked up, the signature is recorded as unverified.
+func DecryptVerifyAndBuildRFC822Into(
+	kr *crypto.KeyRing,
+	keys SenderKeyProvider,
+	msg proton.Message,
+	attData [][]byte,
+	opts JobOptions,
+	buf *bytes.Buffer,
+) error {
+	if !opts.VerifySignatures && !opts.UnwrapPGPMIME {
+		return DecryptAndBuildRFC822Into(kr, msg, attData, opts, buf)
+	}
+
+	decrypted := DecryptAndVerifyMessage(kr, msg, attData, keys)
+
+	return BuildRFC822Into(kr, &decrypted, opts, buf)
+}
@@ -25,4 +25,11 @@ type JobOptions struct {
 	AddMessageDate         bool // Whether to include message time as X-Pm-Date.
 	AddMessageIDReference  bool // Whether to include the MessageID in References.
 	SanitizeMBOXHeaderLine bool // Whether to ignore header line representing MBOX delimiter
+	VerifySignatures       bool // Whether to include the signature verification results as X-Pm-Signature-Status and Authentication-Results.
+	AddMetadata            bool // Whether to include the raw message metadata as X-Pm-Label-Ids, X-Pm-Address-Id, X-Pm-Flags and X-Pm-Size.
+	UnwrapPGPMIME          bool // Whether to build PGP/MIME messages as their decrypted MIME tree, with the original attached.
+	BlockRemoteContent     bool // Whether to replace remote images and CSS in HTML bodies with a placeholder and strip tracking pixels.
+
+	RemoteContentURL   func(string) string // Rewrites the URLs of blocked remote images, e.g. to a proxy. The placeholder is used if nil.
+	TrustedAuthServIDs []string            // The servers whose Authentication-Results headers are kept. DefaultTrustedAuthServIDs is used if empty.
 }
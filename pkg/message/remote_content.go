@@ -0,0 +1,348 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/ProtonMail/proton-bridge/v3/pkg/message/parser"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// RemoteContentHeader is set on messages whose remote content was blocked.
+// It records how many remote images, CSS references and tracking pixels were removed.
+const RemoteContentHeader = "X-Pm-Remote-Content"
+
+// RemoteContentPlaceholder replaces the URL of blocked remote images. It is a transparent 1x1 GIF.
+const RemoteContentPlaceholder = "data:image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"
+
+// RemoteContentReport counts the remote content removed from a message.
+type RemoteContentReport struct {
+	Images   int  // Remote URLs in element attributes (src, srcset, background, poster).
+	Styles   int  // Remote URLs and imports in CSS, and remote stylesheets.
+	Trackers int  // Remote images of at most 1x1 pixels or hidden, removed altogether.
+	Proxied  bool // Whether remote URLs were rewritten to a proxy rather than to the placeholder.
+}
+
+func (report RemoteContentReport) IsEmpty() bool {
+	return report.Images == 0 && report.Styles == 0 && report.Trackers == 0
+}
+
+func (report RemoteContentReport) String() string {
+	action := "blocked"
+	if report.Proxied {
+		action = "proxied"
+	}
+
+	return fmt.Sprintf("%s; images=%d; css=%d; trackers=%d", action, report.Images, report.Styles, report.Trackers)
+}
+
+var (
+	remoteCSSURLRegexp    = regexp.MustCompile(`(?i)url\(\s*['"]?\s*((?:https?:)?//[^'")\s]*)\s*['"]?\s*\)`)
+	remoteCSSImportRegexp = regexp.MustCompile(`(?i)@import\s+(?:url\()?\s*['"]?\s*(?:https?:)?//[^;]*;?`)
+	cssDeclarationRegexp  = regexp.MustCompile(`(?i)(width|height|display|visibility)\s*:\s*([^;]+)`)
+)
+
+// blockRemoteContent removes the remote content of the message's HTML body, or of every HTML part of its MIME body.
+// Remote images are rewritten with the given function, or replaced by the placeholder if it is nil.
+// The result only depends on the body, so the built message stays the same across builds.
+func blockRemoteContent(decrypted *DecryptedMessage, rewrite func(string) string) {
+	if decrypted.BodyErr != nil {
+		return
+	}
+
+	var (
+		body   []byte
+		report RemoteContentReport
+	)
+
+	switch decrypted.Msg.MIMEType {
+	case rfc822.TextHTML:
+		body, report = blockRemoteHTML(decrypted.Body.Bytes(), rewrite)
+
+	case rfc822.MultipartMixed:
+		var err error
+
+		if body, report, err = blockRemoteMIME(decrypted.Body.Bytes(), rewrite); err != nil {
+			log.WithError(err).WithField("messageID", decrypted.Msg.ID).Warn("Failed to parse MIME body, remote content is not blocked")
+			return
+		}
+	}
+
+	if report.IsEmpty() {
+		return
+	}
+
+	decrypted.Body.Reset()
+	decrypted.Body.Write(body)
+	decrypted.RemoteContent = report
+}
+
+// blockRemoteMIME blocks the remote content of every HTML part of the given MIME body.
+// The body is only re-encoded if some remote content was found.
+func blockRemoteMIME(body []byte, rewrite func(string) string) ([]byte, RemoteContentReport, error) {
+	report := RemoteContentReport{Proxied: rewrite != nil}
+
+	p, err := parser.New(bytes.NewReader(body))
+	if err != nil {
+		return nil, report, err
+	}
+
+	if err := p.NewWalker().RegisterContentTypeHandler("^text/html$", func(part *parser.Part) error {
+		html, partReport := blockRemoteHTML(part.Body, rewrite)
+
+		part.Body = html
+		report.Images += partReport.Images
+		report.Styles += partReport.Styles
+		report.Trackers += partReport.Trackers
+
+		return nil
+	}).Walk(); err != nil {
+		return nil, report, err
+	}
+
+	if report.IsEmpty() {
+		return body, report, nil
+	}
+
+	var buf bytes.Buffer
+
+	if err := p.NewWriter().Write(&buf); err != nil {
+		return nil, report, err
+	}
+
+	return buf.Bytes(), report, nil
+}
+
+// blockRemoteHTML rewrites remote URLs in the given HTML and strips tracking pixels.
+// Tokens which are not changed are copied as is.
+func blockRemoteHTML(body []byte, rewrite func(string) string) ([]byte, RemoteContentReport) {
+	var (
+		report  = RemoteContentReport{Proxied: rewrite != nil}
+		out     bytes.Buffer
+		inStyle bool
+	)
+
+	if rewrite == nil {
+		rewrite = func(string) string { return RemoteContentPlaceholder }
+	}
+
+	out.Grow(len(body))
+
+	z := html.NewTokenizer(bytes.NewReader(body))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// The tokenizer only fails on EOF when reading from memory.
+			break
+		}
+
+		raw := bytes.Clone(z.Raw())
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+
+			inStyle = tt == html.StartTagToken && tok.DataAtom == atom.Style
+
+			if isTrackingPixel(tok) {
+				report.Trackers++
+				continue
+			}
+
+			if isRemoteStylesheet(tok) {
+				report.Styles++
+				continue
+			}
+
+			if blockRemoteAttrs(&tok, rewrite, &report) {
+				out.WriteString(tok.String())
+			} else {
+				out.Write(raw)
+			}
+
+		case html.EndTagToken:
+			inStyle = false
+
+			out.Write(raw)
+
+		case html.TextToken:
+			if inStyle {
+				css, n := blockRemoteCSS(string(raw), rewrite)
+				report.Styles += n
+				out.WriteString(css)
+			} else {
+				out.Write(raw)
+			}
+
+		default:
+			out.Write(raw)
+		}
+	}
+
+	if report.IsEmpty() {
+		return body, report
+	}
+
+	return out.Bytes(), report
+}
+
+// blockRemoteAttrs rewrites the remote URLs in the token's attributes. It returns whether the token was changed.
+func blockRemoteAttrs(tok *html.Token, rewrite func(string) string, report *RemoteContentReport) bool {
+	var changed bool
+
+	attrs := tok.Attr[:0]
+
+	for _, attr := range tok.Attr {
+		switch strings.ToLower(attr.Key) {
+		case "src", "background", "poster":
+			if isRemoteURL(attr.Val) {
+				attr.Val = rewrite(strings.TrimSpace(attr.Val))
+				report.Images++
+				changed = true
+			}
+
+		case "srcset":
+			if hasRemoteSrcset(attr.Val) {
+				report.Images++
+				changed = true
+
+				continue
+			}
+
+		case "style":
+			if css, n := blockRemoteCSS(attr.Val, rewrite); n > 0 {
+				attr.Val = css
+				report.Styles += n
+				changed = true
+			}
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	tok.Attr = attrs
+
+	return changed
+}
+
+// blockRemoteCSS rewrites remote URLs in the given CSS and drops remote imports.
+// It returns the number of references removed.
+func blockRemoteCSS(css string, rewrite func(string) string) (string, int) {
+	var n int
+
+	css = remoteCSSImportRegexp.ReplaceAllStringFunc(css, func(string) string {
+		n++
+		return ""
+	})
+
+	css = remoteCSSURLRegexp.ReplaceAllStringFunc(css, func(match string) string {
+		n++
+		return "url(" + rewrite(remoteCSSURLRegexp.FindStringSubmatch(match)[1]) + ")"
+	})
+
+	return css, n
+}
+
+// isTrackingPixel returns whether the token is a remote image which is not meant to be seen:
+// one at most 1x1 pixels in size, or hidden.
+func isTrackingPixel(tok html.Token) bool {
+	if tok.DataAtom != atom.Img || !isRemoteURL(getAttr(tok, "src")) {
+		return false
+	}
+
+	width, height := getAttr(tok, "width"), getAttr(tok, "height")
+
+	for _, decl := range cssDeclarationRegexp.FindAllStringSubmatch(getAttr(tok, "style"), -1) {
+		value := strings.ToLower(strings.TrimSpace(decl[2]))
+
+		switch strings.ToLower(decl[1]) {
+		case "width":
+			width = value
+
+		case "height":
+			height = value
+
+		case "display":
+			if strings.HasPrefix(value, "none") {
+				return true
+			}
+
+		case "visibility":
+			if strings.HasPrefix(value, "hidden") {
+				return true
+			}
+		}
+	}
+
+	return isTinyDimension(width) && isTinyDimension(height)
+}
+
+// isTinyDimension returns whether the given HTML or CSS dimension is at most one pixel.
+func isTinyDimension(value string) bool {
+	value = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "px")
+
+	size, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false
+	}
+
+	return size <= 1
+}
+
+func isRemoteStylesheet(tok html.Token) bool {
+	if tok.DataAtom != atom.Link || !isRemoteURL(getAttr(tok, "href")) {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(getAttr(tok, "rel")), "stylesheet")
+}
+
+func hasRemoteSrcset(srcset string) bool {
+	for _, candidate := range strings.Split(srcset, ",") {
+		if isRemoteURL(candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRemoteURL returns whether the URL refers to content that would be fetched over the network.
+func isRemoteURL(url string) bool {
+	url = strings.ToLower(strings.TrimSpace(url))
+
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "//")
+}
+
+func getAttr(tok html.Token, key string) string {
+	for _, attr := range tok.Attr {
+		if strings.EqualFold(attr.Key, key) {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRemoteHTML(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantBody   string
+		wantReport RemoteContentReport
+	}{
+		{
+			name:     "nothing remote",
+			body:     `<p style="color: red">Hi <img src="cid:logo"> <img src="data:image/png;base64,AAAA"></p>`,
+			wantBody: `<p style="color: red">Hi <img src="cid:logo"> <img src="data:image/png;base64,AAAA"></p>`,
+		},
+		{
+			name:       "remote image",
+			body:       `<IMG alt="Logo" SRC="https://example.com/logo.png" srcset="//example.com/logo@2x.png 2x">`,
+			wantBody:   `<img alt="Logo" src="` + RemoteContentPlaceholder + `">`,
+			wantReport: RemoteContentReport{Images: 2},
+		},
+		{
+			name:       "remote background",
+			body:       `<table background="http://example.com/bg.png"><tr><td>Hi</td></tr></table>`,
+			wantBody:   `<table background="` + RemoteContentPlaceholder + `"><tr><td>Hi</td></tr></table>`,
+			wantReport: RemoteContentReport{Images: 1},
+		},
+		{
+			name:       "tracking pixels",
+			body:       `<p>Hi</p><img src="https://t.example.com/a" width="1" height="1"><img src="https://t.example.com/b" style="width: 0px; height: 0px"><img src="https://t.example.com/c" style="display:none">`,
+			wantBody:   `<p>Hi</p>`,
+			wantReport: RemoteContentReport{Trackers: 3},
+		},
+		{
+			name:       "small but local image",
+			body:       `<img src="cid:spacer" width="1" height="1">`,
+			wantBody:   `<img src="cid:spacer" width="1" height="1">`,
+			wantReport: RemoteContentReport{},
+		},
+		{
+			name:       "remote css",
+			body:       `<style>@import url("https://example.com/a.css"); p { background: url('https://example.com/bg.png') }</style><p style="background-image: url(//example.com/p.png)">Hi</p>`,
+			wantBody:   `<style> p { background: url(` + RemoteContentPlaceholder + `) }</style><p style="background-image: url(` + RemoteContentPlaceholder + `)">Hi</p>`,
+			wantReport: RemoteContentReport{Styles: 3},
+		},
+		{
+			name:       "remote stylesheet",
+			body:       `<head><link rel="stylesheet" href="https://example.com/a.css"><link rel="icon" href="https://example.com/favicon.ico"></head>`,
+			wantBody:   `<head><link rel="icon" href="https://example.com/favicon.ico"></head>`,
+			wantReport: RemoteContentReport{Styles: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, report := blockRemoteHTML([]byte(test.body), nil)
+			require.Equal(t, test.wantBody, string(body))
+			require.Equal(t, test.wantReport, report)
+		})
+	}
+}
+
+func TestBlockRemoteHTML_Rewrite(t *testing.T) {
+	rewrite := func(url string) string {
+		return "http://127.0.0.1:1080/image?url=" + url
+	}
+
+	body, report := blockRemoteHTML([]byte(`<img src=" https://example.com/a.png "><p style="background: url('//example.com/b.png')">Hi</p>`), rewrite)
+	require.Equal(t, `<img src="http://127.0.0.1:1080/image?url=https://example.com/a.png"><p style="background: url(http://127.0.0.1:1080/image?url=//example.com/b.png)">Hi</p>`, string(body))
+	require.Equal(t, RemoteContentReport{Images: 1, Styles: 1, Proxied: true}, report)
+	require.Equal(t, "proxied; images=1; css=1; trackers=0", report.String())
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+	"github.com/emersion/go-message/textproto"
+	"github.com/sirupsen/logrus"
+)
+
+// SMIMEStatusHeader records the result of the verification of the S/MIME signature of a message, if it has one.
+const SMIMEStatusHeader = "X-Pm-Smime-Status"
+
+// smimeRoots are the roots the certificates of S/MIME signers must chain up to. Nil uses the system roots.
+var smimeRoots *x509.CertPool //nolint:gochecknoglobals
+
+// SignSMIME wraps the given message into a multipart/signed S/MIME message (RFC 8551) signed with the given identity.
+// The content headers of the message move to the signed part; all the other headers stay on the outer message.
+func SignSMIME(b []byte, id *smime.Identity) ([]byte, error) {
+	header, body, err := readHeaderBody(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var contentLines [][]byte
+
+	fields := header.Fields()
+	for fields.Next() {
+		if !strings.HasPrefix(strings.ToLower(fields.Key()), "content-") {
+			continue
+		}
+
+		raw, err := fields.Raw()
+		if err != nil {
+			return nil, err
+		}
+
+		contentLines = append(contentLines, raw)
+
+		fields.Del()
+	}
+
+	var contentHeader textproto.Header
+
+	// Fields are prepended, so they are added in reverse to keep their order.
+	for i := len(contentLines) - 1; i >= 0; i-- {
+		contentHeader.AddRaw(contentLines[i])
+	}
+
+	content := new(bytes.Buffer)
+
+	if err := textproto.WriteHeader(content, contentHeader); err != nil {
+		return nil, err
+	}
+
+	if _, err := content.Write(body); err != nil {
+		return nil, err
+	}
+
+	// The signature covers the canonical form of the part, with CRLF line endings.
+	signed := canonicalizeLineEndings(content.Bytes())
+
+	sig, err := id.Sign(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := newBoundary(string(signed)).gen()
+
+	if !header.Has("Mime-Version") {
+		header.Set("Mime-Version", "1.0")
+	}
+
+	header.Set("Content-Type", mime.FormatMediaType("multipart/signed", map[string]string{
+		"protocol": "application/pkcs7-signature",
+		"micalg":   "sha-256",
+		"boundary": boundary,
+	}))
+
+	buf := new(bytes.Buffer)
+
+	if err := textproto.WriteHeader(buf, *header); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("--" + boundary + "\r\n")
+	buf.Write(signed)
+	buf.WriteString("\r\n--" + boundary + "\r\n")
+	buf.WriteString("Content-Type: application/pkcs7-signature; name=smime.p7s\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("Content-Disposition: attachment; filename=smime.p7s\r\n")
+	buf.WriteString("\r\n")
+
+	for enc := base64.StdEncoding.EncodeToString(sig); len(enc) > 0; {
+		n := min(len(enc), 76)
+		buf.WriteString(enc[:n] + "\r\n")
+		enc = enc[n:]
+	}
+
+	buf.WriteString("--" + boundary + "--\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// verifySMIMESignature verifies the signature of a multipart/signed S/MIME body.
+// Other bodies are reported as not signed; this includes S/MIME messages whose MIME structure
+// was not kept when they were received, as the signed part can't be rebuilt exactly.
+// The signer's certificate must chain up to a trusted root and be issued for the sender of the message.
+// It is verified at the time the message was received by Proton rather than at the signing time claimed by the sender.
+func verifySMIMESignature(decrypted *DecryptedMessage) SignatureStatus {
+	if decrypted.Msg.MIMEType != "multipart/mixed" {
+		return SignatureNone
+	}
+
+	signed, sigData, err := splitMultipartSigned(decrypted.Body.Bytes(), "application/pkcs7-signature", "application/x-pkcs7-signature")
+	if err != nil {
+		log.WithError(err).WithField("id", decrypted.Msg.ID).Warn("Failed to extract S/MIME signature")
+		return SignatureInvalid
+	} else if signed == nil {
+		return SignatureNone
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(sigData), nil)))
+	if err != nil {
+		return SignatureInvalid
+	}
+
+	signer, err := smime.Verify(signed, sig)
+	if err != nil {
+		log.WithError(err).WithField("id", decrypted.Msg.ID).Debug("S/MIME signature does not verify")
+		return SignatureInvalid
+	}
+
+	if decrypted.Msg.Sender == nil || !smime.CertificateHasEmail(signer.Certificate, decrypted.Msg.Sender.Address) {
+		return SignatureUnknownKey
+	}
+
+	switch err := signer.VerifyChain(smimeRoots, time.Unix(decrypted.Msg.Time, 0)); {
+	case err == nil:
+		return SignatureValid
+
+	case errors.Is(err, smime.ErrRevocationUnknown):
+		return SignatureRevocationUnknown
+
+	case errors.Is(err, smime.ErrRevoked):
+		return SignatureInvalid
+
+	default:
+		log.WithError(err).WithFields(logrus.Fields{
+			"id":      decrypted.Msg.ID,
+			"subject": signer.Certificate.Subject.String(),
+		}).Debug("S/MIME signer certificate is not trusted")
+
+		return SignatureUnknownKey
+	}
+}
+
+func hasSMIMESignature(status SignatureStatus) bool {
+	return status != SignatureNotVerified && status != SignatureNone
+}
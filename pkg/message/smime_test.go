@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+	"github.com/ProtonMail/proton-bridge/v3/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignSMIME(t *testing.T) {
+	_, id := newTestSMIMEIdentity(t, "sender@pm.me")
+
+	signed, err := SignSMIME([]byte("Subject: Hello\r\nContent-Type: text/plain\r\n\r\nbody\r\n"), id)
+	require.NoError(t, err)
+
+	// The content headers move to the signed part, the others stay on the outer message.
+	section(t, signed).
+		expectContentType(is(`multipart/signed`)).
+		expectContentTypeParam(`protocol`, is(`application/pkcs7-signature`)).
+		expectHeader(`Subject`, is(`Hello`))
+
+	section(t, signed, 1).
+		expectContentType(is(`text/plain`)).
+		expectBody(is("body\r\n"))
+
+	section(t, signed, 2).
+		expectContentType(is(`application/pkcs7-signature`)).
+		expectContentDisposition(is(`attachment`))
+}
+
+func TestVerifyMessage_SMIME(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	ca, id := newTestSMIMEIdentity(t, "sender@pm.me")
+	_, other := newTestSMIMEIdentity(t, "other@pm.me")
+
+	newSigned := func(id *smime.Identity) string {
+		signed, err := SignSMIME([]byte("Content-Type: text/plain\r\n\r\nbody"), id)
+		require.NoError(t, err)
+
+		return string(signed)
+	}
+
+	newBody := func(id *smime.Identity) string {
+		return encryptTestBody(t, kr, nil, newSigned(id))
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	smimeRoots = roots
+	defer func() { smimeRoots = nil }()
+
+	// The chain is trusted, but the signature carries no revocation list to check the certificate against.
+	header := buildVerified(t, kr, nil, "multipart/mixed", newBody(id), nil)
+	require.Equal(t, "revocation-unknown", header.Get(SMIMEStatusHeader))
+	require.Equal(t, "proton-bridge; pgp=none; smime=neutral", header.Get("Authentication-Results"))
+
+	// The certificate must be issued for the sender.
+	header = buildVerified(t, kr, nil, "multipart/mixed", newBody(other), nil)
+	require.Equal(t, "unknown-key", header.Get(SMIMEStatusHeader))
+
+	// Any change to the signed part invalidates the signature.
+	tampered := strings.Replace(newSigned(id), "\r\n\r\nbody", "\r\n\r\nb0dy", 1)
+
+	header = buildVerified(t, kr, nil, "multipart/mixed", encryptTestBody(t, kr, nil, tampered), nil)
+	require.Equal(t, "invalid", header.Get(SMIMEStatusHeader))
+	require.Equal(t, "proton-bridge; pgp=none; smime=fail", header.Get("Authentication-Results"))
+
+	// Without the root, the signer is not trusted.
+	smimeRoots = x509.NewCertPool()
+
+	header = buildVerified(t, kr, nil, "multipart/mixed", newBody(id), nil)
+	require.Equal(t, "unknown-key", header.Get(SMIMEStatusHeader))
+
+	// Messages without an S/MIME signature carry no S/MIME result.
+	header = buildVerified(t, kr, nil, "text/plain", encryptTestBody(t, kr, nil, "body"), nil)
+	require.Empty(t, header.Get(SMIMEStatusHeader))
+}
+
+func newTestSMIMEIdentity(t *testing.T, email string) (*x509.Certificate, *smime.Identity) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// The certificates are valid when the test messages were received, in 2020.
+	notBefore := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             notBefore,
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        pkix.Name{CommonName: email},
+		EmailAddresses: []string{email},
+		NotBefore:      notBefore,
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}, ca, key.Public(), caKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	id, err := smime.ParseIdentity(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	)
+	require.NoError(t, err)
+
+	return ca, id
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"bytes"
+
+	"github.com/emersion/go-message"
+	"github.com/emersion/go-message/textproto"
+)
+
+// PGPMIMEHeader is set on unwrapped PGP/MIME messages.
+// It records the result of the signature verification and the part holding the original encrypted message.
+const PGPMIMEHeader = "X-Pm-Pgp-Mime"
+
+// writeUnwrappedPGPRFC822 writes the decrypted PGP/MIME message as a plain MIME message.
+// The decrypted message, without its signature, is the first part; the original encrypted message is the second,
+// so that it can still be fetched for audit.
+func writeUnwrappedPGPRFC822(header message.Header, decrypted *DecryptedMessage, buf *bytes.Buffer) error {
+	body := decrypted.Body.Bytes()
+
+	// Signed-then-encrypted messages carry a multipart/signed body; only keep the signed part.
+	if signed, sig, err := getDetachedSignature(body); err == nil && sig != nil {
+		body = signed
+	}
+
+	bodyHeader, bodyData, err := readHeaderBody(body)
+	if err != nil {
+		return err
+	}
+
+	boundary := newBoundary(decrypted.Msg.ID).gen()
+
+	header.SetContentType("multipart/mixed", map[string]string{"boundary": boundary})
+	header.Del("Content-Transfer-Encoding")
+	header.Set(PGPMIMEHeader, "unwrapped; signature="+decrypted.Signature.String()+"; original-part=2")
+
+	if err := textproto.WriteHeader(buf, header.Header); err != nil {
+		return err
+	}
+
+	mw := textproto.NewMultipartWriter(buf)
+
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	bodyPart, err := mw.CreatePart(*bodyHeader)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bodyPart.Write(bodyData); err != nil {
+		return err
+	}
+
+	var origHeader message.Header
+
+	origHeader.SetContentType("application/octet-stream", map[string]string{"name": "encrypted.asc"})
+	origHeader.SetContentDisposition("attachment", map[string]string{"filename": "encrypted.asc"})
+	origHeader.Set("Content-Description", "Original OpenPGP encrypted message")
+
+	origPart, err := mw.CreatePart(origHeader.Header)
+	if err != nil {
+		return err
+	}
+
+	if _, err := origPart.Write([]byte(decrypted.Msg.Body)); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
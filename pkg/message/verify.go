@@ -0,0 +1,459 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge. If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"bytes"
+	stdcrypto "crypto"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgpErrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/ProtonMail/go-proton-api"
+	"github.com/ProtonMail/gopenpgp/v2/constants"
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/emersion/go-message"
+	"github.com/pkg/errors"
+)
+
+// AuthServID is the authentication service identifier used in the Authentication-Results header added by bridge.
+// Authentication-Results headers already carrying this identifier are removed from the message as they can't be trusted.
+const AuthServID = "proton-bridge"
+
+// DefaultTrustedAuthServIDs are the authentication service identifiers of the Proton MX servers, which are trusted
+// unless JobOptions.TrustedAuthServIDs says otherwise. Authentication-Results headers carrying any other identifier
+// may have been added by anyone, including the sender, and are removed from the message.
+var DefaultTrustedAuthServIDs = []string{"mail.protonmail.ch", "mailsec.protonmail.ch"} //nolint:gochecknoglobals
+
+// SignatureStatus is the result of the verification of the signature of a message.
+type SignatureStatus int
+
+const (
+	SignatureNotVerified       SignatureStatus = iota // The signature was not verified.
+	SignatureNone                                     // The message is not signed.
+	SignatureValid                                    // The signature matches the keys of the sender.
+	SignatureInvalid                                  // The signature does not match the message or the keys of the sender.
+	SignatureUnknownKey                               // The message is signed with a key not known to belong to the sender.
+	SignatureRevocationUnknown                        // The message is signed with a trusted certificate whose revocation couldn't be checked.
+)
+
+func (status SignatureStatus) String() string {
+	switch status {
+	case SignatureNotVerified:
+		return "unverified"
+
+	case SignatureNone:
+		return "none"
+
+	case SignatureValid:
+		return "valid"
+
+	case SignatureInvalid:
+		return "invalid"
+
+	case SignatureUnknownKey:
+		return "unknown-key"
+
+	case SignatureRevocationUnknown:
+		return "revocation-unknown"
+
+	default:
+		return "unknown"
+	}
+}
+
+// authResult returns the RFC 8601 result corresponding to the signature status.
+func (status SignatureStatus) authResult() string {
+	switch status {
+	case SignatureValid:
+		return "pass"
+
+	case SignatureInvalid:
+		return "fail"
+
+	case SignatureUnknownKey, SignatureRevocationUnknown:
+		return "neutral"
+
+	case SignatureNone:
+		return "none"
+
+	default:
+		return "temperror"
+	}
+}
+
+// SenderKeyProvider provides the public keys used to verify signatures of messages from the given sender.
+// It is only called for messages which are signed.
+type SenderKeyProvider interface {
+	GetSenderKeys(sender string) (*crypto.KeyRing, error)
+}
+
+// DecryptAndVerifyMessage decrypts the message like DecryptMessage and verifies its signature against the keys of its sender,
+// storing the result in Signature. The signature embedded in the encrypted body is checked while the body is decrypted;
+// PGP/MIME messages without one may carry a detached signature in a multipart/signed body, which is checked instead.
+// S/MIME signatures are checked independently and stored in SMIMESignature.
+// If the keys of the sender of a signed message can't be looked up, the signature is left unverified.
+func DecryptAndVerifyMessage(kr *crypto.KeyRing, msg proton.Message, attData [][]byte, keys SenderKeyProvider) DecryptedMessage {
+	result := DecryptedMessage{
+		Msg: msg,
+	}
+
+	senderKR := newSenderKeyRing(kr, msg, keys)
+
+	result.Body.Grow(len(msg.Body))
+
+	status, err := decryptAndVerifyBody(senderKR, msg.Body, &result.Body)
+	if err != nil {
+		result.BodyErr = errors.Wrap(ErrDecryptionFailed, err.Error())
+	}
+
+	result.Attachments = decryptAttachments(kr, msg, attData)
+
+	if result.BodyErr != nil {
+		return result
+	}
+
+	result.SMIMESignature = verifySMIMESignature(&result)
+
+	if status == SignatureNone && msg.MIMEType == "multipart/mixed" {
+		status = verifyDetachedSignature(senderKR, result.Body.Bytes())
+	}
+
+	// Without the keys of the sender, the signer of the message is unknown rather than untrusted.
+	if senderKR.err != nil {
+		log.WithError(senderKR.err).WithField("messageID", msg.ID).Warn("Failed to get sender keys, signature not verified")
+		status = SignatureNotVerified
+	}
+
+	result.Signature = status
+
+	return result
+}
+
+// verifyDetachedSignature verifies the detached signature of a PGP/MIME multipart/signed body, if there is one.
+func verifyDetachedSignature(senderKR *senderKeyRing, body []byte) SignatureStatus {
+	signed, sig, err := getDetachedSignature(body)
+	if err != nil {
+		log.WithError(err).Warn("Failed to extract message signature")
+		return SignatureInvalid
+	}
+
+	if sig == nil {
+		return SignatureNone
+	}
+
+	verifyKR, err := senderKR.getVerifyKeyRing()
+	if err != nil {
+		return SignatureNotVerified
+	}
+
+	if verifyKR == nil {
+		return SignatureUnknownKey
+	}
+
+	return getSignatureStatus(verifyKR.VerifyDetached(crypto.NewPlainMessage(signed), sig, 0))
+}
+
+// decryptAndVerifyBody decrypts the armored body into buf and verifies its embedded signature in the same pass.
+// Time checks are disabled as the keys of the sender may have expired since the message was sent.
+func decryptAndVerifyBody(senderKR *senderKeyRing, armored string, buf *bytes.Buffer) (SignatureStatus, error) {
+	enc, err := crypto.NewPGPMessageFromArmored(armored)
+	if err != nil {
+		return SignatureNotVerified, err
+	}
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(enc.GetBinary()), senderKR, nil, &packet.Config{Time: crypto.GetTime})
+	if err != nil {
+		return SignatureNotVerified, err
+	}
+
+	if _, err := buf.ReadFrom(md.UnverifiedBody); err != nil {
+		return SignatureNotVerified, err
+	}
+
+	switch {
+	case !md.IsSigned:
+		return SignatureNone, nil
+
+	case md.SignedBy == nil:
+		return SignatureUnknownKey, nil
+
+	case md.SignatureError != nil && !errors.Is(md.SignatureError, pgpErrors.ErrSignatureExpired):
+		return SignatureInvalid, nil
+
+	case md.Signature == nil || !slices.Contains(allowedSignatureHashes, md.Signature.Hash):
+		return SignatureInvalid, nil
+
+	default:
+		return SignatureValid, nil
+	}
+}
+
+// allowedSignatureHashes are the hash algorithms accepted in signatures, as in gopenpgp.
+var allowedSignatureHashes = []stdcrypto.Hash{ //nolint:gochecknoglobals
+	stdcrypto.SHA224,
+	stdcrypto.SHA256,
+	stdcrypto.SHA384,
+	stdcrypto.SHA512,
+	stdcrypto.SHA3_256,
+	stdcrypto.SHA3_512,
+}
+
+// senderKeyRing decrypts the body of a message with the keys of the user and verifies its signature with the keys of the sender.
+// The keys of the sender are only looked up once a signature is found.
+type senderKeyRing struct {
+	decryptionKeys openpgp.EntityList
+
+	sender string
+	keys   SenderKeyProvider
+
+	verifyKR *crypto.KeyRing
+	fetched  bool
+	err      error
+}
+
+func newSenderKeyRing(kr *crypto.KeyRing, msg proton.Message, keys SenderKeyProvider) *senderKeyRing {
+	senderKR := &senderKeyRing{
+		decryptionKeys: getEntities(kr),
+		keys:           keys,
+	}
+
+	if msg.Sender != nil {
+		senderKR.sender = msg.Sender.Address
+	}
+
+	return senderKR
+}
+
+func (r *senderKeyRing) KeysById(id uint64) []openpgp.Key { //nolint:revive,stylecheck
+	return r.decryptionKeys.KeysById(id)
+}
+
+func (r *senderKeyRing) KeysByIdUsage(id uint64, requiredUsage byte) []openpgp.Key { //nolint:revive,stylecheck
+	if requiredUsage&packet.KeyFlagSign == 0 {
+		return r.decryptionKeys.KeysByIdUsage(id, requiredUsage)
+	}
+
+	verifyKR, err := r.getVerifyKeyRing()
+	if err != nil || verifyKR == nil {
+		return nil
+	}
+
+	return getEntities(verifyKR).KeysByIdUsage(id, requiredUsage)
+}
+
+func (r *senderKeyRing) DecryptionKeys() []openpgp.Key {
+	return r.decryptionKeys.DecryptionKeys()
+}
+
+// getVerifyKeyRing returns the keys of the sender, or nil if the message has no sender address.
+func (r *senderKeyRing) getVerifyKeyRing() (*crypto.KeyRing, error) {
+	if r.fetched || r.sender == "" {
+		return r.verifyKR, r.err
+	}
+
+	r.fetched = true
+
+	r.verifyKR, r.err = r.keys.GetSenderKeys(r.sender)
+
+	return r.verifyKR, r.err
+}
+
+func getEntities(kr *crypto.KeyRing) openpgp.EntityList {
+	entities := make(openpgp.EntityList, 0, kr.CountEntities())
+
+	for _, key := range kr.GetKeys() {
+		entities = append(entities, key.GetEntity())
+	}
+
+	return entities
+}
+
+func getSignatureStatus(err error) SignatureStatus {
+	if err == nil {
+		return SignatureValid
+	}
+
+	var sigErr crypto.SignatureVerificationError
+	if !errors.As(err, &sigErr) {
+		return SignatureInvalid
+	}
+
+	switch sigErr.Status {
+	case constants.SIGNATURE_NOT_SIGNED:
+		return SignatureNone
+
+	case constants.SIGNATURE_NO_VERIFIER:
+		return SignatureUnknownKey
+
+	default:
+		return SignatureInvalid
+	}
+}
+
+// getDetachedSignature returns the signed part and the signature of a multipart/signed PGP/MIME body (RFC 3156).
+func getDetachedSignature(body []byte) ([]byte, *crypto.PGPSignature, error) {
+	signed, sigData, err := splitMultipartSigned(body, "application/pgp-signature")
+	if err != nil || signed == nil {
+		return nil, nil, err
+	}
+
+	sig, err := crypto.NewPGPSignatureFromArmored(string(sigData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signed, sig, nil
+}
+
+// splitMultipartSigned returns the signed part and the undecoded body of the signature part of a multipart/signed body
+// whose protocol is one of the given ones. Both are nil if the body is not such a multipart/signed body.
+func splitMultipartSigned(body []byte, protocols ...string) ([]byte, []byte, error) {
+	header, data, err := readHeaderBody(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/signed" || !slices.Contains(protocols, strings.ToLower(params["protocol"])) || params["boundary"] == "" {
+		return nil, nil, nil //nolint:nilerr
+	}
+
+	parts := bytes.Split(append([]byte("\r\n"), canonicalizeLineEndings(data)...), []byte("\r\n--"+params["boundary"]))
+	if len(parts) < 4 {
+		return nil, nil, errors.New("malformed multipart/signed body")
+	}
+
+	signed, ok := trimDelimiterLine(parts[1])
+	if !ok {
+		return nil, nil, errors.New("malformed signed part")
+	}
+
+	sigPart, ok := trimDelimiterLine(parts[2])
+	if !ok {
+		return nil, nil, errors.New("malformed signature part")
+	}
+
+	_, sigData, err := readHeaderBody(sigPart)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signed, sigData, nil
+}
+
+// trimDelimiterLine removes the remainder of the boundary delimiter line from the start of the part.
+func trimDelimiterLine(part []byte) ([]byte, bool) {
+	idx := bytes.Index(part, []byte("\r\n"))
+	if idx < 0 {
+		return nil, false
+	}
+
+	return part[idx+2:], true
+}
+
+func canonicalizeLineEndings(b []byte) []byte {
+	return bytes.ReplaceAll(bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n"))
+}
+
+// setAuthenticationResults records the verification results in the header.
+// The SPF, DKIM and DMARC verdicts are taken from the Authentication-Results header added by the trusted servers;
+// all other Authentication-Results headers are removed.
+// The S/MIME result is only recorded for messages with an S/MIME signature.
+func setAuthenticationResults(hdr *message.Header, decrypted *DecryptedMessage, trusted []string) {
+	if len(trusted) == 0 {
+		trusted = DefaultTrustedAuthServIDs
+	}
+
+	hdr.Del("X-Pm-Signature-Status")
+	hdr.Del(SMIMEStatusHeader)
+
+	fields := hdr.FieldsByKey("Authentication-Results")
+	for fields.Next() {
+		if !isTrustedAuthServID(trusted, getAuthServID(fields.Value())) {
+			fields.Del()
+		}
+	}
+
+	results := []string{AuthServID, "pgp=" + decrypted.Signature.authResult()}
+
+	if hasSMIMESignature(decrypted.SMIMESignature) {
+		results = append(results, "smime="+decrypted.SMIMESignature.authResult())
+		hdr.Set(SMIMEStatusHeader, decrypted.SMIMESignature.String())
+	}
+
+	for _, method := range []string{"spf", "dkim", "dmarc"} {
+		if result := getServerAuthResult(decrypted.Msg.ParsedHeaders, trusted, method); result != "" {
+			results = append(results, method+"="+result)
+		}
+	}
+
+	hdr.Set("X-Pm-Signature-Status", decrypted.Signature.String())
+	hdr.Add("Authentication-Results", strings.Join(results, "; "))
+}
+
+// getServerAuthResult returns the result of the given method in the topmost Authentication-Results header
+// added by the trusted servers when the message was received.
+// Messages which never went through them, such as messages between Proton users, have no such header.
+func getServerAuthResult(hdr proton.Headers, trusted []string, method string) string {
+	for _, key := range hdr.Order {
+		if !strings.EqualFold(key, "Authentication-Results") {
+			continue
+		}
+
+		for _, value := range hdr.Values[key] {
+			if !isTrustedAuthServID(trusted, getAuthServID(value)) {
+				continue
+			}
+
+			for _, res := range strings.Split(value, ";")[1:] {
+				name, result, ok := strings.Cut(strings.TrimSpace(res), "=")
+				if !ok || !strings.EqualFold(name, method) {
+					continue
+				}
+
+				if fields := strings.Fields(result); len(fields) > 0 {
+					return strings.ToLower(fields[0])
+				}
+			}
+
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func getAuthServID(value string) string {
+	servID, _, _ := strings.Cut(value, ";")
+
+	if fields := strings.Fields(servID); len(fields) > 0 {
+		return fields[0]
+	}
+
+	return ""
+}
+
+func isTrustedAuthServID(trusted []string, servID string) bool {
+	return slices.ContainsFunc(trusted, func(trustedServID string) bool {
+		return strings.EqualFold(servID, trustedServID)
+	})
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge. If not, see <https://www.gnu.org/licenses/>.
+
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+	"github.com/ProtonMail/proton-bridge/v3/utils"
+	"github.com/emersion/go-message/textproto"
+	"github.com/stretchr/testify/require"
+)
+
+type testSenderKeys map[string]*crypto.KeyRing
+
+func (keys testSenderKeys) GetSenderKeys(sender string) (*crypto.KeyRing, error) {
+	return keys[sender], nil
+}
+
+func buildVerified(t *testing.T, kr *crypto.KeyRing, keys SenderKeyProvider, mimeType, arm string, headers map[string][]string) textproto.Header {
+	return buildVerifiedWithOptions(t, kr, keys, mimeType, arm, headers, JobOptions{VerifySignatures: true})
+}
+
+func buildVerifiedWithOptions(
+	t *testing.T,
+	kr *crypto.KeyRing,
+	keys SenderKeyProvider,
+	mimeType, arm string,
+	headers map[string][]string,
+	opts JobOptions,
+) textproto.Header {
+	msg := newRawTestMessageWithHeaders("messageID", "addressID", mimeType, arm, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), headers)
+	msg.Sender = &mail.Address{Address: "sender@pm.me"}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, DecryptVerifyAndBuildRFC822Into(kr, keys, msg, nil, opts, buf))
+
+	header, err := textproto.ReadHeader(bufio.NewReader(buf))
+	require.NoError(t, err)
+
+	return header
+}
+
+func encryptTestBody(t *testing.T, kr, signKR *crypto.KeyRing, body string) string {
+	enc, err := kr.Encrypt(crypto.NewPlainMessageFromString(body), signKR)
+	require.NoError(t, err)
+
+	arm, err := enc.GetArmored()
+	require.NoError(t, err)
+
+	return arm
+}
+
+func TestVerifyMessage_EmbeddedSignature(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+	other := utils.MakeKeyRing(t)
+
+	arm := encryptTestBody(t, kr, kr, "body")
+
+	header := buildVerified(t, kr, testSenderKeys{"sender@pm.me": kr}, "text/plain", arm, nil)
+	require.Equal(t, "valid", header.Get("X-Pm-Signature-Status"))
+	require.Equal(t, "proton-bridge; pgp=pass", header.Get("Authentication-Results"))
+
+	header = buildVerified(t, kr, testSenderKeys{"sender@pm.me": other}, "text/plain", arm, nil)
+	require.Equal(t, "unknown-key", header.Get("X-Pm-Signature-Status"))
+
+	header = buildVerified(t, kr, testSenderKeys{}, "text/plain", arm, nil)
+	require.Equal(t, "unknown-key", header.Get("X-Pm-Signature-Status"))
+}
+
+func TestVerifyMessage_Unsigned(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	// The keys of the sender are not needed for unsigned messages.
+	header := buildVerified(t, kr, nil, "text/plain", encryptTestBody(t, kr, nil, "body"), nil)
+	require.Equal(t, "none", header.Get("X-Pm-Signature-Status"))
+	require.Equal(t, "proton-bridge; pgp=none", header.Get("Authentication-Results"))
+}
+
+func TestVerifyMessage_DetachedSignature(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+	sigKR := utils.MakeKeyRing(t)
+
+	newBody := func(signed, content string) string {
+		sig, err := sigKR.SignDetached(crypto.NewPlainMessageFromString(signed))
+		require.NoError(t, err)
+
+		arm, err := sig.GetArmored()
+		require.NoError(t, err)
+
+		return strings.Join([]string{
+			`Content-Type: multipart/signed; protocol="application/pgp-signature"; micalg=pgp-sha256; boundary="b1"`,
+			``,
+			`--b1`,
+			content,
+			`--b1`,
+			`Content-Type: application/pgp-signature`,
+			``,
+			arm,
+			`--b1--`,
+			``,
+		}, "\r\n")
+	}
+
+	part := "Content-Type: text/plain\r\n\r\nbody"
+
+	header := buildVerified(t, kr, testSenderKeys{"sender@pm.me": sigKR}, "multipart/mixed", encryptTestBody(t, kr, nil, newBody(part, part)), nil)
+	require.Equal(t, "valid", header.Get("X-Pm-Signature-Status"))
+
+	header = buildVerified(t, kr, testSenderKeys{"sender@pm.me": sigKR}, "multipart/mixed", encryptTestBody(t, kr, nil, newBody(part, part+" tampered")), nil)
+	require.Equal(t, "invalid", header.Get("X-Pm-Signature-Status"))
+	require.Equal(t, "proton-bridge; pgp=fail", header.Get("Authentication-Results"))
+}
+
+func TestVerifyMessage_UnwrapPGPMIME(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+	sigKR := utils.MakeKeyRing(t)
+
+	part := "Content-Type: text/plain\r\n\r\nbody"
+
+	sig, err := sigKR.SignDetached(crypto.NewPlainMessageFromString(part))
+	require.NoError(t, err)
+
+	arm, err := sig.GetArmored()
+	require.NoError(t, err)
+
+	body := strings.Join([]string{
+		`Content-Type: multipart/signed; protocol="application/pgp-signature"; micalg=pgp-sha256; boundary="b1"`,
+		``,
+		`--b1`,
+		part,
+		`--b1`,
+		`Content-Type: application/pgp-signature`,
+		``,
+		arm,
+		`--b1--`,
+		``,
+	}, "\r\n")
+
+	msg := newRawTestMessage("messageID", "addressID", "multipart/mixed", encryptTestBody(t, kr, nil, body), time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	msg.Sender = &mail.Address{Address: "sender@pm.me"}
+
+	// Unwrapping verifies the signature even if the verification headers are not requested.
+	buf := new(bytes.Buffer)
+	require.NoError(t, DecryptVerifyAndBuildRFC822Into(kr, testSenderKeys{"sender@pm.me": sigKR}, msg, nil, JobOptions{UnwrapPGPMIME: true}, buf))
+
+	section(t, buf.Bytes()).
+		expectContentType(is(`multipart/mixed`)).
+		expectHeader(PGPMIMEHeader, is(`unwrapped; signature=valid; original-part=2`)).
+		expectHeader(`X-Pm-Signature-Status`, isMissing())
+
+	section(t, buf.Bytes(), 1).
+		expectContentType(is(`text/plain`)).
+		expectBody(is(`body`))
+}
+
+func TestVerifyMessage_AuthenticationResults(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	header := buildVerified(t, kr, testSenderKeys{"sender@pm.me": kr}, "text/plain", encryptTestBody(t, kr, kr, "body"), map[string][]string{
+		"Authentication-Results": {
+			"mail.protonmail.ch; dkim=pass (Good 2048 bit rsa-sha256 signature) header.d=example.com; spf=Pass smtp.mailfrom=example.com; dmarc=fail (p=none dis=none) header.from=example.com",
+			"mx.example.com; spf=fail; dkim=fail",
+			fmt.Sprintf("%v; pgp=pass", AuthServID),
+		},
+		"X-Pm-Signature-Status": {"valid"},
+	})
+
+	// The results of the Proton servers are summarised, and all other results are dropped.
+	require.Equal(t, []string{
+		"proton-bridge; pgp=pass; spf=pass; dkim=pass; dmarc=fail",
+		"mail.protonmail.ch; dkim=pass (Good 2048 bit rsa-sha256 signature) header.d=example.com; spf=Pass smtp.mailfrom=example.com; dmarc=fail (p=none dis=none) header.from=example.com",
+	}, header.Values("Authentication-Results"))
+	require.Equal(t, []string{"valid"}, header.Values("X-Pm-Signature-Status"))
+}
+
+func TestVerifyMessage_AuthenticationResultsFromSender(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	// Messages which didn't go through the Proton MX servers only carry results added by others, which aren't trusted.
+	header := buildVerified(t, kr, testSenderKeys{"sender@pm.me": kr}, "text/plain", encryptTestBody(t, kr, kr, "body"), map[string][]string{
+		"Authentication-Results": {"mx.example.com; spf=pass; dkim=pass; dmarc=pass"},
+	})
+
+	require.Equal(t, []string{"proton-bridge; pgp=pass"}, header.Values("Authentication-Results"))
+}
+
+func TestVerifyMessage_TrustedAuthServIDs(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+
+	headers := map[string][]string{
+		"Authentication-Results": {
+			"mail.protonmail.ch; spf=pass; dkim=pass; dmarc=pass",
+			"mx.example.com; spf=fail; dkim=fail; dmarc=fail",
+		},
+	}
+
+	// Only the results of the configured servers are trusted, in place of the default ones.
+	header := buildVerifiedWithOptions(t, kr, testSenderKeys{"sender@pm.me": kr}, "text/plain", encryptTestBody(t, kr, kr, "body"), headers, JobOptions{
+		VerifySignatures:   true,
+		TrustedAuthServIDs: []string{"MX.example.com"},
+	})
+
+	require.Equal(t, []string{
+		"proton-bridge; pgp=pass; spf=fail; dkim=fail; dmarc=fail",
+		"mx.example.com; spf=fail; dkim=fail; dmarc=fail",
+	}, header.Values("Authentication-Results"))
+}
+
+type failingSenderKeys struct {
+	calls int
+}
+
+func (keys *failingSenderKeys) GetSenderKeys(string) (*crypto.KeyRing, error) {
+	keys.calls++
+
+	return nil, errors.New("network error")
+}
+
+func TestVerifyMessage_SenderKeysUnavailable(t *testing.T) {
+	kr := utils.MakeKeyRing(t)
+	keys := new(failingSenderKeys)
+
+	// The message is still built, with its signature left unverified.
+	header := buildVerified(t, kr, keys, "text/plain", encryptTestBody(t, kr, kr, "body"), nil)
+	require.Equal(t, []string{"unverified"}, header.Values("X-Pm-Signature-Status"))
+	require.Equal(t, []string{"proton-bridge; pgp=temperror"}, header.Values("Authentication-Results"))
+	require.Equal(t, 1, keys.calls)
+
+	// The keys of the sender are not looked up for unsigned messages.
+	header = buildVerified(t, kr, keys, "text/plain", encryptTestBody(t, kr, nil, "body"), nil)
+	require.Equal(t, []string{"none"}, header.Values("X-Pm-Signature-Status"))
+	require.Equal(t, 1, keys.calls)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smime
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrKeyMismatch = errors.New("the private key does not match the certificate")
+
+// Identity is a certificate chain with the private key of its first certificate, used to sign outgoing mail.
+type Identity struct {
+	Chain []*x509.Certificate
+	Key   crypto.Signer
+}
+
+// ParseIdentity parses the PEM-encoded certificate chain and private key of an S/MIME identity.
+// The signing certificate must come first, followed by any intermediate certificates.
+func ParseIdentity(certPEM, keyPEM []byte) (*Identity, error) {
+	var chain []*x509.Certificate
+
+	for rest := certPEM; ; {
+		var block *pem.Block
+
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		} else if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if !publicKeyEqual(chain[0].PublicKey, key.Public()) {
+		return nil, ErrKeyMismatch
+	}
+
+	return &Identity{Chain: chain, Key: key}, nil
+}
+
+// Certificate returns the signing certificate.
+func (id *Identity) Certificate() *x509.Certificate {
+	return id.Chain[0]
+}
+
+// HasEmail returns whether the signing certificate is issued for the given email address.
+func (id *Identity) HasEmail(email string) bool {
+	return CertificateHasEmail(id.Certificate(), email)
+}
+
+// Sign returns a detached signature of the content.
+func (id *Identity) Sign(content []byte) ([]byte, error) {
+	return Sign(content, id.Chain, id.Key)
+}
+
+// CertificateHasEmail returns whether the certificate is issued for the given email address,
+// either in its subject alternative names or, for older certificates, in its subject.
+func CertificateHasEmail(cert *x509.Certificate, email string) bool {
+	if slices.ContainsFunc(cert.EmailAddresses, func(addr string) bool {
+		return strings.EqualFold(addr, email)
+	}) {
+		return true
+	}
+
+	for _, name := range cert.Subject.Names {
+		if !name.Type.Equal(oidEmailAddress) {
+			continue
+		}
+
+		if addr, ok := name.Value.(string); ok && strings.EqualFold(addr, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	for rest := keyPEM; ; {
+		var block *pem.Block
+
+		if block, rest = pem.Decode(rest); block == nil {
+			return nil, errors.New("no private key found")
+		}
+
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse private key: %w", err)
+			}
+
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, ErrUnsupportedKey
+			}
+
+			return signer, nil
+
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+	}
+}
+
+func publicKeyEqual(a, b crypto.PublicKey) bool {
+	switch a := a.(type) {
+	case *rsa.PublicKey:
+		return a.Equal(b)
+
+	case *ecdsa.PublicKey:
+		return a.Equal(b)
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,535 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package smime implements the detached CMS signatures (RFC 5652) used by S/MIME messages (RFC 8551).
+package smime
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidSignature  = errors.New("invalid S/MIME signature")
+	ErrUnsupportedKey    = errors.New("unsupported S/MIME key type")
+	ErrUnsupportedDigest = errors.New("unsupported S/MIME digest algorithm")
+
+	// ErrRevocationUnknown is returned by Signer.VerifyChain when the chain is valid but the signature carries
+	// no current revocation list from the issuer of the signer's certificate. Revocation lists are not fetched.
+	ErrRevocationUnknown = errors.New("revocation status of the S/MIME certificate is unknown")
+	ErrRevoked           = errors.New("the S/MIME certificate is revoked")
+)
+
+var (
+	oidEmailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+
+	oidData       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+	oidAttrContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttrSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+
+	oidSHA1   = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+	oidRSAEncryption   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidSHA1WithRSA     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 5}
+	oidSHA256WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSHA384WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}
+	oidSHA512WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}
+	oidECPublicKey     = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapContentInfo
+	Certificates     rawSet       `asn1:"optional,tag:0"`
+	CRLs             rawSet       `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo `asn1:"set"`
+}
+
+type encapContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional,explicit,tag:0"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerialNumber
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        rawSet `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      rawSet `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// rawSet holds an implicitly tagged SET OF, whose elements are decoded on demand.
+type rawSet struct {
+	Raw asn1.RawContent
+}
+
+// elements returns the DER encoding of the elements of the set.
+func (set rawSet) elements() ([]byte, error) {
+	var val asn1.RawValue
+
+	if _, err := asn1.Unmarshal(set.Raw, &val); err != nil {
+		return nil, err
+	}
+
+	return val.Bytes, nil
+}
+
+// newRawSet returns the set made of the given DER-encoded elements, sorted as required by DER.
+func newRawSet(elements [][]byte) (rawSet, []byte, error) {
+	sort.Slice(elements, func(i, j int) bool {
+		return bytes.Compare(elements[i], elements[j]) < 0
+	})
+
+	der, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: bytes.Join(elements, nil)})
+	if err != nil {
+		return rawSet{}, nil, err
+	}
+
+	return rawSet{Raw: der}, der, nil
+}
+
+// Signer describes the signer of a verified signature.
+type Signer struct {
+	Certificate   *x509.Certificate
+	Intermediates []*x509.Certificate    // The other certificates included in the signature.
+	CRLs          []*x509.RevocationList // The revocation lists included in the signature.
+	SigningTime   time.Time              // The signing time claimed by the signer, if any. It is not verified.
+}
+
+// VerifyChain verifies that the signer's certificate chains up to one of the given roots and may protect email
+// at the given time, which must not come from the signature itself, e.g. the time the message was received.
+// If roots is nil, the system roots are used.
+// The certificate is checked against the revocation lists included in the signature; if there is none from its issuer,
+// an error wrapping ErrRevocationUnknown is returned.
+func (signer *Signer) VerifyChain(roots *x509.CertPool, at time.Time) error {
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range signer.Intermediates {
+		intermediates.AddCert(cert)
+	}
+
+	chains, err := signer.Certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	})
+	if err != nil {
+		return err
+	}
+
+	// A self-signed certificate is its own root, which has no one to revoke it.
+	if len(chains[0]) < 2 {
+		return nil
+	}
+
+	return signer.checkRevocation(chains[0][1], at)
+}
+
+// checkRevocation checks the signer's certificate against the revocation lists of the given issuer which are current
+// at the given time.
+func (signer *Signer) checkRevocation(issuer *x509.Certificate, at time.Time) error {
+	checked := false
+
+	for _, crl := range signer.CRLs {
+		if crl.CheckSignatureFrom(issuer) != nil || at.Before(crl.ThisUpdate) || (!crl.NextUpdate.IsZero() && at.After(crl.NextUpdate)) {
+			continue
+		}
+
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(signer.Certificate.SerialNumber) == 0 && !entry.RevocationTime.After(at) {
+				return ErrRevoked
+			}
+		}
+
+		checked = true
+	}
+
+	if !checked {
+		return fmt.Errorf("%w: no current revocation list from %v", ErrRevocationUnknown, issuer.Subject)
+	}
+
+	return nil
+}
+
+// Sign returns a detached CMS signature of the content, made with the key of the first certificate of the chain.
+// The whole chain is included in the signature so that recipients can verify it.
+func Sign(content []byte, chain []*x509.Certificate, key crypto.Signer) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("missing signing certificate")
+	}
+
+	sigAlgorithm, err := getSignatureAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := crypto.SHA256.New()
+	digest.Write(content)
+
+	signedAttrs, signedAttrsDER, err := newSignedAttributes(digest.Sum(nil), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := crypto.SHA256.New()
+	hash.Write(signedAttrsDER)
+
+	signature, err := key.Sign(rand.Reader, hash.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	certs := make([][]byte, 0, len(chain))
+
+	for _, cert := range chain {
+		certs = append(certs, cert.Raw)
+	}
+
+	// The certificates are a SET OF, but are kept in chain order as done by other implementations.
+	certSet, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: bytes.Join(certs, nil)})
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidSHA256}},
+		EncapContentInfo: encapContentInfo{ContentType: oidData},
+		Certificates:     rawSet{Raw: certSet},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			SID: issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: chain[0].RawIssuer},
+				SerialNumber: chain[0].SerialNumber,
+			},
+			DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			SignedAttrs:        signedAttrs,
+			SignatureAlgorithm: sigAlgorithm,
+			Signature:          signature,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+}
+
+// Verify verifies the detached CMS signature of the content and returns its signer.
+// Only the signature itself is checked; the signer's certificate is checked with Signer.VerifyChain.
+func Verify(content, signature []byte) (*Signer, error) {
+	var info contentInfo
+
+	if _, err := asn1.Unmarshal(signature, &info); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	} else if !info.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("%w: not a signed-data content", ErrInvalidSignature)
+	}
+
+	var sd signedData
+
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	} else if len(sd.SignerInfos) == 0 {
+		return nil, fmt.Errorf("%w: no signer", ErrInvalidSignature)
+	}
+
+	certData, err := sd.Certificates.elements()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	certs, err := x509.ParseCertificates(certData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	si := sd.SignerInfos[0]
+
+	signer, err := findSigner(certs, si.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, ok := getHash(si.DigestAlgorithm.Algorithm)
+	if !ok {
+		return nil, ErrUnsupportedDigest
+	}
+
+	signed, signingTime, err := getSignedContent(si, hash, content)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, ok := getX509SignatureAlgorithm(hash, si.SignatureAlgorithm.Algorithm)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	if err := signer.CheckSignature(algorithm, signed, si.Signature); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return &Signer{
+		Certificate: signer,
+		Intermediates: slices.DeleteFunc(certs, func(cert *x509.Certificate) bool {
+			return cert == signer
+		}),
+		CRLs:        parseCRLs(sd.CRLs),
+		SigningTime: signingTime,
+	}, nil
+}
+
+// parseCRLs returns the revocation lists of the set. Other revocation information, and lists which can't be parsed,
+// are skipped; they only leave the revocation status of the signer unknown.
+func parseCRLs(set rawSet) []*x509.RevocationList {
+	if len(set.Raw) == 0 {
+		return nil
+	}
+
+	data, err := set.elements()
+	if err != nil {
+		return nil
+	}
+
+	var crls []*x509.RevocationList
+
+	for rest := data; len(rest) > 0; {
+		var val asn1.RawValue
+
+		if rest, err = asn1.Unmarshal(rest, &val); err != nil {
+			break
+		}
+
+		if crl, err := x509.ParseRevocationList(val.FullBytes); err == nil {
+			crls = append(crls, crl)
+		}
+	}
+
+	return crls
+}
+
+// newSignedAttributes returns the content type, signing time and message digest attributes,
+// both as stored in the signer info and as encoded when signed.
+func newSignedAttributes(digest []byte, signingTime time.Time) (rawSet, []byte, error) {
+	values := []struct {
+		oid asn1.ObjectIdentifier
+		val any
+	}{
+		{oidAttrContentType, oidData},
+		{oidAttrSigningTime, signingTime.UTC()},
+		{oidAttrMessageDigest, digest},
+	}
+
+	attrs := make([][]byte, 0, len(values))
+
+	for _, value := range values {
+		val, err := asn1.Marshal(value.val)
+		if err != nil {
+			return rawSet{}, nil, err
+		}
+
+		attr, err := asn1.Marshal(attribute{
+			Type:   value.oid,
+			Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: val},
+		})
+		if err != nil {
+			return rawSet{}, nil, err
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	return newRawSet(attrs)
+}
+
+// getSignedContent returns the data covered by the signature.
+// With signed attributes, the signature covers the attributes, whose message digest must match the content.
+func getSignedContent(si signerInfo, hash crypto.Hash, content []byte) ([]byte, time.Time, error) {
+	if len(si.SignedAttrs.Raw) == 0 {
+		return content, time.Time{}, nil
+	}
+
+	data, err := si.SignedAttrs.elements()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	var (
+		digest      []byte
+		signingTime time.Time
+	)
+
+	for rest := data; len(rest) > 0; {
+		var attr attribute
+
+		if rest, err = asn1.Unmarshal(rest, &attr); err != nil {
+			return nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+		}
+
+		switch {
+		case attr.Type.Equal(oidAttrMessageDigest):
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &digest); err != nil {
+				return nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+			}
+
+		case attr.Type.Equal(oidAttrSigningTime):
+			// The signing time is informative; a malformed one is ignored.
+			_, _ = asn1.Unmarshal(attr.Values.Bytes, &signingTime)
+		}
+	}
+
+	h := hash.New()
+	h.Write(content)
+
+	if !bytes.Equal(digest, h.Sum(nil)) {
+		return nil, time.Time{}, fmt.Errorf("%w: message digest mismatch", ErrInvalidSignature)
+	}
+
+	// The attributes are signed with their universal SET OF tag rather than the implicit tag of the signer info.
+	signed, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: data})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return signed, signingTime, nil
+}
+
+func findSigner(certs []*x509.Certificate, sid issuerAndSerialNumber) (*x509.Certificate, error) {
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, sid.Issuer.FullBytes) && cert.SerialNumber.Cmp(sid.SerialNumber) == 0 {
+			return cert, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: signer certificate not included", ErrInvalidSignature)
+}
+
+func getSignatureAlgorithm(key crypto.Signer) (pkix.AlgorithmIdentifier, error) {
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}, nil
+
+	case *ecdsa.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}, nil
+
+	default:
+		return pkix.AlgorithmIdentifier{}, ErrUnsupportedKey
+	}
+}
+
+func getHash(oid asn1.ObjectIdentifier) (crypto.Hash, bool) {
+	switch {
+	case oid.Equal(oidSHA1):
+		return crypto.SHA1, true
+
+	case oid.Equal(oidSHA256):
+		return crypto.SHA256, true
+
+	case oid.Equal(oidSHA384):
+		return crypto.SHA384, true
+
+	case oid.Equal(oidSHA512):
+		return crypto.SHA512, true
+
+	default:
+		return 0, false
+	}
+}
+
+// getX509SignatureAlgorithm maps the digest and signature algorithms of a signer info to the x509 signature algorithm.
+// Signers may either give the key algorithm alone or the combined signature algorithm.
+func getX509SignatureAlgorithm(hash crypto.Hash, oid asn1.ObjectIdentifier) (x509.SignatureAlgorithm, bool) {
+	switch {
+	case oid.Equal(oidRSAEncryption), oid.Equal(oidSHA1WithRSA), oid.Equal(oidSHA256WithRSA),
+		oid.Equal(oidSHA384WithRSA), oid.Equal(oidSHA512WithRSA):
+		switch hash { //nolint:exhaustive
+		case crypto.SHA1:
+			return x509.SHA1WithRSA, true
+
+		case crypto.SHA256:
+			return x509.SHA256WithRSA, true
+
+		case crypto.SHA384:
+			return x509.SHA384WithRSA, true
+
+		case crypto.SHA512:
+			return x509.SHA512WithRSA, true
+		}
+
+	case oid.Equal(oidECPublicKey), oid.Equal(oidECDSAWithSHA256), oid.Equal(oidECDSAWithSHA384),
+		oid.Equal(oidECDSAWithSHA512):
+		switch hash { //nolint:exhaustive
+		case crypto.SHA1:
+			return x509.ECDSAWithSHA1, true
+
+		case crypto.SHA256:
+			return x509.ECDSAWithSHA256, true
+
+		case crypto.SHA384:
+			return x509.ECDSAWithSHA384, true
+
+		case crypto.SHA512:
+			return x509.ECDSAWithSHA512, true
+		}
+	}
+
+	return x509.UnknownSignatureAlgorithm, false
+}
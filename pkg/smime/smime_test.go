@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Proton AG
+//
+// This file is part of Proton Mail Bridge.
+//
+// Proton Mail Bridge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Proton Mail Bridge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Proton Mail Bridge.  If not, see <https://www.gnu.org/licenses/>.
+
+package smime_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/proton-bridge/v3/pkg/smime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	ca, caKey := newCA(t)
+
+	for name, key := range map[string]crypto.Signer{
+		"rsa":   must(rsa.GenerateKey(rand.Reader, 2048)),
+		"ecdsa": must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			certPEM, keyPEM := newLeaf(t, ca, caKey, key, "alice@example.com")
+
+			id, err := smime.ParseIdentity(certPEM, keyPEM)
+			require.NoError(t, err)
+			require.True(t, id.HasEmail("Alice@Example.com"))
+			require.False(t, id.HasEmail("bob@example.com"))
+
+			content := []byte("Content-Type: text/plain\r\n\r\nhello\r\n")
+
+			sig, err := id.Sign(content)
+			require.NoError(t, err)
+
+			signer, err := smime.Verify(content, sig)
+			require.NoError(t, err)
+			require.Equal(t, id.Certificate().Raw, signer.Certificate.Raw)
+			require.WithinDuration(t, time.Now(), signer.SigningTime, time.Minute)
+
+			// The chain is only trusted with the CA as root.
+			require.Error(t, signer.VerifyChain(x509.NewCertPool(), time.Now()))
+
+			roots := x509.NewCertPool()
+			roots.AddCert(ca)
+
+			// Without a revocation list, the revocation of the certificate can't be checked.
+			require.ErrorIs(t, signer.VerifyChain(roots, time.Now()), smime.ErrRevocationUnknown)
+
+			// The certificate is verified at the given time, not at the signing time.
+			err = signer.VerifyChain(roots, time.Now().Add(2*time.Hour))
+			require.Error(t, err)
+			require.NotErrorIs(t, err, smime.ErrRevocationUnknown)
+
+			signer.CRLs = []*x509.RevocationList{newCRL(t, ca, caKey)}
+			require.NoError(t, signer.VerifyChain(roots, time.Now()))
+
+			signer.CRLs = []*x509.RevocationList{newCRL(t, ca, caKey, signer.Certificate.SerialNumber)}
+			require.ErrorIs(t, signer.VerifyChain(roots, time.Now()), smime.ErrRevoked)
+
+			// Any change to the content invalidates the signature.
+			_, err = smime.Verify([]byte("Content-Type: text/plain\r\n\r\nhello!\r\n"), sig)
+			require.ErrorIs(t, err, smime.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestVerify_Malformed(t *testing.T) {
+	_, err := smime.Verify([]byte("content"), []byte("not a signature"))
+	require.ErrorIs(t, err, smime.ErrInvalidSignature)
+}
+
+func TestParseIdentity_KeyMismatch(t *testing.T) {
+	ca, caKey := newCA(t)
+
+	certPEM, _ := newLeaf(t, ca, caKey, must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader)), "alice@example.com")
+	_, keyPEM := newLeaf(t, ca, caKey, must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader)), "alice@example.com")
+
+	_, err := smime.ParseIdentity(certPEM, keyPEM)
+	require.ErrorIs(t, err, smime.ErrKeyMismatch)
+}
+
+func newCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	return must(x509.ParseCertificate(der)), key
+}
+
+func newLeaf(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, key crypto.Signer, email string) ([]byte, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		Subject:        pkix.Name{CommonName: email},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// newCRL returns a current revocation list of the CA, revoking the given certificates.
+func newCRL(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, revoked ...*big.Int) *x509.RevocationList {
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+
+	for _, serial := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca, caKey)
+	require.NoError(t, err)
+
+	return must(x509.ParseRevocationList(der))
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/ProtonMail/proton-bridge/v3/internal/dialer"
 	"github.com/ProtonMail/proton-bridge/v3/internal/events"
 	frontend "github.com/ProtonMail/proton-bridge/v3/internal/frontend/grpc"
+	"github.com/ProtonMail/proton-bridge/v3/internal/policy"
 	"github.com/ProtonMail/proton-bridge/v3/internal/service"
 	"github.com/ProtonMail/proton-bridge/v3/internal/services/observability"
 	"github.com/ProtonMail/proton-bridge/v3/internal/useragent"
@@ -170,10 +171,12 @@ func (t *testCtx) initBridge() (<-chan events.Event, error) {
 		t.locator,
 		vault,
 		t.mocks.Autostarter,
+		t.mocks.Notifier,
 		t.mocks.Updater,
 		t.version,
 		keychain.NewTestKeychainsList(),
 		observability.NewTestService(),
+		policy.Settings{},
 
 		// API stuff
 		t.api.GetHostURL(),